@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/infrastructure/services"
+)
+
+// Maintenance subcommands, run instead of the server:
+//
+//	app rebuild-projection -tenant t1 [-product p1]
+//	app check-consistency -tenant t1
+//	app reconcile -tenant t1 [-correct]
+//	app export -tenant t1 [-type stock|history] [-format csv|ndjson|parquet] [-as-of 2024-03-01] [-from 2024-05-01] [-to 2024-06-01] [-out file]
+//	app snapshot
+//	app outbox-dead
+//	app outbox-requeue -id <entry>
+//	app outbox-discard -id <entry>
+//
+// A dead outbox entry holds back its product's later events until it is
+// requeued or discarded. Results are printed as JSON; export writes the
+// file to -out, or to stdout. check-consistency and reconcile exit with status 1 when they
+// find discrepancies that were not corrected.
+func runCommand(uow interfaces.UnitOfWork, name string, args []string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	ledgerUseCase := usecases.NewStockLedgerUseCase(uow, domain.DefaultSnapshotInterval)
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	tenantID := flags.String("tenant", "", "tenant ID")
+
+	switch name {
+	case "rebuild-projection":
+		productID := flags.String("product", "", "product ID (default: all products with ledger entries)")
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		result, err := ledgerUseCase.RebuildProjection(ctx, usecases.RebuildProjectionRequest{
+			TenantID:  *tenantID,
+			ProductID: *productID,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rebuild-projection: %v\n", err)
+			return 1
+		}
+		return printJSON(result)
+
+	case "check-consistency":
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		report, err := ledgerUseCase.CheckConsistency(ctx, *tenantID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "check-consistency: %v\n", err)
+			return 1
+		}
+		if code := printJSON(report); code != 0 {
+			return code
+		}
+		if !report.Consistent() {
+			return 1
+		}
+		return 0
+
+	case "reconcile":
+		correct := flags.Bool("correct", false, "write adjustment entries for drifted products")
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		report, err := usecases.NewReconcileStockUseCase(uow).Execute(ctx, usecases.ReconcileStockRequest{
+			TenantID:    *tenantID,
+			Correct:     *correct,
+			PerformedBy: "cli",
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "reconcile: %v\n", err)
+			return 1
+		}
+		if code := printJSON(report); code != 0 {
+			return code
+		}
+		if report.Corrected < len(report.Drifts) {
+			return 1
+		}
+		return 0
+
+	case "export":
+		kind := flags.String("type", "stock", "what to export: stock or history")
+		format := flags.String("format", domain.ExportFormatCSV, "csv, ndjson or parquet")
+		fromFlag := flags.String("from", "", "history start, RFC3339 or YYYY-MM-DD (default: 30 days before -to)")
+		toFlag := flags.String("to", "", "history end, RFC3339 or YYYY-MM-DD (default: now)")
+		asOfFlag := flags.String("as-of", "", "export stock levels as they were then, RFC3339 or YYYY-MM-DD for the end of that day")
+		out := flags.String("out", "", "output file (default: stdout)")
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		exportUseCase := usecases.NewExportStockUseCase(uow, services.NewTableEncoder())
+		var export *usecases.StockExport
+		var err error
+		switch *kind {
+		case "stock":
+			var asOf time.Time
+			if asOf, err = parseCommandAsOf(*asOfFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "export: invalid -as-of: %v\n", err)
+				return 2
+			}
+			export, err = exportUseCase.StockLevels(ctx, usecases.ExportStockLevelsRequest{
+				TenantID: *tenantID,
+				Format:   *format,
+				AsOf:     asOf,
+			})
+		case "history":
+			var from, to time.Time
+			if from, err = parseCommandTime(*fromFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "export: invalid -from: %v\n", err)
+				return 2
+			}
+			if to, err = parseCommandTime(*toFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "export: invalid -to: %v\n", err)
+				return 2
+			}
+			export, err = exportUseCase.StockHistory(ctx, usecases.ExportStockHistoryRequest{
+				TenantID: *tenantID,
+				Format:   *format,
+				From:     from,
+				To:       to,
+			})
+		default:
+			fmt.Fprintf(os.Stderr, "export: unknown -type %q\n", *kind)
+			return 2
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			return 1
+		}
+		if *out == "" {
+			if _, err := export.WriteTo(ctx, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "export: %v\n", err)
+				return 1
+			}
+			return 0
+		}
+		file, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			return 1
+		}
+		rows, err := export.WriteTo(ctx, file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "wrote %d rows to %s\n", rows, *out)
+		return 0
+
+	case "snapshot":
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		result, err := usecases.NewStockSnapshotUseCase(uow).Capture(ctx, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+			return 1
+		}
+		return printJSON(result)
+
+	case "outbox-dead":
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		entries, err := usecases.NewDeadOutboxUseCase(uow).List(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "outbox-dead: %v\n", err)
+			return 1
+		}
+		return printJSON(entries)
+
+	case "outbox-requeue", "outbox-discard":
+		entryID := flags.String("id", "", "outbox entry ID")
+		if err := flags.Parse(args); err != nil {
+			return 2
+		}
+		deadOutbox := usecases.NewDeadOutboxUseCase(uow)
+		resolve := deadOutbox.Requeue
+		if name == "outbox-discard" {
+			resolve = deadOutbox.Discard
+		}
+		entry, err := resolve(ctx, *entryID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			return 1
+		}
+		return printJSON(entry)
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		return 2
+	}
+}
+
+func printJSON(v interface{}) int {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "encode result: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// parseCommandTime accepts RFC3339 or a plain date; empty means unset
+func parseCommandTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
+
+// parseCommandAsOf is parseCommandTime, except that a plain date means
+// the end of that day, as the API's as_of does
+func parseCommandAsOf(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.AddDate(0, 0, 1), nil
+}
@@ -3,10 +3,16 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"myapp/internal/api/http"
+	"myapp/internal/application/interfaces"
 	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/infrastructure/events"
 	"myapp/internal/infrastructure/persistence"
 	"myapp/internal/infrastructure/services"
 
@@ -23,16 +29,119 @@ func main() {
 
 	// 2. Setup Infrastructure Layer
 	uow := persistence.NewMongoUnitOfWork(mongoClient, "inventory_db")
-	notificationSvc := services.NewNotificationService(
-		"https://hooks.slack.com/...",
-		"https://email-service.com/api",
-	)
+	if err := persistence.EnsureStockLedgerIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsureImportJobIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsureDailySnapshotIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsurePurchaseOrderIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsureSupplierIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsureProductIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsureProductCostIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.EnsureOutboxIndexes(context.Background(), mongoClient.Database("inventory_db")); err != nil {
+		log.Fatal(err)
+	}
+
+	// Maintenance subcommands run and exit instead of serving
+	if len(os.Args) > 1 {
+		code := runCommand(uow, os.Args[1], os.Args[2:])
+		mongoClient.Disconnect(context.Background())
+		os.Exit(code)
+	}
+
+	templateRenderer := services.NewTemplateRenderer(uow)
+	webhookPublisher := services.NewWebhookPublisher(uow, nil, 3, 2*time.Second)
+	notificationSvc := services.NewRoutingNotificationService(uow, templateRenderer, map[string]interfaces.NotificationChannel{
+		domain.ChannelSlack:   services.NewSlackChannel("https://hooks.slack.com/..."),
+		domain.ChannelEmail:   services.NewEmailChannel("https://email-service.com/api"),
+		domain.ChannelWebhook: services.NewWebhookChannel(webhookPublisher),
+		domain.ChannelLog:     services.NewLogChannel(),
+	})
+
+	// Notifications, webhooks and audit subscribe to domain events
+	eventBus := events.NewBus(4, 256)
+	defer eventBus.Close()
+	schemaRegistry, err := events.NewSchemaRegistry()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventBus.ValidateWith(schemaRegistry)
+	events.SubscribeNotifications(eventBus, notificationSvc)
+	events.SubscribePublisher(eventBus, "webhooks", webhookPublisher)
+	events.SubscribeAuditLog(eventBus, log.Default())
+
+	// Live stock feed for dashboards
+	streamHub := events.NewStreamHub(1000, 64)
+	events.SubscribePublisher(eventBus, "stream", streamHub)
 
 	// 3. Setup Application Layer
-	addStockUseCase := usecases.NewAddStockUseCase(uow, notificationSvc, nil)
+	addStockUseCase := usecases.NewAddStockUseCase(uow, nil)
+	addStockBatchUseCase := usecases.NewAddStockBatchUseCase(uow, nil)
+	removeStockUseCase := usecases.NewRemoveStockUseCase(uow)
+	stockApprovalUseCase := usecases.NewStockApprovalUseCase(uow, addStockUseCase, removeStockUseCase)
+	stockHistoryUseCase := usecases.NewStockHistoryUseCase(uow)
+	reverseStockMovementUseCase := usecases.NewReverseStockMovementUseCase(uow)
+	relayOutboxUseCase := usecases.NewRelayOutboxUseCase(uow, eventBus, "event-bus", 100)
+	manageWebhooksUseCase := usecases.NewManageWebhooksUseCase(uow)
+	manageTemplatesUseCase := usecases.NewManageTemplatesUseCase(uow, templateRenderer)
+	manageRoutingRulesUseCase := usecases.NewManageRoutingRulesUseCase(uow)
+	streamStockEventsUseCase := usecases.NewStreamStockEventsUseCase(uow, streamHub)
+	reconcileStockUseCase := usecases.NewReconcileStockUseCase(uow)
+	cycleCountUseCase := usecases.NewCycleCountUseCase(uow)
+	adjustStockUseCase := usecases.NewAdjustStockUseCase(uow)
+	manageAdjustmentReasonsUseCase := usecases.NewManageAdjustmentReasonsUseCase(uow)
+	adjustmentReportUseCase := usecases.NewAdjustmentReportUseCase(uow)
+	importStockUseCase := usecases.NewImportStockUseCase(uow, services.NewSpreadsheetReader(), nil)
+	exportStockUseCase := usecases.NewExportStockUseCase(uow, services.NewTableEncoder())
+	utilizationReportUseCase := usecases.NewUtilizationReportUseCase(uow)
+	stockSnapshotUseCase := usecases.NewStockSnapshotUseCase(uow)
+	forecastUseCase := usecases.NewForecastUseCase(uow)
+	purchaseOrderUseCase := usecases.NewPurchaseOrderUseCase(uow, nil)
+	manageSuppliersUseCase := usecases.NewManageSuppliersUseCase(uow)
+	valuationReportUseCase := usecases.NewValuationReportUseCase(uow)
+	manageProductUnitsUseCase := usecases.NewManageProductUnitsUseCase(uow)
+	manageProductIdentifiersUseCase := usecases.NewManageProductIdentifiersUseCase(uow)
+
+	// Relay committed outbox events to the bus
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go runOutboxRelay(relayCtx, relayOutboxUseCase, time.Second)
+	go runApprovalExpiry(relayCtx, stockApprovalUseCase, time.Minute)
+	go runImportJobs(relayCtx, importStockUseCase, 2*time.Second)
+	go runDailySnapshots(relayCtx, stockSnapshotUseCase, time.Hour)
 
 	// 4. Setup HTTP Layer
-	stockHandler := http.NewStockHandler(addStockUseCase)
+	stockHandler := http.NewStockHandler(addStockUseCase, removeStockUseCase)
+	stockBatchHandler := http.NewStockBatchHandler(addStockBatchUseCase)
+	importHandler := http.NewImportHandler(importStockUseCase)
+	exportHandler := http.NewExportHandler(exportStockUseCase)
+	stockApprovalHandler := http.NewStockApprovalHandler(stockApprovalUseCase)
+	stockHistoryHandler := http.NewStockHistoryHandler(stockHistoryUseCase, reverseStockMovementUseCase)
+	webhookHandler := http.NewWebhookHandler(manageWebhooksUseCase)
+	templateHandler := http.NewTemplateHandler(manageTemplatesUseCase)
+	routingRuleHandler := http.NewRoutingRuleHandler(manageRoutingRulesUseCase)
+	streamHandler := http.NewStreamHandler(streamStockEventsUseCase)
+	reconciliationHandler := http.NewReconciliationHandler(reconcileStockUseCase)
+	cycleCountHandler := http.NewCycleCountHandler(cycleCountUseCase)
+	adjustmentHandler := http.NewAdjustmentHandler(adjustStockUseCase, manageAdjustmentReasonsUseCase, adjustmentReportUseCase)
+	reportHandler := http.NewReportHandler(utilizationReportUseCase, forecastUseCase, valuationReportUseCase)
+	stockSnapshotHandler := http.NewStockSnapshotHandler(stockSnapshotUseCase)
+	purchaseOrderHandler := http.NewPurchaseOrderHandler(purchaseOrderUseCase)
+	supplierHandler := http.NewSupplierHandler(manageSuppliersUseCase)
+	productUnitsHandler := http.NewProductUnitsHandler(manageProductUnitsUseCase)
+	productIdentifiersHandler := http.NewProductIdentifiersHandler(manageProductIdentifiersUseCase)
 
 	// 5. Setup Fiber App
 	app := fiber.New(fiber.Config{
@@ -45,9 +154,98 @@ func main() {
 
 	// 6. Routes
 	app.Post("/api/v1/stock/add", stockHandler.AddStock)
+	app.Post("/api/v1/stock/add/batch", stockBatchHandler.AddStockBatch)
+	app.Post("/api/v1/imports/stock", importHandler.Upload)
+	app.Get("/api/v1/imports/:id", importHandler.Get)
+	app.Get("/api/v1/imports/:id/errors", importHandler.ErrorReport)
+	app.Get("/api/v1/exports/stock", exportHandler.StockLevels)
+	app.Get("/api/v1/exports/history", exportHandler.StockHistory)
+	app.Post("/api/v1/stock/remove", stockHandler.RemoveStock)
+	app.Post("/api/v1/stock/adjust", adjustmentHandler.Adjust)
+	app.Post("/api/v1/stock/history/:id/reverse", stockHistoryHandler.Reverse)
+	app.Get("/api/v1/products/:id/history", stockHistoryHandler.ProductHistory)
+	app.Get("/api/v1/products/:id/stock", stockSnapshotHandler.ProductStock)
+	app.Get("/api/v1/products/:id/units", productUnitsHandler.Get)
+	app.Put("/api/v1/products/:id/units", productUnitsHandler.Set)
+	app.Get("/api/v1/products/lookup", productIdentifiersHandler.Lookup)
+	app.Get("/api/v1/products/:id/identifiers", productIdentifiersHandler.Get)
+	app.Put("/api/v1/products/:id/identifiers", productIdentifiersHandler.Set)
+	app.Get("/api/v1/stock/stream", streamHandler.Stream)
+	app.Get("/api/v1/stock/ws", http.RequireWebSocket, streamHandler.WebSocket())
+
+	app.Post("/api/v1/webhooks", webhookHandler.Create)
+	app.Get("/api/v1/webhooks", webhookHandler.List)
+	app.Get("/api/v1/webhooks/:id", webhookHandler.Get)
+	app.Put("/api/v1/webhooks/:id", webhookHandler.Update)
+	app.Delete("/api/v1/webhooks/:id", webhookHandler.Delete)
+	app.Get("/api/v1/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
 
-	// 7. Start server
-	log.Fatal(app.Listen(":3000"))
+	app.Get("/api/v1/notification-templates", templateHandler.List)
+	app.Put("/api/v1/notification-templates/:name", templateHandler.Save)
+	app.Delete("/api/v1/notification-templates/:name", templateHandler.Reset)
+	app.Post("/api/v1/notification-templates/:name/preview", templateHandler.Preview)
+
+	app.Post("/api/v1/notification-rules", routingRuleHandler.Create)
+	app.Get("/api/v1/notification-rules", routingRuleHandler.List)
+	app.Put("/api/v1/notification-rules/:id", routingRuleHandler.Update)
+	app.Delete("/api/v1/notification-rules/:id", routingRuleHandler.Delete)
+
+	app.Get("/api/v1/stock-approvals", stockApprovalHandler.List)
+	app.Get("/api/v1/stock-approvals/:id", stockApprovalHandler.Get)
+	app.Post("/api/v1/stock-approvals/:id/approve", stockApprovalHandler.Approve)
+	app.Post("/api/v1/stock-approvals/:id/reject", stockApprovalHandler.Reject)
+
+	app.Get("/api/v1/adjustment-reasons", adjustmentHandler.ListReasons)
+	app.Put("/api/v1/adjustment-reasons/:code", adjustmentHandler.SaveReason)
+	app.Delete("/api/v1/adjustment-reasons/:code", adjustmentHandler.DeleteReason)
+	app.Get("/api/v1/reports/adjustments", adjustmentHandler.Report)
+	app.Get("/api/v1/reports/utilization", reportHandler.Utilization)
+	app.Get("/api/v1/reports/forecast", reportHandler.Forecast)
+	app.Get("/api/v1/reports/valuation", reportHandler.Valuation)
+
+	app.Post("/api/v1/count-sessions", cycleCountHandler.Open)
+	app.Get("/api/v1/count-sessions", cycleCountHandler.List)
+	app.Get("/api/v1/count-sessions/:id", cycleCountHandler.Get)
+	app.Post("/api/v1/count-sessions/:id/counts", cycleCountHandler.SubmitCounts)
+	app.Post("/api/v1/count-sessions/:id/approve", cycleCountHandler.Approve)
+	app.Post("/api/v1/count-sessions/:id/cancel", cycleCountHandler.Cancel)
+
+	app.Post("/api/v1/purchase-orders", purchaseOrderHandler.Create)
+	app.Get("/api/v1/purchase-orders", purchaseOrderHandler.List)
+	app.Get("/api/v1/purchase-orders/:id", purchaseOrderHandler.Get)
+	app.Post("/api/v1/purchase-orders/:id/receive", purchaseOrderHandler.Receive)
+	app.Post("/api/v1/purchase-orders/:id/cancel", purchaseOrderHandler.Cancel)
+
+	app.Post("/api/v1/suppliers", supplierHandler.Create)
+	app.Get("/api/v1/suppliers", supplierHandler.List)
+	app.Get("/api/v1/suppliers/:id", supplierHandler.Get)
+	app.Put("/api/v1/suppliers/:id", supplierHandler.Update)
+	app.Delete("/api/v1/suppliers/:id", supplierHandler.Delete)
+	app.Put("/api/v1/suppliers/:id/products/:product_id", supplierHandler.SetProduct)
+	app.Delete("/api/v1/suppliers/:id/products/:product_id", supplierHandler.RemoveProduct)
+	app.Get("/api/v1/suppliers/:id/history", stockHistoryHandler.SupplierHistory)
+
+	app.Post("/api/v1/admin/stock/reconcile", reconciliationHandler.Reconcile)
+
+	// 7. Start server until SIGINT or SIGTERM
+	shutdownCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	go func() {
+		<-shutdownCtx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("HTTP shutdown error: %v", err)
+		}
+	}()
+	if err := app.Listen(":3000"); err != nil {
+		log.Fatal(err)
+	}
+
+	// Stop background jobs, drain the bus, then let webhook deliveries finish
+	stopRelay()
+	eventBus.Close()
+	if err := webhookPublisher.Close(); err != nil {
+		log.Printf("Webhook shutdown: %v", err)
+	}
 }
 
 func connectMongoDB() *mongo.Client {
@@ -68,9 +266,96 @@ func connectMongoDB() *mongo.Client {
 	return client
 }
 
+func runOutboxRelay(ctx context.Context, relay usecases.RelayOutboxUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := relay.Execute(ctx); err != nil {
+				log.Printf("Outbox relay error: %v", err)
+			}
+		}
+	}
+}
+
+// Expires stock change requests that outlived their TTL
+func runApprovalExpiry(ctx context.Context, approvals usecases.StockApprovalUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := approvals.ExpirePending(ctx); err != nil {
+				log.Printf("Approval expiry error: %v", err)
+			}
+		}
+	}
+}
+
+// Runs queued stock imports one after another
+func runImportJobs(ctx context.Context, imports usecases.ImportStockUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				processed, err := imports.ProcessNext(ctx)
+				if err != nil {
+					log.Printf("Import job error: %v", err)
+				}
+				if !processed || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Snapshots stock once per UTC day: at startup when the process has not
+// done so today, then after each midnight. Retaking a day replaces it, so
+// restarts are harmless.
+func runDailySnapshots(ctx context.Context, snapshots usecases.StockSnapshotUseCase, interval time.Duration) {
+	var lastDay time.Time
+	capture := func() {
+		now := time.Now()
+		if domain.SnapshotDay(now).Equal(lastDay) {
+			return
+		}
+		result, err := snapshots.Capture(ctx, now)
+		if err != nil {
+			log.Printf("Daily snapshot error: %v", err)
+			return
+		}
+		lastDay = result.Day
+		log.Printf("Daily snapshot of %d products for %s", result.Products, result.Day.Format("2006-01-02"))
+	}
+
+	capture()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			capture()
+		}
+	}
+}
+
 func authMiddleware(c *fiber.Ctx) error {
 	// Simple auth middleware
 	// In real app, validate JWT, etc.
 	c.Locals("user_id", "user_123") //temporary hardcoded user id for demonstration purposes
+	// "user_roles" comes from the same token as the user; the stub has no
+	// token, so it grants no roles and nobody can approve stock changes
 	return c.Next()
 }
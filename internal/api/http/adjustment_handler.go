@@ -0,0 +1,205 @@
+// internal/api/http/adjustment_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Reason-coded stock corrections, the reason catalog and the report by reason
+type AdjustmentHandler struct {
+	adjustStockUseCase      usecases.AdjustStockUseCase
+	manageReasonsUseCase    usecases.ManageAdjustmentReasonsUseCase
+	adjustmentReportUseCase usecases.AdjustmentReportUseCase
+}
+
+func NewAdjustmentHandler(
+	adjustStockUseCase usecases.AdjustStockUseCase,
+	manageReasonsUseCase usecases.ManageAdjustmentReasonsUseCase,
+	adjustmentReportUseCase usecases.AdjustmentReportUseCase,
+) *AdjustmentHandler {
+	return &AdjustmentHandler{
+		adjustStockUseCase:      adjustStockUseCase,
+		manageReasonsUseCase:    manageReasonsUseCase,
+		adjustmentReportUseCase: adjustmentReportUseCase,
+	}
+}
+
+// POST /api/v1/stock/adjust
+func (h *AdjustmentHandler) Adjust(c *fiber.Ctx) error {
+	var req AdjustStockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adjustStockUseCase.Execute(ctx, usecases.AdjustStockRequest{
+		ProductID:  req.ProductID,
+		TenantID:   req.TenantID,
+		Delta:      req.Delta,
+		ReasonCode: req.ReasonCode,
+		Notes:      req.Notes,
+		AdjustedBy: userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(200).JSON(AdjustStockResponse{
+		Success:     true,
+		HistoryID:   response.HistoryID,
+		ProductID:   response.ProductID,
+		ProductName: response.ProductName,
+		Previous:    response.PreviousStock,
+		NewStock:    response.NewStock,
+		Delta:       response.Delta,
+		ReasonCode:  response.ReasonCode,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/adjustment-reasons?tenant_id=...
+func (h *AdjustmentHandler) ListReasons(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	reasons, err := h.manageReasonsUseCase.List(ctx, c.Query("tenant_id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]AdjustmentReasonResponse, 0, len(reasons))
+	for _, r := range reasons {
+		result = append(result, toAdjustmentReasonResponse(r))
+	}
+	return c.Status(200).JSON(result)
+}
+
+// PUT /api/v1/adjustment-reasons/:code
+func (h *AdjustmentHandler) SaveReason(c *fiber.Ctx) error {
+	var req SaveAdjustmentReasonRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	reason, err := h.manageReasonsUseCase.Save(ctx, usecases.SaveAdjustmentReasonRequest{
+		TenantID:  req.TenantID,
+		Code:      c.Params("code"),
+		Label:     req.Label,
+		Direction: req.Direction,
+		IsActive:  req.IsActive,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toAdjustmentReasonResponse(*reason))
+}
+
+// DELETE /api/v1/adjustment-reasons/:code?tenant_id=...
+func (h *AdjustmentHandler) DeleteReason(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.manageReasonsUseCase.Delete(ctx, c.Query("tenant_id"), c.Params("code")); err != nil {
+		return handleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+// GET /api/v1/reports/adjustments?tenant_id=...&from=...&to=...
+// from and to are RFC 3339 timestamps or dates; the default is the last 30 days.
+func (h *AdjustmentHandler) Report(c *fiber.Ctx) error {
+	to := time.Now()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseReportTime(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -30)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseReportTime(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		from = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.adjustmentReportUseCase.Execute(ctx, usecases.AdjustmentReportRequest{
+		TenantID: c.Query("tenant_id"),
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	rows := make([]AdjustmentReportRowResponse, 0, len(report.Reasons))
+	for _, r := range report.Reasons {
+		rows = append(rows, toAdjustmentReportRowResponse(r))
+	}
+	return c.Status(200).JSON(AdjustmentReportResponse{
+		TenantID: report.TenantID,
+		From:     report.From.Format(time.RFC3339),
+		To:       report.To.Format(time.RFC3339),
+		Reasons:  rows,
+		Totals:   toAdjustmentReportRowResponse(report.Totals),
+	})
+}
+
+// "2024-05-01" or "2024-05-01T10:00:00Z"
+func parseReportTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
+
+func toAdjustmentReasonResponse(r domain.AdjustmentReason) AdjustmentReasonResponse {
+	resp := AdjustmentReasonResponse{
+		Code:      r.Code,
+		Label:     r.Label,
+		Direction: r.Direction,
+		IsActive:  r.IsActive,
+		IsCustom:  r.IsCustom,
+		UpdatedBy: r.UpdatedBy,
+	}
+	if !r.UpdatedAt.IsZero() {
+		resp.UpdatedAt = r.UpdatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func toAdjustmentReportRowResponse(r usecases.AdjustmentReportRow) AdjustmentReportRowResponse {
+	return AdjustmentReportRowResponse{
+		ReasonCode:  r.ReasonCode,
+		Label:       r.Label,
+		Adjustments: r.Adjustments,
+		Increase:    r.Increase,
+		Decrease:    r.Decrease,
+		Net:         r.Net,
+	}
+}
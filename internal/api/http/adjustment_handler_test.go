@@ -0,0 +1,194 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockAdjustStockUseCase implements usecases.AdjustStockUseCase for handler tests.
+type mockAdjustStockUseCase struct {
+	response *usecases.AdjustStockResponse
+	err      error
+	last     usecases.AdjustStockRequest
+}
+
+func (m *mockAdjustStockUseCase) Execute(ctx context.Context, req usecases.AdjustStockRequest) (*usecases.AdjustStockResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+// mockManageAdjustmentReasonsUseCase implements usecases.ManageAdjustmentReasonsUseCase for handler tests.
+type mockManageAdjustmentReasonsUseCase struct {
+	reasons  []domain.AdjustmentReason
+	err      error
+	lastSave usecases.SaveAdjustmentReasonRequest
+}
+
+func (m *mockManageAdjustmentReasonsUseCase) List(ctx context.Context, tenantID string) ([]domain.AdjustmentReason, error) {
+	return m.reasons, m.err
+}
+
+func (m *mockManageAdjustmentReasonsUseCase) Save(ctx context.Context, req usecases.SaveAdjustmentReasonRequest) (*domain.AdjustmentReason, error) {
+	m.lastSave = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.AdjustmentReason{Code: req.Code, Label: req.Label, Direction: req.Direction, IsActive: req.IsActive, IsCustom: true}, nil
+}
+
+func (m *mockManageAdjustmentReasonsUseCase) Delete(ctx context.Context, tenantID, code string) error {
+	return m.err
+}
+
+// mockAdjustmentReportUseCase implements usecases.AdjustmentReportUseCase for handler tests.
+type mockAdjustmentReportUseCase struct {
+	err  error
+	last usecases.AdjustmentReportRequest
+}
+
+func (m *mockAdjustmentReportUseCase) Execute(ctx context.Context, req usecases.AdjustmentReportRequest) (*usecases.AdjustmentReportResponse, error) {
+	m.last = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	row := usecases.AdjustmentReportRow{ReasonCode: domain.ReasonDamage, Label: "Damaged", Adjustments: 2, Decrease: 4, Net: -4}
+	return &usecases.AdjustmentReportResponse{
+		TenantID: req.TenantID, From: req.From, To: req.To,
+		Reasons: []usecases.AdjustmentReportRow{row}, Totals: row,
+	}, nil
+}
+
+func setupAdjustmentApp(adjust usecases.AdjustStockUseCase, reasons usecases.ManageAdjustmentReasonsUseCase, report usecases.AdjustmentReportUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewAdjustmentHandler(adjust, reasons, report)
+	app.Post("/api/v1/stock/adjust", handler.Adjust)
+	app.Get("/api/v1/adjustment-reasons", handler.ListReasons)
+	app.Put("/api/v1/adjustment-reasons/:code", handler.SaveReason)
+	app.Get("/api/v1/reports/adjustments", handler.Report)
+	return app
+}
+
+func TestAdjustmentHandler_Adjust_Success(t *testing.T) {
+	uc := &mockAdjustStockUseCase{response: &usecases.AdjustStockResponse{
+		HistoryID: "h1", ProductID: "p1", PreviousStock: 10, NewStock: 7, Delta: -3, ReasonCode: domain.ReasonDamage,
+	}}
+	app := setupAdjustmentApp(uc, &mockManageAdjustmentReasonsUseCase{}, &mockAdjustmentReportUseCase{})
+
+	resp := postJSON(t, app, "/api/v1/stock/adjust", map[string]interface{}{
+		"product_id": "p1", "tenant_id": "t1", "delta": -3, "reason_code": "damage",
+	})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.last.Delta != -3 || uc.last.ReasonCode != domain.ReasonDamage || uc.last.AdjustedBy != testUserID {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	var got httphandler.AdjustStockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.HistoryID != "h1" || got.NewStock != 7 || got.Previous != 10 {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestAdjustmentHandler_Adjust_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrInvalidReasonCode, http.StatusBadRequest},
+		{domain.ErrReasonDirectionMismatch, http.StatusBadRequest},
+		{domain.ErrReasonCodeRequired, http.StatusBadRequest},
+		{domain.ErrInsufficientStock, http.StatusBadRequest},
+		{domain.ErrProductLockedForCount, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			app := setupAdjustmentApp(&mockAdjustStockUseCase{err: tt.err}, &mockManageAdjustmentReasonsUseCase{}, &mockAdjustmentReportUseCase{})
+			resp := postJSON(t, app, "/api/v1/stock/adjust", map[string]interface{}{"product_id": "p1", "tenant_id": "t1", "delta": -1})
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustmentHandler_SaveReason_UsesPathCode(t *testing.T) {
+	reasons := &mockManageAdjustmentReasonsUseCase{}
+	app := setupAdjustmentApp(&mockAdjustStockUseCase{}, reasons, &mockAdjustmentReportUseCase{})
+
+	body, _ := json.Marshal(map[string]interface{}{"tenant_id": "t1", "label": "Expired", "direction": "decrease", "is_active": true})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/adjustment-reasons/expired",
+		bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if reasons.lastSave.Code != "expired" || reasons.lastSave.UpdatedBy != testUserID || !reasons.lastSave.IsActive {
+		t.Errorf("use case request = %+v", reasons.lastSave)
+	}
+}
+
+func TestAdjustmentHandler_Report_ParsesRange(t *testing.T) {
+	report := &mockAdjustmentReportUseCase{}
+	app := setupAdjustmentApp(&mockAdjustStockUseCase{}, &mockManageAdjustmentReasonsUseCase{}, report)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/adjustments?tenant_id=t1&from=2024-05-01&to=2024-06-01T00:00:00Z", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	wantFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	wantTo := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if !report.last.From.Equal(wantFrom) || !report.last.To.Equal(wantTo) || report.last.TenantID != "t1" {
+		t.Errorf("use case request = %+v", report.last)
+	}
+	var got httphandler.AdjustmentReportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got.Reasons) != 1 || got.Reasons[0].Net != -4 || got.Totals.Decrease != 4 {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestAdjustmentHandler_Report_BadTime(t *testing.T) {
+	app := setupAdjustmentApp(&mockAdjustStockUseCase{}, &mockManageAdjustmentReasonsUseCase{}, &mockAdjustmentReportUseCase{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/adjustments?tenant_id=t1&from=yesterday", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
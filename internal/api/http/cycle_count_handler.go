@@ -0,0 +1,192 @@
+// internal/api/http/cycle_count_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CycleCountHandler struct {
+	cycleCountUseCase usecases.CycleCountUseCase
+}
+
+func NewCycleCountHandler(cycleCountUseCase usecases.CycleCountUseCase) *CycleCountHandler {
+	return &CycleCountHandler{
+		cycleCountUseCase: cycleCountUseCase,
+	}
+}
+
+func (h *CycleCountHandler) Open(c *fiber.Ctx) error {
+	var req OpenCountSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.cycleCountUseCase.Open(ctx, usecases.OpenCountSessionRequest{
+		TenantID:   req.TenantID,
+		Name:       req.Name,
+		ProductIDs: req.ProductIDs,
+		Location:   req.Location,
+		Lock:       req.Lock,
+		OpenedBy:   userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(201).JSON(toCountSessionResponse(*response))
+}
+
+func (h *CycleCountHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	sessions, err := h.cycleCountUseCase.List(ctx, c.Query("tenant_id"), c.Query("status"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]CountSessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, toCountSessionResponse(s))
+	}
+	return c.Status(200).JSON(result)
+}
+
+func (h *CycleCountHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.cycleCountUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toCountSessionResponse(*response))
+}
+
+func (h *CycleCountHandler) SubmitCounts(c *fiber.Ctx) error {
+	var req SubmitCountsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	counts := make([]usecases.SubmittedCount, 0, len(req.Counts))
+	for _, count := range req.Counts {
+		counts = append(counts, usecases.SubmittedCount{ProductID: count.ProductID, Quantity: count.Quantity})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.cycleCountUseCase.SubmitCounts(ctx, usecases.SubmitCountsRequest{
+		TenantID:  req.TenantID,
+		SessionID: c.Params("id"),
+		CountedBy: userID,
+		Counts:    counts,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toCountSessionResponse(*response))
+}
+
+func (h *CycleCountHandler) Approve(c *fiber.Ctx) error {
+	var req ApproveCountSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.cycleCountUseCase.Approve(ctx, usecases.ApproveCountSessionRequest{
+		TenantID:    req.TenantID,
+		SessionID:   c.Params("id"),
+		ApprovedBy:  userID,
+		ReasonCodes: req.ReasonCodes,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toCountSessionResponse(*response))
+}
+
+func (h *CycleCountHandler) Cancel(c *fiber.Ctx) error {
+	var req CountSessionActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.cycleCountUseCase.Cancel(ctx, req.TenantID, c.Params("id"), userID)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toCountSessionResponse(*response))
+}
+
+func toCountSessionResponse(s usecases.CountSessionResponse) CountSessionResponse {
+	lines := make([]CountLineResponse, 0, len(s.Lines))
+	for _, l := range s.Lines {
+		counts := make([]CountEntryResponse, 0, len(l.Counts))
+		for _, entry := range l.Counts {
+			counts = append(counts, CountEntryResponse{
+				CountedBy: entry.CountedBy,
+				Quantity:  entry.Quantity,
+				CountedAt: entry.CountedAt.Format(time.RFC3339),
+			})
+		}
+		lines = append(lines, CountLineResponse{
+			ProductID:     l.ProductID,
+			ProductName:   l.ProductName,
+			ExpectedStock: l.ExpectedStock,
+			Counted:       l.Counted,
+			Variance:      l.Variance,
+			Disputed:      l.Disputed,
+			Counts:        counts,
+			ReasonCode:    l.ReasonCode,
+			Adjustment:    l.Adjustment,
+		})
+	}
+
+	resp := CountSessionResponse{
+		ID:       s.ID,
+		TenantID: s.TenantID,
+		Name:     s.Name,
+		Location: s.Location,
+		Lock:     s.Lock,
+		Status:   s.Status,
+		Lines:    lines,
+		OpenedBy: s.OpenedBy,
+		OpenedAt: s.OpenedAt.Format(time.RFC3339),
+		ClosedBy: s.ClosedBy,
+	}
+	if !s.ClosedAt.IsZero() {
+		resp.ClosedAt = s.ClosedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,150 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockCycleCountUseCase implements usecases.CycleCountUseCase for handler tests.
+type mockCycleCountUseCase struct {
+	response    *usecases.CountSessionResponse
+	err         error
+	lastOpen    usecases.OpenCountSessionRequest
+	lastSubmit  usecases.SubmitCountsRequest
+	lastApprove usecases.ApproveCountSessionRequest
+}
+
+func (m *mockCycleCountUseCase) Open(ctx context.Context, req usecases.OpenCountSessionRequest) (*usecases.CountSessionResponse, error) {
+	m.lastOpen = req
+	return m.response, m.err
+}
+
+func (m *mockCycleCountUseCase) Get(ctx context.Context, tenantID, sessionID string) (*usecases.CountSessionResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockCycleCountUseCase) List(ctx context.Context, tenantID, status string) ([]usecases.CountSessionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.CountSessionResponse{*m.response}, nil
+}
+
+func (m *mockCycleCountUseCase) SubmitCounts(ctx context.Context, req usecases.SubmitCountsRequest) (*usecases.CountSessionResponse, error) {
+	m.lastSubmit = req
+	return m.response, m.err
+}
+
+func (m *mockCycleCountUseCase) Approve(ctx context.Context, req usecases.ApproveCountSessionRequest) (*usecases.CountSessionResponse, error) {
+	m.lastApprove = req
+	return m.response, m.err
+}
+
+func (m *mockCycleCountUseCase) Cancel(ctx context.Context, tenantID, sessionID, cancelledBy string) (*usecases.CountSessionResponse, error) {
+	return m.response, m.err
+}
+
+func setupCycleCountApp(uc usecases.CycleCountUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewCycleCountHandler(uc)
+	app.Post("/api/v1/count-sessions", handler.Open)
+	app.Post("/api/v1/count-sessions/:id/counts", handler.SubmitCounts)
+	app.Post("/api/v1/count-sessions/:id/approve", handler.Approve)
+	return app
+}
+
+func postJSON(t *testing.T, app *fiber.App, path string, body interface{}) *http.Response {
+	t.Helper()
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp
+}
+
+func TestCycleCountHandler_Open_Success(t *testing.T) {
+	counted := 4
+	uc := &mockCycleCountUseCase{response: &usecases.CountSessionResponse{
+		ID: "c1", TenantID: "t1", Status: domain.CountStatusOpen, Lock: true, OpenedAt: time.Now(),
+		Lines: []usecases.CountLineResponse{{ProductID: "p1", ExpectedStock: 5, Counted: &counted, Variance: -1}},
+	}}
+	app := setupCycleCountApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/count-sessions", map[string]interface{}{
+		"tenant_id": "t1", "product_ids": []string{"p1"}, "lock": true,
+	})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if !uc.lastOpen.Lock || uc.lastOpen.OpenedBy != testUserID || len(uc.lastOpen.ProductIDs) != 1 {
+		t.Errorf("use case request = %+v", uc.lastOpen)
+	}
+	var got httphandler.CountSessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != "c1" || len(got.Lines) != 1 || got.Lines[0].Variance != -1 || *got.Lines[0].Counted != 4 {
+		t.Errorf("response = %+v", got)
+	}
+	if got.ClosedAt != "" {
+		t.Errorf("ClosedAt = %q, want empty for open session", got.ClosedAt)
+	}
+}
+
+func TestCycleCountHandler_SubmitCounts_PassesCounter(t *testing.T) {
+	uc := &mockCycleCountUseCase{response: &usecases.CountSessionResponse{ID: "c1"}}
+	app := setupCycleCountApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/count-sessions/c1/counts", map[string]interface{}{
+		"tenant_id": "t1",
+		"counts":    []map[string]interface{}{{"product_id": "p1", "quantity": 3}},
+	})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	got := uc.lastSubmit
+	if got.SessionID != "c1" || got.CountedBy != testUserID || len(got.Counts) != 1 || got.Counts[0].Quantity != 3 {
+		t.Errorf("use case request = %+v", got)
+	}
+}
+
+func TestCycleCountHandler_Approve_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrCountDisputed, http.StatusConflict},
+		{domain.ErrCountSessionClosed, http.StatusConflict},
+		{domain.ErrCountSessionNotFound, http.StatusNotFound},
+		{domain.ErrInsufficientStock, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			app := setupCycleCountApp(&mockCycleCountUseCase{err: tt.err})
+			resp := postJSON(t, app, "/api/v1/count-sessions/c1/approve", map[string]interface{}{"tenant_id": "t1"})
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
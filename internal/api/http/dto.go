@@ -1,30 +1,745 @@
 // internal/interfaces/http/dto.go
 package http
 
+import "myapp/internal/domain"
+
 // HTTP Request DTO
 type AddStockRequest struct {
-	ProductID string `json:"product_id" validate:"required"`
-	Quantity  int    `json:"quantity" validate:"required,min=1"`
-	TenantID  string `json:"tenant_id" validate:"required"`
-	Notes     string `json:"notes"`
+	// Exactly one of product_id, sku or barcode (EAN/UPC) names the product
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku"`
+	Barcode   string `json:"barcode"`
+	// Whole, or with decimal places for decimal-mode products, e.g. 2.5
+	Quantity domain.Decimal `json:"quantity" validate:"required"`
+	// Unit quantity is in, e.g. "case"; the product's base unit when empty
+	Unit     string `json:"unit"`
+	TenantID string `json:"tenant_id" validate:"required"`
+	Notes    string `json:"notes"`
+	// Optional registered supplier the stock was received from
+	SupplierID string `json:"supplier_id"`
+	// Optional cost of one entered unit, e.g. 12.5 or "12.50"; without one the
+	// units are valued at the product's average unit cost
+	UnitCost *domain.Money `json:"unit_cost"`
 }
 
 // HTTP Response DTO
 type AddStockResponse struct {
-	Success      bool    `json:"success"`
-	ProductID    string  `json:"product_id"`
-	ProductName  string  `json:"product_name"`
-	Previous     int     `json:"previous_stock"`
-	NewStock     int     `json:"new_stock"`
-	Added        int     `json:"added"`
-	MaxAllowed   int     `json:"max_allowed"`
-	Utilization  float64 `json:"utilization_percentage"`
-	Message      string  `json:"message"`
-	Timestamp    string  `json:"timestamp"`
+	Success     bool           `json:"success"`
+	ProductID   string         `json:"product_id"`
+	ProductName string         `json:"product_name"`
+	Previous    domain.Decimal `json:"previous_stock"`
+	NewStock    domain.Decimal `json:"new_stock"`
+	Added       domain.Decimal `json:"added"`
+	MaxAllowed  int            `json:"max_allowed"`
+	Utilization float64        `json:"utilization_percentage"`
+	Message     string         `json:"message"`
+	Timestamp   string         `json:"timestamp"`
+
+	// Added as entered; added and the stock counts are in base_unit
+	EnteredQuantity domain.Decimal `json:"entered_quantity"`
+	Unit            string         `json:"unit"`
+	BaseUnit        string         `json:"base_unit"`
+}
+
+type RemoveStockRequest struct {
+	ProductID string         `json:"product_id" validate:"required"`
+	Quantity  domain.Decimal `json:"quantity" validate:"required"`
+	TenantID  string         `json:"tenant_id" validate:"required"`
+	Notes     string         `json:"notes"`
+}
+
+type RemoveStockResponse struct {
+	Success     bool           `json:"success"`
+	ProductID   string         `json:"product_id"`
+	ProductName string         `json:"product_name"`
+	Previous    domain.Decimal `json:"previous_stock"`
+	NewStock    domain.Decimal `json:"new_stock"`
+	Removed     domain.Decimal `json:"removed"`
+	// What the removed units cost under the tenant's costing method
+	CostOfGoods domain.Money `json:"cost_of_goods"`
+	Message     string       `json:"message"`
+	Timestamp   string       `json:"timestamp"`
+}
+
+// Returned with 202 when a change awaits approval
+type PendingApprovalResponse struct {
+	Success   bool   `json:"success"`
+	Status    string `json:"status"`
+	RequestID string `json:"request_id"`
+	ProductID string `json:"product_id"`
+	ExpiresAt string `json:"expires_at"`
+	Message   string `json:"message"`
 }
 
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Code    string `json:"code,omitempty"`
 	Details string `json:"details,omitempty"`
-}
\ No newline at end of file
+}
+
+type CreateWebhookRequest struct {
+	TenantID   string   `json:"tenant_id" validate:"required"`
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret" validate:"required,min=16"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+}
+
+type UpdateWebhookRequest struct {
+	TenantID   string   `json:"tenant_id" validate:"required"`
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	IsActive   bool     `json:"is_active"`
+}
+
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	TenantID   string   `json:"tenant_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	IsActive   bool     `json:"is_active"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID          string `json:"id"`
+	EventID     string `json:"event_id"`
+	EventType   string `json:"event_type"`
+	Attempt     int    `json:"attempt"`
+	StatusCode  int    `json:"status_code"`
+	Success     bool   `json:"success"`
+	Error       string `json:"error,omitempty"`
+	DurationMS  int64  `json:"duration_ms"`
+	DeliveredAt string `json:"delivered_at"`
+}
+
+type SaveTemplateRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Body     string `json:"body" validate:"required"`
+}
+
+type PreviewTemplateRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Body     string `json:"body"`
+}
+
+type TemplateResponse struct {
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	IsCustom  bool   `json:"is_custom"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type PreviewTemplateResponse struct {
+	Name     string `json:"name"`
+	Rendered string `json:"rendered"`
+}
+
+type RoutingRuleRequest struct {
+	TenantID    string   `json:"tenant_id" validate:"required"`
+	Name        string   `json:"name"`
+	EventType   string   `json:"event_type"`
+	MinSeverity string   `json:"min_severity"`
+	ProductTags []string `json:"product_tags"`
+	Channels    []string `json:"channels" validate:"required,min=1"`
+	IsActive    bool     `json:"is_active"`
+}
+
+type RoutingRuleResponse struct {
+	ID          string   `json:"id"`
+	TenantID    string   `json:"tenant_id"`
+	Name        string   `json:"name"`
+	EventType   string   `json:"event_type,omitempty"`
+	MinSeverity string   `json:"min_severity,omitempty"`
+	ProductTags []string `json:"product_tags,omitempty"`
+	Channels    []string `json:"channels"`
+	IsActive    bool     `json:"is_active"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type ReconcileStockRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Correct  bool   `json:"correct"`
+}
+
+type StockDriftResponse struct {
+	ProductID          string         `json:"product_id"`
+	ProductName        string         `json:"product_name"`
+	CurrentStock       domain.Decimal `json:"current_stock"`
+	ExpectedStock      domain.Decimal `json:"expected_stock"`
+	StockDifference    domain.Decimal `json:"stock_difference"`
+	TotalAdded         domain.Decimal `json:"total_added"`
+	ExpectedTotalAdded domain.Decimal `json:"expected_total_added"`
+	HistoryEntries     int            `json:"history_entries"`
+	Corrected          bool           `json:"corrected"`
+}
+
+type ReconciliationReportResponse struct {
+	TenantID               string               `json:"tenant_id"`
+	CheckedAt              string               `json:"checked_at"`
+	ProductsChecked        int                  `json:"products_checked"`
+	ProductsWithoutHistory int                  `json:"products_without_history"`
+	Drifts                 []StockDriftResponse `json:"drifts"`
+	Corrected              int                  `json:"corrected"`
+}
+
+type OpenCountSessionRequest struct {
+	TenantID   string   `json:"tenant_id" validate:"required"`
+	Name       string   `json:"name"`
+	ProductIDs []string `json:"product_ids"`
+	Location   string   `json:"location"`
+	Lock       bool     `json:"lock"`
+}
+
+type SubmitCountsRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Counts   []struct {
+		ProductID string `json:"product_id" validate:"required"`
+		Quantity  int    `json:"quantity" validate:"min=0"`
+	} `json:"counts" validate:"required,min=1"`
+}
+
+type ApproveCountSessionRequest struct {
+	TenantID    string            `json:"tenant_id" validate:"required"`
+	ReasonCodes map[string]string `json:"reason_codes"`
+}
+
+type CountSessionActionRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+}
+
+type CountEntryResponse struct {
+	CountedBy string `json:"counted_by"`
+	Quantity  int    `json:"quantity"`
+	CountedAt string `json:"counted_at"`
+}
+
+type CountLineResponse struct {
+	ProductID     string               `json:"product_id"`
+	ProductName   string               `json:"product_name"`
+	ExpectedStock int                  `json:"expected_stock"`
+	Counted       *int                 `json:"counted"`
+	Variance      int                  `json:"variance"`
+	Disputed      bool                 `json:"disputed"`
+	Counts        []CountEntryResponse `json:"counts"`
+	ReasonCode    string               `json:"reason_code,omitempty"`
+	Adjustment    int                  `json:"adjustment"`
+}
+
+type CountSessionResponse struct {
+	ID       string              `json:"id"`
+	TenantID string              `json:"tenant_id"`
+	Name     string              `json:"name"`
+	Location string              `json:"location,omitempty"`
+	Lock     bool                `json:"lock"`
+	Status   string              `json:"status"`
+	Lines    []CountLineResponse `json:"lines"`
+	OpenedBy string              `json:"opened_by"`
+	OpenedAt string              `json:"opened_at"`
+	ClosedBy string              `json:"closed_by,omitempty"`
+	ClosedAt string              `json:"closed_at,omitempty"`
+}
+
+type AdjustStockRequest struct {
+	ProductID  string `json:"product_id" validate:"required"`
+	TenantID   string `json:"tenant_id" validate:"required"`
+	Delta      int    `json:"delta" validate:"required"`
+	ReasonCode string `json:"reason_code" validate:"required"`
+	Notes      string `json:"notes"`
+}
+
+type AdjustStockResponse struct {
+	Success     bool   `json:"success"`
+	HistoryID   string `json:"history_id"`
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Previous    int    `json:"previous_stock"`
+	NewStock    int    `json:"new_stock"`
+	Delta       int    `json:"delta"`
+	ReasonCode  string `json:"reason_code"`
+	Timestamp   string `json:"timestamp"`
+}
+
+type SaveAdjustmentReasonRequest struct {
+	TenantID  string `json:"tenant_id" validate:"required"`
+	Label     string `json:"label"`
+	Direction string `json:"direction" validate:"required,oneof=increase decrease any"`
+	IsActive  bool   `json:"is_active"`
+}
+
+type AdjustmentReasonResponse struct {
+	Code      string `json:"code"`
+	Label     string `json:"label"`
+	Direction string `json:"direction"`
+	IsActive  bool   `json:"is_active"`
+	IsCustom  bool   `json:"is_custom"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type AdjustmentReportRowResponse struct {
+	ReasonCode  string `json:"reason_code"`
+	Label       string `json:"label"`
+	Adjustments int    `json:"adjustments"`
+	Increase    int    `json:"increase"`
+	Decrease    int    `json:"decrease"`
+	Net         int    `json:"net"`
+}
+
+type AdjustmentReportResponse struct {
+	TenantID string                        `json:"tenant_id"`
+	From     string                        `json:"from"`
+	To       string                        `json:"to"`
+	Reasons  []AdjustmentReportRowResponse `json:"reasons"`
+	Totals   AdjustmentReportRowResponse   `json:"totals"`
+}
+
+type DecideStockChangeRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Note     string `json:"note"`
+}
+
+type StockChangeRequestResponse struct {
+	ID            string          `json:"id"`
+	TenantID      string          `json:"tenant_id"`
+	ProductID     string          `json:"product_id"`
+	ProductName   string          `json:"product_name"`
+	Operation     string          `json:"operation"`
+	Quantity      domain.Decimal  `json:"quantity"`
+	Unit          string          `json:"unit,omitempty"`
+	Notes         string          `json:"notes,omitempty"`
+	Status        string          `json:"status"`
+	RequestedBy   string          `json:"requested_by"`
+	RequestedAt   string          `json:"requested_at"`
+	ExpiresAt     string          `json:"expires_at"`
+	DecidedBy     string          `json:"decided_by,omitempty"`
+	DecidedAt     string          `json:"decided_at,omitempty"`
+	DecisionNote  string          `json:"decision_note,omitempty"`
+	PreviousStock *domain.Decimal `json:"previous_stock,omitempty"`
+	NewStock      *domain.Decimal `json:"new_stock,omitempty"`
+}
+
+type ReverseStockMovementRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Notes    string `json:"notes"`
+}
+
+type ReverseStockMovementResponse struct {
+	Success         bool           `json:"success"`
+	HistoryID       string         `json:"history_id"`
+	OriginalEntryID string         `json:"original_entry_id"`
+	ProductID       string         `json:"product_id"`
+	ProductName     string         `json:"product_name"`
+	Previous        domain.Decimal `json:"previous_stock"`
+	NewStock        domain.Decimal `json:"new_stock"`
+	Delta           domain.Decimal `json:"delta"`
+	Timestamp       string         `json:"timestamp"`
+}
+
+type StockHistoryEntryResponse struct {
+	ID            string         `json:"id"`
+	ProductID     string         `json:"product_id,omitempty"`
+	Operation     string         `json:"operation"`
+	Quantity      domain.Decimal `json:"quantity"`
+	PreviousStock domain.Decimal `json:"previous_stock"`
+	NewStock      domain.Decimal `json:"new_stock"`
+	Actor         string         `json:"actor"`
+	Notes         string         `json:"notes,omitempty"`
+	ReasonCode    string         `json:"reason_code,omitempty"`
+	Reference     string         `json:"reference,omitempty"`
+	ApprovedBy    string         `json:"approved_by,omitempty"`
+	SupplierID    string         `json:"supplier_id,omitempty"`
+	// Quantity of an add as entered; quantity is in the base unit
+	EnteredQuantity *domain.Decimal `json:"entered_quantity,omitempty"`
+	Unit            string          `json:"unit,omitempty"`
+	// Cost of one entered unit and the value the movement carried in or out
+	UnitCost   *domain.Money `json:"unit_cost,omitempty"`
+	Cost       *domain.Money `json:"cost,omitempty"`
+	ReversalOf string        `json:"reversal_of,omitempty"`
+	ReversedBy string        `json:"reversed_by,omitempty"`
+	CreatedAt  string        `json:"created_at"`
+}
+
+type ProductHistoryResponse struct {
+	ProductID   string                      `json:"product_id"`
+	ProductName string                      `json:"product_name"`
+	Entries     []StockHistoryEntryResponse `json:"entries"`
+}
+
+type SupplierHistoryResponse struct {
+	SupplierID   string                      `json:"supplier_id"`
+	SupplierName string                      `json:"supplier_name"`
+	Entries      []StockHistoryEntryResponse `json:"entries"`
+}
+
+type AddStockBatchRequest struct {
+	// all_or_nothing (default) or best_effort
+	Mode  string            `json:"mode"`
+	Items []AddStockRequest `json:"items" validate:"required,min=1"`
+}
+
+type AddStockBatchResponse struct {
+	Success   bool                        `json:"success"`
+	Mode      string                      `json:"mode"`
+	Succeeded int                         `json:"succeeded"`
+	Failed    int                         `json:"failed"`
+	Items     []AddStockBatchItemResponse `json:"items"`
+	Timestamp string                      `json:"timestamp"`
+}
+
+// Result of one batch item. Status is what /api/v1/stock/add would have
+// answered for it.
+type AddStockBatchItemResponse struct {
+	Index             int             `json:"index"`
+	Success           bool            `json:"success"`
+	Status            int             `json:"status"`
+	ProductID         string          `json:"product_id"`
+	ProductName       string          `json:"product_name,omitempty"`
+	Previous          *domain.Decimal `json:"previous_stock,omitempty"`
+	NewStock          *domain.Decimal `json:"new_stock,omitempty"`
+	Added             *domain.Decimal `json:"added,omitempty"`
+	EnteredQuantity   *domain.Decimal `json:"entered_quantity,omitempty"`
+	Unit              string          `json:"unit,omitempty"`
+	Utilization       float64         `json:"utilization_percentage,omitempty"`
+	ApprovalRequestID string          `json:"approval_request_id,omitempty"`
+	Error             *ErrorResponse  `json:"error,omitempty"`
+}
+
+type ImportRowErrorResponse struct {
+	Line      int    `json:"line"`
+	ProductID string `json:"product_id"`
+	Quantity  string `json:"quantity"`
+	Error     string `json:"error"`
+}
+
+type ImportPreviewRowResponse struct {
+	Line          int    `json:"line"`
+	ProductID     string `json:"product_id"`
+	ProductName   string `json:"product_name"`
+	Quantity      int    `json:"quantity"`
+	PreviousStock int    `json:"previous_stock"`
+	NewStock      int    `json:"new_stock"`
+	NeedsApproval bool   `json:"needs_approval,omitempty"`
+}
+
+type ImportPreviewResponse struct {
+	DryRun        bool                       `json:"dry_run"`
+	FileHash      string                     `json:"file_hash"`
+	TotalRows     int                        `json:"total_rows"`
+	ValidRows     int                        `json:"valid_rows"`
+	InvalidRows   int                        `json:"invalid_rows"`
+	ExistingJobID string                     `json:"existing_job_id,omitempty"`
+	Rows          []ImportPreviewRowResponse `json:"rows"`
+	Errors        []ImportRowErrorResponse   `json:"errors"`
+}
+
+type ImportJobResponse struct {
+	ID            string `json:"id"`
+	TenantID      string `json:"tenant_id"`
+	FileName      string `json:"file_name"`
+	FileHash      string `json:"file_hash"`
+	Format        string `json:"format"`
+	Status        string `json:"status"`
+	TotalRows     int    `json:"total_rows"`
+	ProcessedRows int    `json:"processed_rows"`
+	AppliedRows   int    `json:"applied_rows"`
+	// Rows held as change requests; the error report lists them
+	PendingApprovalRows int     `json:"pending_approval_rows"`
+	FailedRows          int     `json:"failed_rows"`
+	Progress            float64 `json:"progress_percentage"`
+	Failure             string  `json:"failure,omitempty"`
+	Duplicate           bool    `json:"duplicate,omitempty"`
+	CreatedBy           string  `json:"created_by"`
+	CreatedAt           string  `json:"created_at"`
+	StartedAt           string  `json:"started_at,omitempty"`
+	FinishedAt          string  `json:"finished_at,omitempty"`
+}
+
+type UtilizationBandResponse struct {
+	Label string  `json:"label"`
+	Min   float64 `json:"min"`
+	// Omitted for the open-ended top band
+	Max      *float64 `json:"max,omitempty"`
+	Products int      `json:"products"`
+	Units    int      `json:"units"`
+}
+
+type ProductUtilizationResponse struct {
+	ProductID    string  `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	CurrentStock int     `json:"current_stock"`
+	Utilization  float64 `json:"utilization_percentage"`
+}
+
+type UtilizationTotalsResponse struct {
+	Products    int     `json:"products"`
+	Units       int     `json:"units"`
+	Capacity    int     `json:"capacity"`
+	Utilization float64 `json:"utilization_percentage"`
+}
+
+type UtilizationReportResponse struct {
+	TenantID          string                       `json:"tenant_id"`
+	MaxStock          int                          `json:"max_stock"`
+	LowStockThreshold int                          `json:"low_stock_threshold"`
+	Bands             []UtilizationBandResponse    `json:"bands"`
+	Top               []ProductUtilizationResponse `json:"top"`
+	LowStock          []ProductUtilizationResponse `json:"low_stock"`
+	LowStockCount     int                          `json:"low_stock_count"`
+	Totals            UtilizationTotalsResponse    `json:"totals"`
+}
+
+type ProductStockAsOfResponse struct {
+	ProductID   string         `json:"product_id"`
+	ProductName string         `json:"product_name"`
+	TenantID    string         `json:"tenant_id"`
+	AsOf        string         `json:"as_of"`
+	Stock       domain.Decimal `json:"stock"`
+	// Day of the snapshot the stock was rebuilt from, if any
+	SnapshotDay string `json:"snapshot_day,omitempty"`
+}
+
+type ForecastSettingsResponse struct {
+	Method          string  `json:"method"`
+	WindowDays      int     `json:"window_days"`
+	Alpha           float64 `json:"alpha"`
+	LeadTimeDays    int     `json:"lead_time_days"`
+	SafetyStockDays int     `json:"safety_stock_days"`
+	CoverDays       int     `json:"cover_days"`
+}
+
+type ProductForecastResponse struct {
+	ProductID    string  `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	CurrentStock int     `json:"current_stock"`
+	DailyDemand  float64 `json:"daily_demand"`
+	// Omitted when the product is not consumed
+	DaysUntilStockout *float64 `json:"days_until_stockout,omitempty"`
+	SafetyStock       int      `json:"safety_stock"`
+	ReorderPoint      int      `json:"reorder_point"`
+	NeedsReorder      bool     `json:"needs_reorder"`
+	ReorderQuantity   int      `json:"reorder_quantity"`
+}
+
+type ForecastReportResponse struct {
+	TenantID string                    `json:"tenant_id"`
+	Settings ForecastSettingsResponse  `json:"settings"`
+	From     string                    `json:"from"`
+	To       string                    `json:"to"`
+	Products []ProductForecastResponse `json:"products"`
+}
+
+type PurchaseOrderLineRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	Supplier string `json:"supplier" validate:"required_without=SupplierID"`
+	// A registered supplier; its name is used when supplier is empty
+	SupplierID string                     `json:"supplier_id"`
+	Number     string                     `json:"number"`
+	Notes      string                     `json:"notes"`
+	Lines      []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1"`
+	// RFC3339, optional
+	ExpectedAt string `json:"expected_at"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	TenantID         string                     `json:"tenant_id" validate:"required"`
+	Lines            []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1"`
+	Notes            string                     `json:"notes"`
+	AllowOverReceipt bool                       `json:"allow_over_receipt"`
+}
+
+type PurchaseOrderActionRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ProductID    string `json:"product_id"`
+	ProductName  string `json:"product_name"`
+	Expected     int    `json:"expected"`
+	Received     int    `json:"received"`
+	Outstanding  int    `json:"outstanding"`
+	OverReceived int    `json:"over_received"`
+}
+
+type PurchaseOrderReceiptResponse struct {
+	Lines      []PurchaseOrderLineRequest `json:"lines"`
+	ReceivedBy string                     `json:"received_by"`
+	Notes      string                     `json:"notes,omitempty"`
+	ReceivedAt string                     `json:"received_at"`
+}
+
+type PurchaseOrderResponse struct {
+	ID         string                         `json:"id"`
+	TenantID   string                         `json:"tenant_id"`
+	Supplier   string                         `json:"supplier"`
+	SupplierID string                         `json:"supplier_id,omitempty"`
+	Number     string                         `json:"number,omitempty"`
+	Notes      string                         `json:"notes,omitempty"`
+	Status     string                         `json:"status"`
+	Lines      []PurchaseOrderLineResponse    `json:"lines"`
+	Receipts   []PurchaseOrderReceiptResponse `json:"receipts"`
+	ExpectedAt string                         `json:"expected_at,omitempty"`
+	CreatedBy  string                         `json:"created_by"`
+	CreatedAt  string                         `json:"created_at"`
+	ClosedBy   string                         `json:"closed_by,omitempty"`
+	ClosedAt   string                         `json:"closed_at,omitempty"`
+}
+
+type ReceivePurchaseOrderResponse struct {
+	Success bool                  `json:"success"`
+	Order   PurchaseOrderResponse `json:"order"`
+	// Stock change of each received line
+	Stock     []AddStockResponse `json:"stock"`
+	Timestamp string             `json:"timestamp"`
+}
+
+type SupplierProductRequest struct {
+	ProductID        string `json:"product_id" validate:"required"`
+	SupplierSKU      string `json:"supplier_sku"`
+	LeadTimeDays     int    `json:"lead_time_days" validate:"min=0"`
+	MinOrderQuantity int    `json:"min_order_quantity" validate:"min=0"`
+	PackSize         int    `json:"pack_size" validate:"min=0"`
+}
+
+type SupplierRequest struct {
+	TenantID    string `json:"tenant_id" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	ContactName string `json:"contact_name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Notes       string `json:"notes"`
+	// Ignored on create, where suppliers start active
+	IsActive bool `json:"is_active"`
+	// Only read on create; links are changed through the products endpoints
+	Products []SupplierProductRequest `json:"products"`
+}
+
+// Body of PUT /api/v1/suppliers/:id/products/:product_id
+type SupplierProductLinkRequest struct {
+	TenantID         string `json:"tenant_id" validate:"required"`
+	SupplierSKU      string `json:"supplier_sku"`
+	LeadTimeDays     int    `json:"lead_time_days" validate:"min=0"`
+	MinOrderQuantity int    `json:"min_order_quantity" validate:"min=0"`
+	PackSize         int    `json:"pack_size" validate:"min=0"`
+}
+
+type SupplierProductResponse struct {
+	ProductID        string `json:"product_id"`
+	SupplierSKU      string `json:"supplier_sku,omitempty"`
+	LeadTimeDays     int    `json:"lead_time_days"`
+	MinOrderQuantity int    `json:"min_order_quantity"`
+	PackSize         int    `json:"pack_size"`
+}
+
+type SupplierResponse struct {
+	ID          string                    `json:"id"`
+	TenantID    string                    `json:"tenant_id"`
+	Name        string                    `json:"name"`
+	ContactName string                    `json:"contact_name,omitempty"`
+	Email       string                    `json:"email,omitempty"`
+	Phone       string                    `json:"phone,omitempty"`
+	Notes       string                    `json:"notes,omitempty"`
+	IsActive    bool                      `json:"is_active"`
+	Products    []SupplierProductResponse `json:"products"`
+	CreatedAt   string                    `json:"created_at"`
+	UpdatedAt   string                    `json:"updated_at"`
+}
+
+type ProductValuationResponse struct {
+	ProductID        string       `json:"product_id"`
+	ProductName      string       `json:"product_name"`
+	Stock            int          `json:"stock"`
+	CostedQuantity   int          `json:"costed_quantity"`
+	UncostedQuantity int          `json:"uncosted_quantity"`
+	Value            domain.Money `json:"value"`
+	AverageUnitCost  domain.Money `json:"average_unit_cost"`
+	// Removals in the report period
+	QuantityRemoved    int          `json:"quantity_removed"`
+	CostOfGoodsRemoved domain.Money `json:"cost_of_goods_removed"`
+}
+
+type ValuationReportResponse struct {
+	TenantID                string                     `json:"tenant_id"`
+	Method                  string                     `json:"method"`
+	From                    string                     `json:"from"`
+	To                      string                     `json:"to"`
+	Products                []ProductValuationResponse `json:"products"`
+	TotalValue              domain.Money               `json:"total_value"`
+	TotalCostOfGoodsRemoved domain.Money               `json:"total_cost_of_goods_removed"`
+	UncostedQuantity        int                        `json:"uncosted_quantity"`
+}
+
+type UnitConversionRequest struct {
+	Unit     string `json:"unit" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+	Of       string `json:"of" validate:"required"`
+}
+
+type ProductUnitsRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	// "each" when empty
+	BaseUnit string                  `json:"base_unit"`
+	Units    []UnitConversionRequest `json:"units"`
+	// "integer" or "decimal" for goods weighed or measured; the current
+	// mode when empty. Decimal mode keeps quantity_precision places, 3 when
+	// unset.
+	QuantityMode      string `json:"quantity_mode"`
+	QuantityPrecision int    `json:"quantity_precision"`
+}
+
+type ProductUnitResponse struct {
+	Unit     string `json:"unit"`
+	Quantity int    `json:"quantity"`
+	Of       string `json:"of"`
+	// Base units in one unit; omitted when a unit holds a fraction of one
+	BaseQuantity int `json:"base_quantity,omitempty"`
+}
+
+type ProductUnitsResponse struct {
+	ProductID   string                `json:"product_id"`
+	ProductName string                `json:"product_name"`
+	BaseUnit    string                `json:"base_unit"`
+	Units       []ProductUnitResponse `json:"units"`
+
+	QuantityMode      string `json:"quantity_mode"`
+	QuantityPrecision int    `json:"quantity_precision"`
+}
+
+type ProductIdentifiersRequest struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	// Empty clears them; barcodes are EAN-8, UPC-A, EAN-13 or GTIN-14,
+	// stored as GTIN-13
+	SKU     string `json:"sku"`
+	Barcode string `json:"barcode"`
+	// Groups the product as a variant under parent_id; empty makes it
+	// stand alone. variant_options say what sets it apart, e.g. size: L.
+	ParentID       string            `json:"parent_id"`
+	VariantOptions map[string]string `json:"variant_options"`
+}
+
+type ProductVariantResponse struct {
+	ProductID    string            `json:"product_id"`
+	ProductName  string            `json:"product_name"`
+	SKU          string            `json:"sku,omitempty"`
+	Barcode      string            `json:"barcode,omitempty"`
+	Options      map[string]string `json:"options,omitempty"`
+	CurrentStock domain.Decimal    `json:"current_stock"`
+}
+
+type ProductIdentifiersResponse struct {
+	ProductID      string                   `json:"product_id"`
+	ProductName    string                   `json:"product_name"`
+	SKU            string                   `json:"sku,omitempty"`
+	Barcode        string                   `json:"barcode,omitempty"`
+	ParentID       string                   `json:"parent_id,omitempty"`
+	VariantOptions map[string]string        `json:"variant_options,omitempty"`
+	Variants       []ProductVariantResponse `json:"variants"`
+}
@@ -0,0 +1,133 @@
+// internal/api/http/export_handler.go
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Exports are streamed, so they get longer than the usual request timeout
+const exportTimeout = 10 * time.Minute
+
+// Time the client gets to take each part of an export; the server write
+// timeout would otherwise cut off the whole response
+const exportWriteTimeout = 10 * time.Second
+
+var exportContentTypes = map[string]string{
+	domain.ExportFormatCSV:     "text/csv; charset=utf-8",
+	domain.ExportFormatNDJSON:  "application/x-ndjson",
+	domain.ExportFormatParquet: "application/vnd.apache.parquet",
+}
+
+// Stock levels and history for finance, as files
+type ExportHandler struct {
+	exportStockUseCase usecases.ExportStockUseCase
+}
+
+func NewExportHandler(exportStockUseCase usecases.ExportStockUseCase) *ExportHandler {
+	return &ExportHandler{exportStockUseCase: exportStockUseCase}
+}
+
+// GET /api/v1/exports/stock?tenant_id=...&format=csv|ndjson|parquet[&as_of=2024-03-01]
+func (h *ExportHandler) StockLevels(c *fiber.Ctx) error {
+	var asOf time.Time
+	if raw := c.Query("as_of"); raw != "" {
+		parsed, err := parseAsOf(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		asOf = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	export, err := h.exportStockUseCase.StockLevels(ctx, usecases.ExportStockLevelsRequest{
+		TenantID: c.Query("tenant_id"),
+		Format:   c.Query("format", domain.ExportFormatCSV),
+		AsOf:     asOf,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return streamExport(c, export)
+}
+
+// GET /api/v1/exports/history?tenant_id=...&format=csv&from=2024-05-01&to=2024-06-01
+func (h *ExportHandler) StockHistory(c *fiber.Ctx) error {
+	var from, to time.Time
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseReportTime(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseReportTime(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		to = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	export, err := h.exportStockUseCase.StockHistory(ctx, usecases.ExportStockHistoryRequest{
+		TenantID: c.Query("tenant_id"),
+		Format:   c.Query("format", domain.ExportFormatCSV),
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return streamExport(c, export)
+}
+
+// streamExport sends the export as a download while it is written. Errors
+// after the first bytes can only end the response early, so they are logged.
+func streamExport(c *fiber.Ctx, export *usecases.StockExport) error {
+	c.Set(fiber.HeaderContentType, exportContentTypes[export.Format])
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, export.FileName))
+	// The server write timeout is applied once per response; move it
+	// forward before every write so only a stalled client times out, as
+	// StreamHandler.Stream does.
+	conn := c.Context().Conn()
+	extendDeadline := func() {
+		_ = conn.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	}
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		if _, err := export.WriteTo(ctx, deadlineWriter{w: w, extend: extendDeadline}); err != nil {
+			log.Printf("Export %s failed: %v", export.FileName, err)
+		}
+		extendDeadline()
+		_ = w.Flush()
+	})
+	return nil
+}
+
+// deadlineWriter calls extend before each write. The buffered writer
+// below it sends full buffers to the connection during a write, so the
+// deadline is always fresh when they go out.
+type deadlineWriter struct {
+	w      io.Writer
+	extend func()
+}
+
+func (d deadlineWriter) Write(p []byte) (int, error) {
+	d.extend()
+	return d.w.Write(p)
+}
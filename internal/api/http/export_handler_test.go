@@ -0,0 +1,143 @@
+package http_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockExportStockUseCase implements usecases.ExportStockUseCase for handler tests.
+type mockExportStockUseCase struct {
+	err         error
+	lastLevels  usecases.ExportStockLevelsRequest
+	lastHistory usecases.ExportStockHistoryRequest
+}
+
+func (m *mockExportStockUseCase) export(format string) *usecases.StockExport {
+	return usecases.NewStockExport(format, "export."+format, func(ctx context.Context, w io.Writer) (int, error) {
+		_, err := io.WriteString(w, "product_id,current_stock\np1,5\n")
+		return 1, err
+	})
+}
+
+func (m *mockExportStockUseCase) StockLevels(ctx context.Context, req usecases.ExportStockLevelsRequest) (*usecases.StockExport, error) {
+	m.lastLevels = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.export(req.Format), nil
+}
+
+func (m *mockExportStockUseCase) StockHistory(ctx context.Context, req usecases.ExportStockHistoryRequest) (*usecases.StockExport, error) {
+	m.lastHistory = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.export(req.Format), nil
+}
+
+func setupExportApp(uc usecases.ExportStockUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewExportHandler(uc)
+	app.Get("/api/v1/exports/stock", handler.StockLevels)
+	app.Get("/api/v1/exports/history", handler.StockHistory)
+	return app
+}
+
+func TestExportHandler_StockLevels_StreamsCSV(t *testing.T) {
+	uc := &mockExportStockUseCase{}
+	app := setupExportApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/exports/stock?tenant_id=t1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if uc.lastLevels.TenantID != "t1" || uc.lastLevels.Format != domain.ExportFormatCSV {
+		t.Errorf("request = %+v, want tenant t1 and csv by default", uc.lastLevels)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := resp.Header.Get("Content-Disposition"); got != `attachment; filename="export.csv"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "product_id,current_stock\np1,5\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestExportHandler_StockHistory_ParsesRange(t *testing.T) {
+	uc := &mockExportStockUseCase{}
+	app := setupExportApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/exports/history?tenant_id=t1&format=ndjson&from=2024-05-01&to=2024-06-01T00:00:00Z", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	wantFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	wantTo := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if uc.lastHistory.Format != domain.ExportFormatNDJSON || !uc.lastHistory.From.Equal(wantFrom) || !uc.lastHistory.To.Equal(wantTo) {
+		t.Errorf("request = %+v", uc.lastHistory)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
+
+func TestExportHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		path   string
+		status int
+	}{
+		{"unsupported format", domain.ErrUnsupportedExportFormat, "/api/v1/exports/stock?tenant_id=t1&format=xml", 400},
+		{"unknown tenant", domain.ErrTenantNotFound, "/api/v1/exports/stock?tenant_id=nope", 404},
+		{"bad date", nil, "/api/v1/exports/history?tenant_id=t1&from=yesterday", 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupExportApp(&mockExportStockUseCase{err: tt.err})
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestExportHandler_StockLevels_AsOf(t *testing.T) {
+	uc := &mockExportStockUseCase{}
+	app := setupExportApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/exports/stock?tenant_id=t1&as_of=2024-03-01", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if want := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC); !uc.lastLevels.AsOf.Equal(want) {
+		t.Errorf("as of = %v, want the end of March 1st", uc.lastLevels.AsOf)
+	}
+}
@@ -13,12 +13,17 @@ import (
 )
 
 type StockHandler struct {
-	addStockUseCase usecases.AddStockUseCase
+	addStockUseCase    usecases.AddStockUseCase
+	removeStockUseCase usecases.RemoveStockUseCase
 }
 
-func NewStockHandler(addStockUseCase usecases.AddStockUseCase) *StockHandler {
+func NewStockHandler(
+	addStockUseCase usecases.AddStockUseCase,
+	removeStockUseCase usecases.RemoveStockUseCase,
+) *StockHandler {
 	return &StockHandler{
-		addStockUseCase: addStockUseCase,
+		addStockUseCase:    addStockUseCase,
+		removeStockUseCase: removeStockUseCase,
 	}
 }
 
@@ -36,12 +41,19 @@ func (h *StockHandler) AddStock(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 
 	// 3. Convert HTTP DTO to Application DTO
+	quantity, decimalQuantity := splitQuantity(req.Quantity)
 	appReq := usecases.AddStockRequest{
-		ProductID: req.ProductID,
-		Quantity:  req.Quantity,
-		TenantID:  req.TenantID,
-		Notes:     req.Notes,
-		AddedBy:   userID,
+		ProductID:       req.ProductID,
+		SKU:             req.SKU,
+		Barcode:         req.Barcode,
+		Quantity:        quantity,
+		DecimalQuantity: decimalQuantity,
+		Unit:            req.Unit,
+		TenantID:        req.TenantID,
+		Notes:           req.Notes,
+		AddedBy:         userID,
+		SupplierID:      req.SupplierID,
+		UnitCost:        req.UnitCost,
 	}
 
 	// 4. Call use case (business logic)
@@ -50,64 +62,448 @@ func (h *StockHandler) AddStock(c *fiber.Ctx) error {
 
 	response, err := h.addStockUseCase.Execute(ctx, appReq)
 	if err != nil {
-		return h.handleError(c, err)
+		return handleError(c, err)
+	}
+	if response.PendingApproval != nil {
+		return c.Status(202).JSON(toPendingApprovalResponse(response.ProductID, response.PendingApproval))
 	}
 
 	// 5. Convert Application Response to HTTP Response
+	change := stockChange(response.Added, response.PreviousStock, response.NewStock, response.Decimal)
 	resp := AddStockResponse{
+		Success:         true,
+		ProductID:       response.ProductID,
+		ProductName:     response.ProductName,
+		Previous:        change.PreviousStock,
+		NewStock:        change.NewStock,
+		Added:           change.Quantity,
+		MaxAllowed:      response.MaxAllowed,
+		EnteredQuantity: response.EnteredQuantity,
+		Unit:            response.Unit,
+		BaseUnit:        response.BaseUnit,
+		Utilization:     response.Utilization,
+		Message:         "Stock updated successfully",
+		Timestamp:       time.Now().Format(time.RFC3339),
+	}
+
+	// 6. Return HTTP response
+	return c.Status(200).JSON(resp)
+}
+
+// POST /api/v1/stock/remove
+func (h *StockHandler) RemoveStock(c *fiber.Ctx) error {
+	var req RemoveStockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	quantity, decimalQuantity := splitQuantity(req.Quantity)
+	response, err := h.removeStockUseCase.Execute(ctx, usecases.RemoveStockRequest{
+		ProductID:       req.ProductID,
+		Quantity:        quantity,
+		DecimalQuantity: decimalQuantity,
+		TenantID:        req.TenantID,
+		Notes:           req.Notes,
+		RemovedBy:       userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	if response.PendingApproval != nil {
+		return c.Status(202).JSON(toPendingApprovalResponse(response.ProductID, response.PendingApproval))
+	}
+
+	change := stockChange(response.Removed, response.PreviousStock, response.NewStock, response.Decimal)
+	return c.Status(200).JSON(RemoveStockResponse{
 		Success:     true,
 		ProductID:   response.ProductID,
 		ProductName: response.ProductName,
-		Previous:    response.PreviousStock,
-		NewStock:    response.NewStock,
-		Added:       response.Added,
-		MaxAllowed:  response.MaxAllowed,
-		Utilization: response.Utilization,
+		Previous:    change.PreviousStock,
+		NewStock:    change.NewStock,
+		Removed:     change.Quantity,
+		CostOfGoods: response.CostOfGoods,
 		Message:     "Stock updated successfully",
 		Timestamp:   time.Now().Format(time.RFC3339),
+	})
+}
+
+// splitQuantity passes a whole quantity as is and one with decimal places
+// as the exact quantity decimal-mode products take
+func splitQuantity(q domain.Decimal) (int, *domain.Decimal) {
+	if q.Scale() == 0 {
+		return q.IntPart(), nil
 	}
+	return q.IntPart(), &q
+}
 
-	// 6. Return HTTP response
-	return c.Status(200).JSON(resp)
+// stockChange is a change in whole units, or the exact quantities of a
+// decimal-mode product when the use case reported them
+func stockChange(quantity, previous, current int, exact *domain.DecimalStockChange) domain.DecimalStockChange {
+	if exact != nil {
+		return *exact
+	}
+	return domain.DecimalStockChange{
+		Quantity:      domain.DecimalFromInt(quantity),
+		PreviousStock: domain.DecimalFromInt(previous),
+		NewStock:      domain.DecimalFromInt(current),
+	}
 }
 
-func (h *StockHandler) handleError(c *fiber.Ctx, err error) error {
+func toPendingApprovalResponse(productID string, pending *usecases.PendingApproval) PendingApprovalResponse {
+	return PendingApprovalResponse{
+		Success:   true,
+		Status:    domain.ChangeRequestPending,
+		RequestID: pending.RequestID,
+		ProductID: productID,
+		ExpiresAt: pending.ExpiresAt.Format(time.RFC3339),
+		Message:   "Change exceeds the approval threshold and awaits approval",
+	}
+}
+
+// Shared by all handlers in this package
+func handleError(c *fiber.Ctx, err error) error {
+	status, resp := errorResponse(err)
+	return c.Status(status).JSON(resp)
+}
+
+// errorResponse maps an error to its HTTP status and body, for handlers
+// that report errors per item instead of per request
+func errorResponse(err error) (int, ErrorResponse) {
 	// Map domain errors to HTTP status codes
 	switch err.(type) {
 	case domain.ErrStockExceedsLimit:
-		return c.Status(400).JSON(ErrorResponse{
+		return 400, ErrorResponse{
 			Error: err.Error(),
 			Code:  "STOCK_LIMIT_EXCEEDED",
-		})
+		}
+	case domain.ErrInvalidImportFile:
+		return 400, ErrorResponse{
+			Error:   "Invalid import file",
+			Code:    "INVALID_IMPORT_FILE",
+			Details: err.(domain.ErrInvalidImportFile).Reason,
+		}
+	case domain.ErrOverReceipt:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "OVER_RECEIPT",
+		}
+	case domain.ErrInvalidTemplate:
+		return 400, ErrorResponse{
+			Error:   "Invalid template",
+			Code:    "INVALID_TEMPLATE",
+			Details: err.Error(),
+		}
 	}
 
 	// Map other domain errors
 	switch err {
 	case domain.ErrProductNotFound:
-		return c.Status(404).JSON(ErrorResponse{
+		return 404, ErrorResponse{
 			Error: "Product not found",
 			Code:  "PRODUCT_NOT_FOUND",
-		})
+		}
 	case domain.ErrTenantNotFound:
-		return c.Status(404).JSON(ErrorResponse{
+		return 404, ErrorResponse{
 			Error: "Tenant not found",
 			Code:  "TENANT_NOT_FOUND",
-		})
+		}
 	case domain.ErrTenantInactive:
-		return c.Status(400).JSON(ErrorResponse{
+		return 400, ErrorResponse{
 			Error: "Tenant is inactive",
 			Code:  "TENANT_INACTIVE",
-		})
+		}
 	case domain.ErrInvalidQuantity:
-		return c.Status(400).JSON(ErrorResponse{
+		return 400, ErrorResponse{
 			Error: "Quantity must be positive",
 			Code:  "INVALID_QUANTITY",
-		})
+		}
+	case domain.ErrWebhookNotFound:
+		return 404, ErrorResponse{
+			Error: "Webhook subscription not found",
+			Code:  "WEBHOOK_NOT_FOUND",
+		}
+	case domain.ErrInvalidWebhookURL, domain.ErrWebhookURLNotPublic, domain.ErrInvalidWebhookSecret:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_WEBHOOK",
+		}
+	case domain.ErrTemplateNotFound:
+		return 404, ErrorResponse{
+			Error: "Notification template not found",
+			Code:  "TEMPLATE_NOT_FOUND",
+		}
+	case domain.ErrRoutingRuleNotFound:
+		return 404, ErrorResponse{
+			Error: "Routing rule not found",
+			Code:  "ROUTING_RULE_NOT_FOUND",
+		}
+	case domain.ErrInvalidChannel:
+		return 400, ErrorResponse{
+			Error: "Invalid notification channel",
+			Code:  "INVALID_CHANNEL",
+		}
+	case domain.ErrInvalidSeverity:
+		return 400, ErrorResponse{
+			Error: "Invalid severity",
+			Code:  "INVALID_SEVERITY",
+		}
+	case domain.ErrInvalidEventType:
+		return 400, ErrorResponse{
+			Error: "Invalid event type",
+			Code:  "INVALID_EVENT_TYPE",
+		}
+	case domain.ErrInsufficientStock:
+		return 400, ErrorResponse{
+			Error: "Insufficient stock",
+			Code:  "INSUFFICIENT_STOCK",
+		}
+	case domain.ErrReasonCodeRequired:
+		return 400, ErrorResponse{
+			Error: "Reason code is required",
+			Code:  "REASON_CODE_REQUIRED",
+		}
+	case domain.ErrInvalidReasonCode, domain.ErrInvalidReasonDirection, domain.ErrReasonDirectionMismatch:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REASON_CODE",
+		}
+	case domain.ErrAdjustmentReasonNotFound:
+		return 404, ErrorResponse{
+			Error: "Adjustment reason not found",
+			Code:  "ADJUSTMENT_REASON_NOT_FOUND",
+		}
+	case domain.ErrInvalidTimeRange:
+		return 400, ErrorResponse{
+			Error: "Invalid time range",
+			Code:  "INVALID_TIME_RANGE",
+		}
+	case domain.ErrChangeRequestNotFound:
+		return 404, ErrorResponse{
+			Error: "Stock change request not found",
+			Code:  "CHANGE_REQUEST_NOT_FOUND",
+		}
+	case domain.ErrChangeRequestClosed:
+		return 409, ErrorResponse{
+			Error: "Stock change request was already decided",
+			Code:  "CHANGE_REQUEST_CLOSED",
+		}
+	case domain.ErrChangeRequestExpired:
+		return 409, ErrorResponse{
+			Error: "Stock change request has expired",
+			Code:  "CHANGE_REQUEST_EXPIRED",
+		}
+	case domain.ErrApproverRoleRequired, domain.ErrSelfApproval:
+		return 403, ErrorResponse{
+			Error: err.Error(),
+			Code:  "APPROVAL_FORBIDDEN",
+		}
+	case domain.ErrHistoryEntryNotFound:
+		return 404, ErrorResponse{
+			Error: "Stock history entry not found",
+			Code:  "HISTORY_ENTRY_NOT_FOUND",
+		}
+	case domain.ErrMovementAlreadyReversed:
+		return 409, ErrorResponse{
+			Error: "Stock movement was already reversed",
+			Code:  "ALREADY_REVERSED",
+		}
+	case domain.ErrMovementNotReversible:
+		return 409, ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_REVERSIBLE",
+		}
+	case domain.ErrEmptyBatch, domain.ErrBatchTooLarge, domain.ErrInvalidBatchMode:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_BATCH",
+		}
+	case domain.ErrBatchAborted:
+		return 424, ErrorResponse{
+			Error: err.Error(),
+			Code:  "BATCH_ABORTED",
+		}
+	case domain.ErrImportJobNotFound:
+		return 404, ErrorResponse{
+			Error: "Import job not found",
+			Code:  "IMPORT_JOB_NOT_FOUND",
+		}
+	case domain.ErrUnsupportedImportFormat:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "UNSUPPORTED_IMPORT_FORMAT",
+		}
+	case domain.ErrUnsupportedExportFormat:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "UNSUPPORTED_EXPORT_FORMAT",
+		}
+	case domain.ErrInvalidForecastSettings:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_FORECAST_SETTINGS",
+		}
+	case domain.ErrPurchaseOrderNotFound:
+		return 404, ErrorResponse{
+			Error: "Purchase order not found",
+			Code:  "PURCHASE_ORDER_NOT_FOUND",
+		}
+	case domain.ErrPurchaseOrderClosed:
+		return 409, ErrorResponse{
+			Error: "Purchase order is received or cancelled",
+			Code:  "PURCHASE_ORDER_CLOSED",
+		}
+	case domain.ErrPurchaseOrderChanged:
+		return 409, ErrorResponse{
+			Error: "Purchase order was changed concurrently, retry the request",
+			Code:  "CONCURRENT_UPDATE",
+		}
+	case domain.ErrEmptyPurchaseOrder, domain.ErrDuplicatePurchaseOrderLine, domain.ErrProductNotOnPurchaseOrder:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_PURCHASE_ORDER",
+		}
+	case domain.ErrSupplierNotFound:
+		return 404, ErrorResponse{
+			Error: "Supplier not found",
+			Code:  "SUPPLIER_NOT_FOUND",
+		}
+	case domain.ErrSupplierInactive:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "SUPPLIER_INACTIVE",
+		}
+	case domain.ErrInvalidSupplier, domain.ErrInvalidSupplierProduct:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_SUPPLIER",
+		}
+	case domain.ErrProductNotSupplied:
+		return 404, ErrorResponse{
+			Error: err.Error(),
+			Code:  "PRODUCT_NOT_SUPPLIED",
+		}
+	case domain.ErrUnknownUnit:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "UNKNOWN_UNIT",
+		}
+	case domain.ErrNonIntegralQuantity:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "NON_INTEGRAL_QUANTITY",
+		}
+	case domain.ErrQuantityTooPrecise:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "QUANTITY_TOO_PRECISE",
+		}
+	case domain.ErrInvalidDecimal:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_QUANTITY",
+		}
+	case domain.ErrQuantityOutOfRange:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "QUANTITY_OUT_OF_RANGE",
+		}
+	case domain.ErrInvalidQuantityMode:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_QUANTITY_MODE",
+		}
+	case domain.ErrDecimalUnsupported:
+		return 409, ErrorResponse{
+			Error: err.Error(),
+			Code:  "DECIMAL_QUANTITY_UNSUPPORTED",
+		}
+	case domain.ErrInvalidProductID, domain.ErrInvalidProductIdentifier:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_PRODUCT_REFERENCE",
+		}
+	case domain.ErrInvalidSKU:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_SKU",
+		}
+	case domain.ErrInvalidBarcode:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_BARCODE",
+		}
+	case domain.ErrDuplicateProductIdentifier:
+		return 409, ErrorResponse{
+			Error: err.Error(),
+			Code:  "DUPLICATE_PRODUCT_IDENTIFIER",
+		}
+	case domain.ErrInvalidVariant:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_VARIANT",
+		}
+	case domain.ErrInvalidUnitConversion:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_UNIT_CONVERSION",
+		}
+	case domain.ErrInvalidUnitCost, domain.ErrInvalidMoney:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_UNIT_COST",
+		}
+	case domain.ErrProductCostChanged:
+		return 409, ErrorResponse{
+			Error: "Product cost was changed concurrently, retry the request",
+			Code:  "CONCURRENT_UPDATE",
+		}
+	case domain.ErrDuplicateImport:
+		return 409, ErrorResponse{
+			Error: err.Error(),
+			Code:  "DUPLICATE_IMPORT",
+		}
+	case domain.ErrConcurrentStockUpdate:
+		return 409, ErrorResponse{
+			Error: "Stock was changed concurrently, retry the request",
+			Code:  "CONCURRENT_UPDATE",
+		}
+	case domain.ErrProductLockedForCount:
+		return 409, ErrorResponse{
+			Error: "Product is locked by a stock count",
+			Code:  "PRODUCT_LOCKED",
+		}
+	case domain.ErrCountSessionNotFound:
+		return 404, ErrorResponse{
+			Error: "Count session not found",
+			Code:  "COUNT_SESSION_NOT_FOUND",
+		}
+	case domain.ErrCountSessionClosed:
+		return 409, ErrorResponse{
+			Error: "Count session is closed",
+			Code:  "COUNT_SESSION_CLOSED",
+		}
+	case domain.ErrEmptyCountSession, domain.ErrProductNotInCount:
+		return 400, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_COUNT",
+		}
+	case domain.ErrCountIncomplete, domain.ErrCountDisputed:
+		return 409, ErrorResponse{
+			Error: err.Error(),
+			Code:  "COUNT_NOT_APPROVABLE",
+		}
 	default:
 		// Log internal errors but don't expose details
 		log.Printf("Internal error: %v", err)
-		return c.Status(500).JSON(ErrorResponse{
+		return 500, ErrorResponse{
 			Error: "Internal server error",
-		})
+		}
 	}
 }
@@ -23,9 +23,11 @@ var errInternal = errors.New("internal failure")
 type mockAddStockUseCase struct {
 	response *usecases.AddStockResponse
 	err      error
+	last     usecases.AddStockRequest
 }
 
 func (m *mockAddStockUseCase) Execute(ctx context.Context, req usecases.AddStockRequest) (*usecases.AddStockResponse, error) {
+	m.last = req
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -37,7 +39,7 @@ const testUserID = "test-user-123"
 func setupAddStockApp(uc usecases.AddStockUseCase) *fiber.App {
 	app := fiber.New()
 	app.Use(httputil.UserIDMiddleware(testUserID))
-	handler := httphandler.NewStockHandler(uc)
+	handler := httphandler.NewStockHandler(uc, nil)
 	app.Post("/api/v1/stock/add", handler.AddStock)
 	return app
 }
@@ -82,8 +84,158 @@ func TestStockHandler_AddStock_Success(t *testing.T) {
 	if !result.Success || result.ProductID != "p1" || result.ProductName != "Widget" {
 		t.Errorf("response: success=%v product_id=%s product_name=%s", result.Success, result.ProductID, result.ProductName)
 	}
-	if result.Previous != 10 || result.NewStock != 25 || result.Added != 15 {
-		t.Errorf("response: previous=%d new_stock=%d added=%d", result.Previous, result.NewStock, result.Added)
+	if result.Previous.String() != "10" || result.NewStock.String() != "25" || result.Added.String() != "15" {
+		t.Errorf("response: previous=%s new_stock=%s added=%s", result.Previous, result.NewStock, result.Added)
+	}
+}
+
+func TestStockHandler_AddStock_UnitCost(t *testing.T) {
+	tests := []struct {
+		name string
+		cost interface{}
+		want string
+	}{
+		{"number", 12.5, "12.50"},
+		{"string", "0.99", "0.99"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mockAddStockUseCase{response: &usecases.AddStockResponse{ProductID: "p1"}}
+			app := setupAddStockApp(uc)
+
+			resp := postJSON(t, app, "/api/v1/stock/add", map[string]interface{}{
+				"product_id": "p1", "quantity": 3, "tenant_id": "t1", "unit_cost": tt.cost,
+			})
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+			if uc.last.UnitCost == nil || uc.last.UnitCost.String() != tt.want {
+				t.Errorf("unit cost = %v, want %s", uc.last.UnitCost, tt.want)
+			}
+		})
+	}
+}
+
+func TestStockHandler_AddStock_Unit(t *testing.T) {
+	uc := &mockAddStockUseCase{response: &usecases.AddStockResponse{
+		ProductID: "p1", PreviousStock: 0, NewStock: 36, Added: 36, EnteredQuantity: domain.DecimalFromInt(3), Unit: "case", BaseUnit: "each",
+	}}
+	app := setupAddStockApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/stock/add", map[string]interface{}{
+		"product_id": "p1", "quantity": 3, "unit": "case", "tenant_id": "t1",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.last.Quantity != 3 || uc.last.Unit != "case" {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	var result httphandler.AddStockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.Added.String() != "36" || result.EnteredQuantity.String() != "3" || result.Unit != "case" || result.BaseUnit != "each" {
+		t.Errorf("response = %+v", result)
+	}
+}
+
+func TestStockHandler_AddStock_NonIntegralQuantity(t *testing.T) {
+	uc := &mockAddStockUseCase{err: domain.ErrNonIntegralQuantity}
+	app := setupAddStockApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/stock/add", map[string]interface{}{
+		"product_id": "p1", "quantity": 5, "unit": "each", "tenant_id": "t1",
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestStockHandler_AddStock_DecimalQuantity(t *testing.T) {
+	exact := &domain.DecimalStockChange{}
+	exact.Quantity, _ = domain.ParseDecimal("2.500")
+	exact.PreviousStock, _ = domain.ParseDecimal("10.250")
+	exact.NewStock, _ = domain.ParseDecimal("12.750")
+	uc := &mockAddStockUseCase{response: &usecases.AddStockResponse{
+		ProductID: "p1", PreviousStock: 10, NewStock: 12, Added: 2, Decimal: exact,
+	}}
+	app := setupAddStockApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/stock/add", json.RawMessage(`{"product_id":"p1","quantity":2.5,"tenant_id":"t1"}`))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.last.DecimalQuantity == nil || uc.last.DecimalQuantity.String() != "2.5" {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	var result map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	// Every digit survives, trailing zeros included
+	if string(result["added"]) != "2.500" || string(result["previous_stock"]) != "10.250" || string(result["new_stock"]) != "12.750" {
+		t.Errorf("response: added=%s previous=%s new_stock=%s", result["added"], result["previous_stock"], result["new_stock"])
+	}
+}
+
+func TestStockHandler_AddStock_QuantityOutOfRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity string
+	}{
+		{"huge exponent", "1e9999999999"},
+		{"large exponent", "1e100000000"},
+		{"more than 18 digits", "1234567890123456789"},
+		{"too many places", "0.0000000001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &mockAddStockUseCase{}
+			app := setupAddStockApp(uc)
+
+			resp := postJSON(t, app, "/api/v1/stock/add", json.RawMessage(`{"product_id":"p1","quantity":`+tt.quantity+`,"tenant_id":"t1"}`))
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+			if uc.last.ProductID != "" {
+				t.Errorf("use case called with %+v", uc.last)
+			}
+		})
+	}
+}
+
+func TestStockHandler_AddStock_BySKU(t *testing.T) {
+	uc := &mockAddStockUseCase{response: &usecases.AddStockResponse{ProductID: "p1", NewStock: 5, Added: 5}}
+	app := setupAddStockApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/stock/add", map[string]interface{}{
+		"sku": "WID-001", "quantity": 5, "tenant_id": "t1",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.last.SKU != "WID-001" || uc.last.ProductID != "" {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	var result httphandler.AddStockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.ProductID != "p1" {
+		t.Errorf("product_id = %s, want the resolved p1", result.ProductID)
+	}
+}
+
+func TestStockHandler_AddStock_InvalidUnitCost(t *testing.T) {
+	uc := &mockAddStockUseCase{response: &usecases.AddStockResponse{ProductID: "p1"}}
+	app := setupAddStockApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/stock/add", map[string]interface{}{
+		"product_id": "p1", "quantity": 3, "tenant_id": "t1", "unit_cost": "1.234",
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
 	}
 }
 
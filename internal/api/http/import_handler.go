@@ -0,0 +1,193 @@
+// internal/api/http/import_handler.go
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Stock receipts imported from CSV or XLSX packing lists
+type ImportHandler struct {
+	importStockUseCase usecases.ImportStockUseCase
+}
+
+func NewImportHandler(importStockUseCase usecases.ImportStockUseCase) *ImportHandler {
+	return &ImportHandler{importStockUseCase: importStockUseCase}
+}
+
+// POST /api/v1/imports/stock (multipart form)
+//
+// Fields: file, tenant_id, optional format (csv or xlsx), dry_run and
+// column_product_id, column_quantity, column_notes naming the header of
+// each column. A dry run answers with a preview; otherwise the file is
+// queued (202), or the earlier job is returned (200) when it was already
+// imported. A file whose job failed is only imported again with
+// resume=true, which continues that job after its last applied row.
+func (h *ImportHandler) Upload(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error:   "Invalid request format",
+			Details: "file is required",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+	req := usecases.ImportStockRequest{
+		TenantID: c.FormValue("tenant_id"),
+		FileName: fileHeader.Filename,
+		Format:   c.FormValue("format"),
+		Data:     data,
+		Mapping: domain.ImportColumnMapping{
+			ProductID: c.FormValue("column_product_id"),
+			Quantity:  c.FormValue("column_quantity"),
+			Notes:     c.FormValue("column_notes"),
+		},
+		RequestedBy: userID,
+	}
+	req.Resume, _ = strconv.ParseBool(c.FormValue("resume"))
+
+	ctx, cancel := context.WithTimeout(c.Context(), 30*time.Second)
+	defer cancel()
+
+	if dryRun, _ := strconv.ParseBool(c.FormValue("dry_run")); dryRun {
+		preview, err := h.importStockUseCase.Preview(ctx, req)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.Status(200).JSON(toImportPreviewResponse(preview))
+	}
+
+	job, err := h.importStockUseCase.Start(ctx, req)
+	if err != nil {
+		return handleError(c, err)
+	}
+	if job.Duplicate {
+		return c.Status(200).JSON(toImportJobResponse(job))
+	}
+	return c.Status(202).JSON(toImportJobResponse(job))
+}
+
+// GET /api/v1/imports/:id?tenant_id=...
+func (h *ImportHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	job, err := h.importStockUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toImportJobResponse(job))
+}
+
+// GET /api/v1/imports/:id/errors?tenant_id=...
+//
+// Downloads the rows that were not applied as CSV.
+func (h *ImportHandler) ErrorReport(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	jobID := c.Params("id")
+	rowErrors, err := h.importStockUseCase.ErrorReport(ctx, c.Query("tenant_id"), jobID)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="import-%s-errors.csv"`, jobID))
+	w := csv.NewWriter(c.Response().BodyWriter())
+	_ = w.Write([]string{"line", "product_id", "quantity", "error"})
+	for _, e := range rowErrors {
+		_ = w.Write([]string{strconv.Itoa(e.Line), e.ProductID, e.Quantity, e.Message})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func toImportRowErrorResponses(errs []domain.ImportRowError) []ImportRowErrorResponse {
+	result := make([]ImportRowErrorResponse, 0, len(errs))
+	for _, e := range errs {
+		result = append(result, ImportRowErrorResponse{
+			Line:      e.Line,
+			ProductID: e.ProductID,
+			Quantity:  e.Quantity,
+			Error:     e.Message,
+		})
+	}
+	return result
+}
+
+func toImportPreviewResponse(p *usecases.ImportPreviewResponse) ImportPreviewResponse {
+	rows := make([]ImportPreviewRowResponse, 0, len(p.Rows))
+	for _, r := range p.Rows {
+		rows = append(rows, ImportPreviewRowResponse{
+			Line:          r.Line,
+			ProductID:     r.ProductID,
+			ProductName:   r.ProductName,
+			Quantity:      r.Quantity,
+			PreviousStock: r.PreviousStock,
+			NewStock:      r.NewStock,
+			NeedsApproval: r.NeedsApproval,
+		})
+	}
+	return ImportPreviewResponse{
+		DryRun:        true,
+		FileHash:      p.FileHash,
+		TotalRows:     p.TotalRows,
+		ValidRows:     p.ValidRows,
+		InvalidRows:   len(p.Errors),
+		ExistingJobID: p.ExistingJobID,
+		Rows:          rows,
+		Errors:        toImportRowErrorResponses(p.Errors),
+	}
+}
+
+func toImportJobResponse(j *usecases.ImportJobResponse) ImportJobResponse {
+	resp := ImportJobResponse{
+		ID:                  j.ID,
+		TenantID:            j.TenantID,
+		FileName:            j.FileName,
+		FileHash:            j.FileHash,
+		Format:              j.Format,
+		Status:              j.Status,
+		TotalRows:           j.TotalRows,
+		ProcessedRows:       j.ProcessedRows,
+		AppliedRows:         j.AppliedRows,
+		PendingApprovalRows: j.PendingApprovalRows,
+		FailedRows:          j.FailedRows,
+		Progress:            j.Progress,
+		Failure:             j.Failure,
+		Duplicate:           j.Duplicate,
+		CreatedBy:           j.CreatedBy,
+		CreatedAt:           j.CreatedAt.Format(time.RFC3339),
+	}
+	if !j.StartedAt.IsZero() {
+		resp.StartedAt = j.StartedAt.Format(time.RFC3339)
+	}
+	if !j.FinishedAt.IsZero() {
+		resp.FinishedAt = j.FinishedAt.Format(time.RFC3339)
+	}
+	return resp
+}
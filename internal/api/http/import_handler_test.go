@@ -0,0 +1,199 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockImportStockUseCase implements usecases.ImportStockUseCase for handler tests.
+type mockImportStockUseCase struct {
+	preview   *usecases.ImportPreviewResponse
+	job       *usecases.ImportJobResponse
+	rowErrors []domain.ImportRowError
+	err       error
+	last      usecases.ImportStockRequest
+	dryRun    bool
+}
+
+func (m *mockImportStockUseCase) Preview(ctx context.Context, req usecases.ImportStockRequest) (*usecases.ImportPreviewResponse, error) {
+	m.last, m.dryRun = req, true
+	return m.preview, m.err
+}
+
+func (m *mockImportStockUseCase) Start(ctx context.Context, req usecases.ImportStockRequest) (*usecases.ImportJobResponse, error) {
+	m.last = req
+	return m.job, m.err
+}
+
+func (m *mockImportStockUseCase) Get(ctx context.Context, tenantID, jobID string) (*usecases.ImportJobResponse, error) {
+	return m.job, m.err
+}
+
+func (m *mockImportStockUseCase) ErrorReport(ctx context.Context, tenantID, jobID string) ([]domain.ImportRowError, error) {
+	return m.rowErrors, m.err
+}
+
+func (m *mockImportStockUseCase) ProcessNext(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func setupImportApp(uc usecases.ImportStockUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewImportHandler(uc)
+	app.Post("/api/v1/imports/stock", handler.Upload)
+	app.Get("/api/v1/imports/:id", handler.Get)
+	app.Get("/api/v1/imports/:id/errors", handler.ErrorReport)
+	return app
+}
+
+func uploadFile(t *testing.T, app *fiber.App, fields map[string]string, fileName, content string) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		_ = w.WriteField(k, v)
+	}
+	part, _ := w.CreateFormFile("file", fileName)
+	_, _ = part.Write([]byte(content))
+	_ = w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/imports/stock", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp
+}
+
+func TestImportHandler_Upload_DryRun(t *testing.T) {
+	uc := &mockImportStockUseCase{preview: &usecases.ImportPreviewResponse{
+		TotalRows: 2, ValidRows: 1,
+		Rows:   []usecases.ImportPreviewRow{{Line: 2, ProductID: "p1", Quantity: 5, PreviousStock: 10, NewStock: 15}},
+		Errors: []domain.ImportRowError{{Line: 3, ProductID: "p9", Quantity: "1", Message: "product not found"}},
+	}}
+	resp := uploadFile(t, setupImportApp(uc), map[string]string{
+		"tenant_id": "t1", "dry_run": "true", "column_product_id": "SKU",
+	}, "list.csv", "SKU,quantity\np1,5\np9,1\n")
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if !uc.dryRun || uc.last.TenantID != "t1" || uc.last.FileName != "list.csv" || uc.last.Mapping.ProductID != "SKU" || uc.last.RequestedBy != testUserID {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	if !strings.HasPrefix(string(uc.last.Data), "SKU,quantity") {
+		t.Errorf("data = %q", uc.last.Data)
+	}
+	var got httphandler.ImportPreviewResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !got.DryRun || got.InvalidRows != 1 || got.Rows[0].NewStock != 15 || got.Errors[0].Error != "product not found" {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestImportHandler_Upload_QueuesJob(t *testing.T) {
+	job := &usecases.ImportJobResponse{ID: "import-1", Status: domain.ImportStatusPending, TotalRows: 2, CreatedAt: time.Now()}
+	uc := &mockImportStockUseCase{job: job}
+	app := setupImportApp(uc)
+
+	resp := uploadFile(t, app, map[string]string{"tenant_id": "t1"}, "list.csv", "product_id,quantity\np1,5\n")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || uc.dryRun {
+		t.Errorf("status = %d, dry run = %v; want %d and a queued job", resp.StatusCode, uc.dryRun, http.StatusAccepted)
+	}
+
+	job.Duplicate = true
+	resp = uploadFile(t, app, map[string]string{"tenant_id": "t1"}, "list.csv", "product_id,quantity\np1,5\n")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("duplicate status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var got httphandler.ImportJobResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != "import-1" || !got.Duplicate {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestImportHandler_Upload_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+		code string
+	}{
+		{"bad file", domain.ErrInvalidImportFile{Reason: `missing column "quantity"`}, http.StatusBadRequest, "INVALID_IMPORT_FILE"},
+		{"format", domain.ErrUnsupportedImportFormat, http.StatusBadRequest, "UNSUPPORTED_IMPORT_FORMAT"},
+		{"tenant", domain.ErrTenantNotFound, http.StatusNotFound, "TENANT_NOT_FOUND"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := uploadFile(t, setupImportApp(&mockImportStockUseCase{err: tt.err}), map[string]string{"tenant_id": "t1"}, "list.csv", "x")
+			defer resp.Body.Close()
+			var got httphandler.ErrorResponse
+			_ = json.NewDecoder(resp.Body).Decode(&got)
+			if resp.StatusCode != tt.want || got.Code != tt.code {
+				t.Errorf("status = %d, code = %q; want %d, %q", resp.StatusCode, got.Code, tt.want, tt.code)
+			}
+		})
+	}
+}
+
+func TestImportHandler_Upload_MissingFile(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/imports/stock", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := setupImportApp(&mockImportStockUseCase{}).Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestImportHandler_ErrorReport_CSV(t *testing.T) {
+	uc := &mockImportStockUseCase{rowErrors: []domain.ImportRowError{
+		{Line: 3, ProductID: "p9", Quantity: "1", Message: "product not found"},
+		{Line: 5, ProductID: "p1", Quantity: "abc", Message: "quantity must be a whole number"},
+	}}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/imports/import-1/errors?tenant_id=t1", nil)
+	resp, err := setupImportApp(uc).Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") {
+		t.Fatalf("status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(resp.Header.Get("Content-Disposition"), "import-import-1-errors.csv") {
+		t.Errorf("Content-Disposition = %q", resp.Header.Get("Content-Disposition"))
+	}
+	body, _ := io.ReadAll(resp.Body)
+	want := "line,product_id,quantity,error\n3,p9,1,product not found\n5,p1,abc,quantity must be a whole number\n"
+	if string(body) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
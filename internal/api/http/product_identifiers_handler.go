@@ -0,0 +1,95 @@
+// internal/api/http/product_identifiers_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SKUs, barcodes and variants of products
+type ProductIdentifiersHandler struct {
+	manageProductIdentifiersUseCase usecases.ManageProductIdentifiersUseCase
+}
+
+func NewProductIdentifiersHandler(manageProductIdentifiersUseCase usecases.ManageProductIdentifiersUseCase) *ProductIdentifiersHandler {
+	return &ProductIdentifiersHandler{
+		manageProductIdentifiersUseCase: manageProductIdentifiersUseCase,
+	}
+}
+
+// GET /api/v1/products/lookup?tenant_id=...&sku=... or &barcode=...
+func (h *ProductIdentifiersHandler) Lookup(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageProductIdentifiersUseCase.Lookup(ctx, c.Query("tenant_id"), c.Query("sku"), c.Query("barcode"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toProductIdentifiersResponse(response))
+}
+
+// GET /api/v1/products/:id/identifiers?tenant_id=...
+func (h *ProductIdentifiersHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageProductIdentifiersUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toProductIdentifiersResponse(response))
+}
+
+// PUT /api/v1/products/:id/identifiers
+func (h *ProductIdentifiersHandler) Set(c *fiber.Ctx) error {
+	var req ProductIdentifiersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageProductIdentifiersUseCase.Set(ctx, usecases.SetProductIdentifiersRequest{
+		TenantID:       req.TenantID,
+		ProductID:      c.Params("id"),
+		SKU:            req.SKU,
+		Barcode:        req.Barcode,
+		ParentID:       req.ParentID,
+		VariantOptions: req.VariantOptions,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toProductIdentifiersResponse(response))
+}
+
+func toProductIdentifiersResponse(r *usecases.ProductIdentifiersResponse) ProductIdentifiersResponse {
+	variants := make([]ProductVariantResponse, 0, len(r.Variants))
+	for _, v := range r.Variants {
+		variants = append(variants, ProductVariantResponse{
+			ProductID:    v.ProductID,
+			ProductName:  v.ProductName,
+			SKU:          v.SKU,
+			Barcode:      v.Barcode,
+			Options:      v.Options,
+			CurrentStock: v.CurrentStock.Decimal(),
+		})
+	}
+	return ProductIdentifiersResponse{
+		ProductID:      r.ProductID,
+		ProductName:    r.ProductName,
+		SKU:            r.SKU,
+		Barcode:        r.Barcode,
+		ParentID:       r.ParentID,
+		VariantOptions: r.VariantOptions,
+		Variants:       variants,
+	}
+}
@@ -0,0 +1,141 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockManageProductIdentifiersUseCase implements usecases.ManageProductIdentifiersUseCase for handler tests.
+type mockManageProductIdentifiersUseCase struct {
+	response   *usecases.ProductIdentifiersResponse
+	err        error
+	lastGet    [2]string
+	lastLookup [3]string
+	lastSet    usecases.SetProductIdentifiersRequest
+}
+
+func (m *mockManageProductIdentifiersUseCase) Get(ctx context.Context, tenantID, productID string) (*usecases.ProductIdentifiersResponse, error) {
+	m.lastGet = [2]string{tenantID, productID}
+	return m.response, m.err
+}
+
+func (m *mockManageProductIdentifiersUseCase) Lookup(ctx context.Context, tenantID, sku, barcode string) (*usecases.ProductIdentifiersResponse, error) {
+	m.lastLookup = [3]string{tenantID, sku, barcode}
+	return m.response, m.err
+}
+
+func (m *mockManageProductIdentifiersUseCase) Set(ctx context.Context, req usecases.SetProductIdentifiersRequest) (*usecases.ProductIdentifiersResponse, error) {
+	m.lastSet = req
+	return m.response, m.err
+}
+
+func setupProductIdentifiersApp(uc usecases.ManageProductIdentifiersUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewProductIdentifiersHandler(uc)
+	app.Get("/api/v1/products/lookup", handler.Lookup)
+	app.Get("/api/v1/products/:id/identifiers", handler.Get)
+	app.Put("/api/v1/products/:id/identifiers", handler.Set)
+	return app
+}
+
+func productIdentifiersResponse() *usecases.ProductIdentifiersResponse {
+	return &usecases.ProductIdentifiersResponse{
+		ProductID:   "p1",
+		ProductName: "T-shirt",
+		SKU:         "TEE",
+		Variants: []usecases.ProductVariant{
+			{ProductID: "p2", ProductName: "T-shirt, L", SKU: "TEE-L", Barcode: "4006381333931",
+				Options: map[string]string{"size": "L"}, CurrentStock: mustStock(7)},
+		},
+	}
+}
+
+func mustStock(q int) domain.StockQuantity {
+	s, err := domain.NewStockQuantity(q)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func TestProductIdentifiersHandler_Lookup(t *testing.T) {
+	uc := &mockManageProductIdentifiersUseCase{response: productIdentifiersResponse()}
+	app := setupProductIdentifiersApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/products/lookup?tenant_id=t1&barcode=4006381333931", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.lastLookup != [3]string{"t1", "", "4006381333931"} {
+		t.Errorf("use case called with %v", uc.lastLookup)
+	}
+	var body httphandler.ProductIdentifiersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.SKU != "TEE" || len(body.Variants) != 1 || body.Variants[0].Options["size"] != "L" || body.Variants[0].CurrentStock.String() != "7" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestProductIdentifiersHandler_Set(t *testing.T) {
+	uc := &mockManageProductIdentifiersUseCase{response: productIdentifiersResponse()}
+	app := setupProductIdentifiersApp(uc)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"tenant_id": "t1", "sku": "TEE-L", "barcode": "4006381333931",
+		"parent_id": "p1", "variant_options": map[string]string{"size": "L"},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/p2/identifiers", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := uc.lastSet; got.ProductID != "p2" || got.SKU != "TEE-L" || got.ParentID != "p1" || got.VariantOptions["size"] != "L" {
+		t.Errorf("use case request = %+v", got)
+	}
+}
+
+func TestProductIdentifiersHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"invalid barcode", domain.ErrInvalidBarcode, http.StatusBadRequest},
+		{"duplicate sku", domain.ErrDuplicateProductIdentifier, http.StatusConflict},
+		{"invalid variant", domain.ErrInvalidVariant, http.StatusBadRequest},
+		{"not found", domain.ErrProductNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupProductIdentifiersApp(&mockManageProductIdentifiersUseCase{err: tt.err})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/products/p1/identifiers?tenant_id=t1", nil), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// internal/api/http/product_units_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Units of measure a product's stock can be entered in
+type ProductUnitsHandler struct {
+	manageProductUnitsUseCase usecases.ManageProductUnitsUseCase
+}
+
+func NewProductUnitsHandler(manageProductUnitsUseCase usecases.ManageProductUnitsUseCase) *ProductUnitsHandler {
+	return &ProductUnitsHandler{
+		manageProductUnitsUseCase: manageProductUnitsUseCase,
+	}
+}
+
+// GET /api/v1/products/:id/units?tenant_id=...
+func (h *ProductUnitsHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageProductUnitsUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toProductUnitsResponse(response))
+}
+
+// PUT /api/v1/products/:id/units
+func (h *ProductUnitsHandler) Set(c *fiber.Ctx) error {
+	var req ProductUnitsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	units := make([]domain.UnitConversion, 0, len(req.Units))
+	for _, u := range req.Units {
+		units = append(units, domain.UnitConversion{Unit: u.Unit, Quantity: u.Quantity, Of: u.Of})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageProductUnitsUseCase.Set(ctx, usecases.SetProductUnitsRequest{
+		TenantID:  req.TenantID,
+		ProductID: c.Params("id"),
+		BaseUnit:  req.BaseUnit,
+		Units:     units,
+
+		QuantityMode:      req.QuantityMode,
+		QuantityPrecision: req.QuantityPrecision,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toProductUnitsResponse(response))
+}
+
+func toProductUnitsResponse(r *usecases.ProductUnitsResponse) ProductUnitsResponse {
+	units := make([]ProductUnitResponse, 0, len(r.Units))
+	for _, u := range r.Units {
+		units = append(units, ProductUnitResponse{
+			Unit:         u.Unit,
+			Quantity:     u.Quantity,
+			Of:           u.Of,
+			BaseQuantity: u.BaseQuantity,
+		})
+	}
+	return ProductUnitsResponse{
+		ProductID:   r.ProductID,
+		ProductName: r.ProductName,
+		BaseUnit:    r.BaseUnit,
+		Units:       units,
+
+		QuantityMode:      r.QuantityMode,
+		QuantityPrecision: r.QuantityPrecision,
+	}
+}
@@ -0,0 +1,132 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockManageProductUnitsUseCase implements usecases.ManageProductUnitsUseCase for handler tests.
+type mockManageProductUnitsUseCase struct {
+	response *usecases.ProductUnitsResponse
+	err      error
+	lastGet  [2]string
+	lastSet  usecases.SetProductUnitsRequest
+}
+
+func (m *mockManageProductUnitsUseCase) Get(ctx context.Context, tenantID, productID string) (*usecases.ProductUnitsResponse, error) {
+	m.lastGet = [2]string{tenantID, productID}
+	return m.response, m.err
+}
+
+func (m *mockManageProductUnitsUseCase) Set(ctx context.Context, req usecases.SetProductUnitsRequest) (*usecases.ProductUnitsResponse, error) {
+	m.lastSet = req
+	return m.response, m.err
+}
+
+func setupProductUnitsApp(uc usecases.ManageProductUnitsUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewProductUnitsHandler(uc)
+	app.Get("/api/v1/products/:id/units", handler.Get)
+	app.Put("/api/v1/products/:id/units", handler.Set)
+	return app
+}
+
+func productUnitsResponse() *usecases.ProductUnitsResponse {
+	return &usecases.ProductUnitsResponse{
+		ProductID:   "p1",
+		ProductName: "Widget",
+		BaseUnit:    "each",
+		Units: []usecases.ProductUnit{
+			{UnitConversion: domain.UnitConversion{Unit: "case", Quantity: 12, Of: "each"}, BaseQuantity: 12},
+			{UnitConversion: domain.UnitConversion{Unit: "pallet", Quantity: 40, Of: "case"}, BaseQuantity: 480},
+		},
+	}
+}
+
+func TestProductUnitsHandler_Get(t *testing.T) {
+	uc := &mockManageProductUnitsUseCase{response: productUnitsResponse()}
+	app := setupProductUnitsApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/products/p1/units?tenant_id=t1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.lastGet != [2]string{"t1", "p1"} {
+		t.Errorf("use case called with %v", uc.lastGet)
+	}
+	var body httphandler.ProductUnitsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.BaseUnit != "each" || len(body.Units) != 2 || body.Units[1].Unit != "pallet" || body.Units[1].BaseQuantity != 480 {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestProductUnitsHandler_Set(t *testing.T) {
+	uc := &mockManageProductUnitsUseCase{response: productUnitsResponse()}
+	app := setupProductUnitsApp(uc)
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"tenant_id": "t1",
+		"base_unit": "each",
+		"units": []map[string]interface{}{
+			{"unit": "case", "quantity": 12, "of": "each"},
+			{"unit": "pallet", "quantity": 40, "of": "case"},
+		},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/p1/units", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	got := uc.lastSet
+	if got.TenantID != "t1" || got.ProductID != "p1" || got.BaseUnit != "each" || len(got.Units) != 2 ||
+		got.Units[1] != (domain.UnitConversion{Unit: "pallet", Quantity: 40, Of: "case"}) {
+		t.Errorf("use case request = %+v", got)
+	}
+}
+
+func TestProductUnitsHandler_Set_InvalidConversion(t *testing.T) {
+	uc := &mockManageProductUnitsUseCase{err: domain.ErrInvalidUnitConversion}
+	app := setupProductUnitsApp(uc)
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"tenant_id": "t1",
+		"units":     []map[string]interface{}{{"unit": "case", "quantity": 12, "of": "box"}},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/p1/units", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var body httphandler.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Code != "INVALID_UNIT_CONVERSION" {
+		t.Errorf("code = %q", body.Code)
+	}
+}
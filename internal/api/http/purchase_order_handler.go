@@ -0,0 +1,220 @@
+// internal/api/http/purchase_order_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PurchaseOrderHandler struct {
+	purchaseOrderUseCase usecases.PurchaseOrderUseCase
+}
+
+func NewPurchaseOrderHandler(purchaseOrderUseCase usecases.PurchaseOrderUseCase) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		purchaseOrderUseCase: purchaseOrderUseCase,
+	}
+}
+
+// POST /api/v1/purchase-orders
+func (h *PurchaseOrderHandler) Create(c *fiber.Ctx) error {
+	var req CreatePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+	var expectedAt time.Time
+	if req.ExpectedAt != "" {
+		parsed, err := parseReportTime(req.ExpectedAt)
+		if err != nil {
+			return c.Status(400).JSON(ErrorResponse{
+				Error: "Invalid expected_at, use RFC3339 or YYYY-MM-DD",
+			})
+		}
+		expectedAt = parsed
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.purchaseOrderUseCase.Create(ctx, usecases.CreatePurchaseOrderRequest{
+		TenantID:   req.TenantID,
+		Supplier:   req.Supplier,
+		SupplierID: req.SupplierID,
+		Number:     req.Number,
+		Notes:      req.Notes,
+		Lines:      toProductQuantities(req.Lines),
+		ExpectedAt: expectedAt,
+		CreatedBy:  userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(201).JSON(toPurchaseOrderResponse(*response))
+}
+
+// GET /api/v1/purchase-orders?tenant_id=...&status=...
+func (h *PurchaseOrderHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	orders, err := h.purchaseOrderUseCase.List(ctx, c.Query("tenant_id"), c.Query("status"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]PurchaseOrderResponse, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, toPurchaseOrderResponse(o))
+	}
+	return c.Status(200).JSON(result)
+}
+
+// GET /api/v1/purchase-orders/:id?tenant_id=...
+func (h *PurchaseOrderHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.purchaseOrderUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toPurchaseOrderResponse(*response))
+}
+
+// POST /api/v1/purchase-orders/:id/receive
+func (h *PurchaseOrderHandler) Receive(c *fiber.Ctx) error {
+	var req ReceivePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.purchaseOrderUseCase.Receive(ctx, usecases.ReceivePurchaseOrderRequest{
+		TenantID:         req.TenantID,
+		OrderID:          c.Params("id"),
+		Lines:            toProductQuantities(req.Lines),
+		Notes:            req.Notes,
+		ReceivedBy:       userID,
+		AllowOverReceipt: req.AllowOverReceipt,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	stock := make([]AddStockResponse, 0, len(response.Stock))
+	for _, s := range response.Stock {
+		change := stockChange(s.Added, s.PreviousStock, s.NewStock, s.Decimal)
+		stock = append(stock, AddStockResponse{
+			Success:     true,
+			ProductID:   s.ProductID,
+			ProductName: s.ProductName,
+			Previous:    change.PreviousStock,
+			NewStock:    change.NewStock,
+			Added:       change.Quantity,
+			MaxAllowed:  s.MaxAllowed,
+			Utilization: s.Utilization,
+			Message:     "Stock updated successfully",
+			Timestamp:   now,
+		})
+	}
+	return c.Status(200).JSON(ReceivePurchaseOrderResponse{
+		Success:   true,
+		Order:     toPurchaseOrderResponse(*response.Order),
+		Stock:     stock,
+		Timestamp: now,
+	})
+}
+
+// POST /api/v1/purchase-orders/:id/cancel
+func (h *PurchaseOrderHandler) Cancel(c *fiber.Ctx) error {
+	var req PurchaseOrderActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.purchaseOrderUseCase.Cancel(ctx, req.TenantID, c.Params("id"), userID)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toPurchaseOrderResponse(*response))
+}
+
+func toProductQuantities(lines []PurchaseOrderLineRequest) []domain.ProductQuantity {
+	quantities := make([]domain.ProductQuantity, 0, len(lines))
+	for _, l := range lines {
+		quantities = append(quantities, domain.ProductQuantity{ProductID: l.ProductID, Quantity: l.Quantity})
+	}
+	return quantities
+}
+
+func toPurchaseOrderResponse(o usecases.PurchaseOrderResponse) PurchaseOrderResponse {
+	lines := make([]PurchaseOrderLineResponse, 0, len(o.Lines))
+	for _, l := range o.Lines {
+		lines = append(lines, PurchaseOrderLineResponse{
+			ProductID:    l.ProductID,
+			ProductName:  l.ProductName,
+			Expected:     l.Expected,
+			Received:     l.Received,
+			Outstanding:  l.Outstanding,
+			OverReceived: l.OverReceived,
+		})
+	}
+	receipts := make([]PurchaseOrderReceiptResponse, 0, len(o.Receipts))
+	for _, r := range o.Receipts {
+		receiptLines := make([]PurchaseOrderLineRequest, 0, len(r.Lines))
+		for _, l := range r.Lines {
+			receiptLines = append(receiptLines, PurchaseOrderLineRequest{ProductID: l.ProductID, Quantity: l.Quantity})
+		}
+		receipts = append(receipts, PurchaseOrderReceiptResponse{
+			Lines:      receiptLines,
+			ReceivedBy: r.ReceivedBy,
+			Notes:      r.Notes,
+			ReceivedAt: r.ReceivedAt.Format(time.RFC3339),
+		})
+	}
+
+	resp := PurchaseOrderResponse{
+		ID:         o.ID,
+		TenantID:   o.TenantID,
+		Supplier:   o.Supplier,
+		SupplierID: o.SupplierID,
+		Number:     o.Number,
+		Notes:      o.Notes,
+		Status:     o.Status,
+		Lines:      lines,
+		Receipts:   receipts,
+		CreatedBy:  o.CreatedBy,
+		CreatedAt:  o.CreatedAt.Format(time.RFC3339),
+		ClosedBy:   o.ClosedBy,
+	}
+	if !o.ExpectedAt.IsZero() {
+		resp.ExpectedAt = o.ExpectedAt.Format(time.RFC3339)
+	}
+	if !o.ClosedAt.IsZero() {
+		resp.ClosedAt = o.ClosedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,194 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockPurchaseOrderUseCase implements usecases.PurchaseOrderUseCase for handler tests.
+type mockPurchaseOrderUseCase struct {
+	order       *usecases.PurchaseOrderResponse
+	receipt     *usecases.ReceivePurchaseOrderResponse
+	err         error
+	lastCreate  usecases.CreatePurchaseOrderRequest
+	lastReceive usecases.ReceivePurchaseOrderRequest
+}
+
+func (m *mockPurchaseOrderUseCase) Create(ctx context.Context, req usecases.CreatePurchaseOrderRequest) (*usecases.PurchaseOrderResponse, error) {
+	m.lastCreate = req
+	return m.order, m.err
+}
+
+func (m *mockPurchaseOrderUseCase) Get(ctx context.Context, tenantID, orderID string) (*usecases.PurchaseOrderResponse, error) {
+	return m.order, m.err
+}
+
+func (m *mockPurchaseOrderUseCase) List(ctx context.Context, tenantID, status string) ([]usecases.PurchaseOrderResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.PurchaseOrderResponse{*m.order}, nil
+}
+
+func (m *mockPurchaseOrderUseCase) Receive(ctx context.Context, req usecases.ReceivePurchaseOrderRequest) (*usecases.ReceivePurchaseOrderResponse, error) {
+	m.lastReceive = req
+	return m.receipt, m.err
+}
+
+func (m *mockPurchaseOrderUseCase) Cancel(ctx context.Context, tenantID, orderID, cancelledBy string) (*usecases.PurchaseOrderResponse, error) {
+	return m.order, m.err
+}
+
+func setupPurchaseOrderApp(uc usecases.PurchaseOrderUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewPurchaseOrderHandler(uc)
+	app.Post("/api/v1/purchase-orders", handler.Create)
+	app.Get("/api/v1/purchase-orders/:id", handler.Get)
+	app.Post("/api/v1/purchase-orders/:id/receive", handler.Receive)
+	return app
+}
+
+func testPurchaseOrder() *usecases.PurchaseOrderResponse {
+	return &usecases.PurchaseOrderResponse{
+		ID: "po-1", TenantID: "t1", Supplier: "Acme", Status: domain.PurchaseOrderPartiallyReceived,
+		Lines: []usecases.PurchaseOrderLineResponse{
+			{ProductID: "p1", ProductName: "Widget", Expected: 20, Received: 8, Outstanding: 12},
+		},
+		Receipts: []domain.PurchaseOrderReceipt{{
+			Lines:      []domain.ProductQuantity{{ProductID: "p1", Quantity: 8}},
+			ReceivedBy: testUserID,
+			ReceivedAt: time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC),
+		}},
+		CreatedBy: testUserID,
+		CreatedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestPurchaseOrderHandler_Create(t *testing.T) {
+	uc := &mockPurchaseOrderUseCase{order: testPurchaseOrder()}
+	app := setupPurchaseOrderApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/purchase-orders", map[string]interface{}{
+		"tenant_id":   "t1",
+		"supplier":    "Acme",
+		"expected_at": "2024-03-10",
+		"lines":       []map[string]interface{}{{"product_id": "p1", "quantity": 20}},
+	})
+	if resp.StatusCode != 201 {
+		t.Fatalf("status = %d, want 201", resp.StatusCode)
+	}
+	req := uc.lastCreate
+	if req.CreatedBy != testUserID || len(req.Lines) != 1 || req.Lines[0].Quantity != 20 ||
+		!req.ExpectedAt.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("request = %+v", req)
+	}
+
+	var body httphandler.PurchaseOrderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.ID != "po-1" || body.Lines[0].Outstanding != 12 || len(body.Receipts) != 1 || body.Receipts[0].ReceivedAt != "2024-03-02T09:00:00Z" {
+		t.Errorf("body = %+v", body)
+	}
+	if body.ExpectedAt != "" || body.ClosedAt != "" {
+		t.Errorf("unset times = %q, %q, want omitted", body.ExpectedAt, body.ClosedAt)
+	}
+}
+
+func TestPurchaseOrderHandler_Create_InvalidExpectedAt(t *testing.T) {
+	app := setupPurchaseOrderApp(&mockPurchaseOrderUseCase{order: testPurchaseOrder()})
+
+	resp := postJSON(t, app, "/api/v1/purchase-orders", map[string]interface{}{
+		"tenant_id": "t1", "supplier": "Acme", "expected_at": "next week",
+	})
+	if resp.StatusCode != 400 {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestPurchaseOrderHandler_Receive(t *testing.T) {
+	uc := &mockPurchaseOrderUseCase{receipt: &usecases.ReceivePurchaseOrderResponse{
+		Order: testPurchaseOrder(),
+		Stock: []usecases.AddStockResponse{{ProductID: "p1", ProductName: "Widget", PreviousStock: 10, NewStock: 18, Added: 8, MaxAllowed: 100}},
+	}}
+	app := setupPurchaseOrderApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/purchase-orders/po-1/receive", map[string]interface{}{
+		"tenant_id":          "t1",
+		"allow_over_receipt": true,
+		"lines":              []map[string]interface{}{{"product_id": "p1", "quantity": 8}},
+	})
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if req := uc.lastReceive; req.OrderID != "po-1" || req.ReceivedBy != testUserID || !req.AllowOverReceipt || req.Lines[0].Quantity != 8 {
+		t.Errorf("request = %+v", req)
+	}
+
+	var body httphandler.ReceivePurchaseOrderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !body.Success || body.Order.Status != domain.PurchaseOrderPartiallyReceived || len(body.Stock) != 1 || body.Stock[0].NewStock.String() != "18" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestPurchaseOrderHandler_Receive_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"over-receipt", domain.ErrOverReceipt{ProductID: "p1", Outstanding: 12, Receiving: 15}, 400, "OVER_RECEIPT"},
+		{"stock limit", domain.ErrStockExceedsLimit{Current: 90, Adding: 20, WouldBe: 110, MaxAllowed: 100}, 400, "STOCK_LIMIT_EXCEEDED"},
+		{"closed order", domain.ErrPurchaseOrderClosed, 409, "PURCHASE_ORDER_CLOSED"},
+		{"unknown order", domain.ErrPurchaseOrderNotFound, 404, "PURCHASE_ORDER_NOT_FOUND"},
+		{"product not ordered", domain.ErrProductNotOnPurchaseOrder, 400, "INVALID_PURCHASE_ORDER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupPurchaseOrderApp(&mockPurchaseOrderUseCase{err: tt.err})
+
+			resp := postJSON(t, app, "/api/v1/purchase-orders/po-1/receive", map[string]interface{}{
+				"tenant_id": "t1",
+				"lines":     []map[string]interface{}{{"product_id": "p1", "quantity": 15}},
+			})
+			var body httphandler.ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus || body.Code != tt.wantCode {
+				t.Errorf("status = %d, code = %q, want %d %q", resp.StatusCode, body.Code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderHandler_Get(t *testing.T) {
+	app := setupPurchaseOrderApp(&mockPurchaseOrderUseCase{order: testPurchaseOrder()})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/purchase-orders/po-1?tenant_id=t1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	var body httphandler.PurchaseOrderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.StatusCode != 200 || body.Supplier != "Acme" || body.CreatedAt != "2024-03-01T09:00:00Z" {
+		t.Errorf("status = %d, body = %+v", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,74 @@
+// internal/api/http/reconciliation_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Admin endpoint comparing product counters with stock history
+type ReconciliationHandler struct {
+	reconcileStockUseCase usecases.ReconcileStockUseCase
+}
+
+func NewReconciliationHandler(reconcileStockUseCase usecases.ReconcileStockUseCase) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconcileStockUseCase: reconcileStockUseCase,
+	}
+}
+
+// POST /api/v1/admin/stock/reconcile
+// Reports drift only unless "correct" is true.
+func (h *ReconciliationHandler) Reconcile(c *fiber.Ctx) error {
+	var req ReconcileStockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	// Scans every product of the tenant, so it gets more time than a write
+	ctx, cancel := context.WithTimeout(c.Context(), 60*time.Second)
+	defer cancel()
+
+	report, err := h.reconcileStockUseCase.Execute(ctx, usecases.ReconcileStockRequest{
+		TenantID:    req.TenantID,
+		Correct:     req.Correct,
+		PerformedBy: userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toReconciliationReportResponse(*report))
+}
+
+func toReconciliationReportResponse(r usecases.ReconciliationReport) ReconciliationReportResponse {
+	drifts := make([]StockDriftResponse, 0, len(r.Drifts))
+	for _, d := range r.Drifts {
+		drifts = append(drifts, StockDriftResponse{
+			ProductID:          d.ProductID,
+			ProductName:        d.ProductName,
+			CurrentStock:       d.CurrentStock,
+			ExpectedStock:      d.ExpectedStock,
+			StockDifference:    d.StockDifference(),
+			TotalAdded:         d.TotalAdded,
+			ExpectedTotalAdded: d.ExpectedTotalAdded,
+			HistoryEntries:     d.HistoryEntries,
+			Corrected:          d.Corrected,
+		})
+	}
+	return ReconciliationReportResponse{
+		TenantID:               r.TenantID,
+		CheckedAt:              r.CheckedAt.Format(time.RFC3339),
+		ProductsChecked:        r.ProductsChecked,
+		ProductsWithoutHistory: r.ProductsWithoutHistory,
+		Drifts:                 drifts,
+		Corrected:              r.Corrected,
+	}
+}
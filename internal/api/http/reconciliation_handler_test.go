@@ -0,0 +1,96 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockReconcileStockUseCase implements usecases.ReconcileStockUseCase for handler tests.
+type mockReconcileStockUseCase struct {
+	report  *usecases.ReconciliationReport
+	err     error
+	lastReq usecases.ReconcileStockRequest
+}
+
+func (m *mockReconcileStockUseCase) Execute(ctx context.Context, req usecases.ReconcileStockRequest) (*usecases.ReconciliationReport, error) {
+	m.lastReq = req
+	return m.report, m.err
+}
+
+func setupReconciliationApp(uc usecases.ReconcileStockUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewReconciliationHandler(uc)
+	app.Post("/api/v1/admin/stock/reconcile", handler.Reconcile)
+	return app
+}
+
+func TestReconciliationHandler_Reconcile_Success(t *testing.T) {
+	uc := &mockReconcileStockUseCase{
+		report: &usecases.ReconciliationReport{
+			TenantID: "t1", CheckedAt: time.Now(), ProductsChecked: 2,
+			Drifts: []domain.StockDrift{{
+				ProductID: "p1", CurrentStock: domain.DecimalFromInt(12), ExpectedStock: domain.DecimalFromInt(10),
+				TotalAdded: domain.DecimalFromInt(40), ExpectedTotalAdded: domain.DecimalFromInt(10), Corrected: true,
+			}},
+			Corrected: 1,
+		},
+	}
+	app := setupReconciliationApp(uc)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"tenant_id": "t1", "correct": true})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/stock/reconcile", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if !uc.lastReq.Correct || uc.lastReq.TenantID != "t1" || uc.lastReq.PerformedBy != testUserID {
+		t.Errorf("use case request = %+v", uc.lastReq)
+	}
+
+	var got httphandler.ReconciliationReportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got.Drifts) != 1 || got.Drifts[0].StockDifference.String() != "2" || !got.Drifts[0].Corrected {
+		t.Errorf("drifts = %+v", got.Drifts)
+	}
+	if got.Corrected != 1 || got.ProductsChecked != 2 {
+		t.Errorf("report = %+v", got)
+	}
+}
+
+func TestReconciliationHandler_Reconcile_TenantNotFound(t *testing.T) {
+	app := setupReconciliationApp(&mockReconcileStockUseCase{err: domain.ErrTenantNotFound})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/stock/reconcile", bytes.NewReader([]byte(`{"tenant_id":"nope"}`)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
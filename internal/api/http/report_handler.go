@@ -0,0 +1,192 @@
+// internal/api/http/report_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Stock reports across a tenant's products
+type ReportHandler struct {
+	utilizationReportUseCase usecases.UtilizationReportUseCase
+	forecastUseCase          usecases.ForecastUseCase
+	valuationReportUseCase   usecases.ValuationReportUseCase
+}
+
+func NewReportHandler(
+	utilizationReportUseCase usecases.UtilizationReportUseCase,
+	forecastUseCase usecases.ForecastUseCase,
+	valuationReportUseCase usecases.ValuationReportUseCase,
+) *ReportHandler {
+	return &ReportHandler{
+		utilizationReportUseCase: utilizationReportUseCase,
+		forecastUseCase:          forecastUseCase,
+		valuationReportUseCase:   valuationReportUseCase,
+	}
+}
+
+// GET /api/v1/reports/utilization?tenant_id=...&top=10&low_stock_threshold=10
+func (h *ReportHandler) Utilization(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.utilizationReportUseCase.Execute(ctx, usecases.UtilizationReportRequest{
+		TenantID:          c.Query("tenant_id"),
+		TopN:              c.QueryInt("top", 0),
+		LowStockThreshold: c.QueryInt("low_stock_threshold", 0),
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	bands := make([]UtilizationBandResponse, 0, len(report.Bands))
+	for i, b := range report.Bands {
+		band := UtilizationBandResponse{
+			Label:    b.Band.Label,
+			Min:      b.Band.Min,
+			Products: b.Products,
+			Units:    b.Units,
+		}
+		if i < len(report.Bands)-1 {
+			max := b.Band.Max
+			band.Max = &max
+		}
+		bands = append(bands, band)
+	}
+	return c.Status(200).JSON(UtilizationReportResponse{
+		TenantID:          report.TenantID,
+		MaxStock:          report.MaxStock,
+		LowStockThreshold: report.LowStockThreshold,
+		Bands:             bands,
+		Top:               toProductUtilizationResponses(report.Top),
+		LowStock:          toProductUtilizationResponses(report.LowStock),
+		LowStockCount:     report.LowStockCount,
+		Totals: UtilizationTotalsResponse{
+			Products:    report.Totals.Products,
+			Units:       report.Totals.Units,
+			Capacity:    report.Totals.Capacity,
+			Utilization: report.Totals.Utilization,
+		},
+	})
+}
+
+// GET /api/v1/reports/forecast?tenant_id=...[&product_id=...][&method=exponential_smoothing][&window_days=28]
+func (h *ReportHandler) Forecast(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.forecastUseCase.Report(ctx, usecases.ForecastReportRequest{
+		TenantID:   c.Query("tenant_id"),
+		ProductID:  c.Query("product_id"),
+		Method:     c.Query("method"),
+		WindowDays: c.QueryInt("window_days", 0),
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	products := make([]ProductForecastResponse, 0, len(report.Products))
+	for _, f := range report.Products {
+		products = append(products, ProductForecastResponse{
+			ProductID:         f.ProductID,
+			ProductName:       f.ProductName,
+			CurrentStock:      f.CurrentStock,
+			DailyDemand:       f.DailyDemand,
+			DaysUntilStockout: f.DaysUntilStockout,
+			SafetyStock:       f.SafetyStock,
+			ReorderPoint:      f.ReorderPoint,
+			NeedsReorder:      f.NeedsReorder,
+			ReorderQuantity:   f.ReorderQuantity,
+		})
+	}
+	return c.Status(200).JSON(ForecastReportResponse{
+		TenantID: report.TenantID,
+		Settings: ForecastSettingsResponse{
+			Method:          report.Settings.Method,
+			WindowDays:      report.Settings.WindowDays,
+			Alpha:           report.Settings.Alpha,
+			LeadTimeDays:    report.Settings.LeadTimeDays,
+			SafetyStockDays: report.Settings.SafetyStockDays,
+			CoverDays:       report.Settings.CoverDays,
+		},
+		From:     report.From.Format(time.RFC3339),
+		To:       report.To.Format(time.RFC3339),
+		Products: products,
+	})
+}
+
+// GET /api/v1/reports/valuation?tenant_id=...[&from=...][&to=...]
+// Stock is valued as it stands now; from/to bound the cost of goods removed
+func (h *ReportHandler) Valuation(c *fiber.Ctx) error {
+	to := time.Now()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseReportTime(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -30)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseReportTime(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		from = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.valuationReportUseCase.Execute(ctx, usecases.ValuationReportRequest{
+		TenantID: c.Query("tenant_id"),
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	products := make([]ProductValuationResponse, 0, len(report.Products))
+	for _, v := range report.Products {
+		products = append(products, ProductValuationResponse{
+			ProductID:          v.ProductID,
+			ProductName:        v.ProductName,
+			Stock:              v.Stock,
+			CostedQuantity:     v.CostedQuantity,
+			UncostedQuantity:   v.UncostedQuantity,
+			Value:              v.Value,
+			AverageUnitCost:    v.AverageUnitCost,
+			QuantityRemoved:    v.QuantityRemoved,
+			CostOfGoodsRemoved: v.CostOfGoodsRemoved,
+		})
+	}
+	return c.Status(200).JSON(ValuationReportResponse{
+		TenantID:                report.TenantID,
+		Method:                  report.Method,
+		From:                    report.From.Format(time.RFC3339),
+		To:                      report.To.Format(time.RFC3339),
+		Products:                products,
+		TotalValue:              report.TotalValue,
+		TotalCostOfGoodsRemoved: report.TotalCostOfGoodsRemoved,
+		UncostedQuantity:        report.UncostedQuantity,
+	})
+}
+
+func toProductUtilizationResponses(products []domain.ProductUtilization) []ProductUtilizationResponse {
+	resp := make([]ProductUtilizationResponse, 0, len(products))
+	for _, p := range products {
+		resp = append(resp, ProductUtilizationResponse{
+			ProductID:    p.ProductID,
+			ProductName:  p.ProductName,
+			CurrentStock: p.CurrentStock,
+			Utilization:  p.Utilization,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,263 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockUtilizationReportUseCase implements usecases.UtilizationReportUseCase for handler tests.
+type mockUtilizationReportUseCase struct {
+	resp *usecases.UtilizationReportResponse
+	err  error
+	last usecases.UtilizationReportRequest
+}
+
+func (m *mockUtilizationReportUseCase) Execute(ctx context.Context, req usecases.UtilizationReportRequest) (*usecases.UtilizationReportResponse, error) {
+	m.last = req
+	return m.resp, m.err
+}
+
+// mockForecastUseCase implements usecases.ForecastUseCase for handler tests.
+type mockForecastUseCase struct {
+	resp *usecases.ForecastReportResponse
+	err  error
+	last usecases.ForecastReportRequest
+}
+
+func (m *mockForecastUseCase) Report(ctx context.Context, req usecases.ForecastReportRequest) (*usecases.ForecastReportResponse, error) {
+	m.last = req
+	return m.resp, m.err
+}
+
+// mockValuationReportUseCase implements usecases.ValuationReportUseCase for handler tests.
+type mockValuationReportUseCase struct {
+	resp *usecases.ValuationReportResponse
+	err  error
+	last usecases.ValuationReportRequest
+}
+
+func (m *mockValuationReportUseCase) Execute(ctx context.Context, req usecases.ValuationReportRequest) (*usecases.ValuationReportResponse, error) {
+	m.last = req
+	return m.resp, m.err
+}
+
+func setupReportApp(uc usecases.UtilizationReportUseCase) *fiber.App {
+	return setupReportAppWith(uc, &mockForecastUseCase{})
+}
+
+func setupReportAppWith(utilization usecases.UtilizationReportUseCase, forecast usecases.ForecastUseCase) *fiber.App {
+	return setupReportAppFull(utilization, forecast, &mockValuationReportUseCase{})
+}
+
+func setupReportAppFull(utilization usecases.UtilizationReportUseCase, forecast usecases.ForecastUseCase, valuation usecases.ValuationReportUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewReportHandler(utilization, forecast, valuation)
+	app.Get("/api/v1/reports/utilization", handler.Utilization)
+	app.Get("/api/v1/reports/forecast", handler.Forecast)
+	app.Get("/api/v1/reports/valuation", handler.Valuation)
+	return app
+}
+
+func TestReportHandler_Utilization(t *testing.T) {
+	uc := &mockUtilizationReportUseCase{resp: &usecases.UtilizationReportResponse{
+		TenantID:          "t1",
+		MaxStock:          100,
+		LowStockThreshold: 5,
+		Bands: []domain.UtilizationBandTotals{
+			{Band: domain.UtilizationBand{Label: "0-50", Min: 0, Max: 50}, Products: 1, Units: 3},
+			{Band: domain.UtilizationBand{Label: "50+", Min: 50}, Products: 1, Units: 90},
+		},
+		Top:           []domain.ProductUtilization{{ProductID: "p2", ProductName: "Bolt", CurrentStock: 90, Utilization: 90}},
+		LowStock:      []domain.ProductUtilization{{ProductID: "p1", ProductName: "Nut", CurrentStock: 3, Utilization: 3}},
+		LowStockCount: 1,
+		Totals:        usecases.UtilizationTotals{Products: 2, Units: 93, Capacity: 200, Utilization: 46.5},
+	}}
+	app := setupReportApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/utilization?tenant_id=t1&top=5&low_stock_threshold=5", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if uc.last.TenantID != "t1" || uc.last.TopN != 5 || uc.last.LowStockThreshold != 5 {
+		t.Errorf("request = %+v", uc.last)
+	}
+
+	var body httphandler.UtilizationReportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Bands) != 2 || body.Bands[0].Max == nil || *body.Bands[0].Max != 50 || body.Bands[1].Max != nil {
+		t.Errorf("bands = %+v, want an open-ended last band", body.Bands)
+	}
+	if len(body.Top) != 1 || body.Top[0].ProductID != "p2" || len(body.LowStock) != 1 || body.LowStock[0].CurrentStock != 3 {
+		t.Errorf("top = %+v, low stock = %+v", body.Top, body.LowStock)
+	}
+	if body.Totals.Capacity != 200 || body.Totals.Utilization != 46.5 {
+		t.Errorf("totals = %+v", body.Totals)
+	}
+}
+
+func TestReportHandler_Utilization_UnknownTenant(t *testing.T) {
+	app := setupReportApp(&mockUtilizationReportUseCase{err: domain.ErrTenantNotFound})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/utilization?tenant_id=nope", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 404 {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestReportHandler_Forecast(t *testing.T) {
+	days := 2.5
+	uc := &mockForecastUseCase{resp: &usecases.ForecastReportResponse{
+		TenantID: "t1",
+		Settings: domain.ForecastSettings{Method: domain.ForecastMethodExponentialSmoothing, WindowDays: 14}.WithDefaults(),
+		From:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Products: []domain.ProductForecast{
+			{ProductID: "p1", ProductName: "Nut", CurrentStock: 10, DailyDemand: 4, DaysUntilStockout: &days,
+				SafetyStock: 12, ReorderPoint: 40, NeedsReorder: true, ReorderQuantity: 90},
+			{ProductID: "p2", ProductName: "Bolt", CurrentStock: 7},
+		},
+	}}
+	app := setupReportAppWith(&mockUtilizationReportUseCase{}, uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/forecast?tenant_id=t1&method=exponential_smoothing&window_days=14", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if uc.last.TenantID != "t1" || uc.last.Method != domain.ForecastMethodExponentialSmoothing || uc.last.WindowDays != 14 {
+		t.Errorf("request = %+v", uc.last)
+	}
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["from"] != "2024-03-01T00:00:00Z" || body["settings"].(map[string]any)["window_days"] != 14.0 {
+		t.Errorf("body = %v", body)
+	}
+	products := body["products"].([]any)
+	p1, p2 := products[0].(map[string]any), products[1].(map[string]any)
+	if p1["days_until_stockout"] != 2.5 || p1["reorder_quantity"] != 90.0 || p1["needs_reorder"] != true {
+		t.Errorf("p1 = %v", p1)
+	}
+	if _, ok := p2["days_until_stockout"]; ok {
+		t.Errorf("p2 = %v, want days_until_stockout omitted", p2)
+	}
+}
+
+func TestReportHandler_Forecast_InvalidSettings(t *testing.T) {
+	app := setupReportAppWith(&mockUtilizationReportUseCase{}, &mockForecastUseCase{err: domain.ErrInvalidForecastSettings})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/forecast?tenant_id=t1&method=guess", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	var body httphandler.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.StatusCode != 400 || body.Code != "INVALID_FORECAST_SETTINGS" {
+		t.Errorf("status = %d, code = %q, want 400 INVALID_FORECAST_SETTINGS", resp.StatusCode, body.Code)
+	}
+}
+
+func TestReportHandler_Valuation(t *testing.T) {
+	uc := &mockValuationReportUseCase{resp: &usecases.ValuationReportResponse{
+		TenantID: "t1",
+		Method:   domain.CostingFIFO,
+		From:     time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Products: []domain.ProductValuation{{
+			ProductID:          "p1",
+			ProductName:        "Nut",
+			Stock:              12,
+			CostedQuantity:     10,
+			UncostedQuantity:   2,
+			Value:              domain.NewMoney(2550),
+			AverageUnitCost:    domain.NewMoney(255),
+			CostOfGoodsRemoved: domain.NewMoney(1000),
+			QuantityRemoved:    5,
+		}},
+		TotalValue:              domain.NewMoney(2550),
+		TotalCostOfGoodsRemoved: domain.NewMoney(1000),
+		UncostedQuantity:        2,
+	}}
+	app := setupReportAppFull(&mockUtilizationReportUseCase{}, &mockForecastUseCase{}, uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/valuation?tenant_id=t1&from=2024-05-01&to=2024-06-01", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if uc.last.TenantID != "t1" || !uc.last.From.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) || !uc.last.To.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("request = %+v", uc.last)
+	}
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["method"] != "fifo" || body["total_value"] != 25.5 || body["total_cost_of_goods_removed"] != 10.0 {
+		t.Errorf("body = %v", body)
+	}
+	products := body["products"].([]any)
+	if len(products) != 1 {
+		t.Fatalf("products = %v", products)
+	}
+	p := products[0].(map[string]any)
+	if p["value"] != 25.5 || p["average_unit_cost"] != 2.55 || p["uncosted_quantity"] != 2.0 || p["quantity_removed"] != 5.0 {
+		t.Errorf("product = %v", p)
+	}
+}
+
+func TestReportHandler_Valuation_DefaultsToLast30Days(t *testing.T) {
+	uc := &mockValuationReportUseCase{resp: &usecases.ValuationReportResponse{TenantID: "t1"}}
+	app := setupReportAppFull(&mockUtilizationReportUseCase{}, &mockForecastUseCase{}, uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/valuation?tenant_id=t1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := uc.last.To.Sub(uc.last.From); got != 30*24*time.Hour {
+		t.Errorf("range = %v, want 30 days", got)
+	}
+}
+
+func TestReportHandler_Valuation_InvalidTime(t *testing.T) {
+	uc := &mockValuationReportUseCase{}
+	app := setupReportAppFull(&mockUtilizationReportUseCase{}, &mockForecastUseCase{}, uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/valuation?tenant_id=t1&from=yesterday", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+}
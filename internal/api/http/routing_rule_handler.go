@@ -0,0 +1,114 @@
+// internal/api/http/routing_rule_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RoutingRuleHandler struct {
+	manageRoutingRulesUseCase usecases.ManageRoutingRulesUseCase
+}
+
+func NewRoutingRuleHandler(manageRoutingRulesUseCase usecases.ManageRoutingRulesUseCase) *RoutingRuleHandler {
+	return &RoutingRuleHandler{
+		manageRoutingRulesUseCase: manageRoutingRulesUseCase,
+	}
+}
+
+func (h *RoutingRuleHandler) Create(c *fiber.Ctx) error {
+	var req RoutingRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageRoutingRulesUseCase.Create(ctx, usecases.CreateRoutingRuleRequest{
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		EventType:   req.EventType,
+		MinSeverity: req.MinSeverity,
+		ProductTags: req.ProductTags,
+		Channels:    req.Channels,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(201).JSON(toRoutingRuleResponse(*response))
+}
+
+func (h *RoutingRuleHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	rules, err := h.manageRoutingRulesUseCase.List(ctx, c.Query("tenant_id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]RoutingRuleResponse, 0, len(rules))
+	for _, r := range rules {
+		result = append(result, toRoutingRuleResponse(r))
+	}
+	return c.Status(200).JSON(result)
+}
+
+func (h *RoutingRuleHandler) Update(c *fiber.Ctx) error {
+	var req RoutingRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageRoutingRulesUseCase.Update(ctx, usecases.UpdateRoutingRuleRequest{
+		ID:          c.Params("id"),
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		EventType:   req.EventType,
+		MinSeverity: req.MinSeverity,
+		ProductTags: req.ProductTags,
+		Channels:    req.Channels,
+		IsActive:    req.IsActive,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toRoutingRuleResponse(*response))
+}
+
+func (h *RoutingRuleHandler) Delete(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.manageRoutingRulesUseCase.Delete(ctx, c.Query("tenant_id"), c.Params("id")); err != nil {
+		return handleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+func toRoutingRuleResponse(r usecases.RoutingRuleResponse) RoutingRuleResponse {
+	return RoutingRuleResponse{
+		ID:          r.ID,
+		TenantID:    r.TenantID,
+		Name:        r.Name,
+		EventType:   r.EventType,
+		MinSeverity: r.MinSeverity,
+		ProductTags: r.ProductTags,
+		Channels:    r.Channels,
+		IsActive:    r.IsActive,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+	}
+}
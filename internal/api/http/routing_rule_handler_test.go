@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+)
+
+// mockManageRoutingRulesUseCase implements usecases.ManageRoutingRulesUseCase for handler tests.
+type mockManageRoutingRulesUseCase struct {
+	response   *usecases.RoutingRuleResponse
+	err        error
+	lastCreate usecases.CreateRoutingRuleRequest
+}
+
+func (m *mockManageRoutingRulesUseCase) Create(ctx context.Context, req usecases.CreateRoutingRuleRequest) (*usecases.RoutingRuleResponse, error) {
+	m.lastCreate = req
+	return m.response, m.err
+}
+
+func (m *mockManageRoutingRulesUseCase) List(ctx context.Context, tenantID string) ([]usecases.RoutingRuleResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.RoutingRuleResponse{*m.response}, nil
+}
+
+func (m *mockManageRoutingRulesUseCase) Update(ctx context.Context, req usecases.UpdateRoutingRuleRequest) (*usecases.RoutingRuleResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockManageRoutingRulesUseCase) Delete(ctx context.Context, tenantID, ruleID string) error {
+	return m.err
+}
+
+func setupRoutingRuleApp(uc usecases.ManageRoutingRulesUseCase) *fiber.App {
+	app := fiber.New()
+	handler := httphandler.NewRoutingRuleHandler(uc)
+	app.Post("/api/v1/notification-rules", handler.Create)
+	app.Get("/api/v1/notification-rules", handler.List)
+	return app
+}
+
+func TestRoutingRuleHandler_Create_Success(t *testing.T) {
+	uc := &mockManageRoutingRulesUseCase{
+		response: &usecases.RoutingRuleResponse{
+			ID: "r1", TenantID: "t1", ProductTags: []string{"fragile"},
+			Channels: []string{domain.ChannelEmail}, IsActive: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		},
+	}
+	app := setupRoutingRuleApp(uc)
+
+	body := map[string]interface{}{
+		"tenant_id":    "t1",
+		"min_severity": "critical",
+		"product_tags": []string{"fragile"},
+		"channels":     []string{domain.ChannelEmail},
+	}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/notification-rules", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	var result httphandler.RoutingRuleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.ID != "r1" || len(result.Channels) != 1 {
+		t.Errorf("response = %+v", result)
+	}
+	if uc.lastCreate.MinSeverity != "critical" || uc.lastCreate.ProductTags[0] != "fragile" {
+		t.Errorf("use case request = %+v", uc.lastCreate)
+	}
+}
+
+func TestRoutingRuleHandler_Create_InvalidChannel(t *testing.T) {
+	uc := &mockManageRoutingRulesUseCase{err: domain.ErrInvalidChannel}
+	app := setupRoutingRuleApp(uc)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"tenant_id": "t1", "channels": []string{"pager"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/notification-rules", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var errResp httphandler.ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errResp)
+	if errResp.Code != "INVALID_CHANNEL" {
+		t.Errorf("code = %q", errResp.Code)
+	}
+}
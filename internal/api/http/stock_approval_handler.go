@@ -0,0 +1,128 @@
+// internal/api/http/stock_approval_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Pending stock changes above a tenant's approval threshold
+type StockApprovalHandler struct {
+	stockApprovalUseCase usecases.StockApprovalUseCase
+}
+
+func NewStockApprovalHandler(stockApprovalUseCase usecases.StockApprovalUseCase) *StockApprovalHandler {
+	return &StockApprovalHandler{
+		stockApprovalUseCase: stockApprovalUseCase,
+	}
+}
+
+// GET /api/v1/stock-approvals?tenant_id=...&status=pending
+func (h *StockApprovalHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	requests, err := h.stockApprovalUseCase.List(ctx, c.Query("tenant_id"), c.Query("status"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]StockChangeRequestResponse, 0, len(requests))
+	for _, r := range requests {
+		result = append(result, toStockChangeRequestResponse(&r))
+	}
+	return c.Status(200).JSON(result)
+}
+
+// GET /api/v1/stock-approvals/:id?tenant_id=...
+func (h *StockApprovalHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	request, err := h.stockApprovalUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toStockChangeRequestResponse(request))
+}
+
+// POST /api/v1/stock-approvals/:id/approve
+func (h *StockApprovalHandler) Approve(c *fiber.Ctx) error {
+	return h.decide(c, h.stockApprovalUseCase.Approve)
+}
+
+// POST /api/v1/stock-approvals/:id/reject
+func (h *StockApprovalHandler) Reject(c *fiber.Ctx) error {
+	return h.decide(c, h.stockApprovalUseCase.Reject)
+}
+
+func (h *StockApprovalHandler) decide(
+	c *fiber.Ctx,
+	decide func(context.Context, usecases.DecideStockChangeRequest) (*usecases.StockChangeRequestResponse, error),
+) error {
+	var req DecideStockChangeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	request, err := decide(ctx, usecases.DecideStockChangeRequest{
+		TenantID:  req.TenantID,
+		RequestID: c.Params("id"),
+		DecidedBy: userID,
+		Roles:     userRoles(c),
+		Note:      req.Note,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toStockChangeRequestResponse(request))
+}
+
+// Roles set by the auth middleware in "user_roles"
+func userRoles(c *fiber.Ctx) []string {
+	roles, _ := c.Locals("user_roles").([]string)
+	return roles
+}
+
+func toStockChangeRequestResponse(r *usecases.StockChangeRequestResponse) StockChangeRequestResponse {
+	resp := StockChangeRequestResponse{
+		ID:           r.ID,
+		TenantID:     r.TenantID,
+		ProductID:    r.ProductID,
+		ProductName:  r.ProductName,
+		Operation:    r.Operation,
+		Quantity:     domain.DecimalFromInt(r.Quantity),
+		Unit:         r.Unit,
+		Notes:        r.Notes,
+		Status:       r.Status,
+		RequestedBy:  r.RequestedBy,
+		RequestedAt:  r.RequestedAt.Format(time.RFC3339),
+		ExpiresAt:    r.ExpiresAt.Format(time.RFC3339),
+		DecidedBy:    r.DecidedBy,
+		DecisionNote: r.DecisionNote,
+	}
+	if !r.DecidedAt.IsZero() {
+		resp.DecidedAt = r.DecidedAt.Format(time.RFC3339)
+	}
+	if r.DecimalQuantity != nil {
+		resp.Quantity = *r.DecimalQuantity
+	}
+	if r.Applied != nil {
+		change := stockChange(0, r.Applied.PreviousStock, r.Applied.NewStock, r.Applied.Decimal)
+		resp.PreviousStock = &change.PreviousStock
+		resp.NewStock = &change.NewStock
+	}
+	return resp
+}
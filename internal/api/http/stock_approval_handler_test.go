@@ -0,0 +1,165 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockRemoveStockUseCase implements usecases.RemoveStockUseCase for handler tests.
+type mockRemoveStockUseCase struct {
+	response *usecases.RemoveStockResponse
+	err      error
+	last     usecases.RemoveStockRequest
+}
+
+func (m *mockRemoveStockUseCase) Execute(ctx context.Context, req usecases.RemoveStockRequest) (*usecases.RemoveStockResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+// mockStockApprovalUseCase implements usecases.StockApprovalUseCase for handler tests.
+type mockStockApprovalUseCase struct {
+	response *usecases.StockChangeRequestResponse
+	err      error
+	last     usecases.DecideStockChangeRequest
+}
+
+func (m *mockStockApprovalUseCase) List(ctx context.Context, tenantID, status string) ([]usecases.StockChangeRequestResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.StockChangeRequestResponse{*m.response}, nil
+}
+
+func (m *mockStockApprovalUseCase) Get(ctx context.Context, tenantID, requestID string) (*usecases.StockChangeRequestResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockStockApprovalUseCase) Approve(ctx context.Context, req usecases.DecideStockChangeRequest) (*usecases.StockChangeRequestResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+func (m *mockStockApprovalUseCase) Reject(ctx context.Context, req usecases.DecideStockChangeRequest) (*usecases.StockChangeRequestResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+func (m *mockStockApprovalUseCase) ExpirePending(ctx context.Context) (int64, error) {
+	return 0, m.err
+}
+
+func setupStockApprovalApp(uc usecases.StockApprovalUseCase, roles ...string) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	app.Use(httputil.UserRolesMiddleware(roles...))
+	handler := httphandler.NewStockApprovalHandler(uc)
+	app.Post("/api/v1/stock-approvals/:id/approve", handler.Approve)
+	app.Post("/api/v1/stock-approvals/:id/reject", handler.Reject)
+	return app
+}
+
+func TestStockHandler_AddStock_PendingApproval(t *testing.T) {
+	expires := time.Now().Add(24 * time.Hour)
+	uc := &mockAddStockUseCase{response: &usecases.AddStockResponse{
+		ProductID: "p1", PendingApproval: &usecases.PendingApproval{RequestID: "r1", ExpiresAt: expires},
+	}}
+	app := setupAddStockApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/stock/add", map[string]interface{}{"product_id": "p1", "tenant_id": "t1", "quantity": 500})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	var got httphandler.PendingApprovalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.RequestID != "r1" || got.Status != domain.ChangeRequestPending || got.ExpiresAt != expires.Format(time.RFC3339) {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestStockHandler_RemoveStock_Success(t *testing.T) {
+	uc := &mockRemoveStockUseCase{response: &usecases.RemoveStockResponse{
+		ProductID: "p1", PreviousStock: 10, NewStock: 7, Removed: 3,
+	}}
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	app.Post("/api/v1/stock/remove", httphandler.NewStockHandler(nil, uc).RemoveStock)
+
+	resp := postJSON(t, app, "/api/v1/stock/remove", map[string]interface{}{"product_id": "p1", "tenant_id": "t1", "quantity": 3})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.last.Quantity != 3 || uc.last.RemovedBy != testUserID {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	var got httphandler.RemoveStockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.NewStock.String() != "7" || got.Removed.String() != "3" {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestStockApprovalHandler_Approve_PassesApproverAndRoles(t *testing.T) {
+	uc := &mockStockApprovalUseCase{response: &usecases.StockChangeRequestResponse{
+		ID: "r1", Status: domain.ChangeRequestApproved, DecidedBy: testUserID, DecidedAt: time.Now(),
+		Applied: &usecases.AppliedStockChange{PreviousStock: 10, NewStock: 510},
+	}}
+	app := setupStockApprovalApp(uc, domain.RoleStockApprover)
+
+	resp := postJSON(t, app, "/api/v1/stock-approvals/r1/approve", map[string]interface{}{"tenant_id": "t1"})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if uc.last.RequestID != "r1" || uc.last.DecidedBy != testUserID || len(uc.last.Roles) != 1 || uc.last.Roles[0] != domain.RoleStockApprover {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	var got httphandler.StockChangeRequestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.NewStock == nil || got.NewStock.String() != "510" || got.DecidedAt == "" {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestStockApprovalHandler_Reject_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrApproverRoleRequired, http.StatusForbidden},
+		{domain.ErrSelfApproval, http.StatusForbidden},
+		{domain.ErrChangeRequestNotFound, http.StatusNotFound},
+		{domain.ErrChangeRequestClosed, http.StatusConflict},
+		{domain.ErrChangeRequestExpired, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			app := setupStockApprovalApp(&mockStockApprovalUseCase{err: tt.err})
+			resp := postJSON(t, app, "/api/v1/stock-approvals/r1/reject", map[string]interface{}{"tenant_id": "t1", "note": "no"})
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
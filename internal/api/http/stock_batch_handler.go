@@ -0,0 +1,123 @@
+// internal/api/http/stock_batch_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Adds stock for many shipment lines in one request
+type StockBatchHandler struct {
+	addStockBatchUseCase usecases.AddStockBatchUseCase
+}
+
+func NewStockBatchHandler(addStockBatchUseCase usecases.AddStockBatchUseCase) *StockBatchHandler {
+	return &StockBatchHandler{addStockBatchUseCase: addStockBatchUseCase}
+}
+
+// POST /api/v1/stock/add/batch
+//
+// Answers 200 when every item was applied. Otherwise a best-effort batch
+// answers 207 and an all-or-nothing batch the status of its first failed
+// item; the body always has a result per item.
+func (h *StockBatchHandler) AddStockBatch(c *fiber.Ctx) error {
+	var req AddStockBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	items := make([]usecases.AddStockRequest, 0, len(req.Items))
+	for _, item := range req.Items {
+		quantity, decimalQuantity := splitQuantity(item.Quantity)
+		items = append(items, usecases.AddStockRequest{
+			ProductID:       item.ProductID,
+			SKU:             item.SKU,
+			Barcode:         item.Barcode,
+			Quantity:        quantity,
+			DecimalQuantity: decimalQuantity,
+			Unit:            item.Unit,
+			TenantID:        item.TenantID,
+			Notes:           item.Notes,
+			AddedBy:         userID,
+			SupplierID:      item.SupplierID,
+			UnitCost:        item.UnitCost,
+		})
+	}
+
+	// Large batches get more time than a single add
+	ctx, cancel := context.WithTimeout(c.Context(), 60*time.Second)
+	defer cancel()
+
+	response, err := h.addStockBatchUseCase.Execute(ctx, usecases.AddStockBatchRequest{
+		Items: items,
+		Mode:  req.Mode,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := AddStockBatchResponse{
+		Success:   response.Failed == 0,
+		Mode:      response.Mode,
+		Succeeded: response.Succeeded,
+		Failed:    response.Failed,
+		Items:     make([]AddStockBatchItemResponse, 0, len(response.Items)),
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+	status := 200
+	for _, result := range response.Items {
+		item := toAddStockBatchItemResponse(req.Items[result.Index].ProductID, result)
+		if status == 200 && !item.Success && result.Err != domain.ErrBatchAborted {
+			status = item.Status
+			if response.Mode == usecases.BatchModeBestEffort {
+				status = 207
+			}
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return c.Status(status).JSON(resp)
+}
+
+func toAddStockBatchItemResponse(productID string, result usecases.AddStockBatchItemResult) AddStockBatchItemResponse {
+	item := AddStockBatchItemResponse{
+		Index:     result.Index,
+		ProductID: productID,
+	}
+	// Items given by SKU or barcode report the product they resolved to
+	if result.Response != nil {
+		item.ProductID = result.Response.ProductID
+	}
+	if result.Err != nil {
+		status, errResp := errorResponse(result.Err)
+		item.Status = status
+		item.Error = &errResp
+		return item
+	}
+
+	r := result.Response
+	item.Success = true
+	item.Status = 200
+	item.ProductName = r.ProductName
+	change := stockChange(r.Added, r.PreviousStock, r.NewStock, r.Decimal)
+	item.Previous = &change.PreviousStock
+	item.NewStock = &change.NewStock
+	item.Utilization = r.Utilization
+	if r.PendingApproval != nil {
+		item.Status = 202
+		item.ApprovalRequestID = r.PendingApproval.RequestID
+		return item
+	}
+	item.Added = &change.Quantity
+	item.EnteredQuantity = &r.EnteredQuantity
+	item.Unit = r.Unit
+	return item
+}
@@ -0,0 +1,135 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockAddStockBatchUseCase implements usecases.AddStockBatchUseCase for handler tests.
+type mockAddStockBatchUseCase struct {
+	response *usecases.AddStockBatchResponse
+	err      error
+	last     usecases.AddStockBatchRequest
+}
+
+func (m *mockAddStockBatchUseCase) Execute(ctx context.Context, req usecases.AddStockBatchRequest) (*usecases.AddStockBatchResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+func setupStockBatchApp(uc usecases.AddStockBatchUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	app.Post("/api/v1/stock/add/batch", httphandler.NewStockBatchHandler(uc).AddStockBatch)
+	return app
+}
+
+func batchBody(mode string) map[string]interface{} {
+	return map[string]interface{}{
+		"mode": mode,
+		"items": []map[string]interface{}{
+			{"product_id": "p1", "tenant_id": "t1", "quantity": 5},
+			{"product_id": "p2", "tenant_id": "t1", "quantity": 50},
+		},
+	}
+}
+
+func decodeBatch(t *testing.T, resp *http.Response) httphandler.AddStockBatchResponse {
+	t.Helper()
+	var got httphandler.AddStockBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return got
+}
+
+func TestStockBatchHandler_AddStockBatch_AllApplied(t *testing.T) {
+	uc := &mockAddStockBatchUseCase{response: &usecases.AddStockBatchResponse{
+		Mode: usecases.BatchModeAllOrNothing, Succeeded: 2,
+		Items: []usecases.AddStockBatchItemResult{
+			{Index: 0, Response: &usecases.AddStockResponse{ProductID: "p1", PreviousStock: 10, NewStock: 15, Added: 5}},
+			{Index: 1, Response: &usecases.AddStockResponse{ProductID: "p2", PendingApproval: &usecases.PendingApproval{RequestID: "r1"}}},
+		},
+	}}
+	resp := postJSON(t, setupStockBatchApp(uc), "/api/v1/stock/add/batch", batchBody(""))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if len(uc.last.Items) != 2 || uc.last.Items[1].AddedBy != testUserID || uc.last.Items[1].Quantity != 50 {
+		t.Errorf("use case request = %+v", uc.last)
+	}
+	got := decodeBatch(t, resp)
+	if !got.Success || got.Items[0].NewStock == nil || got.Items[0].NewStock.String() != "15" || got.Items[0].Status != 200 {
+		t.Errorf("response = %+v", got)
+	}
+	if got.Items[1].Status != http.StatusAccepted || got.Items[1].ApprovalRequestID != "r1" {
+		t.Errorf("pending item = %+v", got.Items[1])
+	}
+}
+
+func TestStockBatchHandler_AddStockBatch_BestEffortPartial(t *testing.T) {
+	uc := &mockAddStockBatchUseCase{response: &usecases.AddStockBatchResponse{
+		Mode: usecases.BatchModeBestEffort, Succeeded: 1, Failed: 1,
+		Items: []usecases.AddStockBatchItemResult{
+			{Index: 0, Response: &usecases.AddStockResponse{ProductID: "p1", NewStock: 15}},
+			{Index: 1, Err: domain.ErrStockExceedsLimit{Current: 90, Adding: 50, WouldBe: 140, MaxAllowed: 100}},
+		},
+	}}
+	resp := postJSON(t, setupStockBatchApp(uc), "/api/v1/stock/add/batch", batchBody(usecases.BatchModeBestEffort))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusMultiStatus)
+	}
+	if uc.last.Mode != usecases.BatchModeBestEffort {
+		t.Errorf("mode = %q", uc.last.Mode)
+	}
+	got := decodeBatch(t, resp)
+	failed := got.Items[1]
+	if got.Success || failed.Success || failed.Status != 400 || failed.ProductID != "p2" || failed.Error == nil || failed.Error.Code != "STOCK_LIMIT_EXCEEDED" {
+		t.Errorf("failed item = %+v", failed)
+	}
+}
+
+func TestStockBatchHandler_AddStockBatch_AllOrNothingFailure(t *testing.T) {
+	uc := &mockAddStockBatchUseCase{response: &usecases.AddStockBatchResponse{
+		Mode: usecases.BatchModeAllOrNothing, Failed: 2,
+		Items: []usecases.AddStockBatchItemResult{
+			{Index: 0, Err: domain.ErrBatchAborted},
+			{Index: 1, Err: domain.ErrProductNotFound},
+		},
+	}}
+	resp := postJSON(t, setupStockBatchApp(uc), "/api/v1/stock/add/batch", batchBody(usecases.BatchModeAllOrNothing))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status = %d, want the failed item's %d", resp.StatusCode, http.StatusNotFound)
+	}
+	got := decodeBatch(t, resp)
+	if got.Items[0].Status != http.StatusFailedDependency || got.Items[0].Error.Code != "BATCH_ABORTED" {
+		t.Errorf("aborted item = %+v", got.Items[0])
+	}
+	if got.Items[1].Error.Code != "PRODUCT_NOT_FOUND" {
+		t.Errorf("failed item = %+v", got.Items[1])
+	}
+}
+
+func TestStockBatchHandler_AddStockBatch_InvalidBatch(t *testing.T) {
+	uc := &mockAddStockBatchUseCase{err: domain.ErrInvalidBatchMode}
+	resp := postJSON(t, setupStockBatchApp(uc), "/api/v1/stock/add/batch", batchBody("sometimes"))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
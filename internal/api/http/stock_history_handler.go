@@ -0,0 +1,144 @@
+// internal/api/http/stock_history_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Stock history queries and reversal of mistaken movements
+type StockHistoryHandler struct {
+	stockHistoryUseCase usecases.StockHistoryUseCase
+	reverseUseCase      usecases.ReverseStockMovementUseCase
+}
+
+func NewStockHistoryHandler(
+	stockHistoryUseCase usecases.StockHistoryUseCase,
+	reverseUseCase usecases.ReverseStockMovementUseCase,
+) *StockHistoryHandler {
+	return &StockHistoryHandler{
+		stockHistoryUseCase: stockHistoryUseCase,
+		reverseUseCase:      reverseUseCase,
+	}
+}
+
+// GET /api/v1/products/:id/history?tenant_id=...&limit=50
+func (h *StockHistoryHandler) ProductHistory(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	history, err := h.stockHistoryUseCase.ProductHistory(ctx, usecases.ProductHistoryRequest{
+		TenantID:  c.Query("tenant_id"),
+		ProductID: c.Params("id"),
+		Limit:     c.QueryInt("limit", 0),
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	entries := make([]StockHistoryEntryResponse, 0, len(history.Entries))
+	for _, e := range history.Entries {
+		entries = append(entries, toStockHistoryEntryResponse(e))
+	}
+	return c.Status(200).JSON(ProductHistoryResponse{
+		ProductID:   history.ProductID,
+		ProductName: history.ProductName,
+		Entries:     entries,
+	})
+}
+
+// GET /api/v1/suppliers/:id/history?tenant_id=...&limit=50
+func (h *StockHistoryHandler) SupplierHistory(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	history, err := h.stockHistoryUseCase.SupplierHistory(ctx, usecases.SupplierHistoryRequest{
+		TenantID:   c.Query("tenant_id"),
+		SupplierID: c.Params("id"),
+		Limit:      c.QueryInt("limit", 0),
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	entries := make([]StockHistoryEntryResponse, 0, len(history.Entries))
+	for _, e := range history.Entries {
+		entries = append(entries, toStockHistoryEntryResponse(e))
+	}
+	return c.Status(200).JSON(SupplierHistoryResponse{
+		SupplierID:   history.SupplierID,
+		SupplierName: history.SupplierName,
+		Entries:      entries,
+	})
+}
+
+// POST /api/v1/stock/history/:id/reverse
+func (h *StockHistoryHandler) Reverse(c *fiber.Ctx) error {
+	var req ReverseStockMovementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.reverseUseCase.Execute(ctx, usecases.ReverseStockMovementRequest{
+		TenantID:       req.TenantID,
+		HistoryEntryID: c.Params("id"),
+		Notes:          req.Notes,
+		ReversedBy:     userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	change := stockChange(response.Delta, response.PreviousStock, response.NewStock, response.Decimal)
+	return c.Status(201).JSON(ReverseStockMovementResponse{
+		Success:         true,
+		HistoryID:       response.HistoryID,
+		OriginalEntryID: response.OriginalEntryID,
+		ProductID:       response.ProductID,
+		ProductName:     response.ProductName,
+		Previous:        change.PreviousStock,
+		NewStock:        change.NewStock,
+		Delta:           change.Quantity,
+		Timestamp:       time.Now().Format(time.RFC3339),
+	})
+}
+
+func toStockHistoryEntryResponse(e domain.StockHistoryEntry) StockHistoryEntryResponse {
+	change := stockChange(e.Quantity, e.PreviousStock, e.NewStock, e.Decimal)
+	resp := StockHistoryEntryResponse{
+		ID:            e.ID,
+		ProductID:     e.ProductID,
+		Operation:     e.Operation,
+		Quantity:      change.Quantity,
+		PreviousStock: change.PreviousStock,
+		NewStock:      change.NewStock,
+		Actor:         e.Actor,
+		Notes:         e.Notes,
+		ReasonCode:    e.ReasonCode,
+		Reference:     e.Reference,
+		ApprovedBy:    e.ApprovedBy,
+		SupplierID:    e.SupplierID,
+		UnitCost:      e.UnitCost,
+		Unit:          e.Unit,
+		Cost:          e.Cost,
+		ReversalOf:    e.ReversalOf,
+		ReversedBy:    e.ReversedBy,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+	if e.Unit != "" {
+		resp.EnteredQuantity = &e.EnteredQuantity
+	}
+	return resp
+}
@@ -0,0 +1,180 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockStockHistoryUseCase implements usecases.StockHistoryUseCase for handler tests.
+type mockStockHistoryUseCase struct {
+	response     *usecases.ProductHistoryResponse
+	supplier     *usecases.SupplierHistoryResponse
+	err          error
+	last         usecases.ProductHistoryRequest
+	lastSupplier usecases.SupplierHistoryRequest
+}
+
+func (m *mockStockHistoryUseCase) ProductHistory(ctx context.Context, req usecases.ProductHistoryRequest) (*usecases.ProductHistoryResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+func (m *mockStockHistoryUseCase) SupplierHistory(ctx context.Context, req usecases.SupplierHistoryRequest) (*usecases.SupplierHistoryResponse, error) {
+	m.lastSupplier = req
+	return m.supplier, m.err
+}
+
+// mockReverseStockMovementUseCase implements usecases.ReverseStockMovementUseCase for handler tests.
+type mockReverseStockMovementUseCase struct {
+	response *usecases.ReverseStockMovementResponse
+	err      error
+	last     usecases.ReverseStockMovementRequest
+}
+
+func (m *mockReverseStockMovementUseCase) Execute(ctx context.Context, req usecases.ReverseStockMovementRequest) (*usecases.ReverseStockMovementResponse, error) {
+	m.last = req
+	return m.response, m.err
+}
+
+func setupStockHistoryApp(history usecases.StockHistoryUseCase, reverse usecases.ReverseStockMovementUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewStockHistoryHandler(history, reverse)
+	app.Get("/api/v1/products/:id/history", handler.ProductHistory)
+	app.Get("/api/v1/suppliers/:id/history", handler.SupplierHistory)
+	app.Post("/api/v1/stock/history/:id/reverse", handler.Reverse)
+	return app
+}
+
+func TestStockHistoryHandler_Reverse_Success(t *testing.T) {
+	reverse := &mockReverseStockMovementUseCase{response: &usecases.ReverseStockMovementResponse{
+		HistoryID: "h2", OriginalEntryID: "h1", ProductID: "p1", PreviousStock: 50, NewStock: 10, Delta: -40,
+	}}
+	app := setupStockHistoryApp(&mockStockHistoryUseCase{}, reverse)
+
+	resp := postJSON(t, app, "/api/v1/stock/history/h1/reverse", map[string]interface{}{"tenant_id": "t1", "notes": "typo"})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if reverse.last.HistoryEntryID != "h1" || reverse.last.ReversedBy != testUserID || reverse.last.Notes != "typo" {
+		t.Errorf("use case request = %+v", reverse.last)
+	}
+	var got httphandler.ReverseStockMovementResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.HistoryID != "h2" || got.OriginalEntryID != "h1" || got.Delta.String() != "-40" {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestStockHistoryHandler_Reverse_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrMovementAlreadyReversed, http.StatusConflict},
+		{domain.ErrMovementNotReversible, http.StatusConflict},
+		{domain.ErrHistoryEntryNotFound, http.StatusNotFound},
+		{domain.ErrInsufficientStock, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			app := setupStockHistoryApp(&mockStockHistoryUseCase{}, &mockReverseStockMovementUseCase{err: tt.err})
+			resp := postJSON(t, app, "/api/v1/stock/history/h1/reverse", map[string]interface{}{"tenant_id": "t1"})
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestStockHistoryHandler_ProductHistory_ShowsLinks(t *testing.T) {
+	history := &mockStockHistoryUseCase{response: &usecases.ProductHistoryResponse{
+		ProductID: "p1",
+		Entries: []domain.StockHistoryEntry{
+			{ID: "h2", Operation: domain.HistoryOperationReversal, Quantity: -40, ReversalOf: "h1", CreatedAt: time.Now()},
+			{ID: "h1", Operation: domain.HistoryOperationStockAdd, Quantity: 40, ReversedBy: "h2", CreatedAt: time.Now()},
+		},
+	}}
+	app := setupStockHistoryApp(history, &mockReverseStockMovementUseCase{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/p1/history?tenant_id=t1&limit=10", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if history.last.ProductID != "p1" || history.last.TenantID != "t1" || history.last.Limit != 10 {
+		t.Errorf("use case request = %+v", history.last)
+	}
+	var got httphandler.ProductHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got.Entries) != 2 || got.Entries[0].ReversalOf != "h1" || got.Entries[1].ReversedBy != "h2" {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestStockHistoryHandler_SupplierHistory(t *testing.T) {
+	history := &mockStockHistoryUseCase{supplier: &usecases.SupplierHistoryResponse{
+		SupplierID:   "s1",
+		SupplierName: "Acme",
+		Entries: []domain.StockHistoryEntry{
+			{ID: "h1", ProductID: "p1", Operation: domain.HistoryOperationStockAdd, Quantity: 40, SupplierID: "s1", CreatedAt: time.Now()},
+		},
+	}}
+	app := setupStockHistoryApp(history, &mockReverseStockMovementUseCase{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/suppliers/s1/history?tenant_id=t1", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if history.lastSupplier.SupplierID != "s1" || history.lastSupplier.TenantID != "t1" {
+		t.Errorf("use case request = %+v", history.lastSupplier)
+	}
+	var got httphandler.SupplierHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.SupplierName != "Acme" || len(got.Entries) != 1 || got.Entries[0].ProductID != "p1" || got.Entries[0].SupplierID != "s1" {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestStockHistoryHandler_SupplierHistory_UnknownSupplier(t *testing.T) {
+	app := setupStockHistoryApp(&mockStockHistoryUseCase{err: domain.ErrSupplierNotFound}, &mockReverseStockMovementUseCase{})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/suppliers/s9/history?tenant_id=t1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,70 @@
+// internal/api/http/stock_snapshot_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Stock of a product at a past moment
+type StockSnapshotHandler struct {
+	stockSnapshotUseCase usecases.StockSnapshotUseCase
+}
+
+func NewStockSnapshotHandler(stockSnapshotUseCase usecases.StockSnapshotUseCase) *StockSnapshotHandler {
+	return &StockSnapshotHandler{stockSnapshotUseCase: stockSnapshotUseCase}
+}
+
+// GET /api/v1/products/:id/stock?tenant_id=...&as_of=2024-03-01
+func (h *StockSnapshotHandler) ProductStock(c *fiber.Ctx) error {
+	var asOf time.Time
+	if raw := c.Query("as_of"); raw != "" {
+		parsed, err := parseAsOf(raw)
+		if err != nil {
+			return handleError(c, domain.ErrInvalidTimeRange)
+		}
+		asOf = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	stock, err := h.stockSnapshotUseCase.ProductStockAsOf(ctx, usecases.ProductStockAsOfRequest{
+		TenantID:  c.Query("tenant_id"),
+		ProductID: c.Params("id"),
+		AsOf:      asOf,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := ProductStockAsOfResponse{
+		ProductID:   stock.ProductID,
+		ProductName: stock.ProductName,
+		TenantID:    stock.TenantID,
+		AsOf:        stock.AsOf.Format(time.RFC3339),
+		Stock:       stock.Stock,
+	}
+	if !stock.SnapshotDay.IsZero() {
+		resp.SnapshotDay = stock.SnapshotDay.Format("2006-01-02")
+	}
+	return c.Status(200).JSON(resp)
+}
+
+// parseAsOf reads an RFC 3339 timestamp, or a date meaning the end of
+// that day in UTC: stock "on March 1st" includes the movements of the 1st.
+func parseAsOf(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,93 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockStockSnapshotUseCase implements usecases.StockSnapshotUseCase for handler tests.
+type mockStockSnapshotUseCase struct {
+	stock *domain.ProductStockAsOf
+	err   error
+	last  usecases.ProductStockAsOfRequest
+}
+
+func (m *mockStockSnapshotUseCase) Capture(ctx context.Context, now time.Time) (*usecases.CaptureSnapshotsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockStockSnapshotUseCase) ProductStockAsOf(ctx context.Context, req usecases.ProductStockAsOfRequest) (*domain.ProductStockAsOf, error) {
+	m.last = req
+	return m.stock, m.err
+}
+
+func setupStockSnapshotApp(uc usecases.StockSnapshotUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewStockSnapshotHandler(uc)
+	app.Get("/api/v1/products/:id/stock", handler.ProductStock)
+	return app
+}
+
+func TestStockSnapshotHandler_ProductStock(t *testing.T) {
+	asOf := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	uc := &mockStockSnapshotUseCase{stock: &domain.ProductStockAsOf{
+		ProductID: "p1", ProductName: "Widget", TenantID: "t1", AsOf: asOf, Stock: domain.DecimalFromInt(30),
+		SnapshotDay: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	app := setupStockSnapshotApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/products/p1/stock?tenant_id=t1&as_of=2024-03-01", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	// A date asks for the stock at the end of that day
+	if uc.last.TenantID != "t1" || uc.last.ProductID != "p1" || !uc.last.AsOf.Equal(asOf) {
+		t.Errorf("request = %+v, want as of %v", uc.last, asOf)
+	}
+
+	var body httphandler.ProductStockAsOfResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Stock.String() != "30" || body.SnapshotDay != "2024-03-01" || body.AsOf != "2024-03-02T00:00:00Z" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestStockSnapshotHandler_ProductStock_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		path   string
+		status int
+	}{
+		{"bad as_of", nil, "/api/v1/products/p1/stock?tenant_id=t1&as_of=last-week", 400},
+		{"unknown product", domain.ErrProductNotFound, "/api/v1/products/p9/stock?tenant_id=t1", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupStockSnapshotApp(&mockStockSnapshotUseCase{err: tt.err})
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil), -1)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
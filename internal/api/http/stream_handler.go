@@ -0,0 +1,226 @@
+// internal/api/http/stream_handler.go
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Pushes stock and alert events to dashboards over SSE or WebSocket.
+// Each message is the event's CloudEvents envelope.
+type StreamHandler struct {
+	streamStockEventsUseCase usecases.StreamStockEventsUseCase
+	heartbeat                time.Duration
+	writeTimeout             time.Duration
+}
+
+func NewStreamHandler(streamStockEventsUseCase usecases.StreamStockEventsUseCase) *StreamHandler {
+	return &StreamHandler{
+		streamStockEventsUseCase: streamStockEventsUseCase,
+		heartbeat:                15 * time.Second,
+		writeTimeout:             10 * time.Second,
+	}
+}
+
+// GET /api/v1/stock/stream?tenant_id=...&product_id=p1,p2
+// Resumes after the Last-Event-ID header (or last_event_id query). When
+// that event is no longer retained the stream starts with a "gap" event
+// and the client should reload current stock.
+func (h *StreamHandler) Stream(c *fiber.Ctx) error {
+	lastEventID := c.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	// The stream outlives this handler, so it gets its own context
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := h.streamStockEventsUseCase.Subscribe(ctx, usecases.StreamStockEventsRequest{
+		TenantID:    c.Query("tenant_id"),
+		ProductIDs:  parseProductIDs(c.Query("product_id")),
+		LastEventID: lastEventID,
+	})
+	if err != nil {
+		cancel()
+		return handleError(c, err)
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The server write timeout is applied once per response; move it
+	// forward before every write so only a stalled client times out.
+	conn := c.Context().Conn()
+	extendDeadline := func() {
+		_ = conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+	}
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		defer sub.Close()
+
+		if sub.Gap() {
+			extendDeadline()
+			fmt.Fprintf(w, "event: gap\ndata: %s\n\n", domain.ErrStreamGap.Error())
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					// Tell slow clients why they were dropped; they reconnect with Last-Event-ID
+					if err := sub.Err(); err != nil {
+						fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+						_ = w.Flush()
+					}
+					return
+				}
+				extendDeadline()
+				if err := writeServerSentEvent(w, event); err != nil {
+					return
+				}
+			case <-ticker.C:
+				// Comment line keeps proxies open and detects gone clients
+				extendDeadline()
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func writeServerSentEvent(w *bufio.Writer, event domain.CloudEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// RequireWebSocket rejects plain HTTP requests to WebSocket routes.
+func RequireWebSocket(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return c.Status(fiber.StatusUpgradeRequired).JSON(ErrorResponse{
+			Error: "WebSocket upgrade required",
+			Code:  "UPGRADE_REQUIRED",
+		})
+	}
+	return c.Next()
+}
+
+// GET /api/v1/stock/ws?tenant_id=...&product_id=p1,p2&last_event_id=...
+// A resume whose event is no longer retained starts with a STREAM_GAP
+// error message instead of an event.
+func (h *StreamHandler) WebSocket() fiber.Handler {
+	return websocket.New(h.serveWebSocket)
+}
+
+func (h *StreamHandler) serveWebSocket(conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lastEventID := conn.Query("last_event_id")
+	if lastEventID == "" {
+		lastEventID = conn.Headers("Last-Event-ID")
+	}
+	sub, err := h.streamStockEventsUseCase.Subscribe(ctx, usecases.StreamStockEventsRequest{
+		TenantID:    conn.Query("tenant_id"),
+		ProductIDs:  parseProductIDs(conn.Query("product_id")),
+		LastEventID: lastEventID,
+	})
+	if err != nil {
+		h.closeWebSocket(conn, websocket.ClosePolicyViolation, err)
+		return
+	}
+	defer sub.Close()
+
+	// Clients only send control frames; reading detects when they leave
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if sub.Gap() {
+		_ = conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+		gap := ErrorResponse{Error: domain.ErrStreamGap.Error(), Code: "STREAM_GAP"}
+		if err := conn.WriteJSON(gap); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					h.closeWebSocket(conn, websocket.CloseTryAgainLater, err)
+				} else {
+					h.closeWebSocket(conn, websocket.CloseNormalClosure, nil)
+				}
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *StreamHandler) closeWebSocket(conn *websocket.Conn, code int, err error) {
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	}
+	message := websocket.FormatCloseMessage(code, reason)
+	_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(h.writeTimeout))
+}
+
+// "p1, p2" -> [p1 p2]
+func parseProductIDs(raw string) []string {
+	var ids []string
+	for _, id := range strings.Split(raw, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,142 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+)
+
+// closedSubscription replays fixed events and then ends.
+type closedSubscription struct {
+	events chan domain.CloudEvent
+	err    error
+	gap    bool
+}
+
+func newClosedSubscription(err error, events ...domain.CloudEvent) *closedSubscription {
+	ch := make(chan domain.CloudEvent, len(events))
+	for _, e := range events {
+		ch <- e
+	}
+	close(ch)
+	return &closedSubscription{events: ch, err: err}
+}
+
+func (s *closedSubscription) Events() <-chan domain.CloudEvent { return s.events }
+func (s *closedSubscription) Err() error                       { return s.err }
+func (s *closedSubscription) Gap() bool                        { return s.gap }
+func (s *closedSubscription) Close()                           {}
+
+// mockStreamStockEventsUseCase implements usecases.StreamStockEventsUseCase for handler tests.
+type mockStreamStockEventsUseCase struct {
+	sub     interfaces.EventSubscription
+	err     error
+	lastReq usecases.StreamStockEventsRequest
+}
+
+func (m *mockStreamStockEventsUseCase) Subscribe(ctx context.Context, req usecases.StreamStockEventsRequest) (interfaces.EventSubscription, error) {
+	m.lastReq = req
+	return m.sub, m.err
+}
+
+func setupStreamApp(uc usecases.StreamStockEventsUseCase) *fiber.App {
+	app := fiber.New()
+	handler := httphandler.NewStreamHandler(uc)
+	app.Get("/api/v1/stock/stream", handler.Stream)
+	app.Get("/api/v1/stock/ws", httphandler.RequireWebSocket, handler.WebSocket())
+	return app
+}
+
+func TestStreamHandler_Stream_WritesServerSentEvents(t *testing.T) {
+	event := domain.CloudEvent{
+		SpecVersion: domain.CloudEventsSpecVersion, ID: "e2", Type: domain.EventTypeStockAdded,
+		Subject: "p1", TenantID: "t1", DataVersion: 2, Data: json.RawMessage(`{"product_id":"p1"}`),
+	}
+	uc := &mockStreamStockEventsUseCase{sub: newClosedSubscription(domain.ErrSlowConsumer, event)}
+	app := setupStreamApp(uc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stock/stream?tenant_id=t1&product_id=p1,%20p2", nil)
+	req.Header.Set("Last-Event-ID", "e1")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	if uc.lastReq.TenantID != "t1" || uc.lastReq.LastEventID != "e1" {
+		t.Errorf("request = %+v, want tenant t1 resuming after e1", uc.lastReq)
+	}
+	if len(uc.lastReq.ProductIDs) != 2 || uc.lastReq.ProductIDs[1] != "p2" {
+		t.Errorf("ProductIDs = %v, want [p1 p2]", uc.lastReq.ProductIDs)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	text := string(body)
+	if !strings.Contains(text, "id: e2\nevent: stock.added\ndata: {") {
+		t.Errorf("body = %q, want SSE frame for e2", text)
+	}
+	if !strings.Contains(text, `"specversion":"1.0"`) {
+		t.Errorf("body = %q, want CloudEvents envelope", text)
+	}
+	if !strings.Contains(text, "event: error\ndata: "+domain.ErrSlowConsumer.Error()) {
+		t.Errorf("body = %q, want slow consumer error frame", text)
+	}
+}
+
+func TestStreamHandler_Stream_ReportsGap(t *testing.T) {
+	sub := newClosedSubscription(nil)
+	sub.gap = true
+	app := setupStreamApp(&mockStreamStockEventsUseCase{sub: sub})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stock/stream?tenant_id=t1", nil)
+	req.Header.Set("Last-Event-ID", "long-gone")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(string(body), "event: gap\ndata: "+domain.ErrStreamGap.Error()) {
+		t.Errorf("body = %q, want gap frame first", body)
+	}
+}
+
+func TestStreamHandler_Stream_TenantNotFound(t *testing.T) {
+	app := setupStreamApp(&mockStreamStockEventsUseCase{err: domain.ErrTenantNotFound})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stock/stream", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestStreamHandler_WebSocket_RequiresUpgrade(t *testing.T) {
+	app := setupStreamApp(&mockStreamStockEventsUseCase{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stock/ws?tenant_id=t1", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusUpgradeRequired {
+		t.Errorf("status = %d, want 426", resp.StatusCode)
+	}
+}
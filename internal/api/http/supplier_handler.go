@@ -0,0 +1,196 @@
+// internal/api/http/supplier_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Supplier registry and the products each supplier supplies
+type SupplierHandler struct {
+	manageSuppliersUseCase usecases.ManageSuppliersUseCase
+}
+
+func NewSupplierHandler(manageSuppliersUseCase usecases.ManageSuppliersUseCase) *SupplierHandler {
+	return &SupplierHandler{
+		manageSuppliersUseCase: manageSuppliersUseCase,
+	}
+}
+
+// POST /api/v1/suppliers
+func (h *SupplierHandler) Create(c *fiber.Ctx) error {
+	var req SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	products := make([]domain.SupplierProduct, 0, len(req.Products))
+	for _, p := range req.Products {
+		products = append(products, domain.SupplierProduct{
+			ProductID:        p.ProductID,
+			SupplierSKU:      p.SupplierSKU,
+			LeadTimeDays:     p.LeadTimeDays,
+			MinOrderQuantity: p.MinOrderQuantity,
+			PackSize:         p.PackSize,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageSuppliersUseCase.Create(ctx, usecases.CreateSupplierRequest{
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Notes:       req.Notes,
+		Products:    products,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(201).JSON(toSupplierResponse(*response))
+}
+
+// GET /api/v1/suppliers?tenant_id=...&product_id=...
+func (h *SupplierHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	suppliers, err := h.manageSuppliersUseCase.List(ctx, c.Query("tenant_id"), c.Query("product_id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]SupplierResponse, 0, len(suppliers))
+	for _, s := range suppliers {
+		result = append(result, toSupplierResponse(s))
+	}
+	return c.Status(200).JSON(result)
+}
+
+// GET /api/v1/suppliers/:id?tenant_id=...
+func (h *SupplierHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageSuppliersUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toSupplierResponse(*response))
+}
+
+// PUT /api/v1/suppliers/:id
+func (h *SupplierHandler) Update(c *fiber.Ctx) error {
+	var req SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageSuppliersUseCase.Update(ctx, usecases.UpdateSupplierRequest{
+		ID:          c.Params("id"),
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Notes:       req.Notes,
+		IsActive:    req.IsActive,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toSupplierResponse(*response))
+}
+
+// DELETE /api/v1/suppliers/:id?tenant_id=...
+func (h *SupplierHandler) Delete(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.manageSuppliersUseCase.Delete(ctx, c.Query("tenant_id"), c.Params("id")); err != nil {
+		return handleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+// PUT /api/v1/suppliers/:id/products/:product_id
+func (h *SupplierHandler) SetProduct(c *fiber.Ctx) error {
+	var req SupplierProductLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageSuppliersUseCase.SetProduct(ctx, usecases.SetSupplierProductRequest{
+		TenantID:   req.TenantID,
+		SupplierID: c.Params("id"),
+		Product: domain.SupplierProduct{
+			ProductID:        c.Params("product_id"),
+			SupplierSKU:      req.SupplierSKU,
+			LeadTimeDays:     req.LeadTimeDays,
+			MinOrderQuantity: req.MinOrderQuantity,
+			PackSize:         req.PackSize,
+		},
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toSupplierResponse(*response))
+}
+
+// DELETE /api/v1/suppliers/:id/products/:product_id?tenant_id=...
+func (h *SupplierHandler) RemoveProduct(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageSuppliersUseCase.RemoveProduct(ctx, c.Query("tenant_id"), c.Params("id"), c.Params("product_id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toSupplierResponse(*response))
+}
+
+func toSupplierResponse(s usecases.SupplierResponse) SupplierResponse {
+	products := make([]SupplierProductResponse, 0, len(s.Products))
+	for _, p := range s.Products {
+		products = append(products, SupplierProductResponse{
+			ProductID:        p.ProductID,
+			SupplierSKU:      p.SupplierSKU,
+			LeadTimeDays:     p.LeadTimeDays,
+			MinOrderQuantity: p.MinOrderQuantity,
+			PackSize:         p.PackSize,
+		})
+	}
+	return SupplierResponse{
+		ID:          s.ID,
+		TenantID:    s.TenantID,
+		Name:        s.Name,
+		ContactName: s.ContactName,
+		Email:       s.Email,
+		Phone:       s.Phone,
+		Notes:       s.Notes,
+		IsActive:    s.IsActive,
+		Products:    products,
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   s.UpdatedAt.Format(time.RFC3339),
+	}
+}
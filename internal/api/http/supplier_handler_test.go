@@ -0,0 +1,161 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+)
+
+// mockManageSuppliersUseCase implements usecases.ManageSuppliersUseCase for handler tests.
+type mockManageSuppliersUseCase struct {
+	response    *usecases.SupplierResponse
+	err         error
+	lastCreate  usecases.CreateSupplierRequest
+	lastProduct usecases.SetSupplierProductRequest
+	lastDelete  [2]string
+}
+
+func (m *mockManageSuppliersUseCase) Create(ctx context.Context, req usecases.CreateSupplierRequest) (*usecases.SupplierResponse, error) {
+	m.lastCreate = req
+	return m.response, m.err
+}
+
+func (m *mockManageSuppliersUseCase) Get(ctx context.Context, tenantID, supplierID string) (*usecases.SupplierResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockManageSuppliersUseCase) List(ctx context.Context, tenantID, productID string) ([]usecases.SupplierResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.SupplierResponse{*m.response}, nil
+}
+
+func (m *mockManageSuppliersUseCase) Update(ctx context.Context, req usecases.UpdateSupplierRequest) (*usecases.SupplierResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockManageSuppliersUseCase) Delete(ctx context.Context, tenantID, supplierID string) error {
+	m.lastDelete = [2]string{tenantID, supplierID}
+	return m.err
+}
+
+func (m *mockManageSuppliersUseCase) SetProduct(ctx context.Context, req usecases.SetSupplierProductRequest) (*usecases.SupplierResponse, error) {
+	m.lastProduct = req
+	return m.response, m.err
+}
+
+func (m *mockManageSuppliersUseCase) RemoveProduct(ctx context.Context, tenantID, supplierID, productID string) (*usecases.SupplierResponse, error) {
+	return m.response, m.err
+}
+
+func setupSupplierApp(uc usecases.ManageSuppliersUseCase) *fiber.App {
+	app := fiber.New()
+	handler := httphandler.NewSupplierHandler(uc)
+	app.Post("/api/v1/suppliers", handler.Create)
+	app.Get("/api/v1/suppliers", handler.List)
+	app.Delete("/api/v1/suppliers/:id", handler.Delete)
+	app.Put("/api/v1/suppliers/:id/products/:product_id", handler.SetProduct)
+	return app
+}
+
+func testSupplier() *usecases.SupplierResponse {
+	return &usecases.SupplierResponse{
+		ID: "s1", TenantID: "t1", Name: "Acme", IsActive: true,
+		Products:  []domain.SupplierProduct{{ProductID: "p1", LeadTimeDays: 5, MinOrderQuantity: 10, PackSize: 6}},
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+}
+
+func TestSupplierHandler_Create(t *testing.T) {
+	uc := &mockManageSuppliersUseCase{response: testSupplier()}
+	app := setupSupplierApp(uc)
+
+	resp := postJSON(t, app, "/api/v1/suppliers", map[string]interface{}{
+		"tenant_id": "t1",
+		"name":      "Acme",
+		"products":  []map[string]interface{}{{"product_id": "p1", "lead_time_days": 5, "min_order_quantity": 10, "pack_size": 6}},
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	if req := uc.lastCreate; req.Name != "Acme" || len(req.Products) != 1 || req.Products[0].PackSize != 6 {
+		t.Errorf("use case request = %+v", req)
+	}
+
+	var body httphandler.SupplierResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.ID != "s1" || !body.IsActive || len(body.Products) != 1 || body.Products[0].MinOrderQuantity != 10 {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestSupplierHandler_SetProduct(t *testing.T) {
+	uc := &mockManageSuppliersUseCase{response: testSupplier()}
+	app := setupSupplierApp(uc)
+
+	payload, _ := json.Marshal(map[string]interface{}{"tenant_id": "t1", "lead_time_days": 3, "pack_size": 12})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/suppliers/s1/products/p2", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := uc.lastProduct; got.SupplierID != "s1" || got.Product.ProductID != "p2" || got.Product.LeadTimeDays != 3 || got.Product.PackSize != 12 {
+		t.Errorf("use case request = %+v", got)
+	}
+}
+
+func TestSupplierHandler_Delete(t *testing.T) {
+	uc := &mockManageSuppliersUseCase{}
+	app := setupSupplierApp(uc)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/suppliers/s1?tenant_id=t1", nil), -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent || uc.lastDelete != [2]string{"t1", "s1"} {
+		t.Errorf("status = %d, delete = %v", resp.StatusCode, uc.lastDelete)
+	}
+}
+
+func TestSupplierHandler_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{domain.ErrSupplierNotFound, http.StatusNotFound, "SUPPLIER_NOT_FOUND"},
+		{domain.ErrInvalidSupplier, http.StatusBadRequest, "INVALID_SUPPLIER"},
+		{domain.ErrInvalidSupplierProduct, http.StatusBadRequest, "INVALID_SUPPLIER"},
+		{domain.ErrSupplierInactive, http.StatusBadRequest, "SUPPLIER_INACTIVE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantCode, func(t *testing.T) {
+			app := setupSupplierApp(&mockManageSuppliersUseCase{err: tt.err})
+			resp := postJSON(t, app, "/api/v1/suppliers", map[string]interface{}{"tenant_id": "t1", "name": "Acme"})
+
+			var body httphandler.ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus || body.Code != tt.wantCode {
+				t.Errorf("status = %d, code = %q, want %d %q", resp.StatusCode, body.Code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
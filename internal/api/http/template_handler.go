@@ -0,0 +1,110 @@
+// internal/api/http/template_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TemplateHandler struct {
+	manageTemplatesUseCase usecases.ManageTemplatesUseCase
+}
+
+func NewTemplateHandler(manageTemplatesUseCase usecases.ManageTemplatesUseCase) *TemplateHandler {
+	return &TemplateHandler{
+		manageTemplatesUseCase: manageTemplatesUseCase,
+	}
+}
+
+func (h *TemplateHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	templates, err := h.manageTemplatesUseCase.List(ctx, c.Query("tenant_id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]TemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, toTemplateResponse(t))
+	}
+	return c.Status(200).JSON(result)
+}
+
+func (h *TemplateHandler) Save(c *fiber.Ctx) error {
+	var req SaveTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageTemplatesUseCase.Save(ctx, usecases.SaveTemplateRequest{
+		TenantID:  req.TenantID,
+		Name:      c.Params("name"),
+		Body:      req.Body,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toTemplateResponse(*response))
+}
+
+func (h *TemplateHandler) Reset(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.manageTemplatesUseCase.Reset(ctx, c.Query("tenant_id"), c.Params("name")); err != nil {
+		return handleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+func (h *TemplateHandler) Preview(c *fiber.Ctx) error {
+	var req PreviewTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageTemplatesUseCase.Preview(ctx, usecases.PreviewTemplateRequest{
+		TenantID: req.TenantID,
+		Name:     c.Params("name"),
+		Body:     req.Body,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(PreviewTemplateResponse{
+		Name:     response.Name,
+		Rendered: response.Rendered,
+	})
+}
+
+func toTemplateResponse(t usecases.TemplateResponse) TemplateResponse {
+	resp := TemplateResponse{
+		Name:      t.Name,
+		Body:      t.Body,
+		IsCustom:  t.IsCustom,
+		UpdatedBy: t.UpdatedBy,
+	}
+	if !t.UpdatedAt.IsZero() {
+		resp.UpdatedAt = t.UpdatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/httputil"
+)
+
+// mockManageTemplatesUseCase implements usecases.ManageTemplatesUseCase for handler tests.
+type mockManageTemplatesUseCase struct {
+	response    *usecases.TemplateResponse
+	preview     *usecases.PreviewTemplateResponse
+	err         error
+	lastSave    usecases.SaveTemplateRequest
+	lastPreview usecases.PreviewTemplateRequest
+}
+
+func (m *mockManageTemplatesUseCase) List(ctx context.Context, tenantID string) ([]usecases.TemplateResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.TemplateResponse{*m.response}, nil
+}
+
+func (m *mockManageTemplatesUseCase) Save(ctx context.Context, req usecases.SaveTemplateRequest) (*usecases.TemplateResponse, error) {
+	m.lastSave = req
+	return m.response, m.err
+}
+
+func (m *mockManageTemplatesUseCase) Reset(ctx context.Context, tenantID, name string) error {
+	return m.err
+}
+
+func (m *mockManageTemplatesUseCase) Preview(ctx context.Context, req usecases.PreviewTemplateRequest) (*usecases.PreviewTemplateResponse, error) {
+	m.lastPreview = req
+	return m.preview, m.err
+}
+
+func setupTemplateApp(uc usecases.ManageTemplatesUseCase) *fiber.App {
+	app := fiber.New()
+	app.Use(httputil.UserIDMiddleware(testUserID))
+	handler := httphandler.NewTemplateHandler(uc)
+	app.Put("/api/v1/notification-templates/:name", handler.Save)
+	app.Post("/api/v1/notification-templates/:name/preview", handler.Preview)
+	return app
+}
+
+func TestTemplateHandler_Save_InvalidTemplate(t *testing.T) {
+	uc := &mockManageTemplatesUseCase{
+		err: domain.ErrInvalidTemplate{Name: domain.TemplateStockAlertSlack, Reason: "unexpected EOF"},
+	}
+	app := setupTemplateApp(uc)
+
+	bodyBytes, _ := json.Marshal(map[string]string{"tenant_id": "t1", "body": "{{.ProductName"})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/notification-templates/"+domain.TemplateStockAlertSlack, bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var errResp httphandler.ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errResp)
+	if errResp.Code != "INVALID_TEMPLATE" || errResp.Details == "" {
+		t.Errorf("code = %q details = %q", errResp.Code, errResp.Details)
+	}
+	if uc.lastSave.UpdatedBy != testUserID || uc.lastSave.Name != domain.TemplateStockAlertSlack {
+		t.Errorf("use case request = %+v", uc.lastSave)
+	}
+}
+
+func TestTemplateHandler_Preview_Success(t *testing.T) {
+	uc := &mockManageTemplatesUseCase{
+		preview: &usecases.PreviewTemplateResponse{Name: domain.TemplateLowStockAlert, Rendered: "Sample Widget low"},
+	}
+	app := setupTemplateApp(uc)
+
+	bodyBytes, _ := json.Marshal(map[string]string{"tenant_id": "t1"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/notification-templates/"+domain.TemplateLowStockAlert+"/preview", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var result httphandler.PreviewTemplateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result.Rendered != "Sample Widget low" {
+		t.Errorf("rendered = %q", result.Rendered)
+	}
+	if uc.lastPreview.TenantID != "t1" || uc.lastPreview.Name != domain.TemplateLowStockAlert {
+		t.Errorf("use case request = %+v", uc.lastPreview)
+	}
+}
@@ -0,0 +1,145 @@
+// internal/api/http/webhook_handler.go
+package http
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/application/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	manageWebhooksUseCase usecases.ManageWebhooksUseCase
+}
+
+func NewWebhookHandler(manageWebhooksUseCase usecases.ManageWebhooksUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		manageWebhooksUseCase: manageWebhooksUseCase,
+	}
+}
+
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var req CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageWebhooksUseCase.Create(ctx, usecases.CreateWebhookRequest{
+		TenantID:   req.TenantID,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(201).JSON(toWebhookResponse(*response))
+}
+
+func (h *WebhookHandler) List(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	responses, err := h.manageWebhooksUseCase.List(ctx, c.Query("tenant_id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]WebhookResponse, 0, len(responses))
+	for _, r := range responses {
+		result = append(result, toWebhookResponse(r))
+	}
+	return c.Status(200).JSON(result)
+}
+
+func (h *WebhookHandler) Get(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageWebhooksUseCase.Get(ctx, c.Query("tenant_id"), c.Params("id"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toWebhookResponse(*response))
+}
+
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	var req UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(ErrorResponse{
+			Error: "Invalid request format",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.manageWebhooksUseCase.Update(ctx, usecases.UpdateWebhookRequest{
+		ID:         c.Params("id"),
+		TenantID:   req.TenantID,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		IsActive:   req.IsActive,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Status(200).JSON(toWebhookResponse(*response))
+}
+
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.manageWebhooksUseCase.Delete(ctx, c.Query("tenant_id"), c.Params("id")); err != nil {
+		return handleError(c, err)
+	}
+	return c.SendStatus(204)
+}
+
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+
+	deliveries, err := h.manageWebhooksUseCase.ListDeliveries(ctx, c.Query("tenant_id"), c.Params("id"), c.QueryInt("limit", 50))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, WebhookDeliveryResponse{
+			ID:          d.ID,
+			EventID:     d.EventID,
+			EventType:   d.EventType,
+			Attempt:     d.Attempt,
+			StatusCode:  d.StatusCode,
+			Success:     d.Success,
+			Error:       d.Error,
+			DurationMS:  d.DurationMS,
+			DeliveredAt: d.DeliveredAt.Format(time.RFC3339),
+		})
+	}
+	return c.Status(200).JSON(result)
+}
+
+func toWebhookResponse(r usecases.WebhookResponse) WebhookResponse {
+	return WebhookResponse{
+		ID:         r.ID,
+		TenantID:   r.TenantID,
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		IsActive:   r.IsActive,
+		CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  r.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	httphandler "myapp/internal/api/http"
+	"myapp/internal/application/usecases"
+	"myapp/internal/domain"
+)
+
+// mockManageWebhooksUseCase implements usecases.ManageWebhooksUseCase for handler tests.
+type mockManageWebhooksUseCase struct {
+	response   *usecases.WebhookResponse
+	deliveries []usecases.WebhookDeliveryResponse
+	err        error
+	lastCreate usecases.CreateWebhookRequest
+	lastTenant string
+}
+
+func (m *mockManageWebhooksUseCase) Create(ctx context.Context, req usecases.CreateWebhookRequest) (*usecases.WebhookResponse, error) {
+	m.lastCreate = req
+	return m.response, m.err
+}
+
+func (m *mockManageWebhooksUseCase) List(ctx context.Context, tenantID string) ([]usecases.WebhookResponse, error) {
+	m.lastTenant = tenantID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []usecases.WebhookResponse{*m.response}, nil
+}
+
+func (m *mockManageWebhooksUseCase) Get(ctx context.Context, tenantID, subscriptionID string) (*usecases.WebhookResponse, error) {
+	m.lastTenant = tenantID
+	return m.response, m.err
+}
+
+func (m *mockManageWebhooksUseCase) Update(ctx context.Context, req usecases.UpdateWebhookRequest) (*usecases.WebhookResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockManageWebhooksUseCase) Delete(ctx context.Context, tenantID, subscriptionID string) error {
+	m.lastTenant = tenantID
+	return m.err
+}
+
+func (m *mockManageWebhooksUseCase) ListDeliveries(ctx context.Context, tenantID, subscriptionID string, limit int) ([]usecases.WebhookDeliveryResponse, error) {
+	return m.deliveries, m.err
+}
+
+func setupWebhookApp(uc usecases.ManageWebhooksUseCase) *fiber.App {
+	app := fiber.New()
+	handler := httphandler.NewWebhookHandler(uc)
+	app.Post("/api/v1/webhooks", handler.Create)
+	app.Get("/api/v1/webhooks", handler.List)
+	app.Get("/api/v1/webhooks/:id", handler.Get)
+	app.Delete("/api/v1/webhooks/:id", handler.Delete)
+	return app
+}
+
+func TestWebhookHandler_Create_Success(t *testing.T) {
+	uc := &mockManageWebhooksUseCase{
+		response: &usecases.WebhookResponse{
+			ID: "wh1", TenantID: "t1", URL: "https://example.com/hook",
+			EventTypes: []string{domain.EventTypeStockAdded}, IsActive: true, CreatedAt: time.Now(),
+		},
+	}
+	app := setupWebhookApp(uc)
+
+	body := map[string]interface{}{
+		"tenant_id":   "t1",
+		"url":         "https://example.com/hook",
+		"secret":      "0123456789abcdef",
+		"event_types": []string{domain.EventTypeStockAdded},
+	}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if result["id"] != "wh1" {
+		t.Errorf("id = %v", result["id"])
+	}
+	if _, ok := result["secret"]; ok {
+		t.Error("response must not expose the secret")
+	}
+	if uc.lastCreate.Secret != "0123456789abcdef" || uc.lastCreate.TenantID != "t1" {
+		t.Errorf("use case request = %+v", uc.lastCreate)
+	}
+}
+
+func TestWebhookHandler_Create_InvalidURL(t *testing.T) {
+	uc := &mockManageWebhooksUseCase{err: domain.ErrInvalidWebhookURL}
+	app := setupWebhookApp(uc)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"tenant_id": "t1", "url": "nope"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var errResp httphandler.ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errResp)
+	if errResp.Code != "INVALID_WEBHOOK" {
+		t.Errorf("code = %q", errResp.Code)
+	}
+}
+
+func TestWebhookHandler_Get_NotFound(t *testing.T) {
+	uc := &mockManageWebhooksUseCase{err: domain.ErrWebhookNotFound}
+	app := setupWebhookApp(uc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/wh1?tenant_id=t1", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	var errResp httphandler.ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errResp)
+	if errResp.Code != "WEBHOOK_NOT_FOUND" {
+		t.Errorf("code = %q", errResp.Code)
+	}
+	if uc.lastTenant != "t1" {
+		t.Errorf("tenant = %q, want t1", uc.lastTenant)
+	}
+}
+
+func TestWebhookHandler_Delete_Success(t *testing.T) {
+	uc := &mockManageWebhooksUseCase{}
+	app := setupWebhookApp(uc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/webhooks/wh1?tenant_id=t1", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
@@ -4,13 +4,45 @@ package interfaces
 import (
 	"context"
 	"myapp/internal/domain"
+	"time"
 )
 
 // Repository interfaces defined by application layer
 type ProductRepository interface {
 	FindByID(ctx context.Context, productID string) (*domain.Product, error)
+	// FindByIDs loads several products in one query; unknown IDs are left out
+	FindByIDs(ctx context.Context, productIDs []string) ([]*domain.Product, error)
+	FindByTenant(ctx context.Context, tenantID string) ([]*domain.Product, error)
+	// FindByIdentifier finds the tenant's product by SKU or barcode
+	FindByIdentifier(ctx context.Context, tenantID string, id domain.ProductIdentifier) (*domain.Product, error)
+	// FindVariants lists the products grouped under parentID
+	FindVariants(ctx context.Context, tenantID, parentID string) ([]*domain.Product, error)
+	// EachByTenant calls fn for the tenant's products one at a time, by ID,
+	// without loading them all; an error from fn stops the iteration
+	EachByTenant(ctx context.Context, tenantID string, fn func(*domain.Product) error) error
 	Save(ctx context.Context, product *domain.Product) error
 	UpdateStock(ctx context.Context, productID string, newStock domain.StockQuantity) error
+	SetTotalAdded(ctx context.Context, productID string, totalAdded domain.Decimal) error
+	// SetCountLock marks the product as locked by a count session; an
+	// empty sessionID releases it
+	SetCountLock(ctx context.Context, productID, sessionID string) error
+	// SetUnits stores the product's base unit and unit conversions
+	SetUnits(ctx context.Context, productID, baseUnit string, units []domain.UnitConversion) error
+	// SetQuantityMode stores how the product counts stock, together with
+	// its stock at the new precision
+	SetQuantityMode(ctx context.Context, productID, mode string, precision int, stock domain.StockQuantity) error
+	// SetIdentifiers stores the product's SKU and barcode, clearing empty
+	// ones. It fails with ErrDuplicateProductIdentifier when another of the
+	// tenant's products has either.
+	SetIdentifiers(ctx context.Context, productID, sku, barcode string) error
+	// SetParent groups the product under parentID, or ungroups it when
+	// parentID is empty
+	SetParent(ctx context.Context, productID, parentID string, options map[string]string) error
+	// Each calls fn for every product of every tenant, by ID
+	Each(ctx context.Context, fn func(*domain.Product) error) error
+	// SummarizeUtilization reports the tenant's products against its limit
+	// as domain.SummarizeUtilization does
+	SummarizeUtilization(ctx context.Context, q domain.UtilizationQuery) (*domain.UtilizationSummary, error)
 }
 
 type TenantRepository interface {
@@ -19,11 +51,206 @@ type TenantRepository interface {
 
 type StockHistoryRepository interface {
 	Create(ctx context.Context, event domain.StockAddedEvent) error
+	Append(ctx context.Context, entry *domain.StockHistoryEntry) error
+	FindByID(ctx context.Context, tenantID, entryID string) (*domain.StockHistoryEntry, error)
+	// A product's entries, newest first; limit <= 0 returns all
+	FindByProduct(ctx context.Context, tenantID, productID string, limit int) ([]domain.StockHistoryEntry, error)
+	// Entries of stock received from a supplier, newest first; limit <= 0
+	// returns all
+	FindBySupplier(ctx context.Context, tenantID, supplierID string, limit int) ([]domain.StockHistoryEntry, error)
+	// MarkReversed links an entry to its reversal. It fails with
+	// ErrMovementAlreadyReversed when the entry already has one.
+	MarkReversed(ctx context.Context, tenantID, entryID, reversalID string) error
+	// EachInRange calls fn for the tenant's entries created in [from, to),
+	// oldest first, one at a time; an error from fn stops the iteration
+	EachInRange(ctx context.Context, tenantID string, from, to time.Time, fn func(domain.StockHistoryEntry) error) error
+	// Totals per product of a tenant's history
+	SummarizeByProduct(ctx context.Context, tenantID string) ([]domain.StockHistorySummary, error)
+	// Totals per reason code of entries with a reason, created in [from, to)
+	SummarizeAdjustments(ctx context.Context, tenantID string, from, to time.Time) ([]domain.AdjustmentTotals, error)
+	// NetChangeByProduct sums the exact signed quantities of entries
+	// created in [from, to) per product ID. An empty productID covers the
+	// whole tenant; products without entries are left out.
+	NetChangeByProduct(ctx context.Context, tenantID, productID string, from, to time.Time) (map[string]domain.Decimal, error)
+	// DailyConsumption totals stock removals per product and UTC day for
+	// entries created in [from, to). Reversed removals are left out, as is
+	// every other product when productID is set.
+	DailyConsumption(ctx context.Context, tenantID, productID string, from, to time.Time) ([]domain.DailyConsumption, error)
+}
+
+// Daily per-product stock levels, for questions about past stock
+type DailyStockSnapshotRepository interface {
+	// SaveAll creates or replaces the snapshots by tenant, product and day
+	SaveAll(ctx context.Context, snapshots []domain.DailyStockSnapshot) error
+	// FindLatest returns the snapshots of the latest day taken before at:
+	// the product's, or the whole tenant's when productID is empty. It
+	// returns none when no snapshot was taken before at.
+	FindLatest(ctx context.Context, tenantID, productID string, at time.Time) ([]domain.DailyStockSnapshot, error)
+}
+
+// Tenant overrides and additions to the built-in adjustment reasons
+type AdjustmentReasonRepository interface {
+	FindByTenant(ctx context.Context, tenantID string) ([]*domain.AdjustmentReason, error)
+	// Save creates or replaces the tenant's reason with the same code
+	Save(ctx context.Context, reason *domain.AdjustmentReason) error
+	Delete(ctx context.Context, tenantID, code string) error
+}
+
+// Stock changes waiting for a second person's approval
+type StockChangeRequestRepository interface {
+	Create(ctx context.Context, request *domain.StockChangeRequest) error
+	FindByID(ctx context.Context, tenantID, requestID string) (*domain.StockChangeRequest, error)
+	// Newest first; an empty status matches all
+	FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.StockChangeRequest, error)
+	// Decide stores the request's decision. It fails with
+	// ErrChangeRequestClosed unless the stored request is still pending, so
+	// only one decision wins.
+	Decide(ctx context.Context, request *domain.StockChangeRequest) error
+	// ExpirePending marks pending requests that expired before now
+	ExpirePending(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Stock import jobs, including the rows they still have to apply
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	FindByID(ctx context.Context, tenantID, jobID string) (*domain.ImportJob, error)
+	// FindByHash returns the tenant's job for a file, failed or not, or
+	// nil when there is none
+	FindByHash(ctx context.Context, tenantID, fileHash string) (*domain.ImportJob, error)
+	// ClaimPending marks the oldest pending job, or a running job whose
+	// lease expired before now, running and leased, and returns it; nil
+	// when none is waiting. Only one caller can claim a job.
+	ClaimPending(ctx context.Context, now time.Time) (*domain.ImportJob, error)
+	// SaveProgress stores the job's status, lease, next row, counters and
+	// row errors
+	SaveProgress(ctx context.Context, job *domain.ImportJob) error
+}
+
+type WebhookSubscriptionRepository interface {
+	FindByID(ctx context.Context, tenantID, subscriptionID string) (*domain.WebhookSubscription, error)
+	FindByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error)
+	Create(ctx context.Context, sub *domain.WebhookSubscription) error
+	Update(ctx context.Context, sub *domain.WebhookSubscription) error
+	Delete(ctx context.Context, tenantID, subscriptionID string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery domain.WebhookDelivery) error
+	FindBySubscription(ctx context.Context, subscriptionID string, limit int) ([]domain.WebhookDelivery, error)
+}
+
+type NotificationTemplateRepository interface {
+	FindByName(ctx context.Context, tenantID, name string) (*domain.NotificationTemplate, error)
+	FindByTenant(ctx context.Context, tenantID string) ([]*domain.NotificationTemplate, error)
+	Save(ctx context.Context, template *domain.NotificationTemplate) error
+	Delete(ctx context.Context, tenantID, name string) error
+}
+
+type RoutingRuleRepository interface {
+	FindByID(ctx context.Context, tenantID, ruleID string) (*domain.RoutingRule, error)
+	FindByTenant(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error)
+	Create(ctx context.Context, rule *domain.RoutingRule) error
+	Update(ctx context.Context, rule *domain.RoutingRule) error
+	Delete(ctx context.Context, tenantID, ruleID string) error
+}
+
+type OutboxRepository interface {
+	Append(ctx context.Context, entries []*domain.OutboxEntry) error
+	// Pending entries due at now, oldest first. Entries whose aggregate has
+	// an older entry still waiting for a retry, or dead, are left out so
+	// each aggregate's events are published in order.
+	FindPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEntry, error)
+	// Dead entries, oldest first
+	FindDead(ctx context.Context, limit int) ([]*domain.OutboxEntry, error)
+	// FindByID fails with domain.ErrOutboxEntryNotFound
+	FindByID(ctx context.Context, id string) (*domain.OutboxEntry, error)
+	Update(ctx context.Context, entry *domain.OutboxEntry) error
+	RecordAttempt(ctx context.Context, attempt domain.OutboxAttempt) error
+	LoadCursor(ctx context.Context, relay string) (*domain.OutboxCursor, error)
+	SaveCursor(ctx context.Context, cursor domain.OutboxCursor) error
+}
+
+type StockLedgerRepository interface {
+	// Append fails with domain.ErrConcurrentStockUpdate when the entry's
+	// sequence is already taken
+	Append(ctx context.Context, entry *domain.StockLedgerEntry) error
+	// Entries of a product with a sequence above afterSequence, in order
+	FindByProduct(ctx context.Context, productID string, afterSequence int64) ([]domain.StockLedgerEntry, error)
+	// Products of a tenant that have ledger entries
+	FindProductIDs(ctx context.Context, tenantID string) ([]string, error)
+	// Latest snapshot of a product, or nil when there is none
+	LatestSnapshot(ctx context.Context, productID string) (*domain.StockSnapshot, error)
+	SaveSnapshot(ctx context.Context, snapshot domain.StockSnapshot) error
+	DeleteSnapshots(ctx context.Context, productID string) error
+}
+
+type CountSessionRepository interface {
+	Create(ctx context.Context, session *domain.CountSession) error
+	FindByID(ctx context.Context, tenantID, sessionID string) (*domain.CountSession, error)
+	// Sessions of a tenant, newest first; empty status lists all
+	FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.CountSession, error)
+	// AddCount appends a count to an open session's line atomically, so
+	// counters can submit concurrently
+	AddCount(ctx context.Context, tenantID, sessionID, productID string, entry domain.CountEntry) error
+	// Close persists an approved or cancelled session. Fails with
+	// domain.ErrCountSessionClosed when it was already closed.
+	Close(ctx context.Context, session *domain.CountSession) error
+}
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, order *domain.PurchaseOrder) error
+	FindByID(ctx context.Context, tenantID, orderID string) (*domain.PurchaseOrder, error)
+	// Orders of a tenant, newest first; empty status lists all
+	FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.PurchaseOrder, error)
+	// Update stores the order's status, lines and receipts and increments
+	// its version. It fails with domain.ErrPurchaseOrderChanged when the
+	// stored version is no longer order.Version.
+	Update(ctx context.Context, order *domain.PurchaseOrder) error
+}
+
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *domain.Supplier) error
+	FindByID(ctx context.Context, tenantID, supplierID string) (*domain.Supplier, error)
+	// Suppliers of a tenant by name; with productID, only those supplying it
+	FindByTenant(ctx context.Context, tenantID, productID string) ([]*domain.Supplier, error)
+	// Update stores the supplier's details and product links
+	Update(ctx context.Context, supplier *domain.Supplier) error
+	Delete(ctx context.Context, tenantID, supplierID string) error
+}
+
+// Cost layers valuing each product's stock
+type ProductCostRepository interface {
+	// FindByProduct returns the product's cost layers, or an empty
+	// ProductCost when none were recorded yet
+	FindByProduct(ctx context.Context, tenantID, productID string) (*domain.ProductCost, error)
+	FindByTenant(ctx context.Context, tenantID string) ([]*domain.ProductCost, error)
+	// Save stores the layers and increments the version. It fails with
+	// domain.ErrProductCostChanged when the stored version is no longer
+	// cost.Version.
+	Save(ctx context.Context, cost *domain.ProductCost) error
 }
 
 // Unit of Work pattern for transaction
 type UnitOfWork interface {
+	// WithTransaction runs fn atomically. Repositories must be used with the
+	// ctx passed to fn for their writes to join the transaction.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
 	Products() ProductRepository
 	Tenants() TenantRepository
 	StockHistory() StockHistoryRepository
+	WebhookSubscriptions() WebhookSubscriptionRepository
+	WebhookDeliveries() WebhookDeliveryRepository
+	NotificationTemplates() NotificationTemplateRepository
+	RoutingRules() RoutingRuleRepository
+	Outbox() OutboxRepository
+	StockLedger() StockLedgerRepository
+	CountSessions() CountSessionRepository
+	AdjustmentReasons() AdjustmentReasonRepository
+	StockChangeRequests() StockChangeRequestRepository
+	ImportJobs() ImportJobRepository
+	DailySnapshots() DailyStockSnapshotRepository
+	PurchaseOrders() PurchaseOrderRepository
+	Suppliers() SupplierRepository
+	ProductCosts() ProductCostRepository
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"myapp/internal/domain"
 )
 
@@ -12,8 +13,67 @@ type NotificationService interface {
 	SendLowStockAlert(ctx context.Context, product *domain.Product, threshold int) error
 }
 
+// A single delivery channel (Slack, email, ...) used by notification routing
+type NotificationChannel interface {
+	Send(ctx context.Context, notification domain.Notification) error
+}
+
+// Renders notification templates, preferring tenant overrides over defaults
+type TemplateRenderer interface {
+	Render(ctx context.Context, tenantID, name string, data interface{}) (string, error)
+	Default(name string) (string, error)
+	Validate(name, body string) error
+	Preview(name, body string) (string, error)
+}
+
+// Publishes domain events wrapped in their CloudEvents envelope
 type EventPublisher interface {
-	Publish(ctx context.Context, event interface{}) error
+	Publish(ctx context.Context, event domain.CloudEvent) error
+}
+
+// Delivers events to tenant webhooks in the background. Close stops
+// accepting events and waits, for a bounded time, for deliveries in flight.
+type WebhookPublisher interface {
+	EventPublisher
+	Close() error
+}
+
+// Live feed of published events for push endpoints
+type EventStream interface {
+	// Subscribe replays retained events after lastEventID, then follows new
+	// ones. When lastEventID is not retained nothing is replayed and the
+	// subscription reports a gap.
+	Subscribe(ctx context.Context, filter domain.EventFilter, lastEventID string) (EventSubscription, error)
+}
+
+type EventSubscription interface {
+	// Events is closed when the subscription ends
+	Events() <-chan domain.CloudEvent
+	// Err reports why the stream ended, e.g. domain.ErrSlowConsumer
+	Err() error
+	// Gap reports that the events after the requested lastEventID could
+	// not be replayed
+	Gap() bool
+	Close()
+}
+
+// Reads the cells of an uploaded spreadsheet, row by row. Fails with
+// domain.ErrUnsupportedImportFormat for formats it cannot read.
+type SpreadsheetReader interface {
+	Read(format string, data []byte) ([][]string, error)
+}
+
+// Writes export rows in one file format as they come. Close writes what
+// is still buffered and any trailer; call it once after the last row.
+type TableWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// Creates table writers for the export formats. Fails with
+// domain.ErrUnsupportedExportFormat for formats it cannot write.
+type TableEncoder interface {
+	NewWriter(format string, w io.Writer, columns []domain.ExportColumn) (TableWriter, error)
 }
 
 // Validator interface
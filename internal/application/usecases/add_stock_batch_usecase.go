@@ -0,0 +1,325 @@
+// internal/application/usecases/add_stock_batch_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Batch modes
+const (
+	// Every item is applied in one transaction, or none is
+	BatchModeAllOrNothing = "all_or_nothing"
+	// Each item is applied on its own; failures do not affect the others
+	BatchModeBestEffort = "best_effort"
+)
+
+// Most items accepted in one batch
+const MaxStockBatchSize = 1000
+
+// Input DTO
+type AddStockBatchRequest struct {
+	Items []AddStockRequest
+	// BatchModeAllOrNothing when empty
+	Mode string
+}
+
+// Output DTOs
+type AddStockBatchResponse struct {
+	Mode      string
+	Succeeded int
+	Failed    int
+	// One result per item, in request order
+	Items []AddStockBatchItemResult
+}
+
+type AddStockBatchItemResult struct {
+	Index    int
+	Response *AddStockResponse
+	// Why the item was not applied. In all-or-nothing mode the items
+	// around a failure carry domain.ErrBatchAborted.
+	Err error
+}
+
+// Use Case interface (what handlers depend on)
+type AddStockBatchUseCase interface {
+	Execute(ctx context.Context, req AddStockBatchRequest) (*AddStockBatchResponse, error)
+}
+
+// Implementation
+type addStockBatchUseCase struct {
+	uow   interfaces.UnitOfWork
+	adder *addStockUseCase
+}
+
+func NewAddStockBatchUseCase(
+	uow interfaces.UnitOfWork,
+	notificationSvc interfaces.NotificationService,
+) AddStockBatchUseCase {
+	return &addStockBatchUseCase{
+		uow:   uow,
+		adder: newAddStockUseCase(uow, notificationSvc),
+	}
+}
+
+func (uc *addStockBatchUseCase) Execute(ctx context.Context, req AddStockBatchRequest) (*AddStockBatchResponse, error) {
+	// 1. Validate the batch
+	mode := req.Mode
+	if mode == "" {
+		mode = BatchModeAllOrNothing
+	}
+	if mode != BatchModeAllOrNothing && mode != BatchModeBestEffort {
+		return nil, domain.ErrInvalidBatchMode
+	}
+	if len(req.Items) == 0 {
+		return nil, domain.ErrEmptyBatch
+	}
+	if len(req.Items) > MaxStockBatchSize {
+		return nil, domain.ErrBatchTooLarge
+	}
+
+	results := make([]AddStockBatchItemResult, len(req.Items))
+	for i, item := range req.Items {
+		results[i] = AddStockBatchItemResult{Index: i, Err: uc.adder.validateRequest(item)}
+	}
+
+	// 2. Load tenants and products once for the whole batch; items given
+	// by SKU or barcode are resolved to their product ID first
+	items := append([]AddStockRequest(nil), req.Items...)
+	tenants, err := uc.loadTenants(ctx, items, results)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.resolveIdentifiers(ctx, items, results); err != nil {
+		return nil, err
+	}
+	products, err := uc.loadProducts(ctx, items, results)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Apply the items
+	if mode == BatchModeAllOrNothing {
+		err = uc.applyAll(ctx, items, tenants, products, results)
+	} else {
+		uc.applyEach(ctx, items, tenants, products, results)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddStockBatchResponse{Mode: mode, Items: results}
+	for _, r := range results {
+		if r.Err != nil {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	return response, nil
+}
+
+// loadTenants looks each distinct tenant up once and fails the items of
+// tenants that are missing or cannot receive stock.
+func (uc *addStockBatchUseCase) loadTenants(ctx context.Context, items []AddStockRequest, results []AddStockBatchItemResult) (map[string]*domain.Tenant, error) {
+	tenants := make(map[string]*domain.Tenant)
+	tenantErrs := make(map[string]error)
+	for i, item := range items {
+		if results[i].Err != nil {
+			continue
+		}
+		if _, seen := tenants[item.TenantID]; !seen {
+			if _, failed := tenantErrs[item.TenantID]; !failed {
+				tenant, err := uc.uow.Tenants().FindByID(ctx, item.TenantID)
+				switch {
+				case err == domain.ErrTenantNotFound:
+					tenantErrs[item.TenantID] = err
+				case err != nil:
+					return nil, err
+				default:
+					if err := tenant.CanReceiveStock(); err != nil {
+						tenantErrs[item.TenantID] = err
+					} else {
+						tenants[item.TenantID] = tenant
+					}
+				}
+			}
+		}
+		if err := tenantErrs[item.TenantID]; err != nil {
+			results[i].Err = err
+		}
+	}
+	return tenants, nil
+}
+
+// resolveIdentifiers sets the product ID of items given by SKU or
+// barcode, looking each distinct one up once, and fails the items whose
+// product does not exist.
+func (uc *addStockBatchUseCase) resolveIdentifiers(ctx context.Context, items []AddStockRequest, results []AddStockBatchItemResult) error {
+	type key struct {
+		tenantID string
+		id       domain.ProductIdentifier
+	}
+	resolved := make(map[key]string)
+	for i, item := range items {
+		if results[i].Err != nil || item.ProductID != "" {
+			continue
+		}
+		id, err := domain.NewProductIdentifier(item.SKU, item.Barcode)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		k := key{tenantID: item.TenantID, id: id}
+		productID, seen := resolved[k]
+		if !seen {
+			product, err := uc.uow.Products().FindByIdentifier(ctx, item.TenantID, id)
+			switch {
+			case err == domain.ErrProductNotFound:
+			case err != nil:
+				return err
+			default:
+				productID = product.ID
+			}
+			resolved[k] = productID
+		}
+		if productID == "" {
+			results[i].Err = domain.ErrProductNotFound
+			continue
+		}
+		items[i].ProductID = productID
+	}
+	return nil
+}
+
+// loadProducts fetches all products of the batch in one query and fails
+// the items whose product does not exist or belongs to another tenant.
+func (uc *addStockBatchUseCase) loadProducts(ctx context.Context, items []AddStockRequest, results []AddStockBatchItemResult) (map[string]*domain.Product, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for i, item := range items {
+		if results[i].Err == nil && !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	found, err := uc.uow.Products().FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[string]*domain.Product, len(found))
+	for _, p := range found {
+		products[p.ID] = p
+	}
+	// Products of other tenants than the item's are not found
+	for i, item := range items {
+		if results[i].Err != nil {
+			continue
+		}
+		if product := products[item.ProductID]; product == nil || product.TenantID != item.TenantID {
+			results[i].Err = domain.ErrProductNotFound
+		}
+	}
+	return products, nil
+}
+
+// applyAll applies every item in one transaction. Any failure rolls the
+// batch back; the failed items keep their own error.
+func (uc *addStockBatchUseCase) applyAll(
+	ctx context.Context,
+	items []AddStockRequest,
+	tenants map[string]*domain.Tenant,
+	products map[string]*domain.Product,
+	results []AddStockBatchItemResult,
+) error {
+	failed := firstFailure(results)
+	var notifications []func()
+	if failed < 0 {
+		err := uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+			// The transaction may be retried, so every attempt starts from
+			// the products as loaded
+			working := copyProducts(products)
+			notifications = notifications[:0]
+			failed = -1
+			for i := range results {
+				results[i].Err = nil
+			}
+			for i, item := range items {
+				response, notify, err := uc.adder.apply(ctx, item, tenants[item.TenantID], working[item.ProductID])
+				if err != nil {
+					failed = i
+					results[i].Err = err
+					return err
+				}
+				results[i].Response = response
+				notifications = append(notifications, notify)
+			}
+			return nil
+		})
+		if err != nil && failed < 0 {
+			return err
+		}
+	}
+
+	if failed >= 0 {
+		for i := range results {
+			results[i].Response = nil
+			if results[i].Err == nil {
+				results[i].Err = domain.ErrBatchAborted
+			}
+		}
+		return nil
+	}
+	for _, notify := range notifications {
+		notify()
+	}
+	return nil
+}
+
+// applyEach applies every valid item in its own transaction.
+func (uc *addStockBatchUseCase) applyEach(
+	ctx context.Context,
+	items []AddStockRequest,
+	tenants map[string]*domain.Tenant,
+	products map[string]*domain.Product,
+	results []AddStockBatchItemResult,
+) {
+	for i, item := range items {
+		if results[i].Err != nil {
+			continue
+		}
+		// Work on a copy so a failed item leaves the loaded product as stored
+		product := *products[item.ProductID]
+		response, notify, err := uc.adder.apply(ctx, item, tenants[item.TenantID], &product)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		*products[item.ProductID] = product
+		results[i].Response = response
+		notify()
+	}
+}
+
+func firstFailure(results []AddStockBatchItemResult) int {
+	for i, r := range results {
+		if r.Err != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+func copyProducts(products map[string]*domain.Product) map[string]*domain.Product {
+	copies := make(map[string]*domain.Product, len(products))
+	for id, p := range products {
+		product := *p
+		copies[id] = &product
+	}
+	return copies
+}
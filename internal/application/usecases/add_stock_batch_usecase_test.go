@@ -0,0 +1,195 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func batchFixture() (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	return stockFixture(testProduct("p1", "Widget", 10), testProduct("p2", "Gadget", 90))
+}
+
+func TestAddStockBatchUseCase_Execute_AllOrNothing_Success(t *testing.T) {
+	uow, products := batchFixture()
+	uc := NewAddStockBatchUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockBatchRequest{Items: []AddStockRequest{
+		{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"},
+		{ProductID: "p2", TenantID: "t1", Quantity: 5, AddedBy: "u1"},
+		{ProductID: "p1", TenantID: "t1", Quantity: 7, AddedBy: "u1"},
+	}})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Mode != BatchModeAllOrNothing || got.Succeeded != 3 || got.Failed != 0 {
+		t.Errorf("response = %+v", got)
+	}
+	// Repeated products build on the earlier items of the batch
+	if r := got.Items[2].Response; r.PreviousStock != 15 || r.NewStock != 22 {
+		t.Errorf("third item = %+v, want 15 -> 22", r)
+	}
+	if products.FindByIDsCalls != 1 {
+		t.Errorf("FindByIDs calls = %d, want 1", products.FindByIDsCalls)
+	}
+	if uow.TxCalls != 4 {
+		t.Errorf("TxCalls = %d, want the batch transaction plus one joined per item", uow.TxCalls)
+	}
+	if len(uow.StockHistRepo.Events) != 3 {
+		t.Errorf("history events = %d, want 3", len(uow.StockHistRepo.Events))
+	}
+}
+
+func TestAddStockBatchUseCase_Execute_AllOrNothing_FailureAbortsBatch(t *testing.T) {
+	uow, _ := batchFixture()
+	uc := NewAddStockBatchUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockBatchRequest{Mode: BatchModeAllOrNothing, Items: []AddStockRequest{
+		{ProductID: "p1", TenantID: "t1", Quantity: 5},
+		{ProductID: "p2", TenantID: "t1", Quantity: 50},
+		{ProductID: "p1", TenantID: "t1", Quantity: 1},
+	}})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Succeeded != 0 || got.Failed != 3 {
+		t.Errorf("succeeded = %d, failed = %d, want 0 and 3", got.Succeeded, got.Failed)
+	}
+	var limitErr domain.ErrStockExceedsLimit
+	if !errors.As(got.Items[1].Err, &limitErr) {
+		t.Errorf("item 1 err = %v, want ErrStockExceedsLimit", got.Items[1].Err)
+	}
+	for _, i := range []int{0, 2} {
+		if got.Items[i].Err != domain.ErrBatchAborted || got.Items[i].Response != nil {
+			t.Errorf("item %d = %+v, want aborted", i, got.Items[i])
+		}
+	}
+}
+
+func TestAddStockBatchUseCase_Execute_AllOrNothing_InvalidItemSkipsTransaction(t *testing.T) {
+	uow, _ := batchFixture()
+	uc := NewAddStockBatchUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockBatchRequest{Items: []AddStockRequest{
+		{ProductID: "p1", TenantID: "t1", Quantity: 5},
+		{ProductID: "missing", TenantID: "t1", Quantity: 5},
+		{ProductID: "p2", TenantID: "t1", Quantity: 0},
+	}})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if uow.TxCalls != 0 || len(uow.StockHistRepo.Events) != 0 {
+		t.Errorf("TxCalls = %d, history = %d, want nothing written", uow.TxCalls, len(uow.StockHistRepo.Events))
+	}
+	want := []error{domain.ErrBatchAborted, domain.ErrProductNotFound, domain.ErrInvalidQuantity}
+	for i, w := range want {
+		if got.Items[i].Err != w {
+			t.Errorf("item %d err = %v, want %v", i, got.Items[i].Err, w)
+		}
+	}
+}
+
+func TestAddStockBatchUseCase_Execute_BestEffort(t *testing.T) {
+	uow, products := batchFixture()
+	uc := NewAddStockBatchUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockBatchRequest{Mode: BatchModeBestEffort, Items: []AddStockRequest{
+		{ProductID: "p1", TenantID: "t1", Quantity: 5},
+		{ProductID: "p2", TenantID: "t1", Quantity: 50},
+		{ProductID: "missing", TenantID: "t1", Quantity: 1},
+		{ProductID: "p2", TenantID: "t1", Quantity: 10},
+	}})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Succeeded != 2 || got.Failed != 2 {
+		t.Errorf("succeeded = %d, failed = %d, want 2 and 2", got.Succeeded, got.Failed)
+	}
+	var limitErr domain.ErrStockExceedsLimit
+	if !errors.As(got.Items[1].Err, &limitErr) || got.Items[2].Err != domain.ErrProductNotFound {
+		t.Errorf("errors = %v, %v", got.Items[1].Err, got.Items[2].Err)
+	}
+	// The failed item did not change the product the last item adds to
+	if r := got.Items[3].Response; r == nil || r.PreviousStock != 90 || r.NewStock != 100 {
+		t.Errorf("last item = %+v, want 90 -> 100", r)
+	}
+	if products.FindByIDsCalls != 1 {
+		t.Errorf("FindByIDs calls = %d, want 1", products.FindByIDsCalls)
+	}
+	if len(uow.StockHistRepo.Events) != 2 {
+		t.Errorf("history events = %d, want 2", len(uow.StockHistRepo.Events))
+	}
+}
+
+func TestAddStockBatchUseCase_Execute_InvalidBatch(t *testing.T) {
+	tooMany := make([]AddStockRequest, MaxStockBatchSize+1)
+	tests := []struct {
+		name string
+		req  AddStockBatchRequest
+		want error
+	}{
+		{"empty", AddStockBatchRequest{}, domain.ErrEmptyBatch},
+		{"too large", AddStockBatchRequest{Items: tooMany}, domain.ErrBatchTooLarge},
+		{"unknown mode", AddStockBatchRequest{Mode: "some", Items: tooMany[:1]}, domain.ErrInvalidBatchMode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, _ := batchFixture()
+			_, err := NewAddStockBatchUseCase(uow, nil).Execute(context.Background(), tt.req)
+			if err != tt.want {
+				t.Errorf("Execute() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddStockBatchUseCase_Execute_BySKUOrBarcode(t *testing.T) {
+	uow, products := batchFixture()
+	products.Products[0].SKU = "WID-001"
+	products.Products[1].Barcode = "0000096385074"
+	uc := NewAddStockBatchUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockBatchRequest{Mode: BatchModeBestEffort, Items: []AddStockRequest{
+		{SKU: "wid-001", TenantID: "t1", Quantity: 5},
+		{Barcode: "96385074", TenantID: "t1", Quantity: 5},
+		{SKU: "WID-001", TenantID: "t1", Quantity: 2},
+		{SKU: "NONE", TenantID: "t1", Quantity: 1},
+	}})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Succeeded != 3 || got.Failed != 1 {
+		t.Errorf("succeeded = %d, failed = %d, want 3 and 1", got.Succeeded, got.Failed)
+	}
+	if r := got.Items[2].Response; r.ProductID != "p1" || r.PreviousStock != 15 || r.NewStock != 17 {
+		t.Errorf("third item = %+v, want p1 15 -> 17", r)
+	}
+	if got.Items[1].Response.ProductID != "p2" {
+		t.Errorf("second item = %+v, want p2", got.Items[1].Response)
+	}
+	if !errors.Is(got.Items[3].Err, domain.ErrProductNotFound) {
+		t.Errorf("fourth item err = %v, want %v", got.Items[3].Err, domain.ErrProductNotFound)
+	}
+}
+
+func TestAddStockBatchUseCase_Execute_OtherTenantsProduct(t *testing.T) {
+	uow, products := batchFixture()
+	products.Products[1].TenantID = "t2"
+	uc := NewAddStockBatchUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockBatchRequest{Mode: BatchModeBestEffort, Items: []AddStockRequest{
+		{ProductID: "p1", TenantID: "t1", Quantity: 5},
+		{ProductID: "p2", TenantID: "t1", Quantity: 5},
+	}})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Succeeded != 1 || !errors.Is(got.Items[1].Err, domain.ErrProductNotFound) {
+		t.Errorf("items = %+v, want p2 not found", got.Items)
+	}
+	if products.Products[1].CurrentStock.Value() != 90 {
+		t.Errorf("other tenant's stock = %d, want 90", products.Products[1].CurrentStock.Value())
+	}
+}
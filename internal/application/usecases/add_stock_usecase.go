@@ -10,11 +10,42 @@ import (
 
 // Input DTO (Application-specific, not HTTP-specific)
 type AddStockRequest struct {
+	// The product by exactly one of its ID, SKU or barcode
 	ProductID string
+	SKU       string
+	Barcode   string
 	Quantity  int
-	TenantID  string
-	Notes     string
-	AddedBy   string
+	// Unit Quantity is entered in, one of the product's units; empty is
+	// the base unit
+	Unit     string
+	TenantID string
+	Notes    string
+	AddedBy  string
+	// Set when an approved change request runs the add: the approval
+	// threshold is skipped and both are recorded in history
+	ApprovedBy        string
+	ApprovalRequestID string
+	// Set when stock is received against a purchase order: the order
+	// authorizes the add, so the approval threshold is skipped, and
+	// history references the order
+	PurchaseOrderID string
+	// The supplier the stock came from, so history can be reported per
+	// supplier; it must be active
+	SupplierID string
+	// What each entered unit cost; without it the units are valued at the
+	// product's average unit cost
+	UnitCost *domain.Money
+	// Exact quantity for decimal-mode products, e.g. 2.5 kg; used instead
+	// of Quantity when set
+	DecimalQuantity *domain.Decimal
+}
+
+// entered is the quantity as the caller gave it, in Unit
+func (req AddStockRequest) entered() domain.Decimal {
+	if req.DecimalQuantity != nil {
+		return *req.DecimalQuantity
+	}
+	return domain.DecimalFromInt(req.Quantity)
 }
 
 // Output DTO
@@ -23,9 +54,18 @@ type AddStockResponse struct {
 	ProductName   string
 	PreviousStock int
 	NewStock      int
-	Added         int
-	MaxAllowed    int
-	Utilization   float64
+	// Added is in the base unit, EnteredQuantity in Unit
+	Added           int
+	EnteredQuantity domain.Decimal
+	Unit            string
+	BaseUnit        string
+	MaxAllowed      int
+	Utilization     float64
+	// Exact quantities of a decimal-mode product; the ints above are its
+	// whole units
+	Decimal *domain.DecimalStockChange
+	// Set instead of changing stock when the add awaits approval
+	PendingApproval *PendingApproval
 }
 
 // Use Case interface (what handlers depend on)
@@ -37,19 +77,27 @@ type AddStockUseCase interface {
 type addStockUseCase struct {
 	uow                   interfaces.UnitOfWork
 	notificationSvc       interfaces.NotificationService
-	eventPublisher        interfaces.EventPublisher
+	ledger                stockLedger
+	costing               stockCosting
 	recentUpdateThreshold time.Duration
 }
 
 func NewAddStockUseCase(
 	uow interfaces.UnitOfWork,
 	notificationSvc interfaces.NotificationService,
-	eventPublisher interfaces.EventPublisher,
 ) AddStockUseCase {
+	return newAddStockUseCase(uow, notificationSvc)
+}
+
+func newAddStockUseCase(
+	uow interfaces.UnitOfWork,
+	notificationSvc interfaces.NotificationService,
+) *addStockUseCase {
 	return &addStockUseCase{
 		uow:                   uow,
 		notificationSvc:       notificationSvc,
-		eventPublisher:        eventPublisher,
+		ledger:                stockLedger{uow: uow, snapshotEvery: domain.DefaultSnapshotInterval},
+		costing:               stockCosting{uow: uow},
 		recentUpdateThreshold: 5 * time.Minute,
 	}
 }
@@ -72,16 +120,99 @@ func (uc *addStockUseCase) Execute(ctx context.Context, req AddStockRequest) (*A
 	}
 
 	// 5. Get product
-	product, err := uc.uow.Products().FindByID(ctx, req.ProductID)
+	product, err := uc.findProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	// 6. Create quantity value object
-	quantity, err := domain.NewStockQuantity(req.Quantity)
+	response, notify, err := uc.apply(ctx, req, tenant, product)
 	if err != nil {
 		return nil, err
 	}
+	notify()
+	return response, nil
+}
+
+// apply adds stock to a loaded product and saves it. The returned notify
+// sends the async alerts; callers run it once the change is committed.
+func (uc *addStockUseCase) apply(
+	ctx context.Context,
+	req AddStockRequest,
+	tenant *domain.Tenant,
+	product *domain.Product,
+) (*AddStockResponse, func(), error) {
+	var err error
+	// Another tenant's product is not found, whatever path loaded it
+	if product.TenantID != tenant.ID {
+		return nil, nil, domain.ErrProductNotFound
+	}
+	if product.IsLockedForCount() {
+		return nil, nil, domain.ErrProductLockedForCount
+	}
+	if req.SupplierID != "" {
+		supplier, err := uc.uow.Suppliers().FindByID(ctx, tenant.ID, req.SupplierID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !supplier.IsActive {
+			return nil, nil, domain.ErrSupplierInactive
+		}
+	}
+
+	// 6. Convert to the product's base unit, the unit stock is kept in.
+	// Integer-mode products take whole entered quantities only.
+	entered := req.entered()
+	if !product.IsDecimal() && !entered.IsInteger() {
+		return nil, nil, domain.ErrNonIntegralQuantity
+	}
+	if product.IsDecimal() && req.UnitCost != nil {
+		return nil, nil, domain.ErrDecimalUnsupported
+	}
+	quantity, err := product.ToBaseQuantity(entered, req.Unit)
+	if err != nil {
+		return nil, nil, err
+	}
+	unit := req.Unit
+	if unit == "" {
+		unit = product.BaseUnitName()
+	}
+
+	// Large adds wait for a second person when the tenant requires it
+	if req.ApprovedBy == "" && req.PurchaseOrderID == "" && tenant.RequiresApprovalFor(quantity) {
+		request := domain.NewStockChangeRequest(tenant, product, domain.ChangeOperationAdd, entered.IntPart(), req.Notes, req.AddedBy)
+		request.DecimalQuantity = req.DecimalQuantity
+		request.Unit = req.Unit
+		request.SupplierID = req.SupplierID
+		request.UnitCost = req.UnitCost
+		pending, err := requestApproval(ctx, uc.uow, request)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &AddStockResponse{
+			ProductID:       product.ID,
+			ProductName:     product.Name,
+			PreviousStock:   product.CurrentStock.Value(),
+			NewStock:        product.CurrentStock.Value(),
+			MaxAllowed:      tenant.MaxStock.Value(),
+			Utilization:     product.UtilizationPercentage(tenant.MaxStock),
+			PendingApproval: pending,
+		}, func() {}, nil
+	}
+
+	// In event-sourced mode the ledger, not the stored value, is the current stock
+	var projection *domain.StockProjection
+	var ledgerEntries []domain.StockLedgerEntry
+	if tenant.IsEventSourced() {
+		// The ledger counts whole units
+		if product.IsDecimal() {
+			return nil, nil, domain.ErrDecimalUnsupported
+		}
+		projection, ledgerEntries, err = uc.ledger.open(ctx, product)
+		if err != nil {
+			return nil, nil, err
+		}
+		product.CurrentStock = projection.CurrentStock()
+	}
 
 	// 7. Business rule: Check if product was recently updated
 	if product.IsRecentlyUpdated(uc.recentUpdateThreshold) {
@@ -92,15 +223,41 @@ func (uc *addStockUseCase) Execute(ctx context.Context, req AddStockRequest) (*A
 	// 8. Add stock with business logic
 	previousStock := product.CurrentStock
 	if err := product.AddStock(quantity, tenant.MaxStock); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if projection != nil {
+		entry, err := projection.Record(domain.LedgerStockAdded, quantity.Value(), req.AddedBy, req.Notes)
+		if err != nil {
+			return nil, nil, err
+		}
+		ledgerEntries = append(ledgerEntries, entry)
 	}
 
-	// 9. Save updated product
-	if err := uc.uow.Products().Save(ctx, product); err != nil {
-		return nil, err
+	// 9. Value the units under the tenant's costing method
+	now := time.Now()
+	var value *domain.Money
+	if req.UnitCost != nil {
+		total := req.UnitCost.Times(entered.IntPart())
+		value = &total
+	}
+	cost, received, err := uc.costing.receive(ctx, tenant, product, quantity.Value(), value, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	var unitCost, receivedCost *domain.Money
+	if cost != nil {
+		share := received.Share(1, entered.IntPart())
+		if req.UnitCost != nil {
+			share = *req.UnitCost
+		}
+		unitCost, receivedCost = &share, &received
 	}
 
-	// 10. Create audit log
+	// 10. Build audit log and domain events
+	reference := req.ApprovalRequestID
+	if req.PurchaseOrderID != "" {
+		reference = req.PurchaseOrderID
+	}
 	stockEvent := domain.StockAddedEvent{
 		ProductID: product.ID,
 		TenantID:  req.TenantID,
@@ -108,18 +265,25 @@ func (uc *addStockUseCase) Execute(ctx context.Context, req AddStockRequest) (*A
 		Previous:  previousStock,
 		Current:   product.CurrentStock,
 		AddedBy:   req.AddedBy,
-		Timestamp: time.Now(),
+		Timestamp: now,
 		Notes:     req.Notes,
-	}
 
-	if err := uc.uow.StockHistory().Create(ctx, stockEvent); err != nil {
-		return nil, err
+		ApprovedBy: req.ApprovedBy,
+		Reference:  reference,
+		SupplierID: req.SupplierID,
+
+		Unit:            unit,
+		EnteredQuantity: entered,
+		UnitCost:        unitCost,
+		Cost:            receivedCost,
 	}
+	events := []domain.Event{stockEvent}
 
-	// 11. Check if stock limit alert needed
+	// Check if stock limit alert needed
+	var alertEvent *domain.StockLimitAlertEvent
 	utilization := product.UtilizationPercentage(tenant.MaxStock)
 	if utilization > 80 {
-		alertEvent := domain.StockLimitAlertEvent{
+		alertEvent = &domain.StockLimitAlertEvent{
 			ProductID:   product.ID,
 			ProductName: product.Name,
 			Current:     product.CurrentStock,
@@ -127,49 +291,129 @@ func (uc *addStockUseCase) Execute(ctx context.Context, req AddStockRequest) (*A
 			Utilization: utilization,
 			TenantID:    req.TenantID,
 			Timestamp:   time.Now(),
+			ProductTags: product.Tags,
 		}
+		events = append(events, *alertEvent)
+	}
 
-		// Async notification (fire and forget in background)
-		go func() {
-			ctx := context.Background()
-			_ = uc.notificationSvc.SendStockAlert(ctx, alertEvent)
-		}()
+	// Check for low stock
+	lowStock := product.IsLowStock(domain.DefaultLowStockThreshold)
+	if lowStock {
+		events = append(events, domain.LowStockEvent{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			TenantID:    req.TenantID,
+			Current:     product.CurrentStock,
+			Threshold:   domain.DefaultLowStockThreshold,
+			ProductTags: product.Tags,
+			Timestamp:   time.Now(),
+		})
 	}
 
-	// 12. Check for low stock
-	if product.IsLowStock(10) {
-		go func() {
-			ctx := context.Background()
-			_ = uc.notificationSvc.SendLowStockAlert(ctx, product, 10)
-		}()
+	outboxEntries := make([]*domain.OutboxEntry, 0, len(events))
+	for _, event := range events {
+		entry, err := domain.NewOutboxEntry(event, req.TenantID)
+		if err != nil {
+			return nil, nil, err
+		}
+		outboxEntries = append(outboxEntries, entry)
 	}
 
-	// 13. Publish domain event
-	if uc.eventPublisher != nil {
-		_ = uc.eventPublisher.Publish(ctx, stockEvent)
+	// 11. Save product, history, cost layers, ledger and outbox atomically.
+	// The outbox relay publishes the events once the transaction has
+	// committed.
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if projection != nil {
+			// A concurrent writer taking the same sequence aborts this transaction
+			if err := uc.ledger.append(ctx, projection, ledgerEntries); err != nil {
+				return err
+			}
+		}
+		if err := uc.uow.Products().Save(ctx, product); err != nil {
+			return err
+		}
+		if err := uc.uow.StockHistory().Create(ctx, stockEvent); err != nil {
+			return err
+		}
+		if err := uc.costing.save(ctx, cost); err != nil {
+			return err
+		}
+		return uc.uow.Outbox().Append(ctx, outboxEntries)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 12. Async notification (fire and forget in background).
+	// Without a notification service, alerts reach subscribers via the outbox.
+	notify := func() {
+		if uc.notificationSvc == nil {
+			return
+		}
+		if alertEvent != nil {
+			go func() {
+				ctx := context.Background()
+				_ = uc.notificationSvc.SendStockAlert(ctx, *alertEvent)
+			}()
+		}
+		if lowStock {
+			go func() {
+				ctx := context.Background()
+				_ = uc.notificationSvc.SendLowStockAlert(ctx, product, domain.DefaultLowStockThreshold)
+			}()
+		}
 	}
 
-	// 15. Return response
+	// 13. Return response
 	return &AddStockResponse{
-		ProductID:     product.ID,
-		ProductName:   product.Name,
-		PreviousStock: previousStock.Value(),
-		NewStock:      product.CurrentStock.Value(),
-		Added:         quantity.Value(),
-		MaxAllowed:    tenant.MaxStock.Value(),
-		Utilization:   utilization,
-	}, nil
+		ProductID:       product.ID,
+		ProductName:     product.Name,
+		PreviousStock:   previousStock.Value(),
+		NewStock:        product.CurrentStock.Value(),
+		Added:           quantity.Value(),
+		EnteredQuantity: entered,
+		Unit:            unit,
+		BaseUnit:        product.BaseUnitName(),
+		MaxAllowed:      tenant.MaxStock.Value(),
+		Utilization:     utilization,
+		Decimal:         domain.NewDecimalStockChange(quantity.Decimal(), previousStock, product.CurrentStock),
+	}, notify, nil
+}
+
+// findProduct loads the product by ID, or the tenant's product by SKU or
+// barcode
+func (uc *addStockUseCase) findProduct(ctx context.Context, req AddStockRequest) (*domain.Product, error) {
+	if req.ProductID != "" {
+		return uc.uow.Products().FindByID(ctx, req.ProductID)
+	}
+	id, err := domain.NewProductIdentifier(req.SKU, req.Barcode)
+	if err != nil {
+		return nil, err
+	}
+	return uc.uow.Products().FindByIdentifier(ctx, req.TenantID, id)
 }
 
 func (uc *addStockUseCase) validateRequest(req AddStockRequest) error {
-	if req.ProductID == "" {
+	given := 0
+	for _, ref := range []string{req.ProductID, req.SKU, req.Barcode} {
+		if ref != "" {
+			given++
+		}
+	}
+	if given == 0 {
 		return domain.ErrInvalidProductID
 	}
+	if given > 1 {
+		return domain.ErrInvalidProductIdentifier
+	}
 	if req.TenantID == "" {
 		return domain.ErrTenantNotFound
 	}
-	if req.Quantity <= 0 {
+	if req.entered().Sign() <= 0 {
 		return domain.ErrInvalidQuantity
 	}
+	if req.UnitCost != nil && req.UnitCost.IsNegative() {
+		return domain.ErrInvalidUnitCost
+	}
 	return nil
 }
@@ -29,8 +29,9 @@ func TestAddStockUseCase_Execute_Validation(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{},
 		TenantsRepo:   &mocks.MockTenantRepo{},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	tests := []struct {
@@ -77,8 +78,9 @@ func TestAddStockUseCase_Execute_TenantNotFound(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{},
 		TenantsRepo:   &mocks.MockTenantRepo{FindErr: errFindTenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"}
@@ -97,8 +99,9 @@ func TestAddStockUseCase_Execute_TenantInactive(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"}
@@ -117,8 +120,9 @@ func TestAddStockUseCase_Execute_ProductNotFound(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{FindErr: errFindProduct},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"}
@@ -141,8 +145,9 @@ func TestAddStockUseCase_Execute_StockExceedsLimit(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{Product: product},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"}
@@ -169,8 +174,9 @@ func TestAddStockUseCase_Execute_SaveProductFails(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{Product: product, SaveErr: errSaveProduct},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 3, AddedBy: "u1"}
@@ -193,8 +199,9 @@ func TestAddStockUseCase_Execute_StockHistoryCreateFails(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{Product: product},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{CreateErr: errCreateHistory},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 3, AddedBy: "u1"}
@@ -215,13 +222,14 @@ func TestAddStockUseCase_Execute_Success(t *testing.T) {
 	}
 	hist := &mocks.MockStockHistoryRepo{}
 	notif := &mocks.MockNotificationService{}
-	pub := &mocks.MockEventPublisher{}
+	outbox := &mocks.MockOutboxRepo{}
 	uow := &mocks.MockUnitOfWork{
 		ProductsRepo:  &mocks.MockProductRepo{Product: product},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: hist,
+		OutboxRepo:    outbox,
 	}
-	uc := NewAddStockUseCase(uow, notif, pub)
+	uc := NewAddStockUseCase(uow, notif)
 	ctx := context.Background()
 
 	req := AddStockRequest{
@@ -266,23 +274,28 @@ func TestAddStockUseCase_Execute_Success(t *testing.T) {
 			t.Errorf("StockAddedEvent: Previous=%d Current=%d", e.Previous.Value(), e.Current.Value())
 		}
 	}
-	if len(pub.Published) != 1 {
-		t.Errorf("EventPublisher.Publish calls = %d, want 1", len(pub.Published))
+	if uow.TxCalls != 1 {
+		t.Errorf("WithTransaction calls = %d, want 1", uow.TxCalls)
+	}
+	if len(outbox.Entries) != 1 || outbox.Entries[0].EventType != domain.EventTypeStockAdded {
+		t.Errorf("outbox entries = %+v, want one %s", outbox.Entries, domain.EventTypeStockAdded)
 	}
 }
 
-func TestAddStockUseCase_Execute_Success_NoEventPublisher(t *testing.T) {
+func TestAddStockUseCase_Execute_Success_NoAlertsWithinLimits(t *testing.T) {
 	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true}
 	product := &domain.Product{
 		ID: "p1", Name: "Widget", CurrentStock: mustQuantity(10),
 		LastUpdated: time.Now().Add(-1 * time.Hour), TenantID: "t1",
 	}
+	notif := &mocks.MockNotificationService{}
 	uow := &mocks.MockUnitOfWork{
 		ProductsRepo:  &mocks.MockProductRepo{Product: product},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{}, nil)
+	uc := NewAddStockUseCase(uow, notif)
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"}
@@ -293,6 +306,11 @@ func TestAddStockUseCase_Execute_Success_NoEventPublisher(t *testing.T) {
 	if got == nil || got.NewStock != 15 {
 		t.Fatalf("Execute() response: got=%+v, want NewStock=15", got)
 	}
+	// 15/100 is neither low nor near the limit; alerts would be sent async
+	time.Sleep(50 * time.Millisecond)
+	if len(notif.StockAlerts) != 0 || notif.LowStockCalls != 0 {
+		t.Errorf("alerts sent: stock=%d low=%d, want none", len(notif.StockAlerts), notif.LowStockCalls)
+	}
 }
 
 func TestAddStockUseCase_Execute_Success_HighUtilizationSendsAlert(t *testing.T) {
@@ -306,8 +324,9 @@ func TestAddStockUseCase_Execute_Success_HighUtilizationSendsAlert(t *testing.T)
 		ProductsRepo:  &mocks.MockProductRepo{Product: product},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, notif, nil)
+	uc := NewAddStockUseCase(uow, notif)
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 10, AddedBy: "u1"}
@@ -338,8 +357,9 @@ func TestAddStockUseCase_Execute_Success_LowStockSendsAlert(t *testing.T) {
 		ProductsRepo:  &mocks.MockProductRepo{Product: product},
 		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
 		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
 	}
-	uc := NewAddStockUseCase(uow, notif, nil)
+	uc := NewAddStockUseCase(uow, notif)
 	ctx := context.Background()
 
 	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 2, AddedBy: "u1"}
@@ -353,3 +373,470 @@ func TestAddStockUseCase_Execute_Success_LowStockSendsAlert(t *testing.T) {
 		t.Errorf("SendLowStockAlert calls = %d, want 1", notif.LowStockCalls)
 	}
 }
+
+func TestAddStockUseCase_Execute_NoNotificationService_WritesAlertsToOutbox(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(10), IsActive: true}
+	product := &domain.Product{
+		ID: "p1", Name: "Widget", CurrentStock: mustQuantity(2),
+		LastUpdated: time.Now().Add(-1 * time.Hour), TenantID: "t1", Tags: []string{"fragile"},
+	}
+	outbox := &mocks.MockOutboxRepo{}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  &mocks.MockProductRepo{Product: product},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    outbox,
+	}
+	uc := NewAddStockUseCase(uow, nil)
+
+	// 9/10 is both above 80% utilization and below the low stock threshold
+	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 7, AddedBy: "u1"}
+	if _, err := uc.Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+
+	if len(outbox.Entries) != 3 {
+		t.Fatalf("outbox entries = %d, want 3", len(outbox.Entries))
+	}
+	var decoded []domain.Event
+	for _, entry := range outbox.Entries {
+		if entry.TenantID != "t1" || entry.AggregateID != "p1" || entry.Status != domain.OutboxPending {
+			t.Errorf("entry = %+v, want pending entry for t1/p1", entry)
+		}
+		event, err := entry.Decode()
+		if err != nil {
+			t.Fatalf("Decode(%s) err = %v", entry.EventType, err)
+		}
+		decoded = append(decoded, event)
+	}
+	if _, ok := decoded[0].(domain.StockAddedEvent); !ok {
+		t.Errorf("entry[0] = %T, want StockAddedEvent", decoded[0])
+	}
+	alert, ok := decoded[1].(domain.StockLimitAlertEvent)
+	if !ok || len(alert.ProductTags) != 1 || alert.ProductTags[0] != "fragile" {
+		t.Errorf("entry[1] = %+v, want StockLimitAlertEvent with tags", decoded[1])
+	}
+	low, ok := decoded[2].(domain.LowStockEvent)
+	if !ok || low.Current.Value() != 9 || low.Threshold != 10 {
+		t.Errorf("entry[2] = %+v, want LowStockEvent", decoded[2])
+	}
+}
+
+func TestAddStockUseCase_Execute_OutboxAppendFails(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true}
+	product := &domain.Product{
+		ID: "p1", Name: "Widget", CurrentStock: mustQuantity(10),
+		LastUpdated: time.Now().Add(-1 * time.Hour), TenantID: "t1",
+	}
+	errAppend := errors.New("append outbox failed")
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  &mocks.MockProductRepo{Product: product},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{AppendErr: errAppend},
+	}
+	uc := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
+
+	req := AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1"}
+	got, err := uc.Execute(context.Background(), req)
+	if got != nil {
+		t.Fatalf("Execute() expected nil response, got %+v", got)
+	}
+	if !errors.Is(err, errAppend) {
+		t.Errorf("Execute() err = %v, want %v", err, errAppend)
+	}
+}
+
+func TestAddStockUseCase_Execute_EventSourced_RecordsOpeningBalanceAndEntry(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true,
+		StockMode: domain.StockModeEventSourced}
+	product := &domain.Product{
+		ID: "p1", Name: "Widget", CurrentStock: mustQuantity(10),
+		LastUpdated: time.Now().Add(-1 * time.Hour), TenantID: "t1",
+	}
+	ledger := &mocks.MockStockLedgerRepo{}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  &mocks.MockProductRepo{Product: product},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
+		LedgerRepo:    ledger,
+	}
+	uc := NewAddStockUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PreviousStock != 10 || got.NewStock != 15 {
+		t.Errorf("response stock: previous=%d new=%d, want 10, 15", got.PreviousStock, got.NewStock)
+	}
+	if len(ledger.Entries) != 2 {
+		t.Fatalf("ledger entries = %d, want 2", len(ledger.Entries))
+	}
+	opening, added := ledger.Entries[0], ledger.Entries[1]
+	if opening.Type != domain.LedgerOpeningBalance || opening.Delta != 10 || opening.Sequence != 1 {
+		t.Errorf("opening entry = %+v", opening)
+	}
+	if added.Type != domain.LedgerStockAdded || added.Delta != 5 || added.Sequence != 2 || added.Actor != "u1" {
+		t.Errorf("added entry = %+v", added)
+	}
+}
+
+func TestAddStockUseCase_Execute_EventSourced_LedgerIsSourceOfTruth(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true,
+		StockMode: domain.StockModeEventSourced}
+	// Stored stock has drifted from the ledger
+	product := &domain.Product{
+		ID: "p1", Name: "Widget", CurrentStock: mustQuantity(99),
+		LastUpdated: time.Now().Add(-1 * time.Hour), TenantID: "t1",
+	}
+	ledger := &mocks.MockStockLedgerRepo{
+		Entries: []domain.StockLedgerEntry{
+			{ProductID: "p1", TenantID: "t1", Sequence: 1, Type: domain.LedgerStockAdded, Delta: 20},
+			{ProductID: "p1", TenantID: "t1", Sequence: 2, Type: domain.LedgerStockAdded, Delta: 10},
+		},
+		Snapshots: []domain.StockSnapshot{{ProductID: "p1", TenantID: "t1", Stock: 20, Version: 1}},
+	}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  &mocks.MockProductRepo{Product: product},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
+		LedgerRepo:    ledger,
+	}
+	uc := NewAddStockUseCase(uow, nil)
+
+	got, err := uc.Execute(context.Background(), AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PreviousStock != 30 || got.NewStock != 35 {
+		t.Errorf("response stock: previous=%d new=%d, want 30, 35", got.PreviousStock, got.NewStock)
+	}
+	if last := ledger.Entries[len(ledger.Entries)-1]; last.Sequence != 3 || last.Delta != 5 {
+		t.Errorf("appended entry = %+v, want sequence 3 delta 5", last)
+	}
+}
+
+func TestAddStockUseCase_Execute_EventSourced_TakesSnapshotAtInterval(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(1000), IsActive: true,
+		StockMode: domain.StockModeEventSourced}
+	product := &domain.Product{ID: "p1", Name: "Widget", CurrentStock: mustQuantity(0), TenantID: "t1"}
+	ledger := &mocks.MockStockLedgerRepo{}
+	for seq := int64(1); seq < domain.DefaultSnapshotInterval; seq++ {
+		ledger.Entries = append(ledger.Entries, domain.StockLedgerEntry{
+			ProductID: "p1", TenantID: "t1", Sequence: seq, Type: domain.LedgerStockAdded, Delta: 1,
+		})
+	}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  &mocks.MockProductRepo{Product: product},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
+		LedgerRepo:    ledger,
+	}
+	uc := NewAddStockUseCase(uow, nil)
+
+	if _, err := uc.Execute(context.Background(), AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 1}); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(ledger.Snapshots) != 1 {
+		t.Fatalf("snapshots = %d, want 1", len(ledger.Snapshots))
+	}
+	if s := ledger.Snapshots[0]; s.Version != domain.DefaultSnapshotInterval || s.Stock != domain.DefaultSnapshotInterval {
+		t.Errorf("snapshot = %+v, want version and stock %d", s, domain.DefaultSnapshotInterval)
+	}
+}
+
+func TestAddStockUseCase_Execute_EventSourced_ConcurrentUpdate(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true,
+		StockMode: domain.StockModeEventSourced}
+	product := &domain.Product{ID: "p1", Name: "Widget", CurrentStock: mustQuantity(0), TenantID: "t1"}
+	hist := &mocks.MockStockHistoryRepo{}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  &mocks.MockProductRepo{Product: product},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: tenant},
+		StockHistRepo: hist,
+		OutboxRepo:    &mocks.MockOutboxRepo{},
+		LedgerRepo:    &mocks.MockStockLedgerRepo{AppendErr: domain.ErrConcurrentStockUpdate},
+	}
+	uc := NewAddStockUseCase(uow, nil)
+
+	_, err := uc.Execute(context.Background(), AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 5})
+	if !errors.Is(err, domain.ErrConcurrentStockUpdate) {
+		t.Fatalf("Execute() err = %v, want %v", err, domain.ErrConcurrentStockUpdate)
+	}
+	if len(hist.Events) != 0 {
+		t.Errorf("history written despite ledger conflict: %+v", hist.Events)
+	}
+}
+
+// case = 12 each, pallet = 40 case
+func unitsFixture() (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	uow, products := widgetFixture(0)
+	uow.TenantsRepo.Tenant.MaxStock = mustQuantity(1000)
+	products.Products[0].Units = []domain.UnitConversion{
+		{Unit: "case", Quantity: 12, Of: "each"},
+		{Unit: "pallet", Quantity: 40, Of: "case"},
+	}
+	return uow, products
+}
+
+func TestAddStockUseCase_Execute_ConvertsUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		unit     string
+		want     int
+		wantUnit string
+	}{
+		{"base unit", 5, "", 5, "each"},
+		{"case", 3, "case", 36, "case"},
+		{"pallet through case", 2, "Pallet", 960, "Pallet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := unitsFixture()
+
+			got, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+				ProductID: "p1", TenantID: "t1", Quantity: tt.quantity, Unit: tt.unit, AddedBy: "u1",
+			})
+			if err != nil {
+				t.Fatalf("Execute() err = %v", err)
+			}
+			if got.Added != tt.want || got.NewStock != tt.want || got.EnteredQuantity != domain.DecimalFromInt(tt.quantity) || got.Unit != tt.wantUnit || got.BaseUnit != "each" {
+				t.Errorf("response = %+v", got)
+			}
+			if products.Products[0].CurrentStock.Value() != tt.want {
+				t.Errorf("stock = %d, want %d", products.Products[0].CurrentStock.Value(), tt.want)
+			}
+			entry := uow.StockHistRepo.Entries[0]
+			if entry.Quantity != tt.want || entry.EnteredQuantity != domain.DecimalFromInt(tt.quantity) || entry.Unit != tt.wantUnit {
+				t.Errorf("history entry = %+v", entry)
+			}
+		})
+	}
+}
+
+func TestAddStockUseCase_Execute_UnitRejections(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  string
+		units []domain.UnitConversion
+		unit  string
+		want  error
+	}{
+		{"unknown unit", "", []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "each"}}, "box", domain.ErrUnknownUnit},
+		// Base is the case, so 5 each are 5/12 of one
+		{"fraction of the base unit", "case", []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "each"}}, "each", domain.ErrNonIntegralQuantity},
+		{"conversion leads nowhere", "", []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "box"}}, "case", domain.ErrInvalidUnitConversion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := unitsFixture()
+			products.Products[0].BaseUnit = tt.base
+			products.Products[0].Units = tt.units
+
+			_, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+				ProductID: "p1", TenantID: "t1", Quantity: 5, Unit: tt.unit, AddedBy: "u1",
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.want)
+			}
+			if len(uow.StockHistRepo.Entries) != 0 {
+				t.Errorf("history written for rejected add")
+			}
+		})
+	}
+}
+
+func TestAddStockUseCase_Execute_UnitCostPerEnteredUnit(t *testing.T) {
+	uow, _ := unitsFixture()
+	costs := &mocks.MockProductCostRepo{}
+	uow.CostsRepo = costs
+
+	_, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 2, Unit: "case", AddedBy: "u1", UnitCost: mustMoney("10.00"),
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if cost := costs.Cost("p1"); cost.Quantity() != 24 || cost.Value().String() != "20.00" {
+		t.Errorf("layers = %d units worth %s, want 24 worth 20.00", cost.Quantity(), cost.Value())
+	}
+	entry := uow.StockHistRepo.Entries[0]
+	if entry.UnitCost.String() != "10.00" || entry.Cost.String() != "20.00" {
+		t.Errorf("history cost = %s each, %s total", entry.UnitCost, entry.Cost)
+	}
+}
+
+func mustDecimal(s string) *domain.Decimal {
+	d, err := domain.ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+// Sold by weight: stock in kg to 3 places, entered in kg or g
+func decimalFixture(stock string) (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	uow, products := widgetFixture(0)
+	product := products.Products[0]
+	product.BaseUnit = "kg"
+	product.Units = []domain.UnitConversion{{Unit: "kg", Quantity: 1000, Of: "g"}}
+	product.CurrentStock, _ = domain.NewDecimalStockQuantity(*mustDecimal(stock))
+	if err := product.SetQuantityMode(domain.QuantityModeDecimal, 3); err != nil {
+		panic(err)
+	}
+	return uow, products
+}
+
+func TestAddStockUseCase_Execute_DecimalQuantities(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity string
+		unit     string
+		want     string
+	}{
+		{"kilograms", "2.5", "", "12.750"},
+		{"grams", "125", "g", "10.375"},
+		{"whole kilograms", "3", "", "13.250"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := decimalFixture("10.25")
+
+			got, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+				ProductID: "p1", TenantID: "t1", DecimalQuantity: mustDecimal(tt.quantity), Unit: tt.unit, AddedBy: "u1",
+			})
+			if err != nil {
+				t.Fatalf("Execute() err = %v", err)
+			}
+			if got.Decimal == nil || got.Decimal.NewStock.String() != tt.want || got.Decimal.PreviousStock.String() != "10.250" {
+				t.Fatalf("response = %+v, decimal = %+v", got, got.Decimal)
+			}
+			if stock := products.Products[0].CurrentStock.Decimal().String(); stock != tt.want {
+				t.Errorf("stock = %s, want %s", stock, tt.want)
+			}
+			entry := uow.StockHistRepo.Entries[0]
+			if entry.Decimal == nil || entry.Decimal.NewStock.String() != tt.want || entry.EnteredQuantity.String() != tt.quantity {
+				t.Errorf("history entry = %+v", entry)
+			}
+		})
+	}
+}
+
+func TestAddStockUseCase_Execute_DecimalTotalAddedIsExact(t *testing.T) {
+	uow, products := decimalFixture("0")
+	uc := NewAddStockUseCase(uow, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := uc.Execute(context.Background(), AddStockRequest{
+			ProductID: "p1", TenantID: "t1", DecimalQuantity: mustDecimal("0.6"), AddedBy: "u1",
+		}); err != nil {
+			t.Fatalf("Execute() err = %v", err)
+		}
+	}
+	if total := products.Products[0].TotalAdded.String(); total != "1.200" {
+		t.Errorf("total added = %s, want 1.200", total)
+	}
+}
+
+func TestAddStockUseCase_Execute_DecimalRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		decimal  bool
+		quantity string
+		want     error
+	}{
+		{"fraction of an integer-mode product", false, "2.5", domain.ErrNonIntegralQuantity},
+		{"finer than the product keeps", true, "0.0005", domain.ErrQuantityTooPrecise},
+		{"over the tenant limit", true, "100.001", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := decimalFixture("0")
+			if !tt.decimal {
+				products.Products[0].QuantityMode = domain.QuantityModeInteger
+			}
+
+			_, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+				ProductID: "p1", TenantID: "t1", DecimalQuantity: mustDecimal(tt.quantity), AddedBy: "u1",
+			})
+			if tt.want == nil {
+				var limitErr domain.ErrStockExceedsLimit
+				if !errors.As(err, &limitErr) {
+					t.Fatalf("Execute() err = %v, want limit exceeded", err)
+				}
+			} else if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.want)
+			}
+			if len(uow.StockHistRepo.Entries) != 0 {
+				t.Errorf("history written for rejected add")
+			}
+		})
+	}
+}
+
+func TestAddStockUseCase_Execute_DecimalOutOfRange(t *testing.T) {
+	// 9e15 kg at three places is near the largest stock a product can hold
+	uow, products := decimalFixture("9000000000000000")
+
+	_, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", DecimalQuantity: mustDecimal("1000000000000000"), AddedBy: "u1",
+	})
+	if !errors.Is(err, domain.ErrQuantityOutOfRange) {
+		t.Fatalf("Execute() err = %v, want %v", err, domain.ErrQuantityOutOfRange)
+	}
+	if stock := products.Products[0].CurrentStock.Decimal().String(); stock != "9000000000000000.000" {
+		t.Errorf("stock = %s, want it unchanged", stock)
+	}
+}
+
+func TestAddStockUseCase_Execute_BySKUOrBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     AddStockRequest
+		wantErr error
+	}{
+		{"sku", AddStockRequest{SKU: "wid-001"}, nil},
+		{"barcode", AddStockRequest{Barcode: "4006381333931"}, nil},
+		{"sku of another tenant", AddStockRequest{SKU: "GAD-001"}, domain.ErrProductNotFound},
+		{"id and sku", AddStockRequest{ProductID: "p1", SKU: "WID-001"}, domain.ErrInvalidProductIdentifier},
+		{"bad check digit", AddStockRequest{Barcode: "4006381333930"}, domain.ErrInvalidBarcode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := widgetFixture(10)
+			products.Products[0].SKU, products.Products[0].Barcode = "WID-001", "4006381333931"
+			products.Products = append(products.Products, &domain.Product{ID: "p2", TenantID: "t2", SKU: "GAD-001"})
+			tt.req.TenantID, tt.req.Quantity, tt.req.AddedBy = "t1", 5, "u1"
+
+			got, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.ProductID != "p1" || got.NewStock != 15) {
+				t.Errorf("response = %+v", got)
+			}
+		})
+	}
+}
+
+func TestAddStockUseCase_Execute_OtherTenantsProduct(t *testing.T) {
+	uow, products := widgetFixture(10)
+	products.Products[0].TenantID = "t2"
+
+	_, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 5, AddedBy: "u1",
+	})
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("Execute() err = %v, want %v", err, domain.ErrProductNotFound)
+	}
+	if products.Products[0].CurrentStock.Value() != 10 {
+		t.Errorf("stock = %d, want 10", products.Products[0].CurrentStock.Value())
+	}
+}
@@ -0,0 +1,110 @@
+// internal/application/usecases/adjust_stock_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Input DTO
+type AdjustStockRequest struct {
+	ProductID string
+	TenantID  string
+	// Signed correction: positive adds stock, negative removes it
+	Delta      int
+	ReasonCode string
+	Notes      string
+	AdjustedBy string
+}
+
+// Output DTO
+type AdjustStockResponse struct {
+	HistoryID     string
+	ProductID     string
+	ProductName   string
+	PreviousStock int
+	NewStock      int
+	Delta         int
+	ReasonCode    string
+}
+
+// Use Case interface (what handlers depend on)
+type AdjustStockUseCase interface {
+	Execute(ctx context.Context, req AdjustStockRequest) (*AdjustStockResponse, error)
+}
+
+// Implementation
+type adjustStockUseCase struct {
+	uow      interfaces.UnitOfWork
+	adjuster stockAdjuster
+}
+
+func NewAdjustStockUseCase(uow interfaces.UnitOfWork) AdjustStockUseCase {
+	return &adjustStockUseCase{
+		uow:      uow,
+		adjuster: newStockAdjuster(uow),
+	}
+}
+
+func (uc *adjustStockUseCase) Execute(ctx context.Context, req AdjustStockRequest) (*AdjustStockResponse, error) {
+	// 1. Validate input
+	if req.ProductID == "" {
+		return nil, domain.ErrInvalidProductID
+	}
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if req.Delta == 0 {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	// 2. Get and validate tenant
+	tenant, err := uc.uow.Tenants().FindByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tenant.CanReceiveStock(); err != nil {
+		return nil, err
+	}
+
+	// 3. The reason must be in the tenant's catalog and fit the direction
+	catalog, err := loadReasonCatalog(ctx, uc.uow, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := catalog.Check(req.ReasonCode, req.Delta); err != nil {
+		return nil, err
+	}
+
+	// 4. Get product
+	product, err := uc.uow.Products().FindByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != req.TenantID {
+		return nil, domain.ErrProductNotFound
+	}
+
+	// 5. Apply the correction
+	entry, err := uc.adjuster.apply(ctx, tenant, product, stockAdjustment{
+		Delta:      req.Delta,
+		Operation:  domain.HistoryOperationStockAdjustment,
+		ReasonCode: req.ReasonCode,
+		Actor:      req.AdjustedBy,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdjustStockResponse{
+		HistoryID:     entry.ID,
+		ProductID:     product.ID,
+		ProductName:   product.Name,
+		PreviousStock: entry.PreviousStock,
+		NewStock:      entry.NewStock,
+		Delta:         entry.Quantity,
+		ReasonCode:    entry.ReasonCode,
+	}, nil
+}
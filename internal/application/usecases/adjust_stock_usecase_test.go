@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func TestAdjustStockUseCase_Execute_Decrease(t *testing.T) {
+	uow, products := widgetFixture(10)
+	product := products.Products[0]
+	uc := NewAdjustStockUseCase(uow)
+
+	got, err := uc.Execute(context.Background(), AdjustStockRequest{
+		ProductID: "p1", TenantID: "t1", Delta: -3, ReasonCode: domain.ReasonDamage, Notes: "crushed", AdjustedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PreviousStock != 10 || got.NewStock != 7 || got.Delta != -3 || got.HistoryID == "" {
+		t.Errorf("response = %+v", got)
+	}
+	if product.CurrentStock.Value() != 7 {
+		t.Errorf("stock = %d, want 7", product.CurrentStock.Value())
+	}
+
+	entries := uow.StockHistRepo.Entries
+	if len(entries) != 1 {
+		t.Fatalf("history entries = %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Operation != domain.HistoryOperationStockAdjustment || e.ReasonCode != domain.ReasonDamage || e.Quantity != -3 || e.Actor != "u1" || e.Notes != "crushed" {
+		t.Errorf("history entry = %+v", e)
+	}
+	if len(uow.OutboxRepo.Entries) != 1 || uow.OutboxRepo.Entries[0].EventType != domain.EventTypeStockAdjusted {
+		t.Errorf("outbox = %+v, want one stock.adjusted entry", uow.OutboxRepo.Entries)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_IncreaseIgnoresMaxStock(t *testing.T) {
+	uow, _ := widgetFixture(99)
+	uc := NewAdjustStockUseCase(uow)
+
+	got, err := uc.Execute(context.Background(), AdjustStockRequest{
+		ProductID: "p1", TenantID: "t1", Delta: 4, ReasonCode: domain.ReasonFound,
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.NewStock != 103 {
+		t.Errorf("NewStock = %d, want 103", got.NewStock)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     AdjustStockRequest
+		reasons []*domain.AdjustmentReason
+		lock    string
+		decimal bool
+		want    error
+	}{
+		{name: "zero delta", req: AdjustStockRequest{Delta: 0, ReasonCode: domain.ReasonDamage}, want: domain.ErrInvalidQuantity},
+		{name: "missing reason", req: AdjustStockRequest{Delta: -1}, want: domain.ErrReasonCodeRequired},
+		{name: "unknown reason", req: AdjustStockRequest{Delta: -1, ReasonCode: "flood"}, want: domain.ErrInvalidReasonCode},
+		{name: "wrong direction", req: AdjustStockRequest{Delta: 2, ReasonCode: domain.ReasonTheft}, want: domain.ErrReasonDirectionMismatch},
+		{name: "insufficient stock", req: AdjustStockRequest{Delta: -6, ReasonCode: domain.ReasonDamage}, want: domain.ErrInsufficientStock},
+		{name: "locked for count", req: AdjustStockRequest{Delta: -1, ReasonCode: domain.ReasonDamage}, lock: "c1", want: domain.ErrProductLockedForCount},
+		{name: "decimal-mode product", req: AdjustStockRequest{Delta: -1, ReasonCode: domain.ReasonDamage}, decimal: true, want: domain.ErrDecimalUnsupported},
+		{
+			name:    "deactivated reason",
+			req:     AdjustStockRequest{Delta: -1, ReasonCode: domain.ReasonTheft},
+			reasons: []*domain.AdjustmentReason{{TenantID: "t1", Code: domain.ReasonTheft, Direction: domain.ReasonDirectionDecrease}},
+			want:    domain.ErrInvalidReasonCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := widgetFixture(5)
+			product := products.Products[0]
+			product.CountSessionID = tt.lock
+			if tt.decimal {
+				product.QuantityMode = domain.QuantityModeDecimal
+			}
+			uow.ReasonsRepo.Reasons = tt.reasons
+			req := tt.req
+			req.ProductID, req.TenantID = "p1", "t1"
+
+			_, err := NewAdjustStockUseCase(uow).Execute(context.Background(), req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.want)
+			}
+			if len(uow.StockHistRepo.Entries) != 0 || product.CurrentStock.Value() != 5 {
+				t.Errorf("rejected adjustment changed state: history=%d stock=%d", len(uow.StockHistRepo.Entries), product.CurrentStock.Value())
+			}
+		})
+	}
+}
+
+func TestAdjustStockUseCase_Execute_CustomReason(t *testing.T) {
+	uow, _ := widgetFixture(5)
+	uow.ReasonsRepo.Reasons = []*domain.AdjustmentReason{
+		{TenantID: "t1", Code: "expired", Label: "Expired", Direction: domain.ReasonDirectionDecrease, IsActive: true},
+	}
+
+	_, err := NewAdjustStockUseCase(uow).Execute(context.Background(), AdjustStockRequest{
+		ProductID: "p1", TenantID: "t1", Delta: -2, ReasonCode: "expired",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got := uow.StockHistRepo.Entries[0].ReasonCode; got != "expired" {
+		t.Errorf("history reason = %q, want expired", got)
+	}
+}
+
+func TestAdjustStockUseCase_Execute_EventSourced_RecordsLedgerAdjustment(t *testing.T) {
+	uow, _ := widgetFixture(10)
+	uow.TenantsRepo.Tenant.StockMode = domain.StockModeEventSourced
+	ledger := &mocks.MockStockLedgerRepo{}
+	uow.LedgerRepo = ledger
+
+	_, err := NewAdjustStockUseCase(uow).Execute(context.Background(), AdjustStockRequest{
+		ProductID: "p1", TenantID: "t1", Delta: -4, ReasonCode: domain.ReasonTheft, AdjustedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(ledger.Entries) != 2 {
+		t.Fatalf("ledger entries = %d, want 2", len(ledger.Entries))
+	}
+	if adj := ledger.Entries[1]; adj.Type != domain.LedgerStockAdjusted || adj.Delta != -4 {
+		t.Errorf("adjustment entry = %+v", adj)
+	}
+}
@@ -0,0 +1,97 @@
+// internal/application/usecases/adjustment_report_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type AdjustmentReportRequest struct {
+	TenantID string
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+}
+
+// Output DTOs
+type AdjustmentReportRow struct {
+	ReasonCode  string
+	Label       string
+	Adjustments int
+	Increase    int
+	Decrease    int
+	Net         int
+}
+
+type AdjustmentReportResponse struct {
+	TenantID string
+	From     time.Time
+	To       time.Time
+	Reasons  []AdjustmentReportRow
+	Totals   AdjustmentReportRow
+}
+
+// Use Case interface (what handlers depend on)
+type AdjustmentReportUseCase interface {
+	Execute(ctx context.Context, req AdjustmentReportRequest) (*AdjustmentReportResponse, error)
+}
+
+// Implementation
+type adjustmentReportUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewAdjustmentReportUseCase(uow interfaces.UnitOfWork) AdjustmentReportUseCase {
+	return &adjustmentReportUseCase{uow: uow}
+}
+
+func (uc *adjustmentReportUseCase) Execute(ctx context.Context, req AdjustmentReportRequest) (*AdjustmentReportResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if !req.From.Before(req.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	totals, err := uc.uow.StockHistory().SummarizeAdjustments(ctx, req.TenantID, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := loadReasonCatalog(ctx, uc.uow, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AdjustmentReportResponse{
+		TenantID: req.TenantID,
+		From:     req.From,
+		To:       req.To,
+		Reasons:  make([]AdjustmentReportRow, 0, len(totals)),
+		Totals:   AdjustmentReportRow{ReasonCode: "total", Label: "Total"},
+	}
+	for _, t := range totals {
+		// Reasons removed from the catalog since are still reported by code
+		label := t.ReasonCode
+		if reason, ok := catalog.Lookup(t.ReasonCode); ok {
+			label = reason.Label
+		}
+		report.Reasons = append(report.Reasons, AdjustmentReportRow{
+			ReasonCode:  t.ReasonCode,
+			Label:       label,
+			Adjustments: t.Adjustments,
+			Increase:    t.Increase,
+			Decrease:    t.Decrease,
+			Net:         t.Net(),
+		})
+		report.Totals.Adjustments += t.Adjustments
+		report.Totals.Increase += t.Increase
+		report.Totals.Decrease += t.Decrease
+		report.Totals.Net += t.Net()
+	}
+	return report, nil
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+	"time"
+)
+
+func TestAdjustmentReportUseCase_Execute_GroupsByReason(t *testing.T) {
+	now := time.Now()
+	hist := &mocks.MockStockHistoryRepo{Entries: []domain.StockHistoryEntry{
+		{TenantID: "t1", Operation: domain.HistoryOperationStockAdjustment, ReasonCode: domain.ReasonDamage, Quantity: -3, CreatedAt: now.Add(-2 * time.Hour)},
+		{TenantID: "t1", Operation: domain.HistoryOperationStockAdjustment, ReasonCode: domain.ReasonDamage, Quantity: -1, CreatedAt: now.Add(-1 * time.Hour)},
+		{TenantID: "t1", Operation: domain.HistoryOperationCountAdjustment, ReasonCode: domain.ReasonRecount, Quantity: 5, CreatedAt: now.Add(-1 * time.Hour)},
+		{TenantID: "t1", Operation: domain.HistoryOperationCountAdjustment, ReasonCode: domain.ReasonRecount, Quantity: -2, CreatedAt: now.Add(-1 * time.Hour)},
+		// Outside the range, another tenant, and a plain stock add
+		{TenantID: "t1", Operation: domain.HistoryOperationStockAdjustment, ReasonCode: domain.ReasonDamage, Quantity: -9, CreatedAt: now.Add(-72 * time.Hour)},
+		{TenantID: "t2", Operation: domain.HistoryOperationStockAdjustment, ReasonCode: domain.ReasonDamage, Quantity: -9, CreatedAt: now.Add(-1 * time.Hour)},
+		{TenantID: "t1", Operation: domain.HistoryOperationStockAdd, Quantity: 20, CreatedAt: now.Add(-1 * time.Hour)},
+	}}
+	uow := &mocks.MockUnitOfWork{
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true}},
+		StockHistRepo: hist,
+		ReasonsRepo:   &mocks.MockAdjustmentReasonRepo{},
+	}
+
+	got, err := NewAdjustmentReportUseCase(uow).Execute(context.Background(), AdjustmentReportRequest{
+		TenantID: "t1", From: now.Add(-24 * time.Hour), To: now,
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(got.Reasons) != 2 {
+		t.Fatalf("reasons = %+v, want damage and recount", got.Reasons)
+	}
+	damage, recount := got.Reasons[0], got.Reasons[1]
+	if damage.ReasonCode != domain.ReasonDamage || damage.Label != "Damaged" || damage.Adjustments != 2 || damage.Decrease != 4 || damage.Net != -4 {
+		t.Errorf("damage row = %+v", damage)
+	}
+	if recount.Adjustments != 2 || recount.Increase != 5 || recount.Decrease != 2 || recount.Net != 3 {
+		t.Errorf("recount row = %+v", recount)
+	}
+	if got.Totals.Adjustments != 4 || got.Totals.Increase != 5 || got.Totals.Decrease != 6 || got.Totals.Net != -1 {
+		t.Errorf("totals = %+v", got.Totals)
+	}
+}
+
+func TestAdjustmentReportUseCase_Execute_InvalidRange(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true}},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		ReasonsRepo:   &mocks.MockAdjustmentReasonRepo{},
+	}
+	now := time.Now()
+	_, err := NewAdjustmentReportUseCase(uow).Execute(context.Background(), AdjustmentReportRequest{
+		TenantID: "t1", From: now, To: now.Add(-time.Hour),
+	})
+	if !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Errorf("Execute() err = %v, want %v", err, domain.ErrInvalidTimeRange)
+	}
+}
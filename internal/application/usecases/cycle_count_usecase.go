@@ -0,0 +1,356 @@
+// internal/application/usecases/cycle_count_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type OpenCountSessionRequest struct {
+	TenantID string
+	Name     string
+	// Products to count; when empty, every product at Location is counted
+	ProductIDs []string
+	Location   string
+	// Block stock changes to the counted products until the session closes
+	Lock     bool
+	OpenedBy string
+}
+
+type SubmittedCount struct {
+	ProductID string
+	Quantity  int
+}
+
+type SubmitCountsRequest struct {
+	TenantID  string
+	SessionID string
+	CountedBy string
+	Counts    []SubmittedCount
+}
+
+type ApproveCountSessionRequest struct {
+	TenantID   string
+	SessionID  string
+	ApprovedBy string
+	// Reason code per product ID from the tenant's catalog;
+	// domain.AdjustmentReasonRecount by default
+	ReasonCodes map[string]string
+}
+
+// Output DTOs
+type CountLineResponse struct {
+	ProductID     string
+	ProductName   string
+	ExpectedStock int
+	Counted       *int
+	Variance      int
+	Disputed      bool
+	Counts        []domain.CountEntry
+	ReasonCode    string
+	Adjustment    int
+}
+
+type CountSessionResponse struct {
+	ID       string
+	TenantID string
+	Name     string
+	Location string
+	Lock     bool
+	Status   string
+	Lines    []CountLineResponse
+	OpenedBy string
+	OpenedAt time.Time
+	ClosedBy string
+	ClosedAt time.Time
+}
+
+// Use Case interface (what handlers depend on)
+type CycleCountUseCase interface {
+	Open(ctx context.Context, req OpenCountSessionRequest) (*CountSessionResponse, error)
+	Get(ctx context.Context, tenantID, sessionID string) (*CountSessionResponse, error)
+	List(ctx context.Context, tenantID, status string) ([]CountSessionResponse, error)
+	SubmitCounts(ctx context.Context, req SubmitCountsRequest) (*CountSessionResponse, error)
+	// Approve adjusts each product by its variance and closes the session
+	Approve(ctx context.Context, req ApproveCountSessionRequest) (*CountSessionResponse, error)
+	Cancel(ctx context.Context, tenantID, sessionID, cancelledBy string) (*CountSessionResponse, error)
+}
+
+// Implementation
+type cycleCountUseCase struct {
+	uow      interfaces.UnitOfWork
+	adjuster stockAdjuster
+}
+
+func NewCycleCountUseCase(uow interfaces.UnitOfWork) CycleCountUseCase {
+	return &cycleCountUseCase{
+		uow:      uow,
+		adjuster: newStockAdjuster(uow),
+	}
+}
+
+func (uc *cycleCountUseCase) Open(ctx context.Context, req OpenCountSessionRequest) (*CountSessionResponse, error) {
+	if _, err := uc.findTenant(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	products, err := uc.sessionProducts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Lock {
+		for _, p := range products {
+			if p.IsLockedForCount() {
+				return nil, domain.ErrProductLockedForCount
+			}
+		}
+	}
+
+	session, err := domain.NewCountSession(req.TenantID, req.Name, req.Location, req.Lock, products, req.OpenedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.uow.CountSessions().Create(ctx, session); err != nil {
+			return err
+		}
+		if !session.Lock {
+			return nil
+		}
+		for _, p := range products {
+			if err := uc.uow.Products().SetCountLock(ctx, p.ID, session.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCountSessionResponse(session), nil
+}
+
+func (uc *cycleCountUseCase) sessionProducts(ctx context.Context, req OpenCountSessionRequest) ([]*domain.Product, error) {
+	var products []*domain.Product
+	if len(req.ProductIDs) > 0 {
+		seen := map[string]bool{}
+		for _, id := range req.ProductIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			product, err := uc.uow.Products().FindByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if product.TenantID != req.TenantID {
+				return nil, domain.ErrProductNotFound
+			}
+			products = append(products, product)
+		}
+		return products, nil
+	}
+
+	if req.Location == "" {
+		return nil, domain.ErrEmptyCountSession
+	}
+	all, err := uc.uow.Products().FindByTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range all {
+		if p.Location == req.Location {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (uc *cycleCountUseCase) Get(ctx context.Context, tenantID, sessionID string) (*CountSessionResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	session, err := uc.uow.CountSessions().FindByID(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return toCountSessionResponse(session), nil
+}
+
+func (uc *cycleCountUseCase) List(ctx context.Context, tenantID, status string) ([]CountSessionResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	sessions, err := uc.uow.CountSessions().FindByTenant(ctx, tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CountSessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, *toCountSessionResponse(s))
+	}
+	return result, nil
+}
+
+func (uc *cycleCountUseCase) SubmitCounts(ctx context.Context, req SubmitCountsRequest) (*CountSessionResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	session, err := uc.uow.CountSessions().FindByID(ctx, req.TenantID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the whole submission before storing any of it
+	entries := make([]domain.CountEntry, 0, len(req.Counts))
+	for _, c := range req.Counts {
+		entry, err := session.NewCountEntry(c.ProductID, req.CountedBy, c.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		for i, c := range req.Counts {
+			if err := uc.uow.CountSessions().AddCount(ctx, req.TenantID, req.SessionID, c.ProductID, entries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.Get(ctx, req.TenantID, req.SessionID)
+}
+
+func (uc *cycleCountUseCase) Approve(ctx context.Context, req ApproveCountSessionRequest) (*CountSessionResponse, error) {
+	tenant, err := uc.findTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := uc.uow.CountSessions().FindByID(ctx, req.TenantID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Approve(req.ApprovedBy, req.ReasonCodes); err != nil {
+		return nil, err
+	}
+	catalog, err := loadReasonCatalog(ctx, uc.uow, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range session.Lines {
+		if line.Adjustment == 0 {
+			continue
+		}
+		if _, err := catalog.Check(line.ReasonCode, line.Adjustment); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, line := range session.Lines {
+			product, err := uc.uow.Products().FindByID(ctx, line.ProductID)
+			if err != nil {
+				return err
+			}
+			if line.Adjustment != 0 {
+				_, err = uc.adjuster.apply(ctx, tenant, product, stockAdjustment{
+					Delta:      line.Adjustment,
+					Operation:  domain.HistoryOperationCountAdjustment,
+					ReasonCode: line.ReasonCode,
+					Actor:      req.ApprovedBy,
+					Notes:      "count session " + session.Name,
+					Reference:  session.ID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if session.Lock {
+				if err := uc.uow.Products().SetCountLock(ctx, line.ProductID, ""); err != nil {
+					return err
+				}
+			}
+		}
+		return uc.uow.CountSessions().Close(ctx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCountSessionResponse(session), nil
+}
+
+func (uc *cycleCountUseCase) Cancel(ctx context.Context, tenantID, sessionID, cancelledBy string) (*CountSessionResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	session, err := uc.uow.CountSessions().FindByID(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Cancel(cancelledBy); err != nil {
+		return nil, err
+	}
+
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if session.Lock {
+			for _, line := range session.Lines {
+				if err := uc.uow.Products().SetCountLock(ctx, line.ProductID, ""); err != nil {
+					return err
+				}
+			}
+		}
+		return uc.uow.CountSessions().Close(ctx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCountSessionResponse(session), nil
+}
+
+func (uc *cycleCountUseCase) findTenant(ctx context.Context, tenantID string) (*domain.Tenant, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	return uc.uow.Tenants().FindByID(ctx, tenantID)
+}
+
+func toCountSessionResponse(s *domain.CountSession) *CountSessionResponse {
+	lines := make([]CountLineResponse, 0, len(s.Lines))
+	for _, l := range s.Lines {
+		line := CountLineResponse{
+			ProductID:     l.ProductID,
+			ProductName:   l.ProductName,
+			ExpectedStock: l.ExpectedStock,
+			Variance:      l.Variance(),
+			Disputed:      l.IsDisputed(),
+			Counts:        l.Counts,
+			ReasonCode:    l.ReasonCode,
+			Adjustment:    l.Adjustment,
+		}
+		if counted, ok := l.Counted(); ok {
+			line.Counted = &counted
+		}
+		lines = append(lines, line)
+	}
+	return &CountSessionResponse{
+		ID:       s.ID,
+		TenantID: s.TenantID,
+		Name:     s.Name,
+		Location: s.Location,
+		Lock:     s.Lock,
+		Status:   s.Status,
+		Lines:    lines,
+		OpenedBy: s.OpenedBy,
+		OpenedAt: s.OpenedAt,
+		ClosedBy: s.ClosedBy,
+		ClosedAt: s.ClosedAt,
+	}
+}
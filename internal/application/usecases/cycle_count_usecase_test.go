@@ -0,0 +1,213 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func cycleCountFixture() (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	uow, products := stockFixture(
+		testProduct("p1", "Widget", 10),
+		testProduct("p2", "Gadget", 5),
+		testProduct("p3", "Gizmo", 7),
+	)
+	for i, location := range []string{"A1", "A1", "B2"} {
+		products.Products[i].Location = location
+	}
+	return uow, products
+}
+
+func submit(t *testing.T, uc CycleCountUseCase, sessionID, counter string, counts ...SubmittedCount) {
+	t.Helper()
+	_, err := uc.SubmitCounts(context.Background(), SubmitCountsRequest{
+		TenantID: "t1", SessionID: sessionID, CountedBy: counter, Counts: counts,
+	})
+	if err != nil {
+		t.Fatalf("SubmitCounts(%s) err = %v", counter, err)
+	}
+}
+
+func TestCycleCountUseCase_Open_ByLocation(t *testing.T) {
+	uow, _ := cycleCountFixture()
+	uc := NewCycleCountUseCase(uow)
+
+	got, err := uc.Open(context.Background(), OpenCountSessionRequest{TenantID: "t1", Location: "A1", OpenedBy: "u1"})
+	if err != nil {
+		t.Fatalf("Open() err = %v", err)
+	}
+	if got.Status != domain.CountStatusOpen || len(got.Lines) != 2 {
+		t.Fatalf("session = %+v, want open with 2 lines", got)
+	}
+	if got.Lines[0].ExpectedStock != 10 || got.Lines[1].ExpectedStock != 5 {
+		t.Errorf("expected stock = %d, %d", got.Lines[0].ExpectedStock, got.Lines[1].ExpectedStock)
+	}
+
+	if _, err := uc.Open(context.Background(), OpenCountSessionRequest{TenantID: "t1", Location: "Z9"}); !errors.Is(err, domain.ErrEmptyCountSession) {
+		t.Errorf("Open(empty location) err = %v, want %v", err, domain.ErrEmptyCountSession)
+	}
+}
+
+func TestCycleCountUseCase_Open_RejectsDecimalProducts(t *testing.T) {
+	uow, products := cycleCountFixture()
+	products.Products[1].QuantityMode = domain.QuantityModeDecimal
+
+	_, err := NewCycleCountUseCase(uow).Open(context.Background(), OpenCountSessionRequest{TenantID: "t1", Location: "A1", Lock: true, OpenedBy: "u1"})
+	if !errors.Is(err, domain.ErrDecimalUnsupported) {
+		t.Fatalf("Open() err = %v, want %v", err, domain.ErrDecimalUnsupported)
+	}
+	if len(uow.CountsRepo.Sessions) != 0 || products.Products[0].IsLockedForCount() {
+		t.Errorf("rejected open created a session or locked products")
+	}
+}
+
+func TestCycleCountUseCase_ApproveAppliesVariances(t *testing.T) {
+	uow, products := cycleCountFixture()
+	hist := uow.StockHistRepo
+	uc := NewCycleCountUseCase(uow)
+	ctx := context.Background()
+
+	session, err := uc.Open(ctx, OpenCountSessionRequest{TenantID: "t1", ProductIDs: []string{"p1", "p2"}})
+	if err != nil {
+		t.Fatalf("Open() err = %v", err)
+	}
+	submit(t, uc, session.ID, "alice", SubmittedCount{"p1", 8}, SubmittedCount{"p2", 5})
+	submit(t, uc, session.ID, "bob", SubmittedCount{"p1", 8})
+
+	// Stock received while the unlocked count was running is kept
+	products.Products[0].CurrentStock = mustQuantity(13)
+
+	got, err := uc.Approve(ctx, ApproveCountSessionRequest{
+		TenantID: "t1", SessionID: session.ID, ApprovedBy: "manager",
+		ReasonCodes: map[string]string{"p1": "damage"},
+	})
+	if err != nil {
+		t.Fatalf("Approve() err = %v", err)
+	}
+	if got.Status != domain.CountStatusApproved || got.ClosedBy != "manager" {
+		t.Errorf("session status=%s closedBy=%s", got.Status, got.ClosedBy)
+	}
+	if products.Products[0].CurrentStock.Value() != 11 || products.Products[1].CurrentStock.Value() != 5 {
+		t.Errorf("stock p1=%d p2=%d, want 11, 5",
+			products.Products[0].CurrentStock.Value(), products.Products[1].CurrentStock.Value())
+	}
+	if len(hist.Entries) != 1 {
+		t.Fatalf("history entries = %+v, want one adjustment", hist.Entries)
+	}
+	entry := hist.Entries[0]
+	if entry.Operation != domain.HistoryOperationCountAdjustment || entry.Quantity != -2 ||
+		entry.ReasonCode != "damage" || entry.Reference != session.ID || entry.Actor != "manager" {
+		t.Errorf("adjustment entry = %+v", entry)
+	}
+	if outbox := uow.OutboxRepo.Entries; len(outbox) != 1 || outbox[0].EventType != domain.EventTypeStockAdjusted {
+		t.Errorf("outbox = %+v, want one %s", outbox, domain.EventTypeStockAdjusted)
+	}
+
+	if _, err := uc.Approve(ctx, ApproveCountSessionRequest{TenantID: "t1", SessionID: session.ID}); !errors.Is(err, domain.ErrCountSessionClosed) {
+		t.Errorf("second Approve() err = %v, want %v", err, domain.ErrCountSessionClosed)
+	}
+}
+
+func TestCycleCountUseCase_ApproveRequiresAgreedCompleteCounts(t *testing.T) {
+	uow, _ := cycleCountFixture()
+	uc := NewCycleCountUseCase(uow)
+	ctx := context.Background()
+
+	session, _ := uc.Open(ctx, OpenCountSessionRequest{TenantID: "t1", ProductIDs: []string{"p1", "p2"}})
+	approve := func() error {
+		_, err := uc.Approve(ctx, ApproveCountSessionRequest{TenantID: "t1", SessionID: session.ID})
+		return err
+	}
+
+	submit(t, uc, session.ID, "alice", SubmittedCount{"p1", 9})
+	if err := approve(); !errors.Is(err, domain.ErrCountIncomplete) {
+		t.Errorf("Approve(incomplete) err = %v, want %v", err, domain.ErrCountIncomplete)
+	}
+
+	submit(t, uc, session.ID, "bob", SubmittedCount{"p1", 10}, SubmittedCount{"p2", 5})
+	if err := approve(); !errors.Is(err, domain.ErrCountDisputed) {
+		t.Errorf("Approve(disputed) err = %v, want %v", err, domain.ErrCountDisputed)
+	}
+
+	// Alice recounts and now agrees with Bob
+	submit(t, uc, session.ID, "alice", SubmittedCount{"p1", 10})
+	if err := approve(); err != nil {
+		t.Errorf("Approve() err = %v", err)
+	}
+}
+
+func TestCycleCountUseCase_LockBlocksStockChanges(t *testing.T) {
+	uow, products := cycleCountFixture()
+	uc := NewCycleCountUseCase(uow)
+	ctx := context.Background()
+
+	session, err := uc.Open(ctx, OpenCountSessionRequest{TenantID: "t1", ProductIDs: []string{"p1"}, Lock: true})
+	if err != nil {
+		t.Fatalf("Open() err = %v", err)
+	}
+	if products.Products[0].CountSessionID != session.ID {
+		t.Fatalf("p1 lock = %q, want %q", products.Products[0].CountSessionID, session.ID)
+	}
+
+	addStock := NewAddStockUseCase(uow, nil)
+	if _, err := addStock.Execute(ctx, AddStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 1}); !errors.Is(err, domain.ErrProductLockedForCount) {
+		t.Errorf("AddStock on locked product err = %v, want %v", err, domain.ErrProductLockedForCount)
+	}
+	if _, err := uc.Open(ctx, OpenCountSessionRequest{TenantID: "t1", ProductIDs: []string{"p1"}, Lock: true}); !errors.Is(err, domain.ErrProductLockedForCount) {
+		t.Errorf("second locking Open() err = %v, want %v", err, domain.ErrProductLockedForCount)
+	}
+
+	submit(t, uc, session.ID, "alice", SubmittedCount{"p1", 12})
+	if _, err := uc.Approve(ctx, ApproveCountSessionRequest{TenantID: "t1", SessionID: session.ID}); err != nil {
+		t.Fatalf("Approve() err = %v", err)
+	}
+	if products.Products[0].CountSessionID != "" || products.Products[0].CurrentStock.Value() != 12 {
+		t.Errorf("after approval: lock=%q stock=%d", products.Products[0].CountSessionID, products.Products[0].CurrentStock.Value())
+	}
+}
+
+func TestCycleCountUseCase_CancelReleasesLock(t *testing.T) {
+	uow, products := cycleCountFixture()
+	uc := NewCycleCountUseCase(uow)
+	ctx := context.Background()
+
+	session, _ := uc.Open(ctx, OpenCountSessionRequest{TenantID: "t1", ProductIDs: []string{"p3"}, Lock: true})
+	got, err := uc.Cancel(ctx, "t1", session.ID, "u1")
+	if err != nil {
+		t.Fatalf("Cancel() err = %v", err)
+	}
+	if got.Status != domain.CountStatusCancelled || products.Products[2].CountSessionID != "" {
+		t.Errorf("status=%s lock=%q", got.Status, products.Products[2].CountSessionID)
+	}
+	if _, err := uc.SubmitCounts(ctx, SubmitCountsRequest{
+		TenantID: "t1", SessionID: session.ID, Counts: []SubmittedCount{{"p3", 1}},
+	}); !errors.Is(err, domain.ErrCountSessionClosed) {
+		t.Errorf("SubmitCounts(cancelled) err = %v, want %v", err, domain.ErrCountSessionClosed)
+	}
+}
+
+func TestCycleCountUseCase_SubmitCounts_Validation(t *testing.T) {
+	uow, _ := cycleCountFixture()
+	uc := NewCycleCountUseCase(uow)
+	ctx := context.Background()
+	session, _ := uc.Open(ctx, OpenCountSessionRequest{TenantID: "t1", ProductIDs: []string{"p1"}})
+
+	tests := []struct {
+		name  string
+		count SubmittedCount
+		want  error
+	}{
+		{"product outside session", SubmittedCount{"p2", 1}, domain.ErrProductNotInCount},
+		{"negative quantity", SubmittedCount{"p1", -1}, domain.ErrInvalidQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.SubmitCounts(ctx, SubmitCountsRequest{TenantID: "t1", SessionID: session.ID, Counts: []SubmittedCount{tt.count}})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("SubmitCounts() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
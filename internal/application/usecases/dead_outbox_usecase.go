@@ -0,0 +1,94 @@
+// internal/application/usecases/dead_outbox_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Dead entries listed at most at once
+const maxDeadOutboxEntries = 100
+
+// Output DTO
+type DeadOutboxEntry struct {
+	ID          string
+	EventType   string
+	AggregateID string
+	TenantID    string
+	Status      domain.OutboxStatus
+	Attempts    int
+	LastError   string
+	CreatedAt   time.Time
+}
+
+// Use Case interface (what maintenance commands depend on). A dead outbox
+// entry holds back every later event of its aggregate, so an operator
+// either requeues it once the cause is fixed or discards it.
+type DeadOutboxUseCase interface {
+	List(ctx context.Context) ([]DeadOutboxEntry, error)
+	// Requeue makes the entry due again with a fresh set of attempts
+	Requeue(ctx context.Context, entryID string) (*DeadOutboxEntry, error)
+	// Discard gives up on the entry and releases the events behind it
+	Discard(ctx context.Context, entryID string) (*DeadOutboxEntry, error)
+}
+
+// Implementation
+type deadOutboxUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewDeadOutboxUseCase(uow interfaces.UnitOfWork) DeadOutboxUseCase {
+	return &deadOutboxUseCase{uow: uow}
+}
+
+func (uc *deadOutboxUseCase) List(ctx context.Context) ([]DeadOutboxEntry, error) {
+	entries, err := uc.uow.Outbox().FindDead(ctx, maxDeadOutboxEntries)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]DeadOutboxEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, toDeadOutboxEntry(e))
+	}
+	return result, nil
+}
+
+func (uc *deadOutboxUseCase) Requeue(ctx context.Context, entryID string) (*DeadOutboxEntry, error) {
+	return uc.resolve(ctx, entryID, func(e *domain.OutboxEntry) error {
+		return e.Requeue(time.Now())
+	})
+}
+
+func (uc *deadOutboxUseCase) Discard(ctx context.Context, entryID string) (*DeadOutboxEntry, error) {
+	return uc.resolve(ctx, entryID, (*domain.OutboxEntry).Discard)
+}
+
+func (uc *deadOutboxUseCase) resolve(ctx context.Context, entryID string, change func(*domain.OutboxEntry) error) (*DeadOutboxEntry, error) {
+	entry, err := uc.uow.Outbox().FindByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(entry); err != nil {
+		return nil, err
+	}
+	if err := uc.uow.Outbox().Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	result := toDeadOutboxEntry(entry)
+	return &result, nil
+}
+
+func toDeadOutboxEntry(e *domain.OutboxEntry) DeadOutboxEntry {
+	return DeadOutboxEntry{
+		ID:          e.ID,
+		EventType:   e.EventType,
+		AggregateID: e.AggregateID,
+		TenantID:    e.TenantID,
+		Status:      e.Status,
+		Attempts:    e.Attempts,
+		LastError:   e.LastError,
+		CreatedAt:   e.CreatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func TestDeadOutboxUseCase_RequeueReleasesAggregate(t *testing.T) {
+	dead := mustOutboxEntry(t, "e1", "p1", 5)
+	dead.Status, dead.Attempts, dead.LastError = domain.OutboxDead, domain.MaxOutboxAttempts, "schema validation failed"
+	outbox := &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{dead, mustOutboxEntry(t, "e2", "p1", 2)}}
+	uow := &mocks.MockUnitOfWork{OutboxRepo: outbox}
+	uc := NewDeadOutboxUseCase(uow)
+	ctx := context.Background()
+
+	listed, err := uc.List(ctx)
+	if err != nil || len(listed) != 1 || listed[0].ID != "e1" || listed[0].LastError != "schema validation failed" {
+		t.Fatalf("List() = %+v, %v", listed, err)
+	}
+
+	got, err := uc.Requeue(ctx, "e1")
+	if err != nil {
+		t.Fatalf("Requeue() err = %v", err)
+	}
+	if got.Status != domain.OutboxPending || got.Attempts != 0 || len(outbox.Updated) != 1 {
+		t.Errorf("requeued = %+v, updates = %d", got, len(outbox.Updated))
+	}
+
+	pub := &failingProductPublisher{}
+	relayed, err := NewRelayOutboxUseCase(uow, pub, "bus", 10).Execute(ctx)
+	if err != nil || relayed.Published != 2 {
+		t.Errorf("relay after requeue = %+v, %v; want both entries published", relayed, err)
+	}
+}
+
+func TestDeadOutboxUseCase_DiscardReleasesAggregate(t *testing.T) {
+	dead := mustOutboxEntry(t, "e1", "p1", 5)
+	dead.Status = domain.OutboxDead
+	outbox := &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{dead, mustOutboxEntry(t, "e2", "p1", 2)}}
+	uow := &mocks.MockUnitOfWork{OutboxRepo: outbox}
+	ctx := context.Background()
+
+	if _, err := NewDeadOutboxUseCase(uow).Discard(ctx, "e1"); err != nil {
+		t.Fatalf("Discard() err = %v", err)
+	}
+	pub := &failingProductPublisher{}
+	relayed, err := NewRelayOutboxUseCase(uow, pub, "bus", 10).Execute(ctx)
+	if err != nil || relayed.Published != 1 || pub.published[0].Subject != "p1" {
+		t.Errorf("relay after discard = %+v, %v; want only e2 published", relayed, err)
+	}
+	if dead.Status != domain.OutboxDiscarded {
+		t.Errorf("status = %s, want %s", dead.Status, domain.OutboxDiscarded)
+	}
+}
+
+func TestDeadOutboxUseCase_Rejections(t *testing.T) {
+	outbox := &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{mustOutboxEntry(t, "e1", "p1", 5)}}
+	uc := NewDeadOutboxUseCase(&mocks.MockUnitOfWork{OutboxRepo: outbox})
+	ctx := context.Background()
+
+	if _, err := uc.Requeue(ctx, "e1"); !errors.Is(err, domain.ErrOutboxEntryNotDead) {
+		t.Errorf("Requeue(pending) err = %v, want %v", err, domain.ErrOutboxEntryNotDead)
+	}
+	if _, err := uc.Discard(ctx, "nope"); !errors.Is(err, domain.ErrOutboxEntryNotFound) {
+		t.Errorf("Discard(unknown) err = %v, want %v", err, domain.ErrOutboxEntryNotFound)
+	}
+	if len(outbox.Updated) != 0 {
+		t.Errorf("rejected calls wrote %d updates", len(outbox.Updated))
+	}
+}
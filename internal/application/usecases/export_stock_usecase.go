@@ -0,0 +1,175 @@
+// internal/application/usecases/export_stock_usecase.go
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type ExportStockLevelsRequest struct {
+	TenantID string
+	Format   string
+	// Exports the stock products had then instead of current stock
+	AsOf time.Time
+}
+
+type ExportStockHistoryRequest struct {
+	TenantID string
+	Format   string
+	// Entries created in [From, To); the last 30 days when both are zero
+	From time.Time
+	To   time.Time
+}
+
+// Output DTO: a validated export, written when the caller is ready to
+// stream it
+type StockExport struct {
+	Format   string
+	FileName string
+	write    func(ctx context.Context, w io.Writer) (int, error)
+}
+
+// NewStockExport wraps a function that streams an export to w and
+// returns the number of rows written.
+func NewStockExport(format, fileName string, write func(ctx context.Context, w io.Writer) (int, error)) *StockExport {
+	return &StockExport{Format: format, FileName: fileName, write: write}
+}
+
+// WriteTo streams the export to w row by row and returns the number of
+// rows written.
+func (e *StockExport) WriteTo(ctx context.Context, w io.Writer) (int, error) {
+	return e.write(ctx, w)
+}
+
+// Use Case interface (what handlers depend on)
+type ExportStockUseCase interface {
+	// Current stock of every product of the tenant, with utilization, or
+	// the stock they had at AsOf when it is set
+	StockLevels(ctx context.Context, req ExportStockLevelsRequest) (*StockExport, error)
+	StockHistory(ctx context.Context, req ExportStockHistoryRequest) (*StockExport, error)
+}
+
+// Implementation
+type exportStockUseCase struct {
+	uow      interfaces.UnitOfWork
+	encoder  interfaces.TableEncoder
+	timeline stockTimeline
+}
+
+func NewExportStockUseCase(uow interfaces.UnitOfWork, encoder interfaces.TableEncoder) ExportStockUseCase {
+	return &exportStockUseCase{uow: uow, encoder: encoder, timeline: stockTimeline{uow: uow}}
+}
+
+func (uc *exportStockUseCase) StockLevels(ctx context.Context, req ExportStockLevelsRequest) (*StockExport, error) {
+	// 1. Validate before anything is streamed
+	tenant, err := uc.validate(ctx, req.TenantID, req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.AsOf.IsZero() {
+		return uc.stockLevelsAsOf(tenant, req), nil
+	}
+
+	// 2. Stream one row per product
+	fileName := fmt.Sprintf("stock-levels-%s-%s.%s", tenant.ID, time.Now().UTC().Format("20060102"), req.Format)
+	return NewStockExport(req.Format, fileName, func(ctx context.Context, w io.Writer) (int, error) {
+		return uc.stream(w, req.Format, domain.StockLevelExportColumns, func(write func([]interface{}) error) error {
+			return uc.uow.Products().EachByTenant(ctx, tenant.ID, func(p *domain.Product) error {
+				return write(domain.StockLevelExportRow(p, tenant.MaxStock))
+			})
+		})
+	}), nil
+}
+
+// stockLevelsAsOf exports the stock rebuilt for every product at req.AsOf
+func (uc *exportStockUseCase) stockLevelsAsOf(tenant *domain.Tenant, req ExportStockLevelsRequest) *StockExport {
+	asOf := req.AsOf
+	fileName := fmt.Sprintf("stock-levels-%s-asof-%s.%s", tenant.ID, asOf.UTC().Format("20060102T150405Z"), req.Format)
+	return NewStockExport(req.Format, fileName, func(ctx context.Context, w io.Writer) (int, error) {
+		now := time.Now()
+		if asOf.After(now) {
+			asOf = now
+		}
+		var products []*domain.Product
+		err := uc.uow.Products().EachByTenant(ctx, tenant.ID, func(p *domain.Product) error {
+			products = append(products, p)
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		levels, err := uc.timeline.stockAsOf(ctx, tenant.ID, "", products, asOf, now)
+		if err != nil {
+			return 0, err
+		}
+		return uc.stream(w, req.Format, domain.StockAsOfExportColumns, func(write func([]interface{}) error) error {
+			for _, level := range levels {
+				if err := write(domain.StockAsOfExportRow(level)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func (uc *exportStockUseCase) StockHistory(ctx context.Context, req ExportStockHistoryRequest) (*StockExport, error) {
+	// 1. Validate before anything is streamed
+	tenant, err := uc.validate(ctx, req.TenantID, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	from, to := req.From, req.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-domain.DefaultHistoryExportRange)
+	}
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	// 2. Stream the entries of the range, oldest first
+	fileName := fmt.Sprintf("stock-history-%s-%s-%s.%s", tenant.ID, from.UTC().Format("20060102"), to.UTC().Format("20060102"), req.Format)
+	return NewStockExport(req.Format, fileName, func(ctx context.Context, w io.Writer) (int, error) {
+		return uc.stream(w, req.Format, domain.StockHistoryExportColumns, func(write func([]interface{}) error) error {
+			return uc.uow.StockHistory().EachInRange(ctx, tenant.ID, from, to, func(e domain.StockHistoryEntry) error {
+				return write(domain.StockHistoryExportRow(e))
+			})
+		})
+	}), nil
+}
+
+func (uc *exportStockUseCase) validate(ctx context.Context, tenantID, format string) (*domain.Tenant, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if !domain.IsValidExportFormat(format) {
+		return nil, domain.ErrUnsupportedExportFormat
+	}
+	return uc.uow.Tenants().FindByID(ctx, tenantID)
+}
+
+// stream writes the rows produced by each to w in the given format.
+func (uc *exportStockUseCase) stream(w io.Writer, format string, columns []domain.ExportColumn, each func(write func([]interface{}) error) error) (int, error) {
+	writer, err := uc.encoder.NewWriter(format, w, columns)
+	if err != nil {
+		return 0, err
+	}
+	rows := 0
+	err = each(func(values []interface{}) error {
+		rows++
+		return writer.WriteRow(values)
+	})
+	if err != nil {
+		return rows, err
+	}
+	return rows, writer.Close()
+}
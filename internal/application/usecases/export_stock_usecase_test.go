@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+	"time"
+)
+
+// Keeps the rows it is given instead of encoding them
+type recordingEncoder struct {
+	format  string
+	columns []domain.ExportColumn
+	rows    [][]interface{}
+	closed  bool
+}
+
+func (e *recordingEncoder) NewWriter(format string, w io.Writer, columns []domain.ExportColumn) (interfaces.TableWriter, error) {
+	e.format, e.columns = format, columns
+	return e, nil
+}
+
+func (e *recordingEncoder) WriteRow(values []interface{}) error {
+	e.rows = append(e.rows, append([]interface{}(nil), values...))
+	return nil
+}
+
+func (e *recordingEncoder) Close() error {
+	e.closed = true
+	return nil
+}
+
+func exportFixture() *mocks.MockUnitOfWork {
+	widget := testProduct("p1", "Widget", 50)
+	widget.Tags = []string{"a", "b"}
+	other := testProduct("p2", "Other tenant", 10)
+	other.TenantID = "t2"
+	uow, _ := stockFixture(widget, other)
+	uow.TenantsRepo.Tenant.MaxStock = mustQuantity(200)
+	return uow
+}
+
+func TestExportStockUseCase_StockLevels(t *testing.T) {
+	encoder := &recordingEncoder{}
+	uc := NewExportStockUseCase(exportFixture(), encoder)
+
+	export, err := uc.StockLevels(context.Background(), ExportStockLevelsRequest{TenantID: "t1", Format: domain.ExportFormatParquet})
+	if err != nil {
+		t.Fatalf("StockLevels() err = %v", err)
+	}
+	if export.Format != domain.ExportFormatParquet || !bytes.HasSuffix([]byte(export.FileName), []byte(".parquet")) {
+		t.Errorf("export = %+v", export)
+	}
+	rows, err := export.WriteTo(context.Background(), io.Discard)
+	if err != nil {
+		t.Fatalf("WriteTo() err = %v", err)
+	}
+	if rows != 1 || len(encoder.rows) != 1 || !encoder.closed {
+		t.Fatalf("rows = %d, encoded = %v, closed = %v; want only t1's product", rows, encoder.rows, encoder.closed)
+	}
+	row := encoder.rows[0]
+	if row[0] != "p1" || row[4] != "a;b" || row[5] != 50 || row[6] != 200 || row[7] != 25.0 {
+		t.Errorf("row = %v", row)
+	}
+}
+
+func TestExportStockUseCase_StockHistory_FiltersRange(t *testing.T) {
+	now := time.Now()
+	uow := exportFixture()
+	uow.StockHistRepo.Entries = []domain.StockHistoryEntry{
+		{ID: "h1", TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockAdd, Quantity: 5, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "h2", TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockAdd, Quantity: 7, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "h3", TenantID: "t2", ProductID: "p2", Operation: domain.HistoryOperationStockAdd, Quantity: 1, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	encoder := &recordingEncoder{}
+
+	export, err := NewExportStockUseCase(uow, encoder).StockHistory(context.Background(), ExportStockHistoryRequest{
+		TenantID: "t1", Format: domain.ExportFormatCSV, From: now.Add(-24 * time.Hour), To: now,
+	})
+	if err != nil {
+		t.Fatalf("StockHistory() err = %v", err)
+	}
+	if _, err := export.WriteTo(context.Background(), io.Discard); err != nil {
+		t.Fatalf("WriteTo() err = %v", err)
+	}
+	if len(encoder.rows) != 1 || encoder.rows[0][0] != "h2" {
+		t.Errorf("rows = %v, want only h2", encoder.rows)
+	}
+}
+
+func TestExportStockUseCase_Validation(t *testing.T) {
+	uc := NewExportStockUseCase(exportFixture(), &recordingEncoder{})
+	ctx := context.Background()
+	now := time.Now()
+
+	if _, err := uc.StockLevels(ctx, ExportStockLevelsRequest{Format: domain.ExportFormatCSV}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("no tenant err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+	if _, err := uc.StockLevels(ctx, ExportStockLevelsRequest{TenantID: "t1", Format: "xml"}); !errors.Is(err, domain.ErrUnsupportedExportFormat) {
+		t.Errorf("format err = %v, want %v", err, domain.ErrUnsupportedExportFormat)
+	}
+	_, err := uc.StockHistory(ctx, ExportStockHistoryRequest{TenantID: "t1", Format: domain.ExportFormatCSV, From: now, To: now.Add(-time.Hour)})
+	if !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Errorf("range err = %v, want %v", err, domain.ErrInvalidTimeRange)
+	}
+}
+
+func TestExportStockUseCase_StockLevels_AsOf(t *testing.T) {
+	uow := snapshotFixture()
+	uow.TenantsRepo = &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true, MaxStock: mustQuantity(100)}}
+	uow.DailyRepo.Snapshots = []domain.DailyStockSnapshot{
+		{TenantID: "t1", ProductID: "p1", Day: domain.SnapshotDay(march2), Stock: domain.DecimalFromInt(45), TakenAt: march2},
+	}
+	encoder := &recordingEncoder{}
+
+	export, err := NewExportStockUseCase(uow, encoder).StockLevels(context.Background(), ExportStockLevelsRequest{
+		TenantID: "t1", Format: domain.ExportFormatCSV, AsOf: march2.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("StockLevels() err = %v", err)
+	}
+	if _, err := export.WriteTo(context.Background(), io.Discard); err != nil {
+		t.Fatalf("WriteTo() err = %v", err)
+	}
+	if len(encoder.columns) != len(domain.StockAsOfExportColumns) || len(encoder.rows) != 1 {
+		t.Fatalf("columns = %v, rows = %v", encoder.columns, encoder.rows)
+	}
+	row := encoder.rows[0]
+	if row[0] != "p1" || row[4] != 40.0 || row[5] != "2024-03-02" {
+		t.Errorf("row = %v, want p1 at 40 from the March 2nd snapshot", row)
+	}
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+)
+
+// stockFixture is the unit of work the stock use case tests start from:
+// active tenant t1 with a max stock of 100, the given products and empty
+// history, outbox, approval, count and reason repositories. Tests adjust
+// the tenant or add repositories on the returned unit of work.
+func stockFixture(products ...*domain.Product) (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	repo := &mocks.MockProductRepo{Products: products}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo:  repo,
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", MaxStock: mustQuantity(100), IsActive: true}},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
+		ChangesRepo:   &mocks.MockStockChangeRequestRepo{},
+		CountsRepo:    &mocks.MockCountSessionRepo{},
+		ReasonsRepo:   &mocks.MockAdjustmentReasonRepo{},
+	}
+	return uow, repo
+}
+
+// widgetFixture is stockFixture with a single product, p1 "Widget"
+func widgetFixture(stock int) (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	return stockFixture(testProduct("p1", "Widget", stock))
+}
+
+// testProduct is an integer-mode product of tenant t1
+func testProduct(id, name string, stock int) *domain.Product {
+	return &domain.Product{ID: id, Name: name, TenantID: "t1", CurrentStock: mustQuantity(stock)}
+}
@@ -0,0 +1,189 @@
+// internal/application/usecases/forecast_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"sort"
+	"time"
+)
+
+// Input DTO
+type ForecastReportRequest struct {
+	TenantID string
+	// Only this product when set
+	ProductID string
+	// Override the tenant's settings when set
+	Method     string
+	WindowDays int
+}
+
+// Output DTO
+type ForecastReportResponse struct {
+	TenantID string
+	Settings domain.ForecastSettings
+	// Days of history the forecast is based on
+	From time.Time
+	To   time.Time
+	// Products to reorder first, soonest stockout first
+	Products []domain.ProductForecast
+}
+
+// Use Case interface (what handlers depend on)
+type ForecastUseCase interface {
+	Report(ctx context.Context, req ForecastReportRequest) (*ForecastReportResponse, error)
+}
+
+// Implementation
+type forecastUseCase struct {
+	uow        interfaces.UnitOfWork
+	forecaster stockForecaster
+}
+
+func NewForecastUseCase(uow interfaces.UnitOfWork) ForecastUseCase {
+	return &forecastUseCase{uow: uow, forecaster: stockForecaster{uow: uow}}
+}
+
+func (uc *forecastUseCase) Report(ctx context.Context, req ForecastReportRequest) (*ForecastReportResponse, error) {
+	// 1. Validate input
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	tenant, err := uc.uow.Tenants().FindByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	settings := tenant.Forecast
+	if req.Method != "" {
+		settings.Method = req.Method
+	}
+	if req.WindowDays != 0 {
+		settings.WindowDays = req.WindowDays
+	}
+	settings = settings.WithDefaults()
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 2. Get products
+	var products []*domain.Product
+	if req.ProductID != "" {
+		product, err := uc.uow.Products().FindByID(ctx, req.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product.TenantID != req.TenantID {
+			return nil, domain.ErrProductNotFound
+		}
+		products = []*domain.Product{product}
+	} else {
+		err := uc.uow.Products().EachByTenant(ctx, req.TenantID, func(p *domain.Product) error {
+			products = append(products, p)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Forecast
+	now := time.Now()
+	forecasts, err := uc.forecaster.forecast(ctx, tenant, req.ProductID, products, settings, now)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		a, b := forecasts[i], forecasts[j]
+		if a.NeedsReorder != b.NeedsReorder {
+			return a.NeedsReorder
+		}
+		if (a.DaysUntilStockout == nil) != (b.DaysUntilStockout == nil) {
+			return a.DaysUntilStockout != nil
+		}
+		if a.DaysUntilStockout != nil && *a.DaysUntilStockout != *b.DaysUntilStockout {
+			return *a.DaysUntilStockout < *b.DaysUntilStockout
+		}
+		return a.ProductID < b.ProductID
+	})
+
+	from, to := settings.Window(now)
+	return &ForecastReportResponse{
+		TenantID: tenant.ID,
+		Settings: settings,
+		From:     from,
+		To:       to,
+		Products: forecasts,
+	}, nil
+}
+
+// Forecasts demand from stock_history removals. Shared by the forecast
+// report and low-stock alerting.
+type stockForecaster struct {
+	uow interfaces.UnitOfWork
+}
+
+// forecast returns the forecasts of the tenant's products in the order
+// given. productID narrows the history lookup to that product.
+func (f stockForecaster) forecast(ctx context.Context, tenant *domain.Tenant, productID string, products []*domain.Product, settings domain.ForecastSettings, now time.Time) ([]domain.ProductForecast, error) {
+	from, to := settings.Window(now)
+	days, err := f.uow.StockHistory().DailyConsumption(ctx, tenant.ID, productID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[string][]domain.DailyConsumption)
+	for _, d := range days {
+		byProduct[d.ProductID] = append(byProduct[d.ProductID], d)
+	}
+
+	forecasts := make([]domain.ProductForecast, 0, len(products))
+	for _, p := range products {
+		series := domain.ConsumptionSeries(byProduct[p.ID], from, settings.WindowDays)
+		forecasts = append(forecasts, domain.NewProductForecast(p, series, settings, tenant.MaxStock))
+	}
+	return forecasts, nil
+}
+
+// lowStockEvent returns the alert for a product below the low stock
+// threshold or, when the tenant alerts on forecasts, at or below its
+// reorder point. It returns nil when neither applies.
+func (f stockForecaster) lowStockEvent(ctx context.Context, tenant *domain.Tenant, product *domain.Product, now time.Time) (*domain.LowStockEvent, error) {
+	event := domain.LowStockEvent{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		TenantID:    tenant.ID,
+		Current:     product.CurrentStock,
+		Threshold:   domain.DefaultLowStockThreshold,
+		ProductTags: product.Tags,
+		Timestamp:   now,
+	}
+	low := product.IsLowStock(domain.DefaultLowStockThreshold)
+
+	if tenant.Forecast.Alerts {
+		settings := tenant.Forecast.WithDefaults()
+		if err := settings.Validate(); err != nil {
+			return nil, err
+		}
+		forecasts, err := f.forecast(ctx, tenant, product.ID, []*domain.Product{product}, settings, now)
+		if err != nil {
+			return nil, err
+		}
+		forecast := forecasts[0]
+		if forecast.DaysUntilStockout != nil {
+			event.ReorderPoint = forecast.ReorderPoint
+			event.DaysUntilStockout = forecast.DaysUntilStockout
+			event.SuggestedQuantity = forecast.ReorderQuantity
+		}
+		if forecast.NeedsReorder {
+			low = true
+			if forecast.ReorderPoint > event.Threshold {
+				event.Threshold = forecast.ReorderPoint
+			}
+		}
+	}
+
+	if !low {
+		return nil, nil
+	}
+	return &event, nil
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+)
+
+// daysAgo is noon of the UTC day n days before today
+func daysAgo(n int) time.Time {
+	return domain.SnapshotDay(time.Now()).AddDate(0, 0, -n).Add(12 * time.Hour)
+}
+
+func forecastFixture() *mocks.MockUnitOfWork {
+	uow, _ := stockFixture(
+		testProduct("p1", "Widget", 20),
+		testProduct("p2", "Gadget", 5),
+		testProduct("p3", "Gizmo", 3),
+	)
+	uow.TenantsRepo.Tenant.MaxStock = mustQuantity(20)
+	uow.TenantsRepo.Tenant.Forecast = domain.ForecastSettings{WindowDays: 4, Alpha: 0.5, LeadTimeDays: 2, SafetyStockDays: 1, CoverDays: 4}
+	uow.StockHistRepo.Entries = []domain.StockHistoryEntry{
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -4, CreatedAt: daysAgo(4)},
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -4, CreatedAt: daysAgo(3)},
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -4, CreatedAt: daysAgo(2)},
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -4, CreatedAt: daysAgo(1)},
+		// Reversed removals, additions and today's removals are not demand
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -100, CreatedAt: daysAgo(1), ReversedBy: "h9"},
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockAdd, Quantity: 30, CreatedAt: daysAgo(2)},
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -50, CreatedAt: domain.SnapshotDay(time.Now())},
+		{TenantID: "t1", ProductID: "p2", Operation: domain.HistoryOperationStockRemove, Quantity: -8, CreatedAt: daysAgo(1)},
+	}
+	return uow
+}
+
+func TestForecastUseCase_Report_MovingAverage(t *testing.T) {
+	uc := NewForecastUseCase(forecastFixture())
+
+	got, err := uc.Report(context.Background(), ForecastReportRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("Report() err = %v", err)
+	}
+	if got.Settings.Method != domain.ForecastMethodMovingAverage || got.To.Sub(got.From) != 4*24*time.Hour {
+		t.Errorf("settings = %+v, window = %v to %v", got.Settings, got.From, got.To)
+	}
+	if len(got.Products) != 3 {
+		t.Fatalf("products = %d, want 3", len(got.Products))
+	}
+
+	// Reorders first, then the soonest stockout, then no demand
+	p2, p1, p3 := got.Products[0], got.Products[1], got.Products[2]
+	if p2.ProductID != "p2" || p1.ProductID != "p1" || p3.ProductID != "p3" {
+		t.Fatalf("order = %s, %s, %s", p2.ProductID, p1.ProductID, p3.ProductID)
+	}
+	if p2.DailyDemand != 2 || p2.ReorderPoint != 6 || !p2.NeedsReorder || p2.ReorderQuantity != 9 {
+		t.Errorf("p2 = %+v", p2)
+	}
+	if p1.DailyDemand != 4 || p1.DaysUntilStockout == nil || *p1.DaysUntilStockout != 5 ||
+		p1.ReorderPoint != 12 || p1.NeedsReorder || p1.ReorderQuantity != 0 {
+		t.Errorf("p1 = %+v", p1)
+	}
+	if p3.DailyDemand != 0 || p3.DaysUntilStockout != nil || p3.NeedsReorder {
+		t.Errorf("p3 = %+v", p3)
+	}
+}
+
+func TestForecastUseCase_Report_ExponentialSmoothing(t *testing.T) {
+	uc := NewForecastUseCase(forecastFixture())
+
+	got, err := uc.Report(context.Background(), ForecastReportRequest{
+		TenantID: "t1", ProductID: "p2", Method: domain.ForecastMethodExponentialSmoothing,
+	})
+	if err != nil {
+		t.Fatalf("Report() err = %v", err)
+	}
+	if len(got.Products) != 1 {
+		t.Fatalf("products = %d, want 1", len(got.Products))
+	}
+	// Series 0, 0, 0, 8 smoothed by 0.5; the order is capped at max stock
+	p2 := got.Products[0]
+	if p2.DailyDemand != 4 || p2.ReorderPoint != 12 || p2.ReorderQuantity != 15 {
+		t.Errorf("p2 = %+v", p2)
+	}
+}
+
+func TestForecastUseCase_Report_Rejections(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ForecastReportRequest
+		want error
+	}{
+		{"no tenant", ForecastReportRequest{}, domain.ErrTenantNotFound},
+		{"unknown method", ForecastReportRequest{TenantID: "t1", Method: "holt_winters"}, domain.ErrInvalidForecastSettings},
+		{"window too long", ForecastReportRequest{TenantID: "t1", WindowDays: 400}, domain.ErrInvalidForecastSettings},
+		{"other tenant's product", ForecastReportRequest{TenantID: "t1", ProductID: "p9"}, domain.ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := forecastFixture()
+			uow.ProductsRepo.Products = append(uow.ProductsRepo.Products,
+				&domain.Product{ID: "p9", TenantID: "t2", CurrentStock: mustQuantity(1)})
+			_, err := NewForecastUseCase(uow).Report(context.Background(), tt.req)
+			if err != tt.want {
+				t.Errorf("Report() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStockForecaster_LowStockEvent(t *testing.T) {
+	uow := forecastFixture()
+	forecaster := stockForecaster{uow: uow}
+	tenant := uow.TenantsRepo.Tenant
+	p1 := uow.ProductsRepo.Products[0]
+	p1.CurrentStock = mustQuantity(12)
+
+	// At the reorder point but above the fixed threshold
+	event, err := forecaster.lowStockEvent(context.Background(), tenant, p1, time.Now())
+	if err != nil || event != nil {
+		t.Fatalf("lowStockEvent() without alerts = %+v, %v, want nil", event, err)
+	}
+
+	tenant.Forecast.Alerts = true
+	event, err = forecaster.lowStockEvent(context.Background(), tenant, p1, time.Now())
+	if err != nil || event == nil {
+		t.Fatalf("lowStockEvent() = %+v, %v", event, err)
+	}
+	if event.Threshold != 12 || event.ReorderPoint != 12 || event.SuggestedQuantity != 8 ||
+		event.DaysUntilStockout == nil || *event.DaysUntilStockout != 3 {
+		t.Errorf("event = %+v", event)
+	}
+
+	p1.CurrentStock = mustQuantity(13)
+	if event, err := forecaster.lowStockEvent(context.Background(), tenant, p1, time.Now()); err != nil || event != nil {
+		t.Errorf("lowStockEvent() above reorder point = %+v, %v, want nil", event, err)
+	}
+}
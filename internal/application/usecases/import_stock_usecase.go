@@ -0,0 +1,429 @@
+// internal/application/usecases/import_stock_usecase.go
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rows applied per batch while an import job runs; progress is saved
+// after each
+const importChunkSize = 100
+
+// Rows shown in a dry-run preview
+const maxImportPreviewRows = 50
+
+// Input DTO
+type ImportStockRequest struct {
+	TenantID string
+	FileName string
+	// domain.ImportFormatCSV or domain.ImportFormatXLSX; taken from the
+	// file name extension when empty
+	Format string
+	Data   []byte
+	// Unmapped columns use the default header names
+	Mapping     domain.ImportColumnMapping
+	RequestedBy string
+	// Queue the file's failed job again instead of reporting it as a
+	// duplicate; it continues after the rows it already handled
+	Resume bool
+}
+
+// Output DTOs
+type ImportPreviewResponse struct {
+	FileHash  string
+	TotalRows int
+	ValidRows int
+	// Rows that would not be applied, by line
+	Errors []domain.ImportRowError
+	// The first valid rows as they would be applied
+	Rows []ImportPreviewRow
+	// Set when the file was already imported
+	ExistingJobID string
+}
+
+type ImportPreviewRow struct {
+	Line          int
+	ProductID     string
+	ProductName   string
+	Quantity      int
+	PreviousStock int
+	NewStock      int
+	// The row exceeds the tenant's approval threshold and would be held
+	NeedsApproval bool
+}
+
+type ImportJobResponse struct {
+	ID            string
+	TenantID      string
+	FileName      string
+	FileHash      string
+	Format        string
+	Status        string
+	TotalRows     int
+	ProcessedRows int
+	AppliedRows   int
+	// Rows held for approval instead of applied
+	PendingApprovalRows int
+	FailedRows          int
+	Progress            float64
+	Failure             string
+	CreatedBy           string
+	CreatedAt           time.Time
+	StartedAt           time.Time
+	FinishedAt          time.Time
+	// The file was imported before; this is the earlier job
+	Duplicate bool
+}
+
+// Use Case interface (what handlers depend on)
+type ImportStockUseCase interface {
+	// Preview validates a file without changing stock
+	Preview(ctx context.Context, req ImportStockRequest) (*ImportPreviewResponse, error)
+	// Start queues the file as an import job, or returns the job that
+	// already imported it. A failed job is requeued only when the request
+	// asks to resume.
+	Start(ctx context.Context, req ImportStockRequest) (*ImportJobResponse, error)
+	Get(ctx context.Context, tenantID, jobID string) (*ImportJobResponse, error)
+	// ErrorReport lists the rows of a job that were not applied, by line,
+	// including those held for approval
+	ErrorReport(ctx context.Context, tenantID, jobID string) ([]domain.ImportRowError, error)
+	// ProcessNext runs the oldest pending job, or a running one whose
+	// worker stopped; false when none was waiting
+	ProcessNext(ctx context.Context) (bool, error)
+}
+
+// Implementation
+type importStockUseCase struct {
+	uow       interfaces.UnitOfWork
+	reader    interfaces.SpreadsheetReader
+	adder     *addStockUseCase
+	batch     AddStockBatchUseCase
+	chunkSize int
+}
+
+func NewImportStockUseCase(
+	uow interfaces.UnitOfWork,
+	reader interfaces.SpreadsheetReader,
+	notificationSvc interfaces.NotificationService,
+) ImportStockUseCase {
+	return &importStockUseCase{
+		uow:       uow,
+		reader:    reader,
+		adder:     newAddStockUseCase(uow, notificationSvc),
+		batch:     NewAddStockBatchUseCase(uow, notificationSvc),
+		chunkSize: importChunkSize,
+	}
+}
+
+// parsedImport is an uploaded file read into rows
+type parsedImport struct {
+	format    string
+	hash      string
+	mapping   domain.ImportColumnMapping
+	rows      []domain.ImportRow
+	rowErrors []domain.ImportRowError
+}
+
+func (uc *importStockUseCase) parse(req ImportStockRequest) (*parsedImport, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	format := strings.ToLower(req.Format)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(path.Ext(req.FileName)), ".")
+	}
+	if format != domain.ImportFormatCSV && format != domain.ImportFormatXLSX {
+		return nil, domain.ErrUnsupportedImportFormat
+	}
+
+	sheet, err := uc.reader.Read(format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	mapping := req.Mapping.WithDefaults()
+	rows, rowErrors, err := mapping.ParseRows(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(req.Data)
+	return &parsedImport{
+		format:    format,
+		hash:      hex.EncodeToString(sum[:]),
+		mapping:   mapping,
+		rows:      rows,
+		rowErrors: rowErrors,
+	}, nil
+}
+
+func (uc *importStockUseCase) loadTenant(ctx context.Context, tenantID string) (*domain.Tenant, error) {
+	tenant, err := uc.uow.Tenants().FindByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tenant.CanReceiveStock(); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+func (uc *importStockUseCase) Preview(ctx context.Context, req ImportStockRequest) (*ImportPreviewResponse, error) {
+	// 1. Read the file
+	parsed, err := uc.parse(req)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := uc.loadTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ImportPreviewResponse{
+		FileHash:  parsed.hash,
+		TotalRows: len(parsed.rows) + len(parsed.rowErrors),
+		Errors:    parsed.rowErrors,
+	}
+	existing, err := uc.uow.ImportJobs().FindByHash(ctx, req.TenantID, parsed.hash)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		preview.ExistingJobID = existing.ID
+	}
+
+	// 2. Load every product of the file at once
+	ids := make([]string, 0, len(parsed.rows))
+	for _, row := range parsed.rows {
+		ids = append(ids, row.ProductID)
+	}
+	found, err := uc.uow.Products().FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[string]*domain.Product, len(found))
+	for _, p := range found {
+		copied := *p
+		products[p.ID] = &copied
+	}
+
+	// 3. Check each row against the add stock rules, on copies of the
+	// products so that rows for the same product add up
+	for _, row := range parsed.rows {
+		previewRow, err := uc.previewRow(row, req.TenantID, tenant, products)
+		if err != nil {
+			preview.Errors = append(preview.Errors, domain.ImportRowError{
+				Line:      row.Line,
+				ProductID: row.ProductID,
+				Quantity:  strconv.Itoa(row.Quantity),
+				Message:   err.Error(),
+			})
+			continue
+		}
+		preview.ValidRows++
+		if len(preview.Rows) < maxImportPreviewRows {
+			preview.Rows = append(preview.Rows, *previewRow)
+		}
+	}
+	sortRowErrors(preview.Errors)
+	return preview, nil
+}
+
+func (uc *importStockUseCase) previewRow(row domain.ImportRow, tenantID string, tenant *domain.Tenant, products map[string]*domain.Product) (*ImportPreviewRow, error) {
+	if err := uc.adder.validateRequest(AddStockRequest{
+		ProductID: row.ProductID,
+		Quantity:  row.Quantity,
+		TenantID:  tenantID,
+	}); err != nil {
+		return nil, err
+	}
+	product := products[row.ProductID]
+	if product == nil || product.TenantID != tenantID {
+		return nil, domain.ErrProductNotFound
+	}
+	if product.IsLockedForCount() {
+		return nil, domain.ErrProductLockedForCount
+	}
+
+	result := &ImportPreviewRow{
+		Line:          row.Line,
+		ProductID:     product.ID,
+		ProductName:   product.Name,
+		Quantity:      row.Quantity,
+		PreviousStock: product.CurrentStock.Value(),
+		NewStock:      product.CurrentStock.Value(),
+	}
+	if tenant.RequiresApproval(row.Quantity) {
+		result.NeedsApproval = true
+		return result, nil
+	}
+	quantity, err := domain.NewStockQuantity(row.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.AddStock(quantity, tenant.MaxStock); err != nil {
+		return nil, err
+	}
+	result.NewStock = product.CurrentStock.Value()
+	return result, nil
+}
+
+func (uc *importStockUseCase) Start(ctx context.Context, req ImportStockRequest) (*ImportJobResponse, error) {
+	// 1. Read the file and check the tenant before queueing
+	parsed, err := uc.parse(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.loadTenant(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	// 2. A file already imported (or being imported) is not queued again.
+	// Its rows may have been partly applied when the job failed, so it is
+	// only resumed on request, never started over.
+	existing, err := uc.uow.ImportJobs().FindByHash(ctx, req.TenantID, parsed.hash)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if req.Resume && existing.Status == domain.ImportStatusFailed {
+			existing.Requeue()
+			if err := uc.uow.ImportJobs().SaveProgress(ctx, existing); err != nil {
+				return nil, err
+			}
+			return toImportJobResponse(existing, false), nil
+		}
+		return toImportJobResponse(existing, true), nil
+	}
+
+	// 3. Queue the job; a concurrent upload of the same file loses here
+	job := domain.NewImportJob(req.TenantID, req.FileName, parsed.hash, parsed.format, parsed.mapping, parsed.rows, parsed.rowErrors, req.RequestedBy)
+	if err := uc.uow.ImportJobs().Create(ctx, job); err != nil {
+		if err != domain.ErrDuplicateImport {
+			return nil, err
+		}
+		existing, err := uc.uow.ImportJobs().FindByHash(ctx, req.TenantID, parsed.hash)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, domain.ErrDuplicateImport
+		}
+		return toImportJobResponse(existing, true), nil
+	}
+	return toImportJobResponse(job, false), nil
+}
+
+func (uc *importStockUseCase) Get(ctx context.Context, tenantID, jobID string) (*ImportJobResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	job, err := uc.uow.ImportJobs().FindByID(ctx, tenantID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return toImportJobResponse(job, false), nil
+}
+
+func (uc *importStockUseCase) ErrorReport(ctx context.Context, tenantID, jobID string) ([]domain.ImportRowError, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	job, err := uc.uow.ImportJobs().FindByID(ctx, tenantID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	errs := append([]domain.ImportRowError(nil), job.Errors...)
+	sortRowErrors(errs)
+	return errs, nil
+}
+
+func (uc *importStockUseCase) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := uc.uow.ImportJobs().ClaimPending(ctx, time.Now())
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	// Apply the rows in best-effort batches so one bad row does not stop
+	// the file, saving progress after each batch. A resumed job starts
+	// after the rows it already handled.
+	for job.NextRow < len(job.Rows) {
+		end := job.NextRow + uc.chunkSize
+		if end > len(job.Rows) {
+			end = len(job.Rows)
+		}
+		chunk := job.Rows[job.NextRow:end]
+
+		items := make([]AddStockRequest, 0, len(chunk))
+		for _, row := range chunk {
+			notes := row.Notes
+			if notes == "" {
+				notes = "Imported from " + job.FileName
+			}
+			items = append(items, AddStockRequest{
+				ProductID: row.ProductID,
+				Quantity:  row.Quantity,
+				TenantID:  job.TenantID,
+				Notes:     notes,
+				AddedBy:   job.CreatedBy,
+			})
+		}
+		result, err := uc.batch.Execute(ctx, AddStockBatchRequest{Items: items, Mode: BatchModeBestEffort})
+		if err != nil {
+			job.Fail(err.Error(), time.Now())
+			if saveErr := uc.uow.ImportJobs().SaveProgress(ctx, job); saveErr != nil {
+				return true, saveErr
+			}
+			return true, err
+		}
+		for i, item := range result.Items {
+			if item.Err == nil && item.Response != nil && item.Response.PendingApproval != nil {
+				job.RecordHeldRow(chunk[i], item.Response.PendingApproval.RequestID)
+				continue
+			}
+			job.RecordRow(chunk[i], item.Err)
+		}
+		job.Renew(time.Now())
+		if err := uc.uow.ImportJobs().SaveProgress(ctx, job); err != nil {
+			return true, err
+		}
+	}
+
+	job.Complete(time.Now())
+	return true, uc.uow.ImportJobs().SaveProgress(ctx, job)
+}
+
+func toImportJobResponse(job *domain.ImportJob, duplicate bool) *ImportJobResponse {
+	return &ImportJobResponse{
+		ID:                  job.ID,
+		TenantID:            job.TenantID,
+		FileName:            job.FileName,
+		FileHash:            job.FileHash,
+		Format:              job.Format,
+		Status:              job.Status,
+		TotalRows:           job.TotalRows,
+		ProcessedRows:       job.ProcessedRows,
+		AppliedRows:         job.AppliedRows,
+		PendingApprovalRows: job.PendingApprovalRows,
+		FailedRows:          job.FailedRows(),
+		Progress:            job.Progress(),
+		Failure:             job.Failure,
+		CreatedBy:           job.CreatedBy,
+		CreatedAt:           job.CreatedAt,
+		StartedAt:           job.StartedAt,
+		FinishedAt:          job.FinishedAt,
+		Duplicate:           duplicate,
+	}
+}
+
+func sortRowErrors(errs []domain.ImportRowError) {
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+}
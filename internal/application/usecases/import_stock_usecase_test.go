@@ -0,0 +1,301 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+// csvLines is a SpreadsheetReader stub that splits CSV-like text on
+// newlines and commas, without quoting.
+type csvLines struct{}
+
+func (csvLines) Read(format string, data []byte) ([][]string, error) {
+	var sheet [][]string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		sheet = append(sheet, strings.Split(line, ","))
+	}
+	return sheet, nil
+}
+
+func importFixture() (*mocks.MockUnitOfWork, ImportStockUseCase) {
+	uow, _ := batchFixture()
+	uow.ImportsRepo = &mocks.MockImportJobRepo{}
+	return uow, NewImportStockUseCase(uow, csvLines{}, nil)
+}
+
+const packingList = `product_id,quantity,notes
+p1,5,carton 1
+p2,20,
+missing,3,
+p1,abc,
+p1,4,carton 2`
+
+func TestImportStockUseCase_Preview(t *testing.T) {
+	uow, uc := importFixture()
+
+	got, err := uc.Preview(context.Background(), ImportStockRequest{
+		TenantID: "t1", FileName: "list.csv", Data: []byte(packingList),
+	})
+	if err != nil {
+		t.Fatalf("Preview() err = %v", err)
+	}
+	if got.TotalRows != 5 || got.ValidRows != 2 || len(got.Errors) != 3 {
+		t.Fatalf("preview = %+v", got)
+	}
+	// Errors come by line: 90 + 20 exceeds the limit, the product is
+	// unknown and the quantity is not a number
+	wantLines := []int{3, 4, 5}
+	for i, line := range wantLines {
+		if got.Errors[i].Line != line {
+			t.Errorf("error %d line = %d, want %d (%+v)", i, got.Errors[i].Line, line, got.Errors[i])
+		}
+	}
+	// Rows for the same product add up
+	if last := got.Rows[1]; last.Line != 6 || last.PreviousStock != 15 || last.NewStock != 19 {
+		t.Errorf("last row = %+v, want 15 -> 19", last)
+	}
+	if uow.ProductsRepo.FindByIDsCalls != 1 || uow.TxCalls != 0 {
+		t.Errorf("FindByIDs calls = %d, TxCalls = %d; a preview reads once and writes nothing", uow.ProductsRepo.FindByIDsCalls, uow.TxCalls)
+	}
+}
+
+func TestImportStockUseCase_Preview_OtherTenantsProduct(t *testing.T) {
+	uow, uc := importFixture()
+	uow.ProductsRepo.Products[1].TenantID = "t2"
+
+	got, err := uc.Preview(context.Background(), ImportStockRequest{
+		TenantID: "t1", FileName: "list.csv", Data: []byte("product_id,quantity\np2,1"),
+	})
+	if err != nil {
+		t.Fatalf("Preview() err = %v", err)
+	}
+	if got.ValidRows != 0 || len(got.Errors) != 1 || got.Errors[0].Message != domain.ErrProductNotFound.Error() {
+		t.Errorf("preview = %+v, want the row rejected as not found", got)
+	}
+}
+
+func TestImportStockUseCase_Preview_ColumnMapping(t *testing.T) {
+	_, uc := importFixture()
+	data := []byte("SKU,Qty\np1,2")
+
+	if _, err := uc.Preview(context.Background(), ImportStockRequest{TenantID: "t1", FileName: "list.csv", Data: data}); !errors.As(err, &domain.ErrInvalidImportFile{}) {
+		t.Errorf("unmapped err = %v, want ErrInvalidImportFile", err)
+	}
+
+	got, err := uc.Preview(context.Background(), ImportStockRequest{
+		TenantID: "t1", FileName: "list.csv", Data: data,
+		Mapping: domain.ImportColumnMapping{ProductID: "sku", Quantity: "QTY"},
+	})
+	if err != nil {
+		t.Fatalf("Preview() err = %v", err)
+	}
+	if got.ValidRows != 1 || got.Rows[0].NewStock != 12 {
+		t.Errorf("preview = %+v", got)
+	}
+}
+
+func TestImportStockUseCase_StartAndProcess(t *testing.T) {
+	uow, uc := importFixture()
+	uc.(*importStockUseCase).chunkSize = 2
+	req := ImportStockRequest{TenantID: "t1", FileName: "list.csv", Data: []byte(packingList), RequestedBy: "u1"}
+
+	job, err := uc.Start(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Start() err = %v", err)
+	}
+	if job.Status != domain.ImportStatusPending || job.TotalRows != 5 || job.ProcessedRows != 1 || job.Duplicate {
+		t.Errorf("queued job = %+v", job)
+	}
+	if len(uow.StockHistRepo.Events) != 0 {
+		t.Fatalf("Start() applied stock")
+	}
+
+	processed, err := uc.ProcessNext(context.Background())
+	if err != nil || !processed {
+		t.Fatalf("ProcessNext() = %v, %v", processed, err)
+	}
+	got, err := uc.Get(context.Background(), "t1", job.ID)
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if got.Status != domain.ImportStatusCompleted || got.AppliedRows != 2 || got.FailedRows != 3 || got.Progress != 100 {
+		t.Errorf("finished job = %+v", got)
+	}
+	// Progress is saved after each batch of two rows, then on completion
+	if saves := uow.ImportsRepo.SaveCalls; saves != 3 {
+		t.Errorf("SaveProgress calls = %d, want 3", saves)
+	}
+	events := uow.StockHistRepo.Events
+	if len(events) != 2 || events[0].Notes != "carton 1" || events[0].AddedBy != "u1" {
+		t.Errorf("history = %+v", events)
+	}
+
+	report, err := uc.ErrorReport(context.Background(), "t1", job.ID)
+	if err != nil {
+		t.Fatalf("ErrorReport() err = %v", err)
+	}
+	if len(report) != 3 || report[0].Line != 3 || report[1].Message != domain.ErrProductNotFound.Error() || report[2].Quantity != "abc" {
+		t.Errorf("report = %+v", report)
+	}
+
+	if processed, _ := uc.ProcessNext(context.Background()); processed {
+		t.Errorf("ProcessNext() found a second job")
+	}
+}
+
+func TestImportStockUseCase_ProcessNext_ReclaimsStaleJob(t *testing.T) {
+	uow, uc := importFixture()
+	job, err := uc.Start(context.Background(), ImportStockRequest{TenantID: "t1", FileName: "list.csv", Data: []byte(packingList)})
+	if err != nil {
+		t.Fatalf("Start() err = %v", err)
+	}
+
+	// A worker handled the first two rows (one applied, one over the
+	// limit) and stopped
+	startedAt := time.Now().Add(-time.Hour)
+	stored := uow.ImportsRepo.Jobs[0]
+	stored.Start(startedAt)
+	stored.RecordRow(stored.Rows[0], nil)
+	stored.RecordRow(stored.Rows[1], domain.ErrStockExceedsLimit{MaxAllowed: 100})
+
+	// Not while its lease holds
+	stored.Renew(time.Now())
+	if processed, _ := uc.ProcessNext(context.Background()); processed {
+		t.Fatalf("ProcessNext() claimed a job whose lease holds")
+	}
+	stored.LeaseUntil = time.Now().Add(-time.Minute)
+
+	processed, err := uc.ProcessNext(context.Background())
+	if err != nil || !processed {
+		t.Fatalf("ProcessNext() = %v, %v", processed, err)
+	}
+	got, _ := uc.Get(context.Background(), "t1", job.ID)
+	if got.Status != domain.ImportStatusCompleted || got.ProcessedRows != 5 || got.AppliedRows != 2 || got.FailedRows != 3 || !got.StartedAt.Equal(startedAt) {
+		t.Errorf("resumed job = %+v", got)
+	}
+	// Only the rows left were applied
+	if events := uow.StockHistRepo.Events; len(events) != 1 || events[0].Notes != "carton 2" {
+		t.Errorf("history = %+v, want only line 6", events)
+	}
+}
+
+func TestImportStockUseCase_Start_FailedFile(t *testing.T) {
+	uow, uc := importFixture()
+	req := ImportStockRequest{TenantID: "t1", FileName: "list.csv", Data: []byte(packingList)}
+	first, err := uc.Start(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Start() err = %v", err)
+	}
+	// The job failed after applying its first row
+	stored := uow.ImportsRepo.Jobs[0]
+	stored.Start(time.Now())
+	stored.RecordRow(stored.Rows[0], nil)
+	stored.Fail("database error", time.Now())
+
+	again, err := uc.Start(context.Background(), req)
+	if err != nil {
+		t.Fatalf("second Start() err = %v", err)
+	}
+	if !again.Duplicate || again.ID != first.ID || again.Status != domain.ImportStatusFailed || len(uow.ImportsRepo.Jobs) != 1 {
+		t.Errorf("upload of a failed file = %+v, want the failed job", again)
+	}
+
+	req.Resume = true
+	resumed, err := uc.Start(context.Background(), req)
+	if err != nil {
+		t.Fatalf("resuming Start() err = %v", err)
+	}
+	if resumed.Duplicate || resumed.ID != first.ID || resumed.Status != domain.ImportStatusPending || resumed.Failure != "" {
+		t.Errorf("resumed = %+v, want the job queued again", resumed)
+	}
+	if _, err := uc.ProcessNext(context.Background()); err != nil {
+		t.Fatalf("ProcessNext() err = %v", err)
+	}
+	got, _ := uc.Get(context.Background(), "t1", first.ID)
+	if got.Status != domain.ImportStatusCompleted || got.AppliedRows != 2 || got.ProcessedRows != 5 {
+		t.Errorf("finished job = %+v", got)
+	}
+	// Line 2 was not applied a second time
+	if events := uow.StockHistRepo.Events; len(events) != 1 || events[0].Notes != "carton 2" {
+		t.Errorf("history = %+v, want only line 6", events)
+	}
+}
+
+func TestImportStockUseCase_ProcessNext_HeldForApproval(t *testing.T) {
+	uow, uc := importFixture()
+	uow.TenantsRepo.Tenant.ApprovalThreshold = 4
+	job, err := uc.Start(context.Background(), ImportStockRequest{TenantID: "t1", FileName: "list.csv", Data: []byte(packingList)})
+	if err != nil {
+		t.Fatalf("Start() err = %v", err)
+	}
+	if _, err := uc.ProcessNext(context.Background()); err != nil {
+		t.Fatalf("ProcessNext() err = %v", err)
+	}
+
+	// Lines 2 and 3 exceed the threshold, line 6 is applied
+	got, _ := uc.Get(context.Background(), "t1", job.ID)
+	if got.AppliedRows != 1 || got.PendingApprovalRows != 2 || got.FailedRows != 2 || got.ProcessedRows != 5 {
+		t.Errorf("job = %+v, want 1 applied, 2 held, 2 failed", got)
+	}
+	if len(uow.ChangesRepo.Requests) != 2 {
+		t.Errorf("change requests = %d, want 2", len(uow.ChangesRepo.Requests))
+	}
+	report, err := uc.ErrorReport(context.Background(), "t1", job.ID)
+	if err != nil {
+		t.Fatalf("ErrorReport() err = %v", err)
+	}
+	if len(report) != 4 || !strings.HasPrefix(report[0].Message, "held for approval") || !strings.HasPrefix(report[1].Message, "held for approval") {
+		t.Errorf("report = %+v, want lines 2 and 3 held for approval", report)
+	}
+}
+
+func TestImportStockUseCase_Start_SameFileTwice(t *testing.T) {
+	uow, uc := importFixture()
+	req := ImportStockRequest{TenantID: "t1", FileName: "list.csv", Data: []byte(packingList)}
+
+	first, err := uc.Start(context.Background(), req)
+	if err != nil {
+		t.Fatalf("first Start() err = %v", err)
+	}
+	req.FileName = "renamed.csv"
+	second, err := uc.Start(context.Background(), req)
+	if err != nil {
+		t.Fatalf("second Start() err = %v", err)
+	}
+	if !second.Duplicate || second.ID != first.ID || len(uow.ImportsRepo.Jobs) != 1 {
+		t.Errorf("second = %+v, want the first job flagged duplicate", second)
+	}
+
+	preview, err := uc.Preview(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Preview() err = %v", err)
+	}
+	if preview.ExistingJobID != first.ID {
+		t.Errorf("ExistingJobID = %q, want %q", preview.ExistingJobID, first.ID)
+	}
+}
+
+func TestImportStockUseCase_Start_Rejections(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ImportStockRequest
+		want error
+	}{
+		{"no tenant", ImportStockRequest{FileName: "a.csv", Data: []byte(packingList)}, domain.ErrTenantNotFound},
+		{"unknown format", ImportStockRequest{TenantID: "t1", FileName: "a.ods", Data: []byte(packingList)}, domain.ErrUnsupportedImportFormat},
+		{"explicit format", ImportStockRequest{TenantID: "t1", FileName: "upload", Format: "pdf", Data: []byte(packingList)}, domain.ErrUnsupportedImportFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, uc := importFixture()
+			if _, err := uc.Start(context.Background(), tt.req); err != tt.want {
+				t.Errorf("Start() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
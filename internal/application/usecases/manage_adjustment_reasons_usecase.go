@@ -0,0 +1,93 @@
+// internal/application/usecases/manage_adjustment_reasons_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type SaveAdjustmentReasonRequest struct {
+	TenantID  string
+	Code      string
+	Label     string
+	Direction string
+	IsActive  bool
+	UpdatedBy string
+}
+
+// Use Case interface (what handlers depend on)
+type ManageAdjustmentReasonsUseCase interface {
+	// List returns the catalog in effect: built-in reasons merged with the
+	// tenant's overrides
+	List(ctx context.Context, tenantID string) ([]domain.AdjustmentReason, error)
+	Save(ctx context.Context, req SaveAdjustmentReasonRequest) (*domain.AdjustmentReason, error)
+	// Delete removes a tenant reason; for built-in codes this restores the default
+	Delete(ctx context.Context, tenantID, code string) error
+}
+
+// Implementation
+type manageAdjustmentReasonsUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewManageAdjustmentReasonsUseCase(uow interfaces.UnitOfWork) ManageAdjustmentReasonsUseCase {
+	return &manageAdjustmentReasonsUseCase{uow: uow}
+}
+
+func (uc *manageAdjustmentReasonsUseCase) List(ctx context.Context, tenantID string) ([]domain.AdjustmentReason, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	catalog, err := loadReasonCatalog(ctx, uc.uow, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Reasons(), nil
+}
+
+func (uc *manageAdjustmentReasonsUseCase) Save(ctx context.Context, req SaveAdjustmentReasonRequest) (*domain.AdjustmentReason, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	reason := &domain.AdjustmentReason{
+		TenantID:  req.TenantID,
+		Code:      req.Code,
+		Label:     req.Label,
+		Direction: req.Direction,
+		IsActive:  req.IsActive,
+		IsCustom:  true,
+		UpdatedBy: req.UpdatedBy,
+		UpdatedAt: time.Now(),
+	}
+	if reason.Label == "" {
+		reason.Label = reason.Code
+	}
+	if err := reason.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.uow.AdjustmentReasons().Save(ctx, reason); err != nil {
+		return nil, err
+	}
+	return reason, nil
+}
+
+func (uc *manageAdjustmentReasonsUseCase) Delete(ctx context.Context, tenantID, code string) error {
+	if tenantID == "" {
+		return domain.ErrTenantNotFound
+	}
+	return uc.uow.AdjustmentReasons().Delete(ctx, tenantID, code)
+}
+
+// Reasons in effect for a tenant
+func loadReasonCatalog(ctx context.Context, uow interfaces.UnitOfWork, tenantID string) (domain.ReasonCatalog, error) {
+	overrides, err := uow.AdjustmentReasons().FindByTenant(ctx, tenantID)
+	if err != nil {
+		return domain.ReasonCatalog{}, err
+	}
+	return domain.NewReasonCatalog(overrides), nil
+}
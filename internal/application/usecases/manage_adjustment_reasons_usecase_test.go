@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func TestManageAdjustmentReasonsUseCase_SaveOverridesAndAdds(t *testing.T) {
+	repo := &mocks.MockAdjustmentReasonRepo{}
+	uow := &mocks.MockUnitOfWork{
+		TenantsRepo: &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true}},
+		ReasonsRepo: repo,
+	}
+	uc := NewManageAdjustmentReasonsUseCase(uow)
+	ctx := context.Background()
+
+	if _, err := uc.Save(ctx, SaveAdjustmentReasonRequest{TenantID: "t1", Code: domain.ReasonTheft, Direction: domain.ReasonDirectionDecrease, UpdatedBy: "u1"}); err != nil {
+		t.Fatalf("Save(theft) err = %v", err)
+	}
+	saved, err := uc.Save(ctx, SaveAdjustmentReasonRequest{TenantID: "t1", Code: "expired", Direction: domain.ReasonDirectionDecrease, IsActive: true})
+	if err != nil {
+		t.Fatalf("Save(expired) err = %v", err)
+	}
+	if saved.Label != "expired" {
+		t.Errorf("Label = %q, want code as default", saved.Label)
+	}
+
+	reasons, err := uc.List(ctx, "t1")
+	if err != nil {
+		t.Fatalf("List() err = %v", err)
+	}
+	byCode := map[string]domain.AdjustmentReason{}
+	for _, r := range reasons {
+		byCode[r.Code] = r
+	}
+	if len(reasons) != 6 {
+		t.Errorf("reasons = %d, want 5 defaults plus expired", len(reasons))
+	}
+	if theft := byCode[domain.ReasonTheft]; theft.IsActive || !theft.IsCustom {
+		t.Errorf("theft = %+v, want inactive override", theft)
+	}
+	if damage := byCode[domain.ReasonDamage]; !damage.IsActive || damage.IsCustom {
+		t.Errorf("damage = %+v, want active default", damage)
+	}
+
+	if err := uc.Delete(ctx, "t1", domain.ReasonTheft); err != nil {
+		t.Fatalf("Delete() err = %v", err)
+	}
+	if err := uc.Delete(ctx, "t1", domain.ReasonTheft); !errors.Is(err, domain.ErrAdjustmentReasonNotFound) {
+		t.Errorf("Delete(again) err = %v, want %v", err, domain.ErrAdjustmentReasonNotFound)
+	}
+}
+
+func TestManageAdjustmentReasonsUseCase_Save_Invalid(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{
+		TenantsRepo: &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true}},
+		ReasonsRepo: &mocks.MockAdjustmentReasonRepo{},
+	}
+	uc := NewManageAdjustmentReasonsUseCase(uow)
+
+	tests := []struct {
+		req  SaveAdjustmentReasonRequest
+		want error
+	}{
+		{SaveAdjustmentReasonRequest{TenantID: "t1", Code: "Bad Code", Direction: domain.ReasonDirectionAny}, domain.ErrInvalidReasonCode},
+		{SaveAdjustmentReasonRequest{TenantID: "t1", Code: "spoiled", Direction: "sideways"}, domain.ErrInvalidReasonDirection},
+		{SaveAdjustmentReasonRequest{Code: "spoiled", Direction: domain.ReasonDirectionAny}, domain.ErrTenantNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := uc.Save(context.Background(), tt.req); !errors.Is(err, tt.want) {
+			t.Errorf("Save(%+v) err = %v, want %v", tt.req, err, tt.want)
+		}
+	}
+}
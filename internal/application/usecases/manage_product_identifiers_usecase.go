@@ -0,0 +1,201 @@
+// internal/application/usecases/manage_product_identifiers_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Input DTOs
+type SetProductIdentifiersRequest struct {
+	TenantID  string
+	ProductID string
+	// Empty clears them
+	SKU     string
+	Barcode string
+	// Product to group this one under as a variant; empty makes it stand
+	// alone
+	ParentID       string
+	VariantOptions map[string]string
+}
+
+// Output DTOs
+type ProductIdentifiersResponse struct {
+	ProductID      string
+	ProductName    string
+	SKU            string
+	Barcode        string
+	ParentID       string
+	VariantOptions map[string]string
+	// Products grouped under this one
+	Variants []ProductVariant
+}
+
+type ProductVariant struct {
+	ProductID    string
+	ProductName  string
+	SKU          string
+	Barcode      string
+	Options      map[string]string
+	CurrentStock domain.StockQuantity
+}
+
+// Use Case interface (what handlers depend on)
+type ManageProductIdentifiersUseCase interface {
+	Get(ctx context.Context, tenantID, productID string) (*ProductIdentifiersResponse, error)
+	// Lookup finds the tenant's product by SKU or barcode, as scanners
+	// send them
+	Lookup(ctx context.Context, tenantID, sku, barcode string) (*ProductIdentifiersResponse, error)
+	// Set replaces the product's SKU, barcode and parent. SKUs and
+	// barcodes are unique within the tenant.
+	Set(ctx context.Context, req SetProductIdentifiersRequest) (*ProductIdentifiersResponse, error)
+}
+
+// Implementation
+type manageProductIdentifiersUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewManageProductIdentifiersUseCase(uow interfaces.UnitOfWork) ManageProductIdentifiersUseCase {
+	return &manageProductIdentifiersUseCase{uow: uow}
+}
+
+func (uc *manageProductIdentifiersUseCase) Get(ctx context.Context, tenantID, productID string) (*ProductIdentifiersResponse, error) {
+	product, err := uc.load(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(ctx, product)
+}
+
+func (uc *manageProductIdentifiersUseCase) Lookup(ctx context.Context, tenantID, sku, barcode string) (*ProductIdentifiersResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	id, err := domain.NewProductIdentifier(sku, barcode)
+	if err != nil {
+		return nil, err
+	}
+	product, err := uc.uow.Products().FindByIdentifier(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(ctx, product)
+}
+
+func (uc *manageProductIdentifiersUseCase) Set(ctx context.Context, req SetProductIdentifiersRequest) (*ProductIdentifiersResponse, error) {
+	product, err := uc.load(ctx, req.TenantID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.SetIdentifiers(req.SKU, req.Barcode); err != nil {
+		return nil, err
+	}
+	// The unique indexes settle races; this answers the common case
+	// before anything is written
+	if err := uc.checkUnique(ctx, product); err != nil {
+		return nil, err
+	}
+
+	var parent *domain.Product
+	if req.ParentID != "" {
+		parent, err = uc.load(ctx, req.TenantID, req.ParentID)
+		if err == domain.ErrProductNotFound || err == domain.ErrInvalidProductID {
+			return nil, domain.ErrInvalidVariant
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	variants, err := uc.uow.Products().FindVariants(ctx, product.TenantID, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.SetParent(parent, len(variants) > 0, req.VariantOptions); err != nil {
+		return nil, err
+	}
+
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.uow.Products().SetIdentifiers(ctx, product.ID, product.SKU, product.Barcode); err != nil {
+			return err
+		}
+		return uc.uow.Products().SetParent(ctx, product.ID, product.ParentID, product.VariantOptions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProductIdentifiersResponse(product, variants), nil
+}
+
+func (uc *manageProductIdentifiersUseCase) checkUnique(ctx context.Context, product *domain.Product) error {
+	for _, id := range []domain.ProductIdentifier{{SKU: product.SKU}, {Barcode: product.Barcode}} {
+		if id.SKU == "" && id.Barcode == "" {
+			continue
+		}
+		other, err := uc.uow.Products().FindByIdentifier(ctx, product.TenantID, id)
+		if err == domain.ErrProductNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if other.ID != product.ID {
+			return domain.ErrDuplicateProductIdentifier
+		}
+	}
+	return nil
+}
+
+func (uc *manageProductIdentifiersUseCase) load(ctx context.Context, tenantID, productID string) (*domain.Product, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if productID == "" {
+		return nil, domain.ErrInvalidProductID
+	}
+	product, err := uc.uow.Products().FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != tenantID {
+		return nil, domain.ErrProductNotFound
+	}
+	return product, nil
+}
+
+func (uc *manageProductIdentifiersUseCase) respond(ctx context.Context, product *domain.Product) (*ProductIdentifiersResponse, error) {
+	// Variants have no variants of their own
+	var variants []*domain.Product
+	if !product.IsVariant() {
+		var err error
+		variants, err = uc.uow.Products().FindVariants(ctx, product.TenantID, product.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return toProductIdentifiersResponse(product, variants), nil
+}
+
+func toProductIdentifiersResponse(product *domain.Product, variants []*domain.Product) *ProductIdentifiersResponse {
+	response := &ProductIdentifiersResponse{
+		ProductID:      product.ID,
+		ProductName:    product.Name,
+		SKU:            product.SKU,
+		Barcode:        product.Barcode,
+		ParentID:       product.ParentID,
+		VariantOptions: product.VariantOptions,
+		Variants:       make([]ProductVariant, 0, len(variants)),
+	}
+	for _, v := range variants {
+		response.Variants = append(response.Variants, ProductVariant{
+			ProductID:    v.ID,
+			ProductName:  v.Name,
+			SKU:          v.SKU,
+			Barcode:      v.Barcode,
+			Options:      v.VariantOptions,
+			CurrentStock: v.CurrentStock,
+		})
+	}
+	return response
+}
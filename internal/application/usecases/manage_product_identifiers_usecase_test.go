@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"testing"
+)
+
+// p1 is the parent, p2 another product of the tenant
+func identifiersFixture() (ManageProductIdentifiersUseCase, []*domain.Product) {
+	uow, products := widgetFixture(10)
+	products.Products = append(products.Products,
+		&domain.Product{ID: "p2", Name: "Widget, large", TenantID: "t1", CurrentStock: mustQuantity(4)},
+		&domain.Product{ID: "p3", Name: "Other tenant's", TenantID: "t2", SKU: "WID-001"},
+	)
+	return NewManageProductIdentifiersUseCase(uow), products.Products
+}
+
+func TestManageProductIdentifiersUseCase_Set(t *testing.T) {
+	uc, products := identifiersFixture()
+	ctx := context.Background()
+
+	// SKUs are unique per tenant, so another tenant's does not clash
+	got, err := uc.Set(ctx, SetProductIdentifiersRequest{
+		TenantID: "t1", ProductID: "p1", SKU: " wid-001 ", Barcode: "4006381333931",
+	})
+	if err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	if got.SKU != "WID-001" || got.Barcode != "4006381333931" || products[0].SKU != "WID-001" {
+		t.Errorf("response = %+v", got)
+	}
+
+	// Stored as GTIN-13, so the EAN-13 form of p2's UPC-A clashes with it
+	products[1].Barcode = "0036000291452"
+	_, err = uc.Set(ctx, SetProductIdentifiersRequest{TenantID: "t1", ProductID: "p1", Barcode: "036000291452"})
+	if !errors.Is(err, domain.ErrDuplicateProductIdentifier) {
+		t.Fatalf("Set() err = %v, want %v", err, domain.ErrDuplicateProductIdentifier)
+	}
+	products[1].Barcode = ""
+
+	// EAN-8 is padded to GTIN-13
+	got, err = uc.Set(ctx, SetProductIdentifiersRequest{TenantID: "t1", ProductID: "p1", SKU: "WID-001", Barcode: "96385074"})
+	if err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	if got.Barcode != "0000096385074" {
+		t.Errorf("response = %+v", got)
+	}
+
+	got, err = uc.Set(ctx, SetProductIdentifiersRequest{
+		TenantID: "t1", ProductID: "p2", SKU: "WID-001-L", Barcode: "4006381333948",
+		ParentID: "p1", VariantOptions: map[string]string{" Size ": "L"},
+	})
+	if err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	if got.ParentID != "p1" || got.VariantOptions["size"] != "L" || products[1].ParentID != "p1" {
+		t.Errorf("response = %+v", got)
+	}
+
+	parent, err := uc.Get(ctx, "t1", "p1")
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if len(parent.Variants) != 1 || parent.Variants[0].ProductID != "p2" || parent.Variants[0].CurrentStock.Value() != 4 {
+		t.Errorf("variants = %+v", parent.Variants)
+	}
+}
+
+func TestManageProductIdentifiersUseCase_Set_Rejections(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SetProductIdentifiersRequest
+		want error
+	}{
+		{"bad check digit", SetProductIdentifiersRequest{Barcode: "4006381333932"}, domain.ErrInvalidBarcode},
+		{"barcode of unknown length", SetProductIdentifiersRequest{Barcode: "12345"}, domain.ErrInvalidBarcode},
+		{"sku with spaces", SetProductIdentifiersRequest{SKU: "WID 001"}, domain.ErrInvalidSKU},
+		{"sku of another product", SetProductIdentifiersRequest{SKU: "wid-002"}, domain.ErrDuplicateProductIdentifier},
+		{"own parent", SetProductIdentifiersRequest{ParentID: "p1"}, domain.ErrInvalidVariant},
+		{"parent of another tenant", SetProductIdentifiersRequest{ParentID: "p3"}, domain.ErrInvalidVariant},
+		{"options without parent", SetProductIdentifiersRequest{VariantOptions: map[string]string{"size": "L"}}, domain.ErrInvalidVariant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, products := identifiersFixture()
+			products[1].SKU = "WID-002"
+			tt.req.TenantID, tt.req.ProductID = "t1", "p1"
+
+			_, err := uc.Set(context.Background(), tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Set() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestManageProductIdentifiersUseCase_Set_VariantsAreOneLevel(t *testing.T) {
+	uc, products := identifiersFixture()
+	products[1].ParentID = "p1"
+	ctx := context.Background()
+
+	// p1 has a variant, so it cannot become one
+	_, err := uc.Set(ctx, SetProductIdentifiersRequest{TenantID: "t1", ProductID: "p1", ParentID: "p2"})
+	if !errors.Is(err, domain.ErrInvalidVariant) {
+		t.Errorf("Set() err = %v, want %v", err, domain.ErrInvalidVariant)
+	}
+	products[2].TenantID, products[2].SKU = "t1", ""
+	// p2 is a variant, so nothing can be grouped under it
+	_, err = uc.Set(ctx, SetProductIdentifiersRequest{TenantID: "t1", ProductID: "p3", ParentID: "p2"})
+	if !errors.Is(err, domain.ErrInvalidVariant) {
+		t.Errorf("Set() err = %v, want %v", err, domain.ErrInvalidVariant)
+	}
+}
+
+func TestManageProductIdentifiersUseCase_Lookup(t *testing.T) {
+	uc, products := identifiersFixture()
+	products[0].SKU, products[0].Barcode = "WID-001", "0036000291452"
+
+	tests := []struct {
+		name      string
+		tenant    string
+		sku, code string
+		wantID    string
+		wantErr   error
+	}{
+		{"by sku, any case", "t1", "wid-001", "", "p1", nil},
+		{"by barcode", "t1", "", "0036000291452", "p1", nil},
+		// The UPC-A and GTIN-14 forms of the same GTIN
+		{"by upc-a", "t1", "", "036000291452", "p1", nil},
+		{"by gtin-14", "t1", "", "00036000291452", "p1", nil},
+		{"sku of another tenant", "t2", "WID-001", "", "p3", nil},
+		{"unknown barcode", "t1", "", "4006381333931", "", domain.ErrProductNotFound},
+		{"both given", "t1", "WID-001", "036000291452", "", domain.ErrInvalidProductIdentifier},
+		{"invalid barcode", "t1", "", "96385075", "", domain.ErrInvalidBarcode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Lookup(context.Background(), tt.tenant, tt.sku, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.ProductID != tt.wantID {
+				t.Errorf("Lookup() = %s, want %s", got.ProductID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// internal/application/usecases/manage_product_units_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Input DTO
+type SetProductUnitsRequest struct {
+	TenantID  string
+	ProductID string
+	// Unit stock is kept in, "each" when empty
+	BaseUnit string
+	Units    []domain.UnitConversion
+	// domain.QuantityModeInteger or QuantityModeDecimal; empty keeps the
+	// product's mode. Precision is for decimal mode, 3 places when zero.
+	QuantityMode      string
+	QuantityPrecision int
+}
+
+// Output DTOs
+type ProductUnitsResponse struct {
+	ProductID   string
+	ProductName string
+	BaseUnit    string
+	Units       []ProductUnit
+	// How stock is counted, with the decimal places it keeps
+	QuantityMode      string
+	QuantityPrecision int
+}
+
+type ProductUnit struct {
+	domain.UnitConversion
+	// How many base units one unit holds; zero when it holds a fraction
+	BaseQuantity int
+}
+
+// Use Case interface (what handlers depend on)
+type ManageProductUnitsUseCase interface {
+	Get(ctx context.Context, tenantID, productID string) (*ProductUnitsResponse, error)
+	// Set replaces the product's units; stock stays as it is, counted in
+	// the new base unit. Switching the quantity mode fails when stock does
+	// not fit the new precision.
+	Set(ctx context.Context, req SetProductUnitsRequest) (*ProductUnitsResponse, error)
+}
+
+// Implementation
+type manageProductUnitsUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewManageProductUnitsUseCase(uow interfaces.UnitOfWork) ManageProductUnitsUseCase {
+	return &manageProductUnitsUseCase{uow: uow}
+}
+
+func (uc *manageProductUnitsUseCase) Get(ctx context.Context, tenantID, productID string) (*ProductUnitsResponse, error) {
+	product, err := uc.load(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+	return toProductUnitsResponse(product), nil
+}
+
+func (uc *manageProductUnitsUseCase) Set(ctx context.Context, req SetProductUnitsRequest) (*ProductUnitsResponse, error) {
+	product, err := uc.load(ctx, req.TenantID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := product.SetUnits(req.BaseUnit, req.Units); err != nil {
+		return nil, err
+	}
+	if err := product.SetQuantityMode(req.QuantityMode, req.QuantityPrecision); err != nil {
+		return nil, err
+	}
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.uow.Products().SetUnits(ctx, product.ID, product.BaseUnit, product.Units); err != nil {
+			return err
+		}
+		return uc.uow.Products().SetQuantityMode(ctx, product.ID, product.QuantityModeName(), product.Precision(), product.CurrentStock)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProductUnitsResponse(product), nil
+}
+
+func (uc *manageProductUnitsUseCase) load(ctx context.Context, tenantID, productID string) (*domain.Product, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if productID == "" {
+		return nil, domain.ErrInvalidProductID
+	}
+	product, err := uc.uow.Products().FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != tenantID {
+		return nil, domain.ErrProductNotFound
+	}
+	return product, nil
+}
+
+func toProductUnitsResponse(product *domain.Product) *ProductUnitsResponse {
+	units := make([]ProductUnit, 0, len(product.Units))
+	for _, u := range product.Units {
+		unit := ProductUnit{UnitConversion: u}
+		if base, err := product.ToBaseQuantity(domain.DecimalFromInt(1), u.Unit); err == nil {
+			unit.BaseQuantity = base.Value()
+		}
+		units = append(units, unit)
+	}
+	return &ProductUnitsResponse{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		BaseUnit:    product.BaseUnitName(),
+		Units:       units,
+
+		QuantityMode:      product.QuantityModeName(),
+		QuantityPrecision: product.Precision(),
+	}
+}
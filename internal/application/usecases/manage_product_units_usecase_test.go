@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"testing"
+)
+
+func TestManageProductUnitsUseCase_Set(t *testing.T) {
+	uow, products := widgetFixture(10)
+	uc := NewManageProductUnitsUseCase(uow)
+
+	got, err := uc.Set(context.Background(), SetProductUnitsRequest{
+		TenantID: "t1", ProductID: "p1",
+		Units: []domain.UnitConversion{
+			{Unit: "pallet", Quantity: 40, Of: "case"},
+			{Unit: "case", Quantity: 12, Of: "each"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	if got.BaseUnit != "each" || len(got.Units) != 2 || got.Units[0].BaseQuantity != 480 || got.Units[1].BaseQuantity != 12 {
+		t.Errorf("response = %+v", got)
+	}
+	if p := products.Products[0]; p.BaseUnit != "each" || len(p.Units) != 2 {
+		t.Errorf("stored units = %q %+v", p.BaseUnit, p.Units)
+	}
+
+	// A unit smaller than the base has no whole base quantity
+	got, err = uc.Set(context.Background(), SetProductUnitsRequest{
+		TenantID: "t1", ProductID: "p1", BaseUnit: "case",
+		Units: []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "each"}},
+	})
+	if err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	if got.BaseUnit != "case" || got.Units[0].BaseQuantity != 1 {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+func TestManageProductUnitsUseCase_Set_Rejections(t *testing.T) {
+	tests := []struct {
+		name   string
+		tenant string
+		units  []domain.UnitConversion
+		want   error
+	}{
+		{"other tenant's product", "t2", nil, domain.ErrProductNotFound},
+		{"zero quantity", "t1", []domain.UnitConversion{{Unit: "case", Quantity: 0, Of: "each"}}, domain.ErrInvalidUnitConversion},
+		{"unit defined twice", "t1", []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "each"}, {Unit: "Case", Quantity: 6, Of: "each"}}, domain.ErrInvalidUnitConversion},
+		{"not linked to the base unit", "t1", []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "box"}}, domain.ErrInvalidUnitConversion},
+		{"contradicting cycle", "t1", []domain.UnitConversion{
+			{Unit: "case", Quantity: 12, Of: "each"},
+			{Unit: "each", Quantity: 1, Of: "case"},
+		}, domain.ErrInvalidUnitConversion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := widgetFixture(10)
+
+			_, err := NewManageProductUnitsUseCase(uow).Set(context.Background(), SetProductUnitsRequest{
+				TenantID: tt.tenant, ProductID: "p1", Units: tt.units,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Set() err = %v, want %v", err, tt.want)
+			}
+			if products.Products[0].Units != nil {
+				t.Errorf("units stored for rejected change")
+			}
+		})
+	}
+}
+
+func TestManageProductUnitsUseCase_Set_QuantityMode(t *testing.T) {
+	uow, products := widgetFixture(10)
+	uc := NewManageProductUnitsUseCase(uow)
+
+	got, err := uc.Set(context.Background(), SetProductUnitsRequest{
+		TenantID: "t1", ProductID: "p1", BaseUnit: "kg", QuantityMode: domain.QuantityModeDecimal,
+	})
+	if err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	if got.QuantityMode != domain.QuantityModeDecimal || got.QuantityPrecision != domain.DefaultQuantityPrecision {
+		t.Errorf("response = %+v", got)
+	}
+	if stock := products.Products[0].CurrentStock.Decimal().String(); stock != "10.000" {
+		t.Errorf("stock = %s, want 10.000", stock)
+	}
+
+	// Stock with a fraction has no integer-mode equivalent
+	products.Products[0].CurrentStock, _ = domain.NewDecimalStockQuantity(*mustDecimal("10.500"))
+	_, err = uc.Set(context.Background(), SetProductUnitsRequest{
+		TenantID: "t1", ProductID: "p1", BaseUnit: "kg", QuantityMode: domain.QuantityModeInteger,
+	})
+	if !errors.Is(err, domain.ErrNonIntegralQuantity) {
+		t.Fatalf("Set() err = %v, want %v", err, domain.ErrNonIntegralQuantity)
+	}
+}
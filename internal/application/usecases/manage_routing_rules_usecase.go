@@ -0,0 +1,159 @@
+// internal/application/usecases/manage_routing_rules_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type CreateRoutingRuleRequest struct {
+	TenantID    string
+	Name        string
+	EventType   string
+	MinSeverity string
+	ProductTags []string
+	Channels    []string
+}
+
+type UpdateRoutingRuleRequest struct {
+	ID          string
+	TenantID    string
+	Name        string
+	EventType   string
+	MinSeverity string
+	ProductTags []string
+	Channels    []string
+	IsActive    bool
+}
+
+// Output DTO
+type RoutingRuleResponse struct {
+	ID          string
+	TenantID    string
+	Name        string
+	EventType   string
+	MinSeverity string
+	ProductTags []string
+	Channels    []string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Use Case interface (what handlers depend on)
+type ManageRoutingRulesUseCase interface {
+	Create(ctx context.Context, req CreateRoutingRuleRequest) (*RoutingRuleResponse, error)
+	List(ctx context.Context, tenantID string) ([]RoutingRuleResponse, error)
+	Update(ctx context.Context, req UpdateRoutingRuleRequest) (*RoutingRuleResponse, error)
+	Delete(ctx context.Context, tenantID, ruleID string) error
+}
+
+// Implementation
+type manageRoutingRulesUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewManageRoutingRulesUseCase(uow interfaces.UnitOfWork) ManageRoutingRulesUseCase {
+	return &manageRoutingRulesUseCase{
+		uow: uow,
+	}
+}
+
+func (uc *manageRoutingRulesUseCase) Create(ctx context.Context, req CreateRoutingRuleRequest) (*RoutingRuleResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if _, err := uc.uow.Tenants().FindByID(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule := &domain.RoutingRule{
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		EventType:   req.EventType,
+		MinSeverity: domain.Severity(req.MinSeverity),
+		ProductTags: req.ProductTags,
+		Channels:    req.Channels,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.uow.RoutingRules().Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return toRoutingRuleResponse(rule), nil
+}
+
+func (uc *manageRoutingRulesUseCase) List(ctx context.Context, tenantID string) ([]RoutingRuleResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	rules, err := uc.uow.RoutingRules().FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]RoutingRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, *toRoutingRuleResponse(rule))
+	}
+	return responses, nil
+}
+
+func (uc *manageRoutingRulesUseCase) Update(ctx context.Context, req UpdateRoutingRuleRequest) (*RoutingRuleResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	rule, err := uc.uow.RoutingRules().FindByID(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Name = req.Name
+	rule.EventType = req.EventType
+	rule.MinSeverity = domain.Severity(req.MinSeverity)
+	rule.ProductTags = req.ProductTags
+	rule.Channels = req.Channels
+	rule.IsActive = req.IsActive
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := uc.uow.RoutingRules().Update(ctx, rule); err != nil {
+		return nil, err
+	}
+	return toRoutingRuleResponse(rule), nil
+}
+
+func (uc *manageRoutingRulesUseCase) Delete(ctx context.Context, tenantID, ruleID string) error {
+	if tenantID == "" {
+		return domain.ErrTenantNotFound
+	}
+	return uc.uow.RoutingRules().Delete(ctx, tenantID, ruleID)
+}
+
+func toRoutingRuleResponse(rule *domain.RoutingRule) *RoutingRuleResponse {
+	return &RoutingRuleResponse{
+		ID:          rule.ID,
+		TenantID:    rule.TenantID,
+		Name:        rule.Name,
+		EventType:   rule.EventType,
+		MinSeverity: string(rule.MinSeverity),
+		ProductTags: rule.ProductTags,
+		Channels:    rule.Channels,
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func newRoutingUoW(rules ...*domain.RoutingRule) *mocks.MockUnitOfWork {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true}
+	return &mocks.MockUnitOfWork{
+		TenantsRepo: &mocks.MockTenantRepo{Tenant: tenant},
+		RoutingRepo: &mocks.MockRoutingRuleRepo{Rules: rules},
+	}
+}
+
+func TestManageRoutingRulesUseCase_Create_Validation(t *testing.T) {
+	uc := NewManageRoutingRulesUseCase(newRoutingUoW())
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  CreateRoutingRuleRequest
+		want error
+	}{
+		{
+			name: "empty tenant id",
+			req:  CreateRoutingRuleRequest{Channels: []string{domain.ChannelSlack}},
+			want: domain.ErrTenantNotFound,
+		},
+		{
+			name: "no channels",
+			req:  CreateRoutingRuleRequest{TenantID: "t1"},
+			want: domain.ErrInvalidChannel,
+		},
+		{
+			name: "unknown channel",
+			req:  CreateRoutingRuleRequest{TenantID: "t1", Channels: []string{"pager"}},
+			want: domain.ErrInvalidChannel,
+		},
+		{
+			name: "unknown severity",
+			req:  CreateRoutingRuleRequest{TenantID: "t1", MinSeverity: "meh", Channels: []string{domain.ChannelSlack}},
+			want: domain.ErrInvalidSeverity,
+		},
+		{
+			name: "non-routable event type",
+			req:  CreateRoutingRuleRequest{TenantID: "t1", EventType: domain.EventTypeStockAdded, Channels: []string{domain.ChannelSlack}},
+			want: domain.ErrInvalidEventType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Create(ctx, tt.req)
+			if got != nil {
+				t.Fatalf("Create() expected nil response on validation error, got %+v", got)
+			}
+			if err == nil || !errors.Is(err, tt.want) {
+				t.Errorf("Create() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestManageRoutingRulesUseCase_Create_Success(t *testing.T) {
+	uow := newRoutingUoW()
+	uc := NewManageRoutingRulesUseCase(uow)
+
+	got, err := uc.Create(context.Background(), CreateRoutingRuleRequest{
+		TenantID:    "t1",
+		Name:        "fragile goods",
+		EventType:   domain.EventTypeStockLimitAlert,
+		MinSeverity: string(domain.SeverityWarning),
+		ProductTags: []string{"fragile"},
+		Channels:    []string{domain.ChannelEmail, domain.ChannelWebhook},
+	})
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	if got.ID == "" || !got.IsActive || len(got.Channels) != 2 {
+		t.Errorf("response = %+v", got)
+	}
+	if len(uow.RoutingRepo.Created) != 1 {
+		t.Errorf("Create calls = %d, want 1", len(uow.RoutingRepo.Created))
+	}
+}
+
+func TestManageRoutingRulesUseCase_Update_NotFound(t *testing.T) {
+	uc := NewManageRoutingRulesUseCase(newRoutingUoW())
+
+	_, err := uc.Update(context.Background(), UpdateRoutingRuleRequest{
+		ID: "missing", TenantID: "t1", Channels: []string{domain.ChannelLog},
+	})
+	if !errors.Is(err, domain.ErrRoutingRuleNotFound) {
+		t.Errorf("Update() err = %v, want %v", err, domain.ErrRoutingRuleNotFound)
+	}
+}
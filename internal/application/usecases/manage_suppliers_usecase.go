@@ -0,0 +1,255 @@
+// internal/application/usecases/manage_suppliers_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type CreateSupplierRequest struct {
+	TenantID    string
+	Name        string
+	ContactName string
+	Email       string
+	Phone       string
+	Notes       string
+	Products    []domain.SupplierProduct
+}
+
+type UpdateSupplierRequest struct {
+	ID          string
+	TenantID    string
+	Name        string
+	ContactName string
+	Email       string
+	Phone       string
+	Notes       string
+	IsActive    bool
+}
+
+type SetSupplierProductRequest struct {
+	TenantID   string
+	SupplierID string
+	Product    domain.SupplierProduct
+}
+
+// Output DTO
+type SupplierResponse struct {
+	ID          string
+	TenantID    string
+	Name        string
+	ContactName string
+	Email       string
+	Phone       string
+	Notes       string
+	IsActive    bool
+	Products    []domain.SupplierProduct
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Use Case interface (what handlers depend on)
+type ManageSuppliersUseCase interface {
+	Create(ctx context.Context, req CreateSupplierRequest) (*SupplierResponse, error)
+	Get(ctx context.Context, tenantID, supplierID string) (*SupplierResponse, error)
+	// List returns the tenant's suppliers, only those supplying productID
+	// when it is set
+	List(ctx context.Context, tenantID, productID string) ([]SupplierResponse, error)
+	Update(ctx context.Context, req UpdateSupplierRequest) (*SupplierResponse, error)
+	Delete(ctx context.Context, tenantID, supplierID string) error
+	// SetProduct links a product to the supplier, replacing any existing link
+	SetProduct(ctx context.Context, req SetSupplierProductRequest) (*SupplierResponse, error)
+	RemoveProduct(ctx context.Context, tenantID, supplierID, productID string) (*SupplierResponse, error)
+}
+
+// Implementation
+type manageSuppliersUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewManageSuppliersUseCase(uow interfaces.UnitOfWork) ManageSuppliersUseCase {
+	return &manageSuppliersUseCase{
+		uow: uow,
+	}
+}
+
+func (uc *manageSuppliersUseCase) Create(ctx context.Context, req CreateSupplierRequest) (*SupplierResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if _, err := uc.uow.Tenants().FindByID(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	supplier := &domain.Supplier{
+		TenantID:    req.TenantID,
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Notes:       req.Notes,
+		IsActive:    true,
+		Products:    req.Products,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := supplier.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.checkProducts(ctx, req.TenantID, supplier.Products); err != nil {
+		return nil, err
+	}
+
+	if err := uc.uow.Suppliers().Create(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return toSupplierResponse(supplier), nil
+}
+
+func (uc *manageSuppliersUseCase) Get(ctx context.Context, tenantID, supplierID string) (*SupplierResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	supplier, err := uc.uow.Suppliers().FindByID(ctx, tenantID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	return toSupplierResponse(supplier), nil
+}
+
+func (uc *manageSuppliersUseCase) List(ctx context.Context, tenantID, productID string) ([]SupplierResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	suppliers, err := uc.uow.Suppliers().FindByTenant(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]SupplierResponse, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		responses = append(responses, *toSupplierResponse(supplier))
+	}
+	return responses, nil
+}
+
+func (uc *manageSuppliersUseCase) Update(ctx context.Context, req UpdateSupplierRequest) (*SupplierResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	supplier, err := uc.uow.Suppliers().FindByID(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier.Name = req.Name
+	supplier.ContactName = req.ContactName
+	supplier.Email = req.Email
+	supplier.Phone = req.Phone
+	supplier.Notes = req.Notes
+	supplier.IsActive = req.IsActive
+	if err := supplier.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.save(ctx, supplier)
+}
+
+func (uc *manageSuppliersUseCase) Delete(ctx context.Context, tenantID, supplierID string) error {
+	if tenantID == "" {
+		return domain.ErrTenantNotFound
+	}
+	return uc.uow.Suppliers().Delete(ctx, tenantID, supplierID)
+}
+
+func (uc *manageSuppliersUseCase) SetProduct(ctx context.Context, req SetSupplierProductRequest) (*SupplierResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	supplier, err := uc.uow.Suppliers().FindByID(ctx, req.TenantID, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if err := supplier.SetProduct(req.Product); err != nil {
+		return nil, err
+	}
+	if err := uc.checkProducts(ctx, req.TenantID, []domain.SupplierProduct{req.Product}); err != nil {
+		return nil, err
+	}
+
+	return uc.save(ctx, supplier)
+}
+
+func (uc *manageSuppliersUseCase) RemoveProduct(ctx context.Context, tenantID, supplierID, productID string) (*SupplierResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	supplier, err := uc.uow.Suppliers().FindByID(ctx, tenantID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if err := supplier.RemoveProduct(productID); err != nil {
+		return nil, err
+	}
+
+	return uc.save(ctx, supplier)
+}
+
+func (uc *manageSuppliersUseCase) save(ctx context.Context, supplier *domain.Supplier) (*SupplierResponse, error) {
+	supplier.UpdatedAt = time.Now()
+	if err := uc.uow.Suppliers().Update(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return toSupplierResponse(supplier), nil
+}
+
+// checkProducts makes sure every linked product exists in the tenant
+func (uc *manageSuppliersUseCase) checkProducts(ctx context.Context, tenantID string, links []domain.SupplierProduct) error {
+	if len(links) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(links))
+	for _, l := range links {
+		ids = append(ids, l.ProductID)
+	}
+	found, err := uc.uow.Products().FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	owned := make(map[string]bool, len(found))
+	for _, p := range found {
+		if p.TenantID == tenantID {
+			owned[p.ID] = true
+		}
+	}
+	for _, id := range ids {
+		if !owned[id] {
+			return domain.ErrProductNotFound
+		}
+	}
+	return nil
+}
+
+func toSupplierResponse(s *domain.Supplier) *SupplierResponse {
+	return &SupplierResponse{
+		ID:          s.ID,
+		TenantID:    s.TenantID,
+		Name:        s.Name,
+		ContactName: s.ContactName,
+		Email:       s.Email,
+		Phone:       s.Phone,
+		Notes:       s.Notes,
+		IsActive:    s.IsActive,
+		Products:    s.Products,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func newSupplierUoW(suppliers ...*domain.Supplier) *mocks.MockUnitOfWork {
+	return &mocks.MockUnitOfWork{
+		ProductsRepo: &mocks.MockProductRepo{Products: []*domain.Product{
+			{ID: "p1", Name: "Widget", TenantID: "t1", CurrentStock: mustQuantity(10)},
+			{ID: "p2", Name: "Gadget", TenantID: "t1", CurrentStock: mustQuantity(10)},
+			{ID: "p9", Name: "Other", TenantID: "t2", CurrentStock: mustQuantity(1)},
+		}},
+		TenantsRepo:   &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", MaxStock: mustQuantity(100), IsActive: true}},
+		StockHistRepo: &mocks.MockStockHistoryRepo{},
+		OutboxRepo:    &mocks.MockOutboxRepo{},
+		ChangesRepo:   &mocks.MockStockChangeRequestRepo{},
+		OrdersRepo:    &mocks.MockPurchaseOrderRepo{},
+		SuppliersRepo: &mocks.MockSupplierRepo{Suppliers: suppliers},
+	}
+}
+
+func TestManageSuppliersUseCase_Create(t *testing.T) {
+	uc := NewManageSuppliersUseCase(newSupplierUoW())
+	ctx := context.Background()
+
+	got, err := uc.Create(ctx, CreateSupplierRequest{
+		TenantID: "t1", Name: "Acme",
+		Products: []domain.SupplierProduct{{ProductID: "p1", LeadTimeDays: 5, MinOrderQuantity: 10, PackSize: 6}},
+	})
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	if got.ID == "" || !got.IsActive || len(got.Products) != 1 || got.Products[0].LeadTimeDays != 5 {
+		t.Errorf("supplier = %+v", got)
+	}
+
+	tests := []struct {
+		name string
+		req  CreateSupplierRequest
+		want error
+	}{
+		{"empty tenant id", CreateSupplierRequest{Name: "Acme"}, domain.ErrTenantNotFound},
+		{"no name", CreateSupplierRequest{TenantID: "t1", Name: " "}, domain.ErrInvalidSupplier},
+		{"negative lead time", CreateSupplierRequest{TenantID: "t1", Name: "Acme", Products: []domain.SupplierProduct{{ProductID: "p1", LeadTimeDays: -1}}}, domain.ErrInvalidSupplierProduct},
+		{"duplicate product", CreateSupplierRequest{TenantID: "t1", Name: "Acme", Products: []domain.SupplierProduct{{ProductID: "p1"}, {ProductID: "p1"}}}, domain.ErrInvalidSupplierProduct},
+		{"other tenant's product", CreateSupplierRequest{TenantID: "t1", Name: "Acme", Products: []domain.SupplierProduct{{ProductID: "p9"}}}, domain.ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.Create(ctx, tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("Create() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestManageSuppliersUseCase_ProductLinks(t *testing.T) {
+	uow := newSupplierUoW(&domain.Supplier{ID: "s1", TenantID: "t1", Name: "Acme", IsActive: true,
+		Products: []domain.SupplierProduct{{ProductID: "p1", LeadTimeDays: 5}}})
+	uc := NewManageSuppliersUseCase(uow)
+	ctx := context.Background()
+
+	got, err := uc.SetProduct(ctx, SetSupplierProductRequest{TenantID: "t1", SupplierID: "s1",
+		Product: domain.SupplierProduct{ProductID: "p1", LeadTimeDays: 3, PackSize: 12}})
+	if err != nil {
+		t.Fatalf("SetProduct(replace) err = %v", err)
+	}
+	if len(got.Products) != 1 || got.Products[0].LeadTimeDays != 3 || got.Products[0].PackSize != 12 {
+		t.Errorf("products = %+v, want the p1 link replaced", got.Products)
+	}
+
+	if _, err := uc.SetProduct(ctx, SetSupplierProductRequest{TenantID: "t1", SupplierID: "s1",
+		Product: domain.SupplierProduct{ProductID: "p2"}}); err != nil {
+		t.Fatalf("SetProduct(add) err = %v", err)
+	}
+	list, err := uc.List(ctx, "t1", "p2")
+	if err != nil || len(list) != 1 {
+		t.Errorf("List(p2) = %d suppliers, err = %v", len(list), err)
+	}
+
+	if _, err := uc.SetProduct(ctx, SetSupplierProductRequest{TenantID: "t1", SupplierID: "s1",
+		Product: domain.SupplierProduct{ProductID: "p9"}}); !errors.Is(err, domain.ErrProductNotFound) {
+		t.Errorf("SetProduct(other tenant's product) err = %v, want %v", err, domain.ErrProductNotFound)
+	}
+
+	got, err = uc.RemoveProduct(ctx, "t1", "s1", "p1")
+	if err != nil || len(got.Products) != 1 || got.Products[0].ProductID != "p2" {
+		t.Errorf("RemoveProduct() = %+v, err = %v", got, err)
+	}
+	if _, err := uc.RemoveProduct(ctx, "t1", "s1", "p1"); !errors.Is(err, domain.ErrProductNotSupplied) {
+		t.Errorf("RemoveProduct(unlinked) err = %v, want %v", err, domain.ErrProductNotSupplied)
+	}
+}
+
+func TestManageSuppliersUseCase_UpdateAndDelete(t *testing.T) {
+	uow := newSupplierUoW(&domain.Supplier{ID: "s1", TenantID: "t1", Name: "Acme", IsActive: true})
+	uc := NewManageSuppliersUseCase(uow)
+	ctx := context.Background()
+
+	got, err := uc.Update(ctx, UpdateSupplierRequest{ID: "s1", TenantID: "t1", Name: "Acme Ltd", Email: "orders@acme.test"})
+	if err != nil {
+		t.Fatalf("Update() err = %v", err)
+	}
+	if got.Name != "Acme Ltd" || got.IsActive || got.Email != "orders@acme.test" {
+		t.Errorf("supplier = %+v", got)
+	}
+	if _, err := uc.Get(ctx, "t2", "s1"); !errors.Is(err, domain.ErrSupplierNotFound) {
+		t.Errorf("Get(other tenant) err = %v, want %v", err, domain.ErrSupplierNotFound)
+	}
+
+	if err := uc.Delete(ctx, "t1", "s1"); err != nil {
+		t.Fatalf("Delete() err = %v", err)
+	}
+	if _, err := uc.Get(ctx, "t1", "s1"); !errors.Is(err, domain.ErrSupplierNotFound) {
+		t.Errorf("Get(deleted) err = %v, want %v", err, domain.ErrSupplierNotFound)
+	}
+}
+
+func TestAddStock_WithSupplier_ReportsHistoryPerSupplier(t *testing.T) {
+	uow := newSupplierUoW(
+		&domain.Supplier{ID: "s1", TenantID: "t1", Name: "Acme", IsActive: true},
+		&domain.Supplier{ID: "s2", TenantID: "t1", Name: "Old Co"},
+	)
+	add := NewAddStockUseCase(uow, nil)
+	ctx := context.Background()
+
+	if _, err := add.Execute(ctx, AddStockRequest{ProductID: "p1", Quantity: 5, TenantID: "t1", SupplierID: "s1"}); err != nil {
+		t.Fatalf("Execute(s1) err = %v", err)
+	}
+	if _, err := add.Execute(ctx, AddStockRequest{ProductID: "p2", Quantity: 3, TenantID: "t1"}); err != nil {
+		t.Fatalf("Execute(no supplier) err = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		supplier string
+		want     error
+	}{
+		{"inactive supplier", "s2", domain.ErrSupplierInactive},
+		{"unknown supplier", "s9", domain.ErrSupplierNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := add.Execute(ctx, AddStockRequest{ProductID: "p1", Quantity: 1, TenantID: "t1", SupplierID: tt.supplier})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Execute() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	history, err := NewStockHistoryUseCase(uow).SupplierHistory(ctx, SupplierHistoryRequest{TenantID: "t1", SupplierID: "s1"})
+	if err != nil {
+		t.Fatalf("SupplierHistory() err = %v", err)
+	}
+	if history.SupplierName != "Acme" || len(history.Entries) != 1 {
+		t.Fatalf("history = %+v, want one entry", history)
+	}
+	if e := history.Entries[0]; e.ProductID != "p1" || e.Quantity != 5 || e.SupplierID != "s1" {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestPurchaseOrderUseCase_RegisteredSupplier(t *testing.T) {
+	uow := newSupplierUoW(&domain.Supplier{ID: "s1", TenantID: "t1", Name: "Acme", IsActive: true})
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	ctx := context.Background()
+
+	order, err := uc.Create(ctx, CreatePurchaseOrderRequest{
+		TenantID: "t1", SupplierID: "s1", Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 4}},
+	})
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	if order.Supplier != "Acme" || order.SupplierID != "s1" {
+		t.Errorf("order supplier = %q (%q), want Acme (s1)", order.Supplier, order.SupplierID)
+	}
+
+	if _, err := uc.Receive(ctx, ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 4}},
+	}); err != nil {
+		t.Fatalf("Receive() err = %v", err)
+	}
+	if e := uow.StockHistRepo.Entries[0]; e.SupplierID != "s1" || e.Reference != order.ID {
+		t.Errorf("history entry = %+v, want supplier s1 and the order reference", e)
+	}
+}
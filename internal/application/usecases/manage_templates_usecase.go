@@ -0,0 +1,177 @@
+// internal/application/usecases/manage_templates_usecase.go
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type SaveTemplateRequest struct {
+	TenantID  string
+	Name      string
+	Body      string
+	UpdatedBy string
+}
+
+type PreviewTemplateRequest struct {
+	TenantID string
+	Name     string
+	Body     string // empty previews the template currently in effect
+}
+
+// Output DTOs
+type TemplateResponse struct {
+	Name      string
+	Body      string
+	IsCustom  bool
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+type PreviewTemplateResponse struct {
+	Name     string
+	Rendered string
+}
+
+// Use Case interface (what handlers depend on)
+type ManageTemplatesUseCase interface {
+	List(ctx context.Context, tenantID string) ([]TemplateResponse, error)
+	Save(ctx context.Context, req SaveTemplateRequest) (*TemplateResponse, error)
+	Reset(ctx context.Context, tenantID, name string) error
+	Preview(ctx context.Context, req PreviewTemplateRequest) (*PreviewTemplateResponse, error)
+}
+
+// Implementation
+type manageTemplatesUseCase struct {
+	uow      interfaces.UnitOfWork
+	renderer interfaces.TemplateRenderer
+}
+
+func NewManageTemplatesUseCase(uow interfaces.UnitOfWork, renderer interfaces.TemplateRenderer) ManageTemplatesUseCase {
+	return &manageTemplatesUseCase{
+		uow:      uow,
+		renderer: renderer,
+	}
+}
+
+func (uc *manageTemplatesUseCase) List(ctx context.Context, tenantID string) ([]TemplateResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	overrides, err := uc.uow.NotificationTemplates().FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.NotificationTemplate, len(overrides))
+	for _, t := range overrides {
+		byName[t.Name] = t
+	}
+
+	// Every known template is listed, with the override taking precedence
+	responses := make([]TemplateResponse, 0, len(domain.NotificationTemplateNames()))
+	for _, name := range domain.NotificationTemplateNames() {
+		if t, ok := byName[name]; ok {
+			responses = append(responses, TemplateResponse{
+				Name:      name,
+				Body:      t.Body,
+				IsCustom:  true,
+				UpdatedBy: t.UpdatedBy,
+				UpdatedAt: t.UpdatedAt,
+			})
+			continue
+		}
+
+		body, err := uc.renderer.Default(name)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, TemplateResponse{Name: name, Body: body})
+	}
+	return responses, nil
+}
+
+func (uc *manageTemplatesUseCase) Save(ctx context.Context, req SaveTemplateRequest) (*TemplateResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	// Broken templates are rejected here so they never reach alerting
+	if err := uc.renderer.Validate(req.Name, req.Body); err != nil {
+		return nil, err
+	}
+
+	template := &domain.NotificationTemplate{
+		TenantID:  req.TenantID,
+		Name:      req.Name,
+		Body:      req.Body,
+		UpdatedBy: req.UpdatedBy,
+		UpdatedAt: time.Now(),
+	}
+	if err := uc.uow.NotificationTemplates().Save(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return &TemplateResponse{
+		Name:      template.Name,
+		Body:      template.Body,
+		IsCustom:  true,
+		UpdatedBy: template.UpdatedBy,
+		UpdatedAt: template.UpdatedAt,
+	}, nil
+}
+
+func (uc *manageTemplatesUseCase) Reset(ctx context.Context, tenantID, name string) error {
+	if tenantID == "" {
+		return domain.ErrTenantNotFound
+	}
+	if !domain.IsKnownTemplate(name) {
+		return domain.ErrTemplateNotFound
+	}
+
+	err := uc.uow.NotificationTemplates().Delete(ctx, tenantID, name)
+	if errors.Is(err, domain.ErrTemplateNotFound) {
+		// Already using the default
+		return nil
+	}
+	return err
+}
+
+func (uc *manageTemplatesUseCase) Preview(ctx context.Context, req PreviewTemplateRequest) (*PreviewTemplateResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if !domain.IsKnownTemplate(req.Name) {
+		return nil, domain.ErrTemplateNotFound
+	}
+
+	body := req.Body
+	if body == "" {
+		current, err := uc.currentBody(ctx, req.TenantID, req.Name)
+		if err != nil {
+			return nil, err
+		}
+		body = current
+	}
+
+	rendered, err := uc.renderer.Preview(req.Name, body)
+	if err != nil {
+		return nil, err
+	}
+	return &PreviewTemplateResponse{Name: req.Name, Rendered: rendered}, nil
+}
+
+func (uc *manageTemplatesUseCase) currentBody(ctx context.Context, tenantID, name string) (string, error) {
+	override, err := uc.uow.NotificationTemplates().FindByName(ctx, tenantID, name)
+	if err == nil {
+		return override.Body, nil
+	}
+	if !errors.Is(err, domain.ErrTemplateNotFound) {
+		return "", err
+	}
+	return uc.renderer.Default(name)
+}
@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func newTemplateUseCase(overrides ...*domain.NotificationTemplate) (ManageTemplatesUseCase, *mocks.MockUnitOfWork, *mocks.MockTemplateRenderer) {
+	uow := &mocks.MockUnitOfWork{
+		TemplatesRepo: &mocks.MockNotificationTemplateRepo{Templates: overrides},
+	}
+	renderer := &mocks.MockTemplateRenderer{
+		Defaults: map[string]string{
+			domain.TemplateStockAlertSlack: "default slack",
+			domain.TemplateStockAlertEmail: "default email",
+			domain.TemplateLowStockAlert:   "default low stock",
+		},
+	}
+	return NewManageTemplatesUseCase(uow, renderer), uow, renderer
+}
+
+func TestManageTemplatesUseCase_List_MergesOverrides(t *testing.T) {
+	uc, _, _ := newTemplateUseCase(&domain.NotificationTemplate{
+		TenantID: "t1", Name: domain.TemplateLowStockAlert, Body: "custom low stock",
+	})
+
+	got, err := uc.List(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("List() err = %v", err)
+	}
+	if len(got) != len(domain.NotificationTemplateNames()) {
+		t.Fatalf("List() len = %d, want %d", len(got), len(domain.NotificationTemplateNames()))
+	}
+	for _, tmpl := range got {
+		wantCustom := tmpl.Name == domain.TemplateLowStockAlert
+		if tmpl.IsCustom != wantCustom {
+			t.Errorf("%s IsCustom = %v, want %v", tmpl.Name, tmpl.IsCustom, wantCustom)
+		}
+		if wantCustom && tmpl.Body != "custom low stock" {
+			t.Errorf("%s Body = %q", tmpl.Name, tmpl.Body)
+		}
+	}
+}
+
+func TestManageTemplatesUseCase_Save_RejectsInvalidTemplate(t *testing.T) {
+	uc, uow, renderer := newTemplateUseCase()
+	renderer.ValidateErr = domain.ErrInvalidTemplate{Name: domain.TemplateStockAlertSlack, Reason: "bad"}
+
+	got, err := uc.Save(context.Background(), SaveTemplateRequest{
+		TenantID: "t1", Name: domain.TemplateStockAlertSlack, Body: "{{.Nope",
+	})
+	if got != nil {
+		t.Fatalf("Save() expected nil response, got %+v", got)
+	}
+	var tmplErr domain.ErrInvalidTemplate
+	if !errors.As(err, &tmplErr) {
+		t.Errorf("Save() err = %v, want ErrInvalidTemplate", err)
+	}
+	if len(uow.TemplatesRepo.Saved) != 0 {
+		t.Errorf("Save calls = %d, want 0", len(uow.TemplatesRepo.Saved))
+	}
+}
+
+func TestManageTemplatesUseCase_Save_Success(t *testing.T) {
+	uc, uow, _ := newTemplateUseCase()
+
+	got, err := uc.Save(context.Background(), SaveTemplateRequest{
+		TenantID: "t1", Name: domain.TemplateStockAlertSlack, Body: "{{.ProductName}}", UpdatedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Save() err = %v", err)
+	}
+	if !got.IsCustom || got.UpdatedBy != "u1" {
+		t.Errorf("response: IsCustom=%v UpdatedBy=%q", got.IsCustom, got.UpdatedBy)
+	}
+	if len(uow.TemplatesRepo.Saved) != 1 || uow.TemplatesRepo.Saved[0].TenantID != "t1" {
+		t.Errorf("Save calls = %+v", uow.TemplatesRepo.Saved)
+	}
+}
+
+func TestManageTemplatesUseCase_Preview_UsesCurrentTemplate(t *testing.T) {
+	uc, _, renderer := newTemplateUseCase(&domain.NotificationTemplate{
+		TenantID: "t1", Name: domain.TemplateStockAlertSlack, Body: "custom slack",
+	})
+	ctx := context.Background()
+
+	got, err := uc.Preview(ctx, PreviewTemplateRequest{TenantID: "t1", Name: domain.TemplateStockAlertSlack})
+	if err != nil {
+		t.Fatalf("Preview() err = %v", err)
+	}
+	if got.Rendered != "rendered: custom slack" {
+		t.Errorf("Rendered = %q", got.Rendered)
+	}
+
+	if _, err := uc.Preview(ctx, PreviewTemplateRequest{TenantID: "t1", Name: domain.TemplateLowStockAlert}); err != nil {
+		t.Fatalf("Preview() err = %v", err)
+	}
+	if renderer.Previewed[1] != "default low stock" {
+		t.Errorf("previewed body = %q, want default", renderer.Previewed[1])
+	}
+}
+
+func TestManageTemplatesUseCase_Preview_UnknownTemplate(t *testing.T) {
+	uc, _, _ := newTemplateUseCase()
+
+	_, err := uc.Preview(context.Background(), PreviewTemplateRequest{TenantID: "t1", Name: "nope"})
+	if !errors.Is(err, domain.ErrTemplateNotFound) {
+		t.Errorf("Preview() err = %v, want %v", err, domain.ErrTemplateNotFound)
+	}
+}
@@ -0,0 +1,199 @@
+// internal/application/usecases/manage_webhooks_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type CreateWebhookRequest struct {
+	TenantID   string
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+type UpdateWebhookRequest struct {
+	ID         string
+	TenantID   string
+	URL        string
+	Secret     string // empty keeps the current secret
+	EventTypes []string
+	IsActive   bool
+}
+
+// Output DTOs (the secret is never returned)
+type WebhookResponse struct {
+	ID         string
+	TenantID   string
+	URL        string
+	EventTypes []string
+	IsActive   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type WebhookDeliveryResponse struct {
+	ID          string
+	EventID     string
+	EventType   string
+	Attempt     int
+	StatusCode  int
+	Success     bool
+	Error       string
+	DurationMS  int64
+	DeliveredAt time.Time
+}
+
+// Use Case interface (what handlers depend on)
+type ManageWebhooksUseCase interface {
+	Create(ctx context.Context, req CreateWebhookRequest) (*WebhookResponse, error)
+	List(ctx context.Context, tenantID string) ([]WebhookResponse, error)
+	Get(ctx context.Context, tenantID, subscriptionID string) (*WebhookResponse, error)
+	Update(ctx context.Context, req UpdateWebhookRequest) (*WebhookResponse, error)
+	Delete(ctx context.Context, tenantID, subscriptionID string) error
+	ListDeliveries(ctx context.Context, tenantID, subscriptionID string, limit int) ([]WebhookDeliveryResponse, error)
+}
+
+// Implementation
+type manageWebhooksUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewManageWebhooksUseCase(uow interfaces.UnitOfWork) ManageWebhooksUseCase {
+	return &manageWebhooksUseCase{
+		uow: uow,
+	}
+}
+
+func (uc *manageWebhooksUseCase) Create(ctx context.Context, req CreateWebhookRequest) (*WebhookResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	// Subscriptions can only be registered for existing tenants
+	if _, err := uc.uow.Tenants().FindByID(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	sub, err := domain.NewWebhookSubscription(req.TenantID, req.URL, req.Secret, req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.uow.WebhookSubscriptions().Create(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return toWebhookResponse(sub), nil
+}
+
+func (uc *manageWebhooksUseCase) List(ctx context.Context, tenantID string) ([]WebhookResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	subs, err := uc.uow.WebhookSubscriptions().FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]WebhookResponse, 0, len(subs))
+	for _, sub := range subs {
+		responses = append(responses, *toWebhookResponse(sub))
+	}
+	return responses, nil
+}
+
+func (uc *manageWebhooksUseCase) Get(ctx context.Context, tenantID, subscriptionID string) (*WebhookResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	sub, err := uc.uow.WebhookSubscriptions().FindByID(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookResponse(sub), nil
+}
+
+func (uc *manageWebhooksUseCase) Update(ctx context.Context, req UpdateWebhookRequest) (*WebhookResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	sub, err := uc.uow.WebhookSubscriptions().FindByID(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.URL = req.URL
+	sub.EventTypes = req.EventTypes
+	sub.IsActive = req.IsActive
+	if req.Secret != "" {
+		sub.Secret = req.Secret
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	sub.UpdatedAt = time.Now()
+
+	if err := uc.uow.WebhookSubscriptions().Update(ctx, sub); err != nil {
+		return nil, err
+	}
+	return toWebhookResponse(sub), nil
+}
+
+func (uc *manageWebhooksUseCase) Delete(ctx context.Context, tenantID, subscriptionID string) error {
+	if tenantID == "" {
+		return domain.ErrTenantNotFound
+	}
+	return uc.uow.WebhookSubscriptions().Delete(ctx, tenantID, subscriptionID)
+}
+
+func (uc *manageWebhooksUseCase) ListDeliveries(ctx context.Context, tenantID, subscriptionID string, limit int) ([]WebhookDeliveryResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	// Ensures the subscription belongs to the tenant before exposing its log
+	if _, err := uc.uow.WebhookSubscriptions().FindByID(ctx, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := uc.uow.WebhookDeliveries().FindBySubscription(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		responses = append(responses, WebhookDeliveryResponse{
+			ID:          d.ID,
+			EventID:     d.EventID,
+			EventType:   d.EventType,
+			Attempt:     d.Attempt,
+			StatusCode:  d.StatusCode,
+			Success:     d.Success,
+			Error:       d.Error,
+			DurationMS:  d.Duration.Milliseconds(),
+			DeliveredAt: d.DeliveredAt,
+		})
+	}
+	return responses, nil
+}
+
+func toWebhookResponse(sub *domain.WebhookSubscription) *WebhookResponse {
+	return &WebhookResponse{
+		ID:         sub.ID,
+		TenantID:   sub.TenantID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		IsActive:   sub.IsActive,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
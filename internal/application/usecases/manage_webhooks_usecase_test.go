@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func newWebhookUoW(subs ...*domain.WebhookSubscription) *mocks.MockUnitOfWork {
+	tenant := &domain.Tenant{ID: "t1", Name: "Tenant", MaxStock: mustQuantity(100), IsActive: true}
+	return &mocks.MockUnitOfWork{
+		TenantsRepo:  &mocks.MockTenantRepo{Tenant: tenant},
+		WebhooksRepo: &mocks.MockWebhookSubscriptionRepo{Subscriptions: subs},
+		DeliveryRepo: &mocks.MockWebhookDeliveryRepo{},
+	}
+}
+
+func TestManageWebhooksUseCase_Create_Validation(t *testing.T) {
+	uc := NewManageWebhooksUseCase(newWebhookUoW())
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  CreateWebhookRequest
+		want error
+	}{
+		{
+			name: "empty tenant id",
+			req:  CreateWebhookRequest{URL: "https://example.com/hook", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrTenantNotFound,
+		},
+		{
+			name: "relative url",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "/hook", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrInvalidWebhookURL,
+		},
+		{
+			name: "loopback url",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "http://127.0.0.1:8080/hook", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "localhost url",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "http://LocalHost./hook", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "private url",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "https://10.0.0.12/hook", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "cloud metadata url",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "http://169.254.169.254/latest/meta-data", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "ipv6 loopback url",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "http://[::1]/hook", Secret: testWebhookSecret, EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "short secret",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "https://example.com/hook", Secret: "short", EventTypes: []string{domain.EventTypeStockAdded}},
+			want: domain.ErrInvalidWebhookSecret,
+		},
+		{
+			name: "unknown event type",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "https://example.com/hook", Secret: testWebhookSecret, EventTypes: []string{"stock.exploded"}},
+			want: domain.ErrInvalidEventType,
+		},
+		{
+			name: "no event types",
+			req:  CreateWebhookRequest{TenantID: "t1", URL: "https://example.com/hook", Secret: testWebhookSecret},
+			want: domain.ErrInvalidEventType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Create(ctx, tt.req)
+			if got != nil {
+				t.Fatalf("Create() expected nil response on validation error, got %+v", got)
+			}
+			if err == nil || !errors.Is(err, tt.want) {
+				t.Errorf("Create() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestManageWebhooksUseCase_Create_Success(t *testing.T) {
+	uow := newWebhookUoW()
+	uc := NewManageWebhooksUseCase(uow)
+
+	got, err := uc.Create(context.Background(), CreateWebhookRequest{
+		TenantID:   "t1",
+		URL:        "https://example.com/hook",
+		Secret:     testWebhookSecret,
+		EventTypes: []string{domain.EventTypeStockAdded, domain.EventTypeStockLimitAlert, domain.EventTypeLowStock},
+	})
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	if got.ID == "" || got.TenantID != "t1" || !got.IsActive {
+		t.Errorf("response: ID=%q TenantID=%q IsActive=%v", got.ID, got.TenantID, got.IsActive)
+	}
+	if len(uow.WebhooksRepo.Created) != 1 || uow.WebhooksRepo.Created[0].Secret != testWebhookSecret {
+		t.Errorf("Create calls = %d, want 1 with secret stored", len(uow.WebhooksRepo.Created))
+	}
+}
+
+func TestManageWebhooksUseCase_Update_KeepsSecretWhenEmpty(t *testing.T) {
+	sub := &domain.WebhookSubscription{
+		ID: "wh1", TenantID: "t1", URL: "https://example.com/hook", Secret: testWebhookSecret,
+		EventTypes: []string{domain.EventTypeStockAdded}, IsActive: true,
+	}
+	uow := newWebhookUoW(sub)
+	uc := NewManageWebhooksUseCase(uow)
+
+	got, err := uc.Update(context.Background(), UpdateWebhookRequest{
+		ID: "wh1", TenantID: "t1", URL: "https://example.com/other",
+		EventTypes: []string{domain.EventTypeStockLimitAlert}, IsActive: false,
+	})
+	if err != nil {
+		t.Fatalf("Update() err = %v", err)
+	}
+	if got.URL != "https://example.com/other" || got.IsActive {
+		t.Errorf("response: URL=%q IsActive=%v", got.URL, got.IsActive)
+	}
+	if sub.Secret != testWebhookSecret {
+		t.Errorf("secret = %q, want unchanged", sub.Secret)
+	}
+}
+
+func TestManageWebhooksUseCase_Get_OtherTenant(t *testing.T) {
+	sub := &domain.WebhookSubscription{ID: "wh1", TenantID: "t2"}
+	uc := NewManageWebhooksUseCase(newWebhookUoW(sub))
+
+	_, err := uc.Get(context.Background(), "t1", "wh1")
+	if !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Get() err = %v, want %v", err, domain.ErrWebhookNotFound)
+	}
+}
+
+func TestManageWebhooksUseCase_ListDeliveries(t *testing.T) {
+	sub := &domain.WebhookSubscription{ID: "wh1", TenantID: "t1"}
+	uow := newWebhookUoW(sub)
+	uow.DeliveryRepo.Deliveries = []domain.WebhookDelivery{
+		{SubscriptionID: "wh1", EventID: "e1", Attempt: 1, StatusCode: 500},
+		{SubscriptionID: "wh1", EventID: "e1", Attempt: 2, StatusCode: 200, Success: true},
+		{SubscriptionID: "wh2", EventID: "e2", Attempt: 1, StatusCode: 200, Success: true},
+	}
+	uc := NewManageWebhooksUseCase(uow)
+
+	got, err := uc.ListDeliveries(context.Background(), "t1", "wh1", 10)
+	if err != nil {
+		t.Fatalf("ListDeliveries() err = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ListDeliveries() len = %d, want 2", len(got))
+	}
+	if !got[1].Success || got[1].Attempt != 2 {
+		t.Errorf("second delivery: Success=%v Attempt=%d", got[1].Success, got[1].Attempt)
+	}
+}
@@ -0,0 +1,319 @@
+// internal/application/usecases/purchase_order_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTOs
+type CreatePurchaseOrderRequest struct {
+	TenantID string
+	Supplier string
+	// A registered supplier; its name is used when Supplier is empty
+	SupplierID string
+	Number     string
+	Notes      string
+	Lines      []domain.ProductQuantity
+	ExpectedAt time.Time
+	CreatedBy  string
+}
+
+type ReceivePurchaseOrderRequest struct {
+	TenantID   string
+	OrderID    string
+	Lines      []domain.ProductQuantity
+	Notes      string
+	ReceivedBy string
+	// Accept lines beyond the quantity still outstanding
+	AllowOverReceipt bool
+}
+
+// Output DTOs
+type PurchaseOrderLineResponse struct {
+	ProductID    string
+	ProductName  string
+	Expected     int
+	Received     int
+	Outstanding  int
+	OverReceived int
+}
+
+type PurchaseOrderResponse struct {
+	ID         string
+	TenantID   string
+	Supplier   string
+	SupplierID string
+	Number     string
+	Notes      string
+	Status     string
+	Lines      []PurchaseOrderLineResponse
+	Receipts   []domain.PurchaseOrderReceipt
+	ExpectedAt time.Time
+	CreatedBy  string
+	CreatedAt  time.Time
+	ClosedBy   string
+	ClosedAt   time.Time
+}
+
+type ReceivePurchaseOrderResponse struct {
+	Order *PurchaseOrderResponse
+	// The stock change of each received line, in receipt order
+	Stock []AddStockResponse
+}
+
+// Use Case interface (what handlers depend on)
+type PurchaseOrderUseCase interface {
+	Create(ctx context.Context, req CreatePurchaseOrderRequest) (*PurchaseOrderResponse, error)
+	Get(ctx context.Context, tenantID, orderID string) (*PurchaseOrderResponse, error)
+	List(ctx context.Context, tenantID, status string) ([]PurchaseOrderResponse, error)
+	// Receive adds the delivered stock and records it on the order, all or
+	// nothing
+	Receive(ctx context.Context, req ReceivePurchaseOrderRequest) (*ReceivePurchaseOrderResponse, error)
+	Cancel(ctx context.Context, tenantID, orderID, cancelledBy string) (*PurchaseOrderResponse, error)
+}
+
+// Implementation
+type purchaseOrderUseCase struct {
+	uow   interfaces.UnitOfWork
+	adder *addStockUseCase
+}
+
+func NewPurchaseOrderUseCase(
+	uow interfaces.UnitOfWork,
+	notificationSvc interfaces.NotificationService,
+) PurchaseOrderUseCase {
+	return &purchaseOrderUseCase{
+		uow:   uow,
+		adder: newAddStockUseCase(uow, notificationSvc),
+	}
+}
+
+func (uc *purchaseOrderUseCase) Create(ctx context.Context, req CreatePurchaseOrderRequest) (*PurchaseOrderResponse, error) {
+	// 1. Validate tenant
+	if _, err := uc.findTenant(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	// 2. Resolve a registered supplier
+	supplierName := req.Supplier
+	if req.SupplierID != "" {
+		supplier, err := uc.uow.Suppliers().FindByID(ctx, req.TenantID, req.SupplierID)
+		if err != nil {
+			return nil, err
+		}
+		if !supplier.IsActive {
+			return nil, domain.ErrSupplierInactive
+		}
+		if supplierName == "" {
+			supplierName = supplier.Name
+		}
+	}
+
+	// 3. Load the ordered products
+	products, err := uc.loadProducts(ctx, req.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Build and save the order
+	order, err := domain.NewPurchaseOrder(req.TenantID, supplierName, req.Number, req.Notes, req.Lines, products, req.ExpectedAt, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	order.SupplierID = req.SupplierID
+	if err := uc.uow.PurchaseOrders().Create(ctx, order); err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponse(order), nil
+}
+
+func (uc *purchaseOrderUseCase) Get(ctx context.Context, tenantID, orderID string) (*PurchaseOrderResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	order, err := uc.uow.PurchaseOrders().FindByID(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponse(order), nil
+}
+
+func (uc *purchaseOrderUseCase) List(ctx context.Context, tenantID, status string) ([]PurchaseOrderResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	orders, err := uc.uow.PurchaseOrders().FindByTenant(ctx, tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PurchaseOrderResponse, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, *toPurchaseOrderResponse(o))
+	}
+	return result, nil
+}
+
+func (uc *purchaseOrderUseCase) Receive(ctx context.Context, req ReceivePurchaseOrderRequest) (*ReceivePurchaseOrderResponse, error) {
+	// 1. Validate tenant
+	tenant, err := uc.findTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tenant.CanReceiveStock(); err != nil {
+		return nil, err
+	}
+
+	// 2. Record the delivery on the order; this rejects closed orders,
+	// unknown products and over-receipts before any stock changes
+	order, err := uc.uow.PurchaseOrders().FindByID(ctx, req.TenantID, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	receipt := domain.PurchaseOrderReceipt{
+		Lines:      req.Lines,
+		ReceivedBy: req.ReceivedBy,
+		Notes:      req.Notes,
+		ReceivedAt: time.Now(),
+	}
+	if err := order.Receive(receipt, req.AllowOverReceipt); err != nil {
+		return nil, err
+	}
+	products, err := uc.loadProducts(ctx, req.Lines)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range req.Lines {
+		if products[line.ProductID] == nil {
+			return nil, domain.ErrProductNotFound
+		}
+	}
+
+	notes := req.Notes
+	if notes == "" {
+		notes = "purchase order " + order.ID
+		if order.Number != "" {
+			notes = "purchase order " + order.Number
+		}
+	}
+
+	// 3. Add each line's stock and store the order in one transaction. A
+	// line over the tenant's limit fails with ErrStockExceedsLimit and
+	// leaves the order as it was.
+	var stock []AddStockResponse
+	var notifications []func()
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		// The transaction may be retried, so every attempt starts from
+		// the products as loaded
+		working := copyProducts(products)
+		stock = stock[:0]
+		notifications = notifications[:0]
+		for _, line := range req.Lines {
+			response, notify, err := uc.adder.apply(ctx, AddStockRequest{
+				ProductID:       line.ProductID,
+				Quantity:        line.Quantity,
+				TenantID:        req.TenantID,
+				Notes:           notes,
+				AddedBy:         req.ReceivedBy,
+				PurchaseOrderID: order.ID,
+				SupplierID:      order.SupplierID,
+			}, tenant, working[line.ProductID])
+			if err != nil {
+				return err
+			}
+			stock = append(stock, *response)
+			notifications = append(notifications, notify)
+		}
+		return uc.uow.PurchaseOrders().Update(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	order.Version++
+
+	for _, notify := range notifications {
+		notify()
+	}
+	return &ReceivePurchaseOrderResponse{
+		Order: toPurchaseOrderResponse(order),
+		Stock: stock,
+	}, nil
+}
+
+func (uc *purchaseOrderUseCase) Cancel(ctx context.Context, tenantID, orderID, cancelledBy string) (*PurchaseOrderResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	order, err := uc.uow.PurchaseOrders().FindByID(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Cancel(cancelledBy); err != nil {
+		return nil, err
+	}
+	if err := uc.uow.PurchaseOrders().Update(ctx, order); err != nil {
+		return nil, err
+	}
+	order.Version++
+	return toPurchaseOrderResponse(order), nil
+}
+
+func (uc *purchaseOrderUseCase) findTenant(ctx context.Context, tenantID string) (*domain.Tenant, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	return uc.uow.Tenants().FindByID(ctx, tenantID)
+}
+
+// loadProducts fetches the lines' products in one query, keyed by ID.
+// Products that do not exist are left out.
+func (uc *purchaseOrderUseCase) loadProducts(ctx context.Context, lines []domain.ProductQuantity) (map[string]*domain.Product, error) {
+	ids := make([]string, 0, len(lines))
+	for _, l := range lines {
+		ids = append(ids, l.ProductID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	found, err := uc.uow.Products().FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[string]*domain.Product, len(found))
+	for _, p := range found {
+		products[p.ID] = p
+	}
+	return products, nil
+}
+
+func toPurchaseOrderResponse(o *domain.PurchaseOrder) *PurchaseOrderResponse {
+	lines := make([]PurchaseOrderLineResponse, 0, len(o.Lines))
+	for _, l := range o.Lines {
+		lines = append(lines, PurchaseOrderLineResponse{
+			ProductID:    l.ProductID,
+			ProductName:  l.ProductName,
+			Expected:     l.Expected,
+			Received:     l.Received,
+			Outstanding:  l.Outstanding(),
+			OverReceived: l.OverReceived(),
+		})
+	}
+	return &PurchaseOrderResponse{
+		ID:         o.ID,
+		TenantID:   o.TenantID,
+		Supplier:   o.Supplier,
+		SupplierID: o.SupplierID,
+		Number:     o.Number,
+		Notes:      o.Notes,
+		Status:     o.Status,
+		Lines:      lines,
+		Receipts:   o.Receipts,
+		ExpectedAt: o.ExpectedAt,
+		CreatedBy:  o.CreatedBy,
+		CreatedAt:  o.CreatedAt,
+		ClosedBy:   o.ClosedBy,
+		ClosedAt:   o.ClosedAt,
+	}
+}
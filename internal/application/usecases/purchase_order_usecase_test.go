@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func purchaseOrderFixture() *mocks.MockUnitOfWork {
+	other := testProduct("p9", "Other", 1)
+	other.TenantID = "t2"
+	uow, _ := stockFixture(testProduct("p1", "Widget", 10), testProduct("p2", "Gadget", 90), other)
+	// Receipts skip the approval threshold
+	uow.TenantsRepo.Tenant.ApprovalThreshold = 5
+	uow.OrdersRepo = &mocks.MockPurchaseOrderRepo{}
+	return uow
+}
+
+func createOrder(t *testing.T, uc PurchaseOrderUseCase, lines ...domain.ProductQuantity) *PurchaseOrderResponse {
+	t.Helper()
+	order, err := uc.Create(context.Background(), CreatePurchaseOrderRequest{
+		TenantID: "t1", Supplier: "Acme", Number: "PO-7", Lines: lines, CreatedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	return order
+}
+
+func TestPurchaseOrderUseCase_Create(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+
+	got := createOrder(t, uc, domain.ProductQuantity{ProductID: "p1", Quantity: 20})
+	if got.ID == "" || got.Status != domain.PurchaseOrderOpen || len(got.Lines) != 1 {
+		t.Fatalf("order = %+v", got)
+	}
+	if line := got.Lines[0]; line.ProductName != "Widget" || line.Expected != 20 || line.Outstanding != 20 {
+		t.Errorf("line = %+v", line)
+	}
+
+	tests := []struct {
+		name  string
+		lines []domain.ProductQuantity
+		want  error
+	}{
+		{"no lines", nil, domain.ErrEmptyPurchaseOrder},
+		{"duplicate product", []domain.ProductQuantity{{ProductID: "p1", Quantity: 1}, {ProductID: "p1", Quantity: 2}}, domain.ErrDuplicatePurchaseOrderLine},
+		{"zero quantity", []domain.ProductQuantity{{ProductID: "p1"}}, domain.ErrInvalidQuantity},
+		{"other tenant's product", []domain.ProductQuantity{{ProductID: "p9", Quantity: 1}}, domain.ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Create(context.Background(), CreatePurchaseOrderRequest{TenantID: "t1", Supplier: "Acme", Lines: tt.lines})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Create() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderUseCase_Receive_PartialThenFull(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	order := createOrder(t, uc,
+		domain.ProductQuantity{ProductID: "p1", Quantity: 20},
+		domain.ProductQuantity{ProductID: "p2", Quantity: 10},
+	)
+
+	got, err := uc.Receive(context.Background(), ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, ReceivedBy: "u2",
+		Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 8}},
+	})
+	if err != nil {
+		t.Fatalf("Receive() err = %v", err)
+	}
+	if got.Order.Status != domain.PurchaseOrderPartiallyReceived || got.Order.Lines[0].Outstanding != 12 {
+		t.Errorf("order = %+v", got.Order)
+	}
+	if len(got.Stock) != 1 || got.Stock[0].PreviousStock != 10 || got.Stock[0].NewStock != 18 || got.Stock[0].PendingApproval != nil {
+		t.Errorf("stock = %+v", got.Stock)
+	}
+	entry := uow.StockHistRepo.Entries[0]
+	if entry.Operation != domain.HistoryOperationStockAdd || entry.Reference != order.ID || entry.Actor != "u2" || entry.Notes != "purchase order PO-7" {
+		t.Errorf("history entry = %+v", entry)
+	}
+
+	got, err = uc.Receive(context.Background(), ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, ReceivedBy: "u2",
+		Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 12}, {ProductID: "p2", Quantity: 10}},
+	})
+	if err != nil {
+		t.Fatalf("Receive() err = %v", err)
+	}
+	if got.Order.Status != domain.PurchaseOrderReceived || got.Order.ClosedBy != "u2" || len(got.Order.Receipts) != 2 {
+		t.Errorf("order = %+v, want received", got.Order)
+	}
+	if len(uow.StockHistRepo.Entries) != 3 {
+		t.Errorf("history entries = %d, want 3", len(uow.StockHistRepo.Entries))
+	}
+
+	_, err = uc.Receive(context.Background(), ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 1}},
+	})
+	if !errors.Is(err, domain.ErrPurchaseOrderClosed) {
+		t.Errorf("Receive(received order) err = %v, want %v", err, domain.ErrPurchaseOrderClosed)
+	}
+}
+
+func TestPurchaseOrderUseCase_Receive_OverReceipt(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	order := createOrder(t, uc, domain.ProductQuantity{ProductID: "p1", Quantity: 20})
+	req := ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 25}},
+	}
+
+	_, err := uc.Receive(context.Background(), req)
+	var over domain.ErrOverReceipt
+	if !errors.As(err, &over) || over.Outstanding != 20 || over.Receiving != 25 {
+		t.Fatalf("Receive() err = %v, want over-receipt of 25 against 20", err)
+	}
+	if len(uow.StockHistRepo.Entries) != 0 {
+		t.Errorf("history entries = %d, want none", len(uow.StockHistRepo.Entries))
+	}
+
+	req.AllowOverReceipt = true
+	got, err := uc.Receive(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Receive(allow over-receipt) err = %v", err)
+	}
+	if line := got.Order.Lines[0]; got.Order.Status != domain.PurchaseOrderReceived || line.Received != 25 || line.OverReceived != 5 {
+		t.Errorf("order = %+v", got.Order)
+	}
+}
+
+func TestPurchaseOrderUseCase_Receive_StockLimitLeavesOrderOpen(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	order := createOrder(t, uc, domain.ProductQuantity{ProductID: "p2", Quantity: 20})
+
+	// Ordered, but 90 + 20 is over the tenant's limit of 100
+	_, err := uc.Receive(context.Background(), ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, Lines: []domain.ProductQuantity{{ProductID: "p2", Quantity: 20}},
+	})
+	var limit domain.ErrStockExceedsLimit
+	if !errors.As(err, &limit) {
+		t.Fatalf("Receive() err = %v, want ErrStockExceedsLimit", err)
+	}
+
+	stored, err := uc.Get(context.Background(), "t1", order.ID)
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if stored.Status != domain.PurchaseOrderOpen || stored.Lines[0].Received != 0 || len(stored.Receipts) != 0 {
+		t.Errorf("stored order = %+v, want unchanged", stored)
+	}
+}
+
+func TestPurchaseOrderUseCase_Receive_Rejections(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	order := createOrder(t, uc, domain.ProductQuantity{ProductID: "p1", Quantity: 20})
+
+	tests := []struct {
+		name    string
+		orderID string
+		lines   []domain.ProductQuantity
+		want    error
+	}{
+		{"unknown order", "po-99", []domain.ProductQuantity{{ProductID: "p1", Quantity: 1}}, domain.ErrPurchaseOrderNotFound},
+		{"product not ordered", order.ID, []domain.ProductQuantity{{ProductID: "p2", Quantity: 1}}, domain.ErrProductNotOnPurchaseOrder},
+		{"no lines", order.ID, nil, domain.ErrEmptyPurchaseOrder},
+		{"negative quantity", order.ID, []domain.ProductQuantity{{ProductID: "p1", Quantity: -1}}, domain.ErrInvalidQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Receive(context.Background(), ReceivePurchaseOrderRequest{TenantID: "t1", OrderID: tt.orderID, Lines: tt.lines})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Receive() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderUseCase_Receive_ConcurrentChange(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	order := createOrder(t, uc, domain.ProductQuantity{ProductID: "p1", Quantity: 20})
+	uow.OrdersRepo.UpdateErr = domain.ErrPurchaseOrderChanged
+
+	_, err := uc.Receive(context.Background(), ReceivePurchaseOrderRequest{
+		TenantID: "t1", OrderID: order.ID, Lines: []domain.ProductQuantity{{ProductID: "p1", Quantity: 5}},
+	})
+	if !errors.Is(err, domain.ErrPurchaseOrderChanged) {
+		t.Errorf("Receive() err = %v, want %v", err, domain.ErrPurchaseOrderChanged)
+	}
+}
+
+func TestPurchaseOrderUseCase_Cancel(t *testing.T) {
+	uow := purchaseOrderFixture()
+	uc := NewPurchaseOrderUseCase(uow, nil)
+	order := createOrder(t, uc, domain.ProductQuantity{ProductID: "p1", Quantity: 20})
+
+	got, err := uc.Cancel(context.Background(), "t1", order.ID, "u3")
+	if err != nil {
+		t.Fatalf("Cancel() err = %v", err)
+	}
+	if got.Status != domain.PurchaseOrderCancelled || got.ClosedBy != "u3" {
+		t.Errorf("order = %+v", got)
+	}
+	if _, err := uc.Cancel(context.Background(), "t1", order.ID, "u3"); !errors.Is(err, domain.ErrPurchaseOrderClosed) {
+		t.Errorf("Cancel(cancelled order) err = %v, want %v", err, domain.ErrPurchaseOrderClosed)
+	}
+
+	list, err := uc.List(context.Background(), "t1", domain.PurchaseOrderCancelled)
+	if err != nil || len(list) != 1 {
+		t.Errorf("List(cancelled) = %d orders, err = %v", len(list), err)
+	}
+}
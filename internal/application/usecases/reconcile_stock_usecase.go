@@ -0,0 +1,113 @@
+// internal/application/usecases/reconcile_stock_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type ReconcileStockRequest struct {
+	TenantID string
+	// Correct writes an adjustment history entry per drifted product and
+	// resets total_added; otherwise only the report is produced
+	Correct     bool
+	PerformedBy string
+}
+
+// Output DTO
+type ReconciliationReport struct {
+	TenantID        string
+	CheckedAt       time.Time
+	ProductsChecked int
+	// Products without any history; their stock cannot be verified
+	ProductsWithoutHistory int
+	Drifts                 []domain.StockDrift
+	Corrected              int
+}
+
+// Use Case interface (what handlers and commands depend on)
+type ReconcileStockUseCase interface {
+	Execute(ctx context.Context, req ReconcileStockRequest) (*ReconciliationReport, error)
+}
+
+// Implementation
+type reconcileStockUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewReconcileStockUseCase(uow interfaces.UnitOfWork) ReconcileStockUseCase {
+	return &reconcileStockUseCase{uow: uow}
+}
+
+func (uc *reconcileStockUseCase) Execute(ctx context.Context, req ReconcileStockRequest) (*ReconciliationReport, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if _, err := uc.uow.Tenants().FindByID(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	products, err := uc.uow.Products().FindByTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := uc.uow.StockHistory().SummarizeByProduct(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[string]domain.StockHistorySummary, len(summaries))
+	for _, s := range summaries {
+		byProduct[s.ProductID] = s
+	}
+
+	report := &ReconciliationReport{TenantID: req.TenantID, CheckedAt: time.Now()}
+	for _, product := range products {
+		report.ProductsChecked++
+		summary, ok := byProduct[product.ID]
+		if !ok {
+			report.ProductsWithoutHistory++
+			continue
+		}
+
+		drift, err := domain.NewStockDrift(product, summary)
+		if err != nil {
+			return nil, err
+		}
+		if !drift.HasDrift() {
+			continue
+		}
+		if req.Correct {
+			if err := uc.correct(ctx, req, &drift); err != nil {
+				return nil, err
+			}
+			report.Corrected++
+		}
+		report.Drifts = append(report.Drifts, drift)
+	}
+	return report, nil
+}
+
+// correct makes history end at current_stock and resets total_added to the
+// sum of history adds.
+func (uc *reconcileStockUseCase) correct(ctx context.Context, req ReconcileStockRequest, drift *domain.StockDrift) error {
+	err := uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if drift.StockDifference().Sign() != 0 {
+			entry := drift.AdjustmentEntry(req.TenantID, req.PerformedBy, time.Now())
+			if err := uc.uow.StockHistory().Append(ctx, &entry); err != nil {
+				return err
+			}
+		}
+		if drift.TotalAddedDifference().Sign() != 0 {
+			return uc.uow.Products().SetTotalAdded(ctx, drift.ProductID, drift.ExpectedTotalAdded)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	drift.Corrected = true
+	return nil
+}
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func historyAdd(productID string, previous, quantity int) domain.StockHistoryEntry {
+	return domain.StockHistoryEntry{
+		ProductID: productID, TenantID: "t1", Operation: domain.HistoryOperationStockAdd,
+		Quantity: quantity, PreviousStock: previous, NewStock: previous + quantity,
+	}
+}
+
+func reconciliationFixture() (*mocks.MockUnitOfWork, *mocks.MockProductRepo, *mocks.MockStockHistoryRepo) {
+	uow, products := stockFixture(
+		// In line with history
+		testProduct("p1", "", 15),
+		// Stock and total_added drifted
+		testProduct("p2", "", 8),
+		// No history yet
+		testProduct("p3", "", 4),
+	)
+	products.Products[0].TotalAdded = domain.DecimalFromInt(10)
+	products.Products[1].TotalAdded = domain.DecimalFromInt(27)
+	uow.StockHistRepo.Entries = []domain.StockHistoryEntry{
+		historyAdd("p1", 5, 10),
+		historyAdd("p2", 0, 5),
+		historyAdd("p2", 5, 5),
+	}
+	return uow, products, uow.StockHistRepo
+}
+
+func TestReconcileStockUseCase_Execute_ReportOnly(t *testing.T) {
+	uow, products, hist := reconciliationFixture()
+	uc := NewReconcileStockUseCase(uow)
+
+	report, err := uc.Execute(context.Background(), ReconcileStockRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if report.ProductsChecked != 3 || report.ProductsWithoutHistory != 1 {
+		t.Errorf("checked=%d without history=%d, want 3, 1", report.ProductsChecked, report.ProductsWithoutHistory)
+	}
+	if len(report.Drifts) != 1 {
+		t.Fatalf("drifts = %+v, want 1", report.Drifts)
+	}
+	d := report.Drifts[0]
+	if d.ProductID != "p2" || d.ExpectedStock.String() != "10" || d.StockDifference().String() != "-2" || d.ExpectedTotalAdded.String() != "10" || d.Corrected {
+		t.Errorf("drift = %+v", d)
+	}
+	if len(hist.Entries) != 3 || len(products.TotalAdded) != 0 || uow.TxCalls != 0 {
+		t.Errorf("report-only run wrote: history=%d totals=%v tx=%d", len(hist.Entries), products.TotalAdded, uow.TxCalls)
+	}
+}
+
+func TestReconcileStockUseCase_Execute_Correct(t *testing.T) {
+	uow, products, hist := reconciliationFixture()
+	uc := NewReconcileStockUseCase(uow)
+
+	report, err := uc.Execute(context.Background(), ReconcileStockRequest{TenantID: "t1", Correct: true, PerformedBy: "admin"})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if report.Corrected != 1 || !report.Drifts[0].Corrected {
+		t.Errorf("corrected = %d, drift = %+v", report.Corrected, report.Drifts[0])
+	}
+
+	adjustment := hist.Entries[len(hist.Entries)-1]
+	if adjustment.Operation != domain.HistoryOperationReconciliation || adjustment.Quantity != -2 ||
+		adjustment.PreviousStock != 10 || adjustment.NewStock != 8 || adjustment.Actor != "admin" {
+		t.Errorf("adjustment entry = %+v", adjustment)
+	}
+	if products.TotalAdded["p2"].String() != "10" {
+		t.Errorf("total_added p2 = %s, want 10", products.TotalAdded["p2"])
+	}
+
+	// A second pass finds nothing left to correct
+	again, err := uc.Execute(context.Background(), ReconcileStockRequest{TenantID: "t1", Correct: true})
+	if err != nil {
+		t.Fatalf("second Execute() err = %v", err)
+	}
+	if len(again.Drifts) != 0 {
+		t.Errorf("drifts after correction = %+v", again.Drifts)
+	}
+}
+
+func TestReconcileStockUseCase_Execute_TenantErrors(t *testing.T) {
+	uc := NewReconcileStockUseCase(&mocks.MockUnitOfWork{
+		TenantsRepo: &mocks.MockTenantRepo{FindErr: domain.ErrTenantNotFound},
+	})
+	ctx := context.Background()
+
+	if _, err := uc.Execute(ctx, ReconcileStockRequest{}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("empty tenant err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+	if _, err := uc.Execute(ctx, ReconcileStockRequest{TenantID: "nope"}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("unknown tenant err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+}
+
+func TestReconcileStockUseCase_Execute_AddAfterCorrection(t *testing.T) {
+	uow, _, _ := reconciliationFixture()
+	uow.TenantsRepo = &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true, MaxStock: mustQuantity(1000)}}
+	uow.OutboxRepo = &mocks.MockOutboxRepo{}
+	uc := NewReconcileStockUseCase(uow)
+	ctx := context.Background()
+
+	if _, err := uc.Execute(ctx, ReconcileStockRequest{TenantID: "t1", Correct: true}); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	add := NewAddStockUseCase(uow, &mocks.MockNotificationService{})
+	if _, err := add.Execute(ctx, AddStockRequest{TenantID: "t1", ProductID: "p2", Quantity: 4}); err != nil {
+		t.Fatalf("AddStock err = %v", err)
+	}
+
+	// total_added grows by what was added, not by the new stock
+	report, err := uc.Execute(ctx, ReconcileStockRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(report.Drifts) != 0 {
+		t.Errorf("drifts after add = %+v", report.Drifts)
+	}
+}
+
+func TestReconcileStockUseCase_Execute_DecimalProduct(t *testing.T) {
+	uow, products := decimalFixture("0")
+	hist := uow.StockHistRepo
+	add := NewAddStockUseCase(uow, nil)
+	ctx := context.Background()
+
+	// Each add is 0 in whole units, together they are 1
+	for i := 0; i < 2; i++ {
+		if _, err := add.Execute(ctx, AddStockRequest{TenantID: "t1", ProductID: "p1", DecimalQuantity: mustDecimal("0.6")}); err != nil {
+			t.Fatalf("AddStock err = %v", err)
+		}
+	}
+
+	uc := NewReconcileStockUseCase(uow)
+	report, err := uc.Execute(ctx, ReconcileStockRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(report.Drifts) != 0 {
+		t.Errorf("drifts = %+v, want none for 1.2 kg added in two steps", report.Drifts)
+	}
+
+	// A real drift is corrected to the gram
+	products.Products[0].CurrentStock, _ = domain.NewDecimalStockQuantity(*mustDecimal("1.150"))
+	report, err = uc.Execute(ctx, ReconcileStockRequest{TenantID: "t1", Correct: true})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(report.Drifts) != 1 || report.Drifts[0].StockDifference().String() != "-0.050" {
+		t.Fatalf("drifts = %+v, want -0.050", report.Drifts)
+	}
+	adjustment := hist.Entries[len(hist.Entries)-1]
+	if adjustment.Decimal == nil || adjustment.Decimal.Quantity.String() != "-0.050" || adjustment.Decimal.NewStock.String() != "1.150" {
+		t.Errorf("adjustment entry = %+v, want exact -0.050 kg", adjustment)
+	}
+	if again, err := uc.Execute(ctx, ReconcileStockRequest{TenantID: "t1"}); err != nil || len(again.Drifts) != 0 {
+		t.Errorf("after correction = %+v, %v; want no drift", again, err)
+	}
+}
@@ -0,0 +1,125 @@
+// internal/application/usecases/relay_outbox_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Output DTO
+type RelayOutboxResponse struct {
+	Published int
+	Failed    int
+	Deferred  int // skipped to keep per-aggregate order after a failure
+}
+
+// Use Case interface
+type RelayOutboxUseCase interface {
+	// Execute publishes one batch of pending outbox entries
+	Execute(ctx context.Context) (*RelayOutboxResponse, error)
+}
+
+// Implementation
+type relayOutboxUseCase struct {
+	uow         interfaces.UnitOfWork
+	publisher   interfaces.EventPublisher
+	relayName   string
+	batchSize   int
+	baseBackoff time.Duration
+}
+
+func NewRelayOutboxUseCase(
+	uow interfaces.UnitOfWork,
+	publisher interfaces.EventPublisher,
+	relayName string,
+	batchSize int,
+) RelayOutboxUseCase {
+	return &relayOutboxUseCase{
+		uow:         uow,
+		publisher:   publisher,
+		relayName:   relayName,
+		batchSize:   batchSize,
+		baseBackoff: time.Second,
+	}
+}
+
+// Delivery is at-least-once: an entry is only marked published after
+// Publish returns, so a crash in between re-publishes it on the next run.
+func (uc *relayOutboxUseCase) Execute(ctx context.Context) (*RelayOutboxResponse, error) {
+	// 1. Load relay progress
+	cursor, err := uc.uow.Outbox().LoadCursor(ctx, uc.relayName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Fetch due entries, oldest first
+	entries, err := uc.uow.Outbox().FindPending(ctx, time.Now(), uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Publish in order
+	result := &RelayOutboxResponse{}
+	blocked := make(map[string]bool)
+	for _, entry := range entries {
+		// A failed entry holds back later events of the same aggregate;
+		// FindPending keeps holding them back on later runs
+		if blocked[entry.AggregateID] {
+			result.Deferred++
+			continue
+		}
+
+		publishErr := uc.publish(ctx, entry)
+		now := time.Now()
+
+		attempt := domain.OutboxAttempt{
+			EntryID:   entry.ID,
+			Attempt:   entry.Attempts + 1,
+			Success:   publishErr == nil,
+			Timestamp: now,
+		}
+		if publishErr != nil {
+			attempt.Error = publishErr.Error()
+		}
+		if err := uc.uow.Outbox().RecordAttempt(ctx, attempt); err != nil {
+			return nil, err
+		}
+
+		if publishErr != nil {
+			entry.MarkFailed(publishErr, now, uc.baseBackoff)
+			blocked[entry.AggregateID] = true
+			result.Failed++
+			cursor.Failed++
+		} else {
+			entry.MarkPublished(now)
+			result.Published++
+			cursor.Published++
+			cursor.LastEntryID = entry.ID
+			cursor.LastPublishedAt = now
+		}
+
+		if err := uc.uow.Outbox().Update(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Persist progress for recovery and monitoring
+	if len(entries) > 0 {
+		cursor.UpdatedAt = time.Now()
+		if err := uc.uow.Outbox().SaveCursor(ctx, *cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *relayOutboxUseCase) publish(ctx context.Context, entry *domain.OutboxEntry) error {
+	event, err := entry.Envelope()
+	if err != nil {
+		return err
+	}
+	return uc.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,207 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+	"time"
+)
+
+// Publisher that fails for events of one product only
+type failingProductPublisher struct {
+	failFor   string
+	published []domain.CloudEvent
+}
+
+func (p *failingProductPublisher) Publish(ctx context.Context, event domain.CloudEvent) error {
+	if event.Subject == p.failFor {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func mustOutboxEntry(t *testing.T, id, productID string, quantity int) *domain.OutboxEntry {
+	t.Helper()
+	entry, err := domain.NewOutboxEntry(domain.StockAddedEvent{
+		ProductID: productID,
+		TenantID:  "t1",
+		Quantity:  mustQuantity(quantity),
+		Timestamp: time.Now(),
+	}, "t1")
+	if err != nil {
+		t.Fatalf("NewOutboxEntry() err = %v", err)
+	}
+	entry.ID = id
+	entry.NextAttemptAt = time.Now().Add(-time.Second)
+	return entry
+}
+
+func TestRelayOutboxUseCase_Execute_PublishesPendingEntries(t *testing.T) {
+	outbox := &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{
+		mustOutboxEntry(t, "e1", "p1", 5),
+		mustOutboxEntry(t, "e2", "p2", 3),
+	}}
+	pub := &mocks.MockEventPublisher{}
+	uc := NewRelayOutboxUseCase(&mocks.MockUnitOfWork{OutboxRepo: outbox}, pub, "bus", 10)
+
+	got, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Published != 2 || got.Failed != 0 {
+		t.Errorf("result = %+v, want 2 published", got)
+	}
+	if len(pub.Published) != 2 {
+		t.Fatalf("Publish calls = %d, want 2", len(pub.Published))
+	}
+	if pub.Published[0].Type != domain.EventTypeStockAdded || pub.Published[0].Subject != "p1" || pub.Published[0].TenantID != "t1" {
+		t.Errorf("Published[0] = %+v, want stock.added envelope for p1", pub.Published[0])
+	}
+	decoded, err := pub.Published[0].Decode()
+	if added, ok := decoded.(domain.StockAddedEvent); err != nil || !ok || added.Quantity.Value() != 5 {
+		t.Errorf("Published[0].Decode() = %+v, %v; want StockAddedEvent with quantity 5", decoded, err)
+	}
+	for _, e := range outbox.Entries {
+		if e.Status != domain.OutboxPublished || e.Attempts != 1 {
+			t.Errorf("entry %s: status=%s attempts=%d, want published/1", e.ID, e.Status, e.Attempts)
+		}
+	}
+	if len(outbox.Attempts) != 2 || !outbox.Attempts[0].Success {
+		t.Errorf("attempts = %+v, want 2 successful", outbox.Attempts)
+	}
+	if outbox.Cursor == nil || outbox.Cursor.Relay != "bus" || outbox.Cursor.LastEntryID != "e2" || outbox.Cursor.Published != 2 {
+		t.Errorf("cursor = %+v, want bus at e2 with 2 published", outbox.Cursor)
+	}
+
+	// Published entries are not relayed again
+	got, err = uc.Execute(context.Background())
+	if err != nil || got.Published != 0 || len(pub.Published) != 2 {
+		t.Errorf("second Execute() = %+v, %v; want nothing published", got, err)
+	}
+}
+
+func TestRelayOutboxUseCase_Execute_FailureDefersLaterEventsOfSameProduct(t *testing.T) {
+	outbox := &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{
+		mustOutboxEntry(t, "e1", "p1", 5),
+		mustOutboxEntry(t, "e2", "p2", 3),
+		mustOutboxEntry(t, "e3", "p1", 2),
+	}}
+	pub := &failingProductPublisher{failFor: "p1"}
+	uc := NewRelayOutboxUseCase(&mocks.MockUnitOfWork{OutboxRepo: outbox}, pub, "bus", 10)
+
+	got, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Published != 1 || got.Failed != 1 || got.Deferred != 1 {
+		t.Errorf("result = %+v, want 1 published, 1 failed, 1 deferred", got)
+	}
+
+	failed := outbox.Entries[0]
+	if failed.Status != domain.OutboxPending || failed.Attempts != 1 || failed.LastError == "" {
+		t.Errorf("failed entry = %+v, want pending retry with error", failed)
+	}
+	if !failed.NextAttemptAt.After(time.Now()) {
+		t.Errorf("NextAttemptAt = %v, want retry scheduled in the future", failed.NextAttemptAt)
+	}
+	if outbox.Entries[2].Attempts != 0 {
+		t.Errorf("deferred entry attempts = %d, want 0", outbox.Entries[2].Attempts)
+	}
+	if len(outbox.Attempts) != 2 || outbox.Attempts[0].Success || outbox.Attempts[0].Error == "" {
+		t.Errorf("attempts = %+v, want failed attempt recorded first", outbox.Attempts)
+	}
+	if outbox.Cursor.Failed != 1 || outbox.Cursor.Published != 1 {
+		t.Errorf("cursor = %+v, want 1 published and 1 failed", outbox.Cursor)
+	}
+}
+
+func TestRelayOutboxUseCase_Execute_FailedEntryHoldsBackLaterRuns(t *testing.T) {
+	outbox := &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{
+		mustOutboxEntry(t, "e1", "p1", 5),
+		mustOutboxEntry(t, "e2", "p1", 2),
+	}}
+	pub := &failingProductPublisher{failFor: "p1"}
+	uc := NewRelayOutboxUseCase(&mocks.MockUnitOfWork{OutboxRepo: outbox}, pub, "bus", 10)
+	ctx := context.Background()
+
+	if _, err := uc.Execute(ctx); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	// The broker is back, but e1 waits for its retry; e2 must wait with it
+	pub.failFor = ""
+	got, err := uc.Execute(ctx)
+	if err != nil {
+		t.Fatalf("second Execute() err = %v", err)
+	}
+	if got.Published != 0 || len(pub.published) != 0 {
+		t.Errorf("second Execute() = %+v, published %d; want e2 held back", got, len(pub.published))
+	}
+
+	outbox.Entries[0].NextAttemptAt = time.Now().Add(-time.Second)
+	if got, err = uc.Execute(ctx); err != nil || got.Published != 2 {
+		t.Fatalf("third Execute() = %+v, %v; want 2 published", got, err)
+	}
+	for i, want := range []int{5, 2} {
+		decoded, err := pub.published[i].Decode()
+		if added, ok := decoded.(domain.StockAddedEvent); err != nil || !ok || added.Quantity.Value() != want {
+			t.Errorf("published[%d] = %+v, %v; want quantity %d (e1 then e2)", i, decoded, err, want)
+		}
+	}
+
+	// A dead entry holds its aggregate back until someone deals with it
+	dead := mustOutboxEntry(t, "e3", "p2", 1)
+	dead.Status = domain.OutboxDead
+	outbox.Entries = append(outbox.Entries, dead, mustOutboxEntry(t, "e4", "p2", 1))
+	if got, err = uc.Execute(ctx); err != nil || got.Published != 0 {
+		t.Errorf("Execute() with dead entry = %+v, %v; want nothing published", got, err)
+	}
+}
+
+func TestRelayOutboxUseCase_Execute_FindPendingFails(t *testing.T) {
+	errFind := errors.New("find pending failed")
+	outbox := &mocks.MockOutboxRepo{FindErr: errFind}
+	uc := NewRelayOutboxUseCase(&mocks.MockUnitOfWork{OutboxRepo: outbox}, &mocks.MockEventPublisher{}, "bus", 10)
+
+	if _, err := uc.Execute(context.Background()); !errors.Is(err, errFind) {
+		t.Errorf("Execute() err = %v, want %v", err, errFind)
+	}
+}
+
+func TestOutboxEntry_MarkFailed_GivesUpAfterMaxAttempts(t *testing.T) {
+	entry := mustOutboxEntry(t, "e1", "p1", 1)
+	for i := 0; i < domain.MaxOutboxAttempts; i++ {
+		entry.MarkFailed(errors.New("down"), time.Now(), time.Millisecond)
+	}
+	if entry.Status != domain.OutboxDead {
+		t.Errorf("Status = %s, want %s", entry.Status, domain.OutboxDead)
+	}
+}
+
+func TestRelayOutboxUseCase_Execute_WrapsLegacyEntries(t *testing.T) {
+	// Entries written before envelopes hold bare version 1 event data
+	legacy := &domain.OutboxEntry{
+		ID: "e1", EventType: domain.EventTypeStockAdded, AggregateID: "p1", TenantID: "t1",
+		Payload: []byte(`{"ProductID":"p1","TenantID":"t1","Quantity":5}`),
+		Status:  domain.OutboxPending, CreatedAt: time.Now(), NextAttemptAt: time.Now().Add(-time.Second),
+	}
+	pub := &mocks.MockEventPublisher{}
+	uc := NewRelayOutboxUseCase(&mocks.MockUnitOfWork{OutboxRepo: &mocks.MockOutboxRepo{Entries: []*domain.OutboxEntry{legacy}}}, pub, "bus", 10)
+
+	if _, err := uc.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(pub.Published) != 1 {
+		t.Fatalf("Publish calls = %d, want 1", len(pub.Published))
+	}
+	got := pub.Published[0]
+	if got.ID != "e1" || got.DataVersion != 1 || got.SpecVersion != domain.CloudEventsSpecVersion {
+		t.Errorf("envelope = %+v, want v1 envelope with entry ID", got)
+	}
+	decoded, err := got.Decode()
+	if added, ok := decoded.(domain.StockAddedEvent); err != nil || !ok || added.Quantity.Value() != 5 {
+		t.Errorf("Decode() = %+v, %v; want upcast StockAddedEvent", decoded, err)
+	}
+}
@@ -0,0 +1,230 @@
+// internal/application/usecases/remove_stock_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type RemoveStockRequest struct {
+	ProductID string
+	Quantity  int
+	TenantID  string
+	Notes     string
+	RemovedBy string
+	// Set when an approved change request runs the removal
+	ApprovedBy        string
+	ApprovalRequestID string
+	// Exact quantity for decimal-mode products; used instead of Quantity
+	// when set
+	DecimalQuantity *domain.Decimal
+}
+
+func (req RemoveStockRequest) entered() domain.Decimal {
+	if req.DecimalQuantity != nil {
+		return *req.DecimalQuantity
+	}
+	return domain.DecimalFromInt(req.Quantity)
+}
+
+// Output DTO
+type RemoveStockResponse struct {
+	ProductID     string
+	ProductName   string
+	PreviousStock int
+	NewStock      int
+	Removed       int
+	// What the removed units cost under the tenant's costing method
+	CostOfGoods domain.Money
+	// Exact quantities of a decimal-mode product; the ints above are its
+	// whole units
+	Decimal *domain.DecimalStockChange
+	// Set instead of changing stock when the removal awaits approval
+	PendingApproval *PendingApproval
+}
+
+// Use Case interface (what handlers depend on)
+type RemoveStockUseCase interface {
+	Execute(ctx context.Context, req RemoveStockRequest) (*RemoveStockResponse, error)
+}
+
+// Implementation
+type removeStockUseCase struct {
+	uow        interfaces.UnitOfWork
+	ledger     stockLedger
+	costing    stockCosting
+	forecaster stockForecaster
+}
+
+func NewRemoveStockUseCase(uow interfaces.UnitOfWork) RemoveStockUseCase {
+	return &removeStockUseCase{
+		uow:        uow,
+		ledger:     stockLedger{uow: uow, snapshotEvery: domain.DefaultSnapshotInterval},
+		costing:    stockCosting{uow: uow},
+		forecaster: stockForecaster{uow: uow},
+	}
+}
+
+func (uc *removeStockUseCase) Execute(ctx context.Context, req RemoveStockRequest) (*RemoveStockResponse, error) {
+	// 1. Validate input
+	if req.ProductID == "" {
+		return nil, domain.ErrInvalidProductID
+	}
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	entered := req.entered()
+	if entered.Sign() <= 0 {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	// 2. Get and validate tenant
+	tenant, err := uc.uow.Tenants().FindByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tenant.CanReceiveStock(); err != nil {
+		return nil, err
+	}
+
+	// 3. Get product
+	product, err := uc.uow.Products().FindByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != req.TenantID {
+		return nil, domain.ErrProductNotFound
+	}
+	if product.IsLockedForCount() {
+		return nil, domain.ErrProductLockedForCount
+	}
+	quantity, err := product.NewQuantity(entered)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Large removals wait for a second person when the tenant requires it
+	if req.ApprovedBy == "" && tenant.RequiresApprovalFor(quantity) {
+		request := domain.NewStockChangeRequest(tenant, product, domain.ChangeOperationRemove, entered.IntPart(), req.Notes, req.RemovedBy)
+		request.DecimalQuantity = req.DecimalQuantity
+		pending, err := requestApproval(ctx, uc.uow, request)
+		if err != nil {
+			return nil, err
+		}
+		return &RemoveStockResponse{
+			ProductID:       product.ID,
+			ProductName:     product.Name,
+			PreviousStock:   product.CurrentStock.Value(),
+			NewStock:        product.CurrentStock.Value(),
+			PendingApproval: pending,
+		}, nil
+	}
+
+	var projection *domain.StockProjection
+	var ledgerEntries []domain.StockLedgerEntry
+	if tenant.IsEventSourced() {
+		// The ledger counts whole units
+		if product.IsDecimal() {
+			return nil, domain.ErrDecimalUnsupported
+		}
+		projection, ledgerEntries, err = uc.ledger.open(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+		product.CurrentStock = projection.CurrentStock()
+	}
+
+	// 5. Remove stock
+	previousStock := product.CurrentStock
+	if err := product.RemoveStock(quantity); err != nil {
+		return nil, err
+	}
+	if projection != nil {
+		entry, err := projection.Record(domain.LedgerStockRemoved, -quantity.Value(), req.RemovedBy, req.Notes)
+		if err != nil {
+			return nil, err
+		}
+		ledgerEntries = append(ledgerEntries, entry)
+	}
+
+	// 6. Cost the removed units under the tenant's costing method
+	now := time.Now()
+	cost, costOfGoods, err := uc.costing.consume(ctx, tenant, product, quantity.Value(), previousStock.Value(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Build audit log and domain events
+	var costOfGoodsRemoved *domain.Money
+	if cost != nil {
+		costOfGoodsRemoved = &costOfGoods
+	}
+	removedEvent := domain.StockRemovedEvent{
+		ProductID:  product.ID,
+		TenantID:   req.TenantID,
+		Quantity:   quantity,
+		Previous:   previousStock,
+		Current:    product.CurrentStock,
+		RemovedBy:  req.RemovedBy,
+		ApprovedBy: req.ApprovedBy,
+		Reference:  req.ApprovalRequestID,
+		Notes:      req.Notes,
+		Timestamp:  now,
+
+		CostOfGoods: costOfGoodsRemoved,
+	}
+	events := []domain.Event{removedEvent}
+	// Low stock by the fixed threshold, or by the forecast reorder point
+	// when the tenant alerts on forecasts
+	lowStock, err := uc.forecaster.lowStockEvent(ctx, tenant, product, now)
+	if err != nil {
+		return nil, err
+	}
+	if lowStock != nil {
+		events = append(events, *lowStock)
+	}
+	outboxEntries := make([]*domain.OutboxEntry, 0, len(events))
+	for _, event := range events {
+		entry, err := domain.NewOutboxEntry(event, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		outboxEntries = append(outboxEntries, entry)
+	}
+	history := domain.StockRemovedHistoryEntry(removedEvent)
+
+	// 8. Save product, history, cost layers, ledger and outbox atomically
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if projection != nil {
+			if err := uc.ledger.append(ctx, projection, ledgerEntries); err != nil {
+				return err
+			}
+		}
+		if err := uc.uow.Products().UpdateStock(ctx, product.ID, product.CurrentStock); err != nil {
+			return err
+		}
+		if err := uc.uow.StockHistory().Append(ctx, &history); err != nil {
+			return err
+		}
+		if err := uc.costing.save(ctx, cost); err != nil {
+			return err
+		}
+		return uc.uow.Outbox().Append(ctx, outboxEntries)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveStockResponse{
+		ProductID:     product.ID,
+		ProductName:   product.Name,
+		PreviousStock: previousStock.Value(),
+		NewStock:      product.CurrentStock.Value(),
+		Removed:       quantity.Value(),
+		CostOfGoods:   costOfGoods,
+		Decimal:       domain.NewDecimalStockChange(quantity.Decimal(), previousStock, product.CurrentStock),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func TestRemoveStockUseCase_Execute_Success(t *testing.T) {
+	uow, products := widgetFixture(30)
+	uc := NewRemoveStockUseCase(uow)
+
+	got, err := uc.Execute(context.Background(), RemoveStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 25, Notes: "order 42", RemovedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PreviousStock != 30 || got.NewStock != 5 || got.Removed != 25 || got.PendingApproval != nil {
+		t.Errorf("response = %+v", got)
+	}
+	if products.StockUpdates["p1"] != 5 {
+		t.Errorf("stored stock = %d, want 5", products.StockUpdates["p1"])
+	}
+
+	entry := uow.StockHistRepo.Entries[0]
+	if entry.Operation != domain.HistoryOperationStockRemove || entry.Quantity != -25 || entry.Actor != "u1" {
+		t.Errorf("history entry = %+v", entry)
+	}
+	var types []string
+	for _, e := range uow.OutboxRepo.Entries {
+		types = append(types, e.EventType)
+	}
+	if len(types) != 2 || types[0] != domain.EventTypeStockRemoved || types[1] != domain.EventTypeLowStock {
+		t.Errorf("outbox event types = %v, want stock.removed and stock.low", types)
+	}
+}
+
+func TestRemoveStockUseCase_Execute_Rejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		lock     string
+		want     error
+	}{
+		{"zero quantity", 0, "", domain.ErrInvalidQuantity},
+		{"negative quantity", -3, "", domain.ErrInvalidQuantity},
+		{"more than in stock", 11, "", domain.ErrInsufficientStock},
+		{"locked for count", 1, "c1", domain.ErrProductLockedForCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, products := widgetFixture(10)
+			products.Products[0].CountSessionID = tt.lock
+
+			_, err := NewRemoveStockUseCase(uow).Execute(context.Background(), RemoveStockRequest{
+				ProductID: "p1", TenantID: "t1", Quantity: tt.quantity,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.want)
+			}
+			if len(uow.StockHistRepo.Entries) != 0 {
+				t.Errorf("history written for rejected removal")
+			}
+		})
+	}
+}
+
+func TestRemoveStockUseCase_Execute_EventSourced_RecordsLedgerRemoval(t *testing.T) {
+	uow, _ := widgetFixture(10)
+	uow.TenantsRepo.Tenant.StockMode = domain.StockModeEventSourced
+	ledger := &mocks.MockStockLedgerRepo{}
+	uow.LedgerRepo = ledger
+
+	if _, err := NewRemoveStockUseCase(uow).Execute(context.Background(), RemoveStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 4, RemovedBy: "u1",
+	}); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(ledger.Entries) != 2 {
+		t.Fatalf("ledger entries = %d, want 2", len(ledger.Entries))
+	}
+	if removed := ledger.Entries[1]; removed.Type != domain.LedgerStockRemoved || removed.Delta != -4 {
+		t.Errorf("removal entry = %+v", removed)
+	}
+}
+
+func TestRemoveStockUseCase_Execute_ForecastAlert(t *testing.T) {
+	uow, _ := widgetFixture(30)
+	// 56 removed over the last 28 days is 2 a day, for a reorder point of 20
+	uow.StockHistRepo.Entries = []domain.StockHistoryEntry{
+		{TenantID: "t1", ProductID: "p1", Operation: domain.HistoryOperationStockRemove, Quantity: -56, CreatedAt: daysAgo(1)},
+	}
+	uow.TenantsRepo.Tenant.Forecast = domain.ForecastSettings{Alerts: true}
+	uc := NewRemoveStockUseCase(uow)
+
+	if _, err := uc.Execute(context.Background(), RemoveStockRequest{ProductID: "p1", TenantID: "t1", Quantity: 12}); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	entries := uow.OutboxRepo.Entries
+	if len(entries) != 2 || entries[1].EventType != domain.EventTypeLowStock {
+		t.Fatalf("outbox entries = %d, want stock.removed and stock.low", len(entries))
+	}
+	var envelope struct {
+		Data domain.LowStockEvent `json:"data"`
+	}
+	if err := json.Unmarshal(entries[1].Payload, &envelope); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := envelope.Data; got.Threshold != 20 || got.ReorderPoint != 20 || got.SuggestedQuantity != 62 {
+		t.Errorf("low stock event = %+v", got)
+	}
+}
@@ -0,0 +1,226 @@
+// internal/application/usecases/reverse_stock_movement_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type ReverseStockMovementRequest struct {
+	TenantID       string
+	HistoryEntryID string
+	Notes          string
+	ReversedBy     string
+}
+
+// Output DTO
+type ReverseStockMovementResponse struct {
+	HistoryID       string
+	OriginalEntryID string
+	ProductID       string
+	ProductName     string
+	PreviousStock   int
+	NewStock        int
+	Delta           int
+	// Exact quantities of a decimal-mode product
+	Decimal *domain.DecimalStockChange
+}
+
+// Use Case interface (what handlers depend on)
+type ReverseStockMovementUseCase interface {
+	Execute(ctx context.Context, req ReverseStockMovementRequest) (*ReverseStockMovementResponse, error)
+}
+
+// Implementation
+type reverseStockMovementUseCase struct {
+	uow     interfaces.UnitOfWork
+	ledger  stockLedger
+	costing stockCosting
+}
+
+func NewReverseStockMovementUseCase(uow interfaces.UnitOfWork) ReverseStockMovementUseCase {
+	return &reverseStockMovementUseCase{
+		uow:     uow,
+		ledger:  stockLedger{uow: uow, snapshotEvery: domain.DefaultSnapshotInterval},
+		costing: stockCosting{uow: uow},
+	}
+}
+
+func (uc *reverseStockMovementUseCase) Execute(ctx context.Context, req ReverseStockMovementRequest) (*ReverseStockMovementResponse, error) {
+	// 1. Validate input
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if req.HistoryEntryID == "" {
+		return nil, domain.ErrHistoryEntryNotFound
+	}
+
+	// 2. Get the movement to reverse
+	original, err := uc.uow.StockHistory().FindByID(ctx, req.TenantID, req.HistoryEntryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := original.CanBeReversed(); err != nil {
+		return nil, err
+	}
+
+	// 3. Get and validate tenant
+	tenant, err := uc.uow.Tenants().FindByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tenant.CanReceiveStock(); err != nil {
+		return nil, err
+	}
+
+	// 4. Get product
+	product, err := uc.uow.Products().FindByID(ctx, original.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != req.TenantID {
+		return nil, domain.ErrProductNotFound
+	}
+	if product.IsLockedForCount() {
+		return nil, domain.ErrProductLockedForCount
+	}
+
+	var projection *domain.StockProjection
+	var ledgerEntries []domain.StockLedgerEntry
+	if tenant.IsEventSourced() {
+		// The ledger counts whole units
+		if product.IsDecimal() {
+			return nil, domain.ErrDecimalUnsupported
+		}
+		projection, ledgerEntries, err = uc.ledger.open(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+		product.CurrentStock = projection.CurrentStock()
+	}
+
+	// 5. Apply the opposite movement under the usual add and removal rules:
+	// undoing a removal may not exceed the tenant limit, undoing an add
+	// may not take stock below zero
+	delta := -original.Quantity
+	exactDelta := domain.DecimalFromInt(delta)
+	if original.Decimal != nil {
+		exactDelta = original.Decimal.Quantity.Neg()
+	}
+	previousStock := product.CurrentStock
+	if exactDelta.Sign() > 0 {
+		quantity, err := domain.NewDecimalStockQuantity(exactDelta)
+		if err != nil {
+			return nil, err
+		}
+		if err := product.AddStock(quantity, tenant.MaxStock); err != nil {
+			return nil, err
+		}
+	} else {
+		quantity, err := domain.NewDecimalStockQuantity(exactDelta.Neg())
+		if err != nil {
+			return nil, err
+		}
+		if err := product.RemoveStock(quantity); err != nil {
+			return nil, err
+		}
+	}
+	if projection != nil {
+		entry, err := projection.Record(domain.LedgerStockReversed, delta, req.ReversedBy, req.Notes)
+		if err != nil {
+			return nil, err
+		}
+		ledgerEntries = append(ledgerEntries, entry)
+	}
+
+	// 6. Undoing a removal returns its units at what they cost; undoing an
+	// add takes units out of the cost layers like a removal
+	now := time.Now()
+	var cost *domain.ProductCost
+	var value domain.Money
+	switch {
+	case exactDelta.Sign() > 0 && original.Cost != nil:
+		value = *original.Cost
+		cost, err = uc.costing.restore(ctx, tenant, product, delta, value, now)
+	case exactDelta.Sign() > 0:
+		cost, value, err = uc.costing.receive(ctx, tenant, product, delta, nil, now)
+	default:
+		cost, value, err = uc.costing.consume(ctx, tenant, product, -delta, previousStock.Value(), now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Build the compensating entry and event
+	history := &domain.StockHistoryEntry{
+		ProductID:     product.ID,
+		TenantID:      req.TenantID,
+		Operation:     domain.HistoryOperationReversal,
+		Quantity:      delta,
+		PreviousStock: previousStock.Value(),
+		NewStock:      product.CurrentStock.Value(),
+		Actor:         req.ReversedBy,
+		Notes:         req.Notes,
+		ReversalOf:    original.ID,
+		Decimal:       domain.NewDecimalStockChange(exactDelta, previousStock, product.CurrentStock),
+		CreatedAt:     now,
+	}
+	if cost != nil {
+		history.Cost = &value
+	}
+	outboxEntry, err := domain.NewOutboxEntry(domain.StockReversedEvent{
+		ProductID:         product.ID,
+		TenantID:          req.TenantID,
+		Delta:             delta,
+		Previous:          previousStock,
+		Current:           product.CurrentStock,
+		ReversedBy:        req.ReversedBy,
+		OriginalEntryID:   original.ID,
+		OriginalOperation: original.Operation,
+		Notes:             req.Notes,
+		Timestamp:         now,
+	}, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 8. Save product, both history links, cost layers, ledger and outbox atomically.
+	// Marking the original fails if another reversal got there first.
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if projection != nil {
+			if err := uc.ledger.append(ctx, projection, ledgerEntries); err != nil {
+				return err
+			}
+		}
+		if err := uc.uow.Products().UpdateStock(ctx, product.ID, product.CurrentStock); err != nil {
+			return err
+		}
+		if err := uc.uow.StockHistory().Append(ctx, history); err != nil {
+			return err
+		}
+		if err := uc.uow.StockHistory().MarkReversed(ctx, req.TenantID, original.ID, history.ID); err != nil {
+			return err
+		}
+		if err := uc.costing.save(ctx, cost); err != nil {
+			return err
+		}
+		return uc.uow.Outbox().Append(ctx, []*domain.OutboxEntry{outboxEntry})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReverseStockMovementResponse{
+		HistoryID:       history.ID,
+		OriginalEntryID: original.ID,
+		ProductID:       product.ID,
+		ProductName:     product.Name,
+		PreviousStock:   history.PreviousStock,
+		NewStock:        history.NewStock,
+		Delta:           delta,
+		Decimal:         history.Decimal,
+	}, nil
+}
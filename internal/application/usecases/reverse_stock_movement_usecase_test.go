@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func reversalFixture(stock int, history ...domain.StockHistoryEntry) (*mocks.MockUnitOfWork, *mocks.MockProductRepo) {
+	uow, products := widgetFixture(stock)
+	uow.StockHistRepo.Entries = history
+	return uow, products
+}
+
+func TestReverseStockMovementUseCase_ReversesAdd(t *testing.T) {
+	uow, products := reversalFixture(50, domain.StockHistoryEntry{
+		ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockAdd,
+		Quantity: 40, PreviousStock: 10, NewStock: 50, Actor: "clerk",
+	})
+	uc := NewReverseStockMovementUseCase(uow)
+
+	got, err := uc.Execute(context.Background(), ReverseStockMovementRequest{
+		TenantID: "t1", HistoryEntryID: "h1", Notes: "typed 40 instead of 4", ReversedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PreviousStock != 50 || got.NewStock != 10 || got.Delta != -40 || got.OriginalEntryID != "h1" {
+		t.Errorf("response = %+v", got)
+	}
+	if products.StockUpdates["p1"] != 10 {
+		t.Errorf("stored stock = %d, want 10", products.StockUpdates["p1"])
+	}
+
+	entries := uow.StockHistRepo.Entries
+	if len(entries) != 2 {
+		t.Fatalf("history entries = %d, want 2", len(entries))
+	}
+	original, reversal := entries[0], entries[1]
+	if reversal.Operation != domain.HistoryOperationReversal || reversal.Quantity != -40 || reversal.ReversalOf != "h1" || reversal.Actor != "u1" {
+		t.Errorf("reversal entry = %+v", reversal)
+	}
+	if original.ReversedBy != reversal.ID {
+		t.Errorf("original ReversedBy = %q, want %q", original.ReversedBy, reversal.ID)
+	}
+	if len(uow.OutboxRepo.Entries) != 1 || uow.OutboxRepo.Entries[0].EventType != domain.EventTypeStockReversed {
+		t.Errorf("outbox = %+v, want one stock.reversed entry", uow.OutboxRepo.Entries)
+	}
+
+	// A second reversal of the same movement is refused
+	_, err = uc.Execute(context.Background(), ReverseStockMovementRequest{TenantID: "t1", HistoryEntryID: "h1"})
+	if !errors.Is(err, domain.ErrMovementAlreadyReversed) {
+		t.Errorf("second Execute() err = %v, want %v", err, domain.ErrMovementAlreadyReversed)
+	}
+}
+
+func TestReverseStockMovementUseCase_ReversesRemovalWithinLimit(t *testing.T) {
+	uow, _ := reversalFixture(95, domain.StockHistoryEntry{
+		ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockRemove, Quantity: -10,
+	})
+
+	_, err := NewReverseStockMovementUseCase(uow).Execute(context.Background(), ReverseStockMovementRequest{
+		TenantID: "t1", HistoryEntryID: "h1",
+	})
+	var limitErr domain.ErrStockExceedsLimit
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("Execute() err = %v, want ErrStockExceedsLimit", err)
+	}
+	if uow.StockHistRepo.Entries[0].ReversedBy != "" || len(uow.StockHistRepo.Entries) != 1 {
+		t.Errorf("failed reversal changed history")
+	}
+}
+
+func TestReverseStockMovementUseCase_Rejections(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry domain.StockHistoryEntry
+		id    string
+		stock int
+		want  error
+	}{
+		{
+			name:  "unknown entry",
+			entry: domain.StockHistoryEntry{ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockAdd, Quantity: 5},
+			id:    "h9", stock: 10, want: domain.ErrHistoryEntryNotFound,
+		},
+		{
+			name:  "other tenant",
+			entry: domain.StockHistoryEntry{ID: "h1", ProductID: "p1", TenantID: "t2", Operation: domain.HistoryOperationStockAdd, Quantity: 5},
+			id:    "h1", stock: 10, want: domain.ErrHistoryEntryNotFound,
+		},
+		{
+			name:  "adjustment",
+			entry: domain.StockHistoryEntry{ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockAdjustment, Quantity: -2},
+			id:    "h1", stock: 10, want: domain.ErrMovementNotReversible,
+		},
+		{
+			name:  "reversal",
+			entry: domain.StockHistoryEntry{ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationReversal, Quantity: -2, ReversalOf: "h0"},
+			id:    "h1", stock: 10, want: domain.ErrMovementNotReversible,
+		},
+		{
+			name:  "stock already gone",
+			entry: domain.StockHistoryEntry{ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockAdd, Quantity: 20},
+			id:    "h1", stock: 5, want: domain.ErrInsufficientStock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, _ := reversalFixture(tt.stock, tt.entry)
+			_, err := NewReverseStockMovementUseCase(uow).Execute(context.Background(), ReverseStockMovementRequest{
+				TenantID: "t1", HistoryEntryID: tt.id,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Execute() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStockHistoryUseCase_ProductHistory_ShowsReversalLinks(t *testing.T) {
+	uow, _ := reversalFixture(50, domain.StockHistoryEntry{
+		ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockAdd, Quantity: 40,
+	})
+	if _, err := NewReverseStockMovementUseCase(uow).Execute(context.Background(), ReverseStockMovementRequest{
+		TenantID: "t1", HistoryEntryID: "h1",
+	}); err != nil {
+		t.Fatalf("reverse err = %v", err)
+	}
+
+	got, err := NewStockHistoryUseCase(uow).ProductHistory(context.Background(), ProductHistoryRequest{TenantID: "t1", ProductID: "p1"})
+	if err != nil {
+		t.Fatalf("ProductHistory() err = %v", err)
+	}
+	if len(got.Entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(got.Entries))
+	}
+	newest, oldest := got.Entries[0], got.Entries[1]
+	if newest.ReversalOf != "h1" || oldest.ReversedBy != newest.ID {
+		t.Errorf("links: newest=%+v oldest=%+v", newest, oldest)
+	}
+
+	if _, err := NewStockHistoryUseCase(uow).ProductHistory(context.Background(), ProductHistoryRequest{TenantID: "t2", ProductID: "p1"}); !errors.Is(err, domain.ErrProductNotFound) {
+		t.Errorf("other tenant err = %v, want %v", err, domain.ErrProductNotFound)
+	}
+}
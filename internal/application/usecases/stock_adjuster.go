@@ -0,0 +1,149 @@
+// internal/application/usecases/stock_adjuster.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// A signed stock correction and why it was made
+type stockAdjustment struct {
+	Delta      int
+	Operation  string // stock_history.operation
+	ReasonCode string
+	Actor      string
+	Notes      string
+	// Document behind the adjustment, e.g. a count session ID. A product
+	// locked by a count can only be adjusted by that count.
+	Reference string
+}
+
+// Applies stock corrections: product, history, cost layers, ledger and a
+// stock.adjusted event are written in one transaction. Shared by the use cases that
+// correct stock rather than receive it.
+type stockAdjuster struct {
+	uow     interfaces.UnitOfWork
+	ledger  stockLedger
+	costing stockCosting
+}
+
+func newStockAdjuster(uow interfaces.UnitOfWork) stockAdjuster {
+	return stockAdjuster{
+		uow:     uow,
+		ledger:  stockLedger{uow: uow, snapshotEvery: domain.DefaultSnapshotInterval},
+		costing: stockCosting{uow: uow},
+	}
+}
+
+// apply adjusts a loaded product. It joins the caller's transaction when
+// there is one.
+func (a stockAdjuster) apply(ctx context.Context, tenant *domain.Tenant, product *domain.Product, adj stockAdjustment) (*domain.StockHistoryEntry, error) {
+	if adj.ReasonCode == "" {
+		return nil, domain.ErrReasonCodeRequired
+	}
+	if product.IsLockedForCount() && product.CountSessionID != adj.Reference {
+		return nil, domain.ErrProductLockedForCount
+	}
+	// Deltas, counts and the ledger are in whole units
+	if product.IsDecimal() {
+		return nil, domain.ErrDecimalUnsupported
+	}
+
+	var projection *domain.StockProjection
+	var ledgerEntries []domain.StockLedgerEntry
+	if tenant.IsEventSourced() {
+		var err error
+		projection, ledgerEntries, err = a.ledger.open(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+		product.CurrentStock = projection.CurrentStock()
+	}
+
+	previousStock := product.CurrentStock
+	if err := product.AdjustStock(adj.Delta); err != nil {
+		return nil, err
+	}
+	if projection != nil {
+		entry, err := projection.Record(domain.LedgerStockAdjusted, adj.Delta, adj.Actor, adj.Notes)
+		if err != nil {
+			return nil, err
+		}
+		ledgerEntries = append(ledgerEntries, entry)
+	}
+
+	// Found units are valued at the average unit cost; lost units leave
+	// the cost layers like a removal
+	now := time.Now()
+	var cost *domain.ProductCost
+	var value domain.Money
+	var err error
+	if adj.Delta > 0 {
+		cost, value, err = a.costing.receive(ctx, tenant, product, adj.Delta, nil, now)
+	} else if adj.Delta < 0 {
+		cost, value, err = a.costing.consume(ctx, tenant, product, -adj.Delta, previousStock.Value(), now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history := &domain.StockHistoryEntry{
+		ProductID:     product.ID,
+		TenantID:      tenant.ID,
+		Operation:     adj.Operation,
+		Quantity:      adj.Delta,
+		PreviousStock: previousStock.Value(),
+		NewStock:      product.CurrentStock.Value(),
+		Actor:         adj.Actor,
+		Notes:         adj.Notes,
+		ReasonCode:    adj.ReasonCode,
+		Reference:     adj.Reference,
+		Decimal:       domain.NewDecimalStockChange(domain.DecimalFromInt(adj.Delta), previousStock, product.CurrentStock),
+		CreatedAt:     now,
+	}
+	if cost != nil {
+		history.Cost = &value
+	}
+	outboxEntry, err := domain.NewOutboxEntry(domain.StockAdjustedEvent{
+		ProductID:  product.ID,
+		TenantID:   tenant.ID,
+		Delta:      adj.Delta,
+		Previous:   previousStock,
+		Current:    product.CurrentStock,
+		ReasonCode: adj.ReasonCode,
+		AdjustedBy: adj.Actor,
+		Operation:  adj.Operation,
+		Reference:  adj.Reference,
+		Notes:      adj.Notes,
+		Timestamp:  now,
+	}, tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if projection != nil {
+			if err := a.ledger.append(ctx, projection, ledgerEntries); err != nil {
+				return err
+			}
+		}
+		if err := a.uow.Products().UpdateStock(ctx, product.ID, product.CurrentStock); err != nil {
+			return err
+		}
+		if err := a.uow.StockHistory().Append(ctx, history); err != nil {
+			return err
+		}
+		if cost != nil {
+			if err := a.costing.save(ctx, cost); err != nil {
+				return err
+			}
+		}
+		return a.uow.Outbox().Append(ctx, []*domain.OutboxEntry{outboxEntry})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
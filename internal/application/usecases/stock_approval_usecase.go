@@ -0,0 +1,248 @@
+// internal/application/usecases/stock_approval_usecase.go
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Returned by stock use cases that held a change for approval
+type PendingApproval struct {
+	RequestID string
+	ExpiresAt time.Time
+}
+
+// Input DTO
+type DecideStockChangeRequest struct {
+	TenantID  string
+	RequestID string
+	DecidedBy string
+	// Roles of the deciding user; domain.RoleStockApprover is required
+	Roles []string
+	// Reason given when rejecting
+	Note string
+}
+
+// Output DTOs
+type StockChangeRequestResponse struct {
+	ID           string
+	TenantID     string
+	ProductID    string
+	ProductName  string
+	Operation    string
+	Quantity     int
+	Unit         string // empty when Quantity is in the base unit
+	Notes        string
+	Status       string
+	RequestedBy  string
+	RequestedAt  time.Time
+	ExpiresAt    time.Time
+	DecidedBy    string
+	DecidedAt    time.Time
+	DecisionNote string
+	// Exact quantity of a decimal-mode product
+	DecimalQuantity *domain.Decimal
+	// Set when approving applied the change
+	Applied *AppliedStockChange
+}
+
+type AppliedStockChange struct {
+	PreviousStock int
+	NewStock      int
+	// Exact quantities of a decimal-mode product
+	Decimal *domain.DecimalStockChange
+}
+
+// Use Case interface (what handlers depend on)
+type StockApprovalUseCase interface {
+	List(ctx context.Context, tenantID, status string) ([]StockChangeRequestResponse, error)
+	Get(ctx context.Context, tenantID, requestID string) (*StockChangeRequestResponse, error)
+	// Approve runs the requested add or removal
+	Approve(ctx context.Context, req DecideStockChangeRequest) (*StockChangeRequestResponse, error)
+	Reject(ctx context.Context, req DecideStockChangeRequest) (*StockChangeRequestResponse, error)
+	// ExpirePending expires requests that outlived their TTL
+	ExpirePending(ctx context.Context) (int64, error)
+}
+
+// Implementation
+type stockApprovalUseCase struct {
+	uow         interfaces.UnitOfWork
+	addStock    AddStockUseCase
+	removeStock RemoveStockUseCase
+}
+
+func NewStockApprovalUseCase(
+	uow interfaces.UnitOfWork,
+	addStock AddStockUseCase,
+	removeStock RemoveStockUseCase,
+) StockApprovalUseCase {
+	return &stockApprovalUseCase{
+		uow:         uow,
+		addStock:    addStock,
+		removeStock: removeStock,
+	}
+}
+
+func (uc *stockApprovalUseCase) List(ctx context.Context, tenantID, status string) ([]StockChangeRequestResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	requests, err := uc.uow.StockChangeRequests().FindByTenant(ctx, tenantID, status)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]StockChangeRequestResponse, 0, len(requests))
+	for _, r := range requests {
+		result = append(result, *toStockChangeRequestResponse(r))
+	}
+	return result, nil
+}
+
+func (uc *stockApprovalUseCase) Get(ctx context.Context, tenantID, requestID string) (*StockChangeRequestResponse, error) {
+	if tenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	request, err := uc.uow.StockChangeRequests().FindByID(ctx, tenantID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return toStockChangeRequestResponse(request), nil
+}
+
+func (uc *stockApprovalUseCase) Approve(ctx context.Context, req DecideStockChangeRequest) (*StockChangeRequestResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	request, err := uc.uow.StockChangeRequests().FindByID(ctx, req.TenantID, req.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.Approve(req.DecidedBy, req.Roles, time.Now()); err != nil {
+		return nil, uc.storeExpiry(ctx, request, err)
+	}
+
+	// Claiming the request in the same transaction as the change keeps
+	// two approvers from applying it twice; a failed change leaves it pending
+	var applied *AppliedStockChange
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.uow.StockChangeRequests().Decide(ctx, request); err != nil {
+			return err
+		}
+		var err error
+		applied, err = uc.apply(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toStockChangeRequestResponse(request)
+	response.Applied = applied
+	return response, nil
+}
+
+// apply runs the original use case on behalf of the requester.
+func (uc *stockApprovalUseCase) apply(ctx context.Context, request *domain.StockChangeRequest) (*AppliedStockChange, error) {
+	switch request.Operation {
+	case domain.ChangeOperationAdd:
+		result, err := uc.addStock.Execute(ctx, AddStockRequest{
+			ProductID:         request.ProductID,
+			Quantity:          request.Quantity,
+			Unit:              request.Unit,
+			TenantID:          request.TenantID,
+			Notes:             request.Notes,
+			AddedBy:           request.RequestedBy,
+			ApprovedBy:        request.DecidedBy,
+			ApprovalRequestID: request.ID,
+			SupplierID:        request.SupplierID,
+			UnitCost:          request.UnitCost,
+			DecimalQuantity:   request.DecimalQuantity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &AppliedStockChange{PreviousStock: result.PreviousStock, NewStock: result.NewStock, Decimal: result.Decimal}, nil
+	case domain.ChangeOperationRemove:
+		result, err := uc.removeStock.Execute(ctx, RemoveStockRequest{
+			ProductID:         request.ProductID,
+			Quantity:          request.Quantity,
+			TenantID:          request.TenantID,
+			Notes:             request.Notes,
+			RemovedBy:         request.RequestedBy,
+			ApprovedBy:        request.DecidedBy,
+			ApprovalRequestID: request.ID,
+			DecimalQuantity:   request.DecimalQuantity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &AppliedStockChange{PreviousStock: result.PreviousStock, NewStock: result.NewStock, Decimal: result.Decimal}, nil
+	}
+	return nil, fmt.Errorf("unknown stock change operation %q", request.Operation)
+}
+
+func (uc *stockApprovalUseCase) Reject(ctx context.Context, req DecideStockChangeRequest) (*StockChangeRequestResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	request, err := uc.uow.StockChangeRequests().FindByID(ctx, req.TenantID, req.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.Reject(req.DecidedBy, req.Roles, req.Note, time.Now()); err != nil {
+		return nil, uc.storeExpiry(ctx, request, err)
+	}
+	if err := uc.uow.StockChangeRequests().Decide(ctx, request); err != nil {
+		return nil, err
+	}
+	return toStockChangeRequestResponse(request), nil
+}
+
+func (uc *stockApprovalUseCase) ExpirePending(ctx context.Context) (int64, error) {
+	return uc.uow.StockChangeRequests().ExpirePending(ctx, time.Now())
+}
+
+// storeExpiry persists a request found expired while deciding on it and
+// returns the decision error.
+func (uc *stockApprovalUseCase) storeExpiry(ctx context.Context, request *domain.StockChangeRequest, decisionErr error) error {
+	if decisionErr != domain.ErrChangeRequestExpired {
+		return decisionErr
+	}
+	if err := uc.uow.StockChangeRequests().Decide(ctx, request); err != nil && err != domain.ErrChangeRequestClosed {
+		return err
+	}
+	return decisionErr
+}
+
+// requestApproval stores a pending change request in place of the change.
+func requestApproval(ctx context.Context, uow interfaces.UnitOfWork, request *domain.StockChangeRequest) (*PendingApproval, error) {
+	if err := uow.StockChangeRequests().Create(ctx, request); err != nil {
+		return nil, err
+	}
+	return &PendingApproval{RequestID: request.ID, ExpiresAt: request.ExpiresAt}, nil
+}
+
+func toStockChangeRequestResponse(r *domain.StockChangeRequest) *StockChangeRequestResponse {
+	return &StockChangeRequestResponse{
+		ID:           r.ID,
+		TenantID:     r.TenantID,
+		ProductID:    r.ProductID,
+		ProductName:  r.ProductName,
+		Operation:    r.Operation,
+		Quantity:     r.Quantity,
+		Unit:         r.Unit,
+		Notes:        r.Notes,
+		Status:       r.Status,
+		RequestedBy:  r.RequestedBy,
+		RequestedAt:  r.RequestedAt,
+		ExpiresAt:    r.ExpiresAt,
+		DecidedBy:    r.DecidedBy,
+		DecidedAt:    r.DecidedAt,
+		DecisionNote: r.DecisionNote,
+
+		DecimalQuantity: r.DecimalQuantity,
+	}
+}
@@ -0,0 +1,267 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+	"time"
+)
+
+var approverRoles = []string{domain.RoleStockApprover}
+
+func approvalFixture() (*mocks.MockUnitOfWork, *mocks.MockProductRepo, StockApprovalUseCase) {
+	uow, products := widgetFixture(40)
+	uow.TenantsRepo.Tenant.ApprovalThreshold = 20
+	addStock := NewAddStockUseCase(uow, nil)
+	removeStock := NewRemoveStockUseCase(uow)
+	return uow, products, NewStockApprovalUseCase(uow, addStock, removeStock)
+}
+
+func TestAddStockUseCase_Execute_AboveThresholdAwaitsApproval(t *testing.T) {
+	uow, products, _ := approvalFixture()
+
+	got, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 21, AddedBy: "alice", Notes: "big delivery",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PendingApproval == nil || got.PendingApproval.RequestID == "" {
+		t.Fatalf("response = %+v, want pending approval", got)
+	}
+	if got.NewStock != 40 || products.Products[0].CurrentStock.Value() != 40 {
+		t.Errorf("stock changed while awaiting approval: %+v", got)
+	}
+	if len(uow.StockHistRepo.Entries) != 0 || len(uow.OutboxRepo.Entries) != 0 {
+		t.Errorf("history or events written while awaiting approval")
+	}
+
+	request := uow.ChangesRepo.Requests[0]
+	if request.Status != domain.ChangeRequestPending || request.Operation != domain.ChangeOperationAdd ||
+		request.Quantity != 21 || request.RequestedBy != "alice" {
+		t.Errorf("change request = %+v", request)
+	}
+	if ttl := request.ExpiresAt.Sub(request.RequestedAt); ttl != domain.DefaultApprovalTTL {
+		t.Errorf("TTL = %v, want %v", ttl, domain.DefaultApprovalTTL)
+	}
+}
+
+func TestAddStockUseCase_Execute_AtThresholdAppliesDirectly(t *testing.T) {
+	uow, _, _ := approvalFixture()
+
+	got, err := NewAddStockUseCase(uow, nil).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 20, AddedBy: "alice",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.PendingApproval != nil || got.NewStock != 60 {
+		t.Errorf("response = %+v, want stock applied", got)
+	}
+}
+
+func TestStockApprovalUseCase_Approve_RunsAddWithAudit(t *testing.T) {
+	uow, _, uc := approvalFixture()
+	ctx := context.Background()
+	pending, err := NewAddStockUseCase(uow, nil).Execute(ctx, AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 30, AddedBy: "alice",
+	})
+	if err != nil {
+		t.Fatalf("AddStock err = %v", err)
+	}
+	requestID := pending.PendingApproval.RequestID
+
+	got, err := uc.Approve(ctx, DecideStockChangeRequest{TenantID: "t1", RequestID: requestID, DecidedBy: "bob", Roles: approverRoles})
+	if err != nil {
+		t.Fatalf("Approve() err = %v", err)
+	}
+	if got.Status != domain.ChangeRequestApproved || got.DecidedBy != "bob" {
+		t.Errorf("request = %+v", got)
+	}
+	if got.Applied == nil || got.Applied.PreviousStock != 40 || got.Applied.NewStock != 70 {
+		t.Errorf("applied = %+v, want 40 -> 70", got.Applied)
+	}
+
+	entry := uow.StockHistRepo.Entries[0]
+	if entry.Actor != "alice" || entry.ApprovedBy != "bob" || entry.Reference != requestID {
+		t.Errorf("history entry = %+v, want requester, approver and request reference", entry)
+	}
+
+	// The request can only be applied once
+	_, err = uc.Approve(ctx, DecideStockChangeRequest{TenantID: "t1", RequestID: requestID, DecidedBy: "carol", Roles: approverRoles})
+	if !errors.Is(err, domain.ErrChangeRequestClosed) {
+		t.Errorf("second Approve() err = %v, want %v", err, domain.ErrChangeRequestClosed)
+	}
+	if len(uow.StockHistRepo.Entries) != 1 {
+		t.Errorf("history entries = %d, want 1", len(uow.StockHistRepo.Entries))
+	}
+}
+
+func TestStockApprovalUseCase_Approve_RunsRemoval(t *testing.T) {
+	uow, products, uc := approvalFixture()
+	ctx := context.Background()
+	pending, err := NewRemoveStockUseCase(uow).Execute(ctx, RemoveStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 35, RemovedBy: "alice",
+	})
+	if err != nil || pending.PendingApproval == nil {
+		t.Fatalf("RemoveStock = %+v, %v; want pending approval", pending, err)
+	}
+
+	if _, err := uc.Approve(ctx, DecideStockChangeRequest{
+		TenantID: "t1", RequestID: pending.PendingApproval.RequestID, DecidedBy: "bob", Roles: approverRoles,
+	}); err != nil {
+		t.Fatalf("Approve() err = %v", err)
+	}
+	if products.Products[0].CurrentStock.Value() != 5 {
+		t.Errorf("stock = %d, want 5", products.Products[0].CurrentStock.Value())
+	}
+	if entry := uow.StockHistRepo.Entries[0]; entry.Operation != domain.HistoryOperationStockRemove || entry.ApprovedBy != "bob" {
+		t.Errorf("history entry = %+v", entry)
+	}
+}
+
+func TestStockApprovalUseCase_Approve_FailedChangeReturnsError(t *testing.T) {
+	uow, products, uc := approvalFixture()
+	ctx := context.Background()
+	pending, _ := NewRemoveStockUseCase(uow).Execute(ctx, RemoveStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 35, RemovedBy: "alice",
+	})
+	// Stock left in the meantime
+	products.Products[0].CurrentStock = mustQuantity(10)
+
+	_, err := uc.Approve(ctx, DecideStockChangeRequest{
+		TenantID: "t1", RequestID: pending.PendingApproval.RequestID, DecidedBy: "bob", Roles: approverRoles,
+	})
+	if !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("Approve() err = %v, want %v", err, domain.ErrInsufficientStock)
+	}
+	// The mock has no rollback; with Mongo the decision is undone with the transaction
+	if uow.TxCalls == 0 {
+		t.Errorf("approval did not run in a transaction")
+	}
+}
+
+func TestStockApprovalUseCase_DecisionRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		by      string
+		roles   []string
+		expired bool
+		want    error
+	}{
+		{"requester cannot approve", "alice", approverRoles, false, domain.ErrSelfApproval},
+		{"role required", "bob", nil, false, domain.ErrApproverRoleRequired},
+		{"expired", "bob", approverRoles, true, domain.ErrChangeRequestExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, _, uc := approvalFixture()
+			ctx := context.Background()
+			pending, _ := NewAddStockUseCase(uow, nil).Execute(ctx, AddStockRequest{
+				ProductID: "p1", TenantID: "t1", Quantity: 30, AddedBy: "alice",
+			})
+			if tt.expired {
+				uow.ChangesRepo.Requests[0].ExpiresAt = time.Now().Add(-time.Minute)
+			}
+
+			_, err := uc.Approve(ctx, DecideStockChangeRequest{
+				TenantID: "t1", RequestID: pending.PendingApproval.RequestID, DecidedBy: tt.by, Roles: tt.roles,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Approve() err = %v, want %v", err, tt.want)
+			}
+			wantStatus := domain.ChangeRequestPending
+			if tt.expired {
+				wantStatus = domain.ChangeRequestExpired
+			}
+			if got := uow.ChangesRepo.Requests[0].Status; got != wantStatus {
+				t.Errorf("stored status = %q, want %q", got, wantStatus)
+			}
+			if len(uow.StockHistRepo.Entries) != 0 {
+				t.Errorf("stock changed without approval")
+			}
+		})
+	}
+}
+
+func TestStockApprovalUseCase_Reject(t *testing.T) {
+	uow, products, uc := approvalFixture()
+	ctx := context.Background()
+	pending, _ := NewAddStockUseCase(uow, nil).Execute(ctx, AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 30, AddedBy: "alice",
+	})
+
+	got, err := uc.Reject(ctx, DecideStockChangeRequest{
+		TenantID: "t1", RequestID: pending.PendingApproval.RequestID, DecidedBy: "bob", Roles: approverRoles, Note: "wrong product",
+	})
+	if err != nil {
+		t.Fatalf("Reject() err = %v", err)
+	}
+	if got.Status != domain.ChangeRequestRejected || got.DecisionNote != "wrong product" || got.DecidedBy != "bob" {
+		t.Errorf("request = %+v", got)
+	}
+	if products.Products[0].CurrentStock.Value() != 40 {
+		t.Errorf("stock changed by rejected request")
+	}
+
+	listed, err := uc.List(ctx, "t1", domain.ChangeRequestPending)
+	if err != nil || len(listed) != 0 {
+		t.Errorf("pending after reject = %+v, %v", listed, err)
+	}
+}
+
+func TestStockApprovalUseCase_ExpirePending(t *testing.T) {
+	uow, _, uc := approvalFixture()
+	ctx := context.Background()
+	uow.TenantsRepo.Tenant.ApprovalTTL = time.Hour
+	for i := 0; i < 2; i++ {
+		if _, err := NewAddStockUseCase(uow, nil).Execute(ctx, AddStockRequest{
+			ProductID: "p1", TenantID: "t1", Quantity: 30, AddedBy: "alice",
+		}); err != nil {
+			t.Fatalf("AddStock err = %v", err)
+		}
+	}
+	uow.ChangesRepo.Requests[0].ExpiresAt = time.Now().Add(-time.Second)
+
+	expired, err := uc.ExpirePending(ctx)
+	if err != nil {
+		t.Fatalf("ExpirePending() err = %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired = %d, want 1", expired)
+	}
+	if uow.ChangesRepo.Requests[1].Status != domain.ChangeRequestPending {
+		t.Errorf("request within its TTL was expired")
+	}
+}
+
+func TestStockApprovalUseCase_Approve_AddsInEnteredUnit(t *testing.T) {
+	uow, products, uc := approvalFixture()
+	uow.TenantsRepo.Tenant.MaxStock = mustQuantity(1000)
+	products.Products[0].Units = []domain.UnitConversion{{Unit: "case", Quantity: 12, Of: "each"}}
+	ctx := context.Background()
+
+	// 2 cases are 24 each, above the threshold of 20
+	pending, err := NewAddStockUseCase(uow, nil).Execute(ctx, AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 2, Unit: "case", AddedBy: "alice",
+	})
+	if err != nil {
+		t.Fatalf("AddStock err = %v", err)
+	}
+	if pending.PendingApproval == nil {
+		t.Fatalf("response = %+v, want pending approval", pending)
+	}
+
+	got, err := uc.Approve(ctx, DecideStockChangeRequest{TenantID: "t1", RequestID: pending.PendingApproval.RequestID, DecidedBy: "bob", Roles: approverRoles})
+	if err != nil {
+		t.Fatalf("Approve() err = %v", err)
+	}
+	if got.Quantity != 2 || got.Unit != "case" || got.Applied.NewStock != 64 {
+		t.Errorf("request = %+v, applied = %+v", got, got.Applied)
+	}
+	if entry := uow.StockHistRepo.Entries[0]; entry.Quantity != 24 || entry.EnteredQuantity != domain.DecimalFromInt(2) || entry.Unit != "case" {
+		t.Errorf("history entry = %+v", entry)
+	}
+}
@@ -0,0 +1,78 @@
+// internal/application/usecases/stock_costing.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Values stock movements at the product's cost layers, under the tenant's
+// costing method. Shared by the use cases that change stock; the changed
+// layers are saved in the movement's transaction. Layers count whole
+// units, so decimal-mode products are not costed: each method returns a
+// nil cost for them.
+type stockCosting struct {
+	uow interfaces.UnitOfWork
+}
+
+// receive adds units bought together for value, or valued at the
+// product's average unit cost when value is nil, and returns the value
+// used.
+func (s stockCosting) receive(ctx context.Context, tenant *domain.Tenant, product *domain.Product, quantity int, value *domain.Money, at time.Time) (*domain.ProductCost, domain.Money, error) {
+	if product.IsDecimal() {
+		return nil, domain.Money{}, nil
+	}
+	cost, err := s.uow.ProductCosts().FindByProduct(ctx, tenant.ID, product.ID)
+	if err != nil {
+		return nil, domain.Money{}, err
+	}
+	received := cost.AverageUnitCost().Times(quantity)
+	if value != nil {
+		received = *value
+	}
+	if err := cost.Receive(quantity, received, tenant.Costing(), at); err != nil {
+		return nil, domain.Money{}, err
+	}
+	cost.UpdatedAt = at
+	return cost, received, nil
+}
+
+// restore puts back units that left costing value, e.g. a reversed removal
+func (s stockCosting) restore(ctx context.Context, tenant *domain.Tenant, product *domain.Product, quantity int, value domain.Money, at time.Time) (*domain.ProductCost, error) {
+	if product.IsDecimal() {
+		return nil, nil
+	}
+	cost, err := s.uow.ProductCosts().FindByProduct(ctx, tenant.ID, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := cost.Return(quantity, value, tenant.Costing(), at); err != nil {
+		return nil, err
+	}
+	cost.UpdatedAt = at
+	return cost, nil
+}
+
+// consume takes units out of a product that had stockBefore units and
+// returns what they cost.
+func (s stockCosting) consume(ctx context.Context, tenant *domain.Tenant, product *domain.Product, quantity, stockBefore int, at time.Time) (*domain.ProductCost, domain.Money, error) {
+	if product.IsDecimal() {
+		return nil, domain.Money{}, nil
+	}
+	cost, err := s.uow.ProductCosts().FindByProduct(ctx, tenant.ID, product.ID)
+	if err != nil {
+		return nil, domain.Money{}, err
+	}
+	value := cost.Consume(quantity, stockBefore)
+	cost.UpdatedAt = at
+	return cost, value, nil
+}
+
+func (s stockCosting) save(ctx context.Context, cost *domain.ProductCost) error {
+	if cost == nil {
+		return nil
+	}
+	return s.uow.ProductCosts().Save(ctx, cost)
+}
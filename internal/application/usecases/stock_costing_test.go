@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func costingFixture(method string, stock int) (*mocks.MockUnitOfWork, *mocks.MockProductCostRepo) {
+	uow, _ := widgetFixture(stock)
+	uow.TenantsRepo.Tenant.CostingMethod = method
+	costs := &mocks.MockProductCostRepo{}
+	uow.CostsRepo = costs
+	return uow, costs
+}
+
+func mustMoney(s string) *domain.Money {
+	m, err := domain.ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return &m
+}
+
+func receiveAt(t *testing.T, uow *mocks.MockUnitOfWork, quantity int, unitCost string) {
+	t.Helper()
+	_, err := NewAddStockUseCase(uow, &mocks.MockNotificationService{}).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: quantity, AddedBy: "u1", UnitCost: mustMoney(unitCost),
+	})
+	if err != nil {
+		t.Fatalf("add stock: %v", err)
+	}
+}
+
+func TestStockCosting_CostOfGoodsRemovedByMethod(t *testing.T) {
+	tests := []struct {
+		method        string
+		wantCOGS      string
+		wantRemaining string
+	}{
+		// The oldest layer leaves first: 10 x 2.00 + 5 x 3.00
+		{domain.CostingFIFO, "35.00", "15.00"},
+		// Every unit costs the average of 2.50
+		{domain.CostingWeightedAverage, "37.50", "12.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			uow, costs := costingFixture(tt.method, 0)
+			receiveAt(t, uow, 10, "2.00")
+			receiveAt(t, uow, 10, "3.00")
+
+			got, err := NewRemoveStockUseCase(uow).Execute(context.Background(), RemoveStockRequest{
+				ProductID: "p1", TenantID: "t1", Quantity: 15, RemovedBy: "u1",
+			})
+			if err != nil {
+				t.Fatalf("Execute() err = %v", err)
+			}
+			if got.CostOfGoods.String() != tt.wantCOGS {
+				t.Errorf("cost of goods = %s, want %s", got.CostOfGoods, tt.wantCOGS)
+			}
+			cost := costs.Cost("p1")
+			if cost.Quantity() != 5 || cost.Value().String() != tt.wantRemaining {
+				t.Errorf("remaining layers = %d units worth %s, want 5 worth %s", cost.Quantity(), cost.Value(), tt.wantRemaining)
+			}
+
+			entries := uow.StockHistRepo.Entries
+			removal := entries[len(entries)-1]
+			if removal.Cost == nil || removal.Cost.String() != tt.wantCOGS {
+				t.Errorf("history cost = %v, want %s", removal.Cost, tt.wantCOGS)
+			}
+			if entries[0].UnitCost == nil || entries[0].UnitCost.String() != "2.00" || entries[0].Cost.String() != "20.00" {
+				t.Errorf("add entry cost = %v / %v", entries[0].UnitCost, entries[0].Cost)
+			}
+		})
+	}
+}
+
+func TestStockCosting_UncostedUnitsLeaveFirst(t *testing.T) {
+	// 5 units were on hand before costing began
+	uow, costs := costingFixture(domain.CostingFIFO, 5)
+	receiveAt(t, uow, 5, "4.00")
+
+	got, err := NewRemoveStockUseCase(uow).Execute(context.Background(), RemoveStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 6, RemovedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.CostOfGoods.String() != "4.00" {
+		t.Errorf("cost of goods = %s, want 4.00", got.CostOfGoods)
+	}
+	if cost := costs.Cost("p1"); cost.Quantity() != 4 || cost.Value().String() != "16.00" {
+		t.Errorf("remaining layers = %d units worth %s", cost.Quantity(), cost.Value())
+	}
+}
+
+func TestStockCosting_AddWithoutUnitCostUsesAverage(t *testing.T) {
+	uow, costs := costingFixture(domain.CostingFIFO, 0)
+	receiveAt(t, uow, 10, "2.00")
+	receiveAt(t, uow, 10, "3.00")
+
+	_, err := NewAddStockUseCase(uow, &mocks.MockNotificationService{}).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 4, AddedBy: "u1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if cost := costs.Cost("p1"); cost.Quantity() != 24 || cost.Value().String() != "60.00" {
+		t.Errorf("layers = %d units worth %s, want 24 worth 60.00", cost.Quantity(), cost.Value())
+	}
+	entries := uow.StockHistRepo.Entries
+	if last := entries[len(entries)-1]; last.UnitCost == nil || last.UnitCost.String() != "2.50" {
+		t.Errorf("unit cost = %v, want 2.50", last.UnitCost)
+	}
+}
+
+func TestStockCosting_NegativeUnitCostRejected(t *testing.T) {
+	uow, costs := costingFixture(domain.CostingFIFO, 0)
+
+	_, err := NewAddStockUseCase(uow, &mocks.MockNotificationService{}).Execute(context.Background(), AddStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 4, AddedBy: "u1", UnitCost: mustMoney("-1.00"),
+	})
+	if err != domain.ErrInvalidUnitCost {
+		t.Fatalf("Execute() err = %v, want %v", err, domain.ErrInvalidUnitCost)
+	}
+	if len(costs.Costs) != 0 {
+		t.Errorf("cost layers saved for rejected add")
+	}
+}
+
+func TestStockCosting_ReversedRemovalRestoresItsCost(t *testing.T) {
+	uow, costs := costingFixture(domain.CostingFIFO, 0)
+	receiveAt(t, uow, 10, "2.00")
+	receiveAt(t, uow, 10, "3.00")
+	if _, err := NewRemoveStockUseCase(uow).Execute(context.Background(), RemoveStockRequest{
+		ProductID: "p1", TenantID: "t1", Quantity: 15, RemovedBy: "u1",
+	}); err != nil {
+		t.Fatalf("remove stock: %v", err)
+	}
+	removal := uow.StockHistRepo.Entries[len(uow.StockHistRepo.Entries)-1]
+
+	if _, err := NewReverseStockMovementUseCase(uow).Execute(context.Background(), ReverseStockMovementRequest{
+		TenantID: "t1", HistoryEntryID: removal.ID, ReversedBy: "u1",
+	}); err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	cost := costs.Cost("p1")
+	if cost.Quantity() != 20 || cost.Value().String() != "50.00" {
+		t.Errorf("layers = %d units worth %s, want 20 worth 50.00", cost.Quantity(), cost.Value())
+	}
+	// The restored units are the oldest again
+	if cost.Layers[0].Value.String() != "35.00" {
+		t.Errorf("first layer = %+v, want the restored 35.00", cost.Layers[0])
+	}
+}
@@ -0,0 +1,118 @@
+// internal/application/usecases/stock_history_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Entries returned when no limit is given, and the most allowed
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 500
+)
+
+// Input DTOs
+type ProductHistoryRequest struct {
+	TenantID  string
+	ProductID string
+	Limit     int
+}
+
+type SupplierHistoryRequest struct {
+	TenantID   string
+	SupplierID string
+	Limit      int
+}
+
+// Output DTOs
+type ProductHistoryResponse struct {
+	ProductID   string
+	ProductName string
+	// Newest first. Reversals carry ReversalOf, reversed movements ReversedBy.
+	Entries []domain.StockHistoryEntry
+}
+
+type SupplierHistoryResponse struct {
+	SupplierID   string
+	SupplierName string
+	// Stock received from the supplier, newest first, across products
+	Entries []domain.StockHistoryEntry
+}
+
+// Use Case interface (what handlers depend on)
+type StockHistoryUseCase interface {
+	ProductHistory(ctx context.Context, req ProductHistoryRequest) (*ProductHistoryResponse, error)
+	SupplierHistory(ctx context.Context, req SupplierHistoryRequest) (*SupplierHistoryResponse, error)
+}
+
+// Implementation
+type stockHistoryUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewStockHistoryUseCase(uow interfaces.UnitOfWork) StockHistoryUseCase {
+	return &stockHistoryUseCase{uow: uow}
+}
+
+func (uc *stockHistoryUseCase) ProductHistory(ctx context.Context, req ProductHistoryRequest) (*ProductHistoryResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if req.ProductID == "" {
+		return nil, domain.ErrInvalidProductID
+	}
+
+	product, err := uc.uow.Products().FindByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != req.TenantID {
+		return nil, domain.ErrProductNotFound
+	}
+
+	entries, err := uc.uow.StockHistory().FindByProduct(ctx, req.TenantID, req.ProductID, historyLimit(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProductHistoryResponse{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Entries:     entries,
+	}, nil
+}
+
+func (uc *stockHistoryUseCase) SupplierHistory(ctx context.Context, req SupplierHistoryRequest) (*SupplierHistoryResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	supplier, err := uc.uow.Suppliers().FindByID(ctx, req.TenantID, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.uow.StockHistory().FindBySupplier(ctx, req.TenantID, supplier.ID, historyLimit(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SupplierHistoryResponse{
+		SupplierID:   supplier.ID,
+		SupplierName: supplier.Name,
+		Entries:      entries,
+	}, nil
+}
+
+// historyLimit applies the default and the cap to a requested limit
+func historyLimit(limit int) int {
+	if limit <= 0 {
+		return defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		return maxHistoryLimit
+	}
+	return limit
+}
@@ -0,0 +1,74 @@
+// internal/application/usecases/stock_ledger.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Loads and extends product ledgers for tenants in event-sourced mode.
+// Shared by the use cases that change stock.
+type stockLedger struct {
+	uow           interfaces.UnitOfWork
+	snapshotEvery int
+}
+
+// load rebuilds the projection from the latest snapshot and the entries after it.
+func (l stockLedger) load(ctx context.Context, productID, tenantID string) (*domain.StockProjection, error) {
+	projection := domain.NewStockProjection(productID, tenantID)
+
+	snapshot, err := l.uow.StockLedger().LatestSnapshot(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		projection = domain.ProjectionFromSnapshot(*snapshot)
+	}
+
+	entries, err := l.uow.StockLedger().FindByProduct(ctx, productID, projection.Version)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if err := projection.Apply(entry); err != nil {
+			return nil, err
+		}
+	}
+	return projection, nil
+}
+
+// open loads the projection of a product, recording its current stock as
+// an opening balance when the product has no ledger yet.
+func (l stockLedger) open(ctx context.Context, product *domain.Product) (*domain.StockProjection, []domain.StockLedgerEntry, error) {
+	projection, err := l.load(ctx, product.ID, product.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pending []domain.StockLedgerEntry
+	if projection.Version == 0 && product.CurrentStock.Value() > 0 {
+		entry, err := projection.Record(domain.LedgerOpeningBalance, product.CurrentStock.Value(), "system", "")
+		if err != nil {
+			return nil, nil, err
+		}
+		pending = append(pending, entry)
+	}
+	return projection, pending, nil
+}
+
+// append persists entries recorded on the projection and snapshots it
+// when due. Call inside the transaction that saves the product.
+func (l stockLedger) append(ctx context.Context, projection *domain.StockProjection, entries []domain.StockLedgerEntry) error {
+	snapshotDue := false
+	for i := range entries {
+		if err := l.uow.StockLedger().Append(ctx, &entries[i]); err != nil {
+			return err
+		}
+		snapshotDue = snapshotDue || domain.SnapshotDue(entries[i].Sequence, l.snapshotEvery)
+	}
+	if snapshotDue {
+		return l.uow.StockLedger().SaveSnapshot(ctx, projection.Snapshot())
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// internal/application/usecases/stock_ledger_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type RebuildProjectionRequest struct {
+	TenantID  string
+	ProductID string // empty rebuilds every product with ledger entries
+}
+
+// Output DTOs
+type RebuiltProjection struct {
+	ProductID     string
+	PreviousStock int
+	Stock         int
+	Version       int64
+	Snapshots     int
+}
+
+type RebuildProjectionResponse struct {
+	TenantID string
+	Products []RebuiltProjection
+}
+
+type ConsistencyReport struct {
+	TenantID        string
+	CheckedAt       time.Time
+	ProductsChecked int
+	Discrepancies   []domain.StockDiscrepancy
+}
+
+func (r *ConsistencyReport) Consistent() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// Use Case interface (what handlers and commands depend on)
+type StockLedgerUseCase interface {
+	// RebuildProjection replays the full ledger, replaces the snapshots and
+	// writes the result to products.current_stock.
+	RebuildProjection(ctx context.Context, req RebuildProjectionRequest) (*RebuildProjectionResponse, error)
+	// CheckConsistency compares ledger projections with products.current_stock.
+	CheckConsistency(ctx context.Context, tenantID string) (*ConsistencyReport, error)
+}
+
+// Implementation
+type stockLedgerUseCase struct {
+	uow    interfaces.UnitOfWork
+	ledger stockLedger
+}
+
+func NewStockLedgerUseCase(uow interfaces.UnitOfWork, snapshotEvery int) StockLedgerUseCase {
+	return &stockLedgerUseCase{
+		uow:    uow,
+		ledger: stockLedger{uow: uow, snapshotEvery: snapshotEvery},
+	}
+}
+
+func (uc *stockLedgerUseCase) RebuildProjection(ctx context.Context, req RebuildProjectionRequest) (*RebuildProjectionResponse, error) {
+	if err := uc.requireEventSourced(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	productIDs := []string{req.ProductID}
+	if req.ProductID == "" {
+		ids, err := uc.uow.StockLedger().FindProductIDs(ctx, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		productIDs = ids
+	}
+
+	response := &RebuildProjectionResponse{TenantID: req.TenantID}
+	for _, productID := range productIDs {
+		rebuilt, err := uc.rebuild(ctx, req.TenantID, productID)
+		if err != nil {
+			return nil, err
+		}
+		if rebuilt != nil {
+			response.Products = append(response.Products, *rebuilt)
+		}
+	}
+	return response, nil
+}
+
+func (uc *stockLedgerUseCase) rebuild(ctx context.Context, tenantID, productID string) (*RebuiltProjection, error) {
+	product, err := uc.uow.Products().FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != tenantID {
+		return nil, domain.ErrProductNotFound
+	}
+	previousStock := product.CurrentStock.Value()
+
+	entries, err := uc.uow.StockLedger().FindByProduct(ctx, productID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		// Nothing recorded yet: the stored stock is still authoritative
+		return nil, nil
+	}
+
+	// Replay from scratch, collecting the snapshots a live ledger would have taken
+	projection := domain.NewStockProjection(productID, tenantID)
+	var snapshots []domain.StockSnapshot
+	for _, entry := range entries {
+		if err := projection.Apply(entry); err != nil {
+			return nil, err
+		}
+		if domain.SnapshotDue(entry.Sequence, uc.ledger.snapshotEvery) {
+			snapshots = append(snapshots, projection.Snapshot())
+		}
+	}
+
+	err = uc.uow.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.uow.StockLedger().DeleteSnapshots(ctx, productID); err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			if err := uc.uow.StockLedger().SaveSnapshot(ctx, snapshot); err != nil {
+				return err
+			}
+		}
+		return uc.uow.Products().UpdateStock(ctx, productID, projection.CurrentStock())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RebuiltProjection{
+		ProductID:     productID,
+		PreviousStock: previousStock,
+		Stock:         projection.Stock,
+		Version:       projection.Version,
+		Snapshots:     len(snapshots),
+	}, nil
+}
+
+func (uc *stockLedgerUseCase) CheckConsistency(ctx context.Context, tenantID string) (*ConsistencyReport, error) {
+	if err := uc.requireEventSourced(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	products, err := uc.uow.Products().FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	ledgerProductIDs, err := uc.uow.StockLedger().FindProductIDs(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConsistencyReport{TenantID: tenantID, CheckedAt: time.Now()}
+	known := make(map[string]bool, len(products))
+	for _, product := range products {
+		known[product.ID] = true
+		projection, err := uc.ledger.load(ctx, product.ID, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		report.ProductsChecked++
+		if projection.Stock != product.CurrentStock.Value() {
+			report.Discrepancies = append(report.Discrepancies, domain.StockDiscrepancy{
+				ProductID:      product.ID,
+				TenantID:       tenantID,
+				ProjectedStock: projection.Stock,
+				RecordedStock:  product.CurrentStock.Value(),
+				LedgerVersion:  projection.Version,
+			})
+		}
+	}
+
+	for _, productID := range ledgerProductIDs {
+		if known[productID] {
+			continue
+		}
+		projection, err := uc.ledger.load(ctx, productID, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		report.Discrepancies = append(report.Discrepancies, domain.StockDiscrepancy{
+			ProductID:      productID,
+			TenantID:       tenantID,
+			ProjectedStock: projection.Stock,
+			LedgerVersion:  projection.Version,
+			ProductMissing: true,
+		})
+	}
+	return report, nil
+}
+
+func (uc *stockLedgerUseCase) requireEventSourced(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return domain.ErrTenantNotFound
+	}
+	tenant, err := uc.uow.Tenants().FindByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if !tenant.IsEventSourced() {
+		return domain.ErrTenantNotEventSourced
+	}
+	return nil
+}
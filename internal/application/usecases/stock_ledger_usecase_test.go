@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func ledgerEntries(productID string, deltas ...int) []domain.StockLedgerEntry {
+	entries := make([]domain.StockLedgerEntry, 0, len(deltas))
+	for i, delta := range deltas {
+		entries = append(entries, domain.StockLedgerEntry{
+			ProductID: productID, TenantID: "t1", Sequence: int64(i + 1),
+			Type: domain.LedgerStockAdded, Delta: delta,
+		})
+	}
+	return entries
+}
+
+func eventSourcedTenant() *domain.Tenant {
+	return &domain.Tenant{ID: "t1", MaxStock: mustQuantity(1000), IsActive: true, StockMode: domain.StockModeEventSourced}
+}
+
+func TestStockLedgerUseCase_RequiresEventSourcedTenant(t *testing.T) {
+	uow := &mocks.MockUnitOfWork{
+		TenantsRepo: &mocks.MockTenantRepo{Tenant: &domain.Tenant{ID: "t1", IsActive: true}},
+	}
+	uc := NewStockLedgerUseCase(uow, 10)
+	ctx := context.Background()
+
+	if _, err := uc.RebuildProjection(ctx, RebuildProjectionRequest{TenantID: "t1"}); !errors.Is(err, domain.ErrTenantNotEventSourced) {
+		t.Errorf("RebuildProjection() err = %v, want %v", err, domain.ErrTenantNotEventSourced)
+	}
+	if _, err := uc.CheckConsistency(ctx, "t1"); !errors.Is(err, domain.ErrTenantNotEventSourced) {
+		t.Errorf("CheckConsistency() err = %v, want %v", err, domain.ErrTenantNotEventSourced)
+	}
+	if _, err := uc.CheckConsistency(ctx, ""); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("CheckConsistency(\"\") err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+}
+
+func TestStockLedgerUseCase_RebuildProjection(t *testing.T) {
+	p1 := &domain.Product{ID: "p1", TenantID: "t1", CurrentStock: mustQuantity(7)}
+	p2 := &domain.Product{ID: "p2", TenantID: "t1", CurrentStock: mustQuantity(3)}
+	products := &mocks.MockProductRepo{Products: []*domain.Product{p1, p2}}
+	ledger := &mocks.MockStockLedgerRepo{
+		Entries: append(ledgerEntries("p1", 1, 1, 1, 1, 1), ledgerEntries("p2", 3)...),
+		// Stale snapshot that the rebuild must replace
+		Snapshots: []domain.StockSnapshot{{ProductID: "p1", TenantID: "t1", Stock: 50, Version: 4}},
+	}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo: products,
+		TenantsRepo:  &mocks.MockTenantRepo{Tenant: eventSourcedTenant()},
+		LedgerRepo:   ledger,
+	}
+	uc := NewStockLedgerUseCase(uow, 2)
+
+	got, err := uc.RebuildProjection(context.Background(), RebuildProjectionRequest{TenantID: "t1"})
+	if err != nil {
+		t.Fatalf("RebuildProjection() err = %v", err)
+	}
+	if len(got.Products) != 2 {
+		t.Fatalf("rebuilt products = %d, want 2", len(got.Products))
+	}
+	first := got.Products[0]
+	if first.ProductID != "p1" || first.PreviousStock != 7 || first.Stock != 5 || first.Version != 5 || first.Snapshots != 2 {
+		t.Errorf("rebuilt p1 = %+v", first)
+	}
+	if p1.CurrentStock.Value() != 5 || p2.CurrentStock.Value() != 3 {
+		t.Errorf("stored stock p1=%d p2=%d, want 5, 3", p1.CurrentStock.Value(), p2.CurrentStock.Value())
+	}
+	for _, s := range ledger.Snapshots {
+		if s.ProductID == "p1" && s.Stock == 50 {
+			t.Errorf("stale snapshot kept: %+v", s)
+		}
+	}
+}
+
+func TestStockLedgerUseCase_RebuildProjection_SkipsProductWithoutLedger(t *testing.T) {
+	product := &domain.Product{ID: "p1", TenantID: "t1", CurrentStock: mustQuantity(7)}
+	products := &mocks.MockProductRepo{Products: []*domain.Product{product}}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo: products,
+		TenantsRepo:  &mocks.MockTenantRepo{Tenant: eventSourcedTenant()},
+		LedgerRepo:   &mocks.MockStockLedgerRepo{},
+	}
+	uc := NewStockLedgerUseCase(uow, 2)
+
+	got, err := uc.RebuildProjection(context.Background(), RebuildProjectionRequest{TenantID: "t1", ProductID: "p1"})
+	if err != nil {
+		t.Fatalf("RebuildProjection() err = %v", err)
+	}
+	if len(got.Products) != 0 || len(products.StockUpdates) != 0 {
+		t.Errorf("product without ledger rebuilt: %+v, updates %v", got.Products, products.StockUpdates)
+	}
+}
+
+func TestStockLedgerUseCase_CheckConsistency(t *testing.T) {
+	products := &mocks.MockProductRepo{Products: []*domain.Product{
+		{ID: "p1", TenantID: "t1", CurrentStock: mustQuantity(3)},
+		{ID: "p2", TenantID: "t1", CurrentStock: mustQuantity(9)},
+	}}
+	ledger := &mocks.MockStockLedgerRepo{
+		Entries: append(append(ledgerEntries("p1", 1, 2), ledgerEntries("p2", 4)...), ledgerEntries("gone", 6)...),
+	}
+	uow := &mocks.MockUnitOfWork{
+		ProductsRepo: products,
+		TenantsRepo:  &mocks.MockTenantRepo{Tenant: eventSourcedTenant()},
+		LedgerRepo:   ledger,
+	}
+	uc := NewStockLedgerUseCase(uow, 100)
+
+	report, err := uc.CheckConsistency(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("CheckConsistency() err = %v", err)
+	}
+	if report.ProductsChecked != 2 {
+		t.Errorf("ProductsChecked = %d, want 2", report.ProductsChecked)
+	}
+	if report.Consistent() || len(report.Discrepancies) != 2 {
+		t.Fatalf("discrepancies = %+v, want 2", report.Discrepancies)
+	}
+	drift := report.Discrepancies[0]
+	if drift.ProductID != "p2" || drift.ProjectedStock != 4 || drift.RecordedStock != 9 || drift.Difference() != 5 {
+		t.Errorf("drift = %+v", drift)
+	}
+	missing := report.Discrepancies[1]
+	if missing.ProductID != "gone" || !missing.ProductMissing || missing.ProjectedStock != 6 {
+		t.Errorf("missing = %+v", missing)
+	}
+}
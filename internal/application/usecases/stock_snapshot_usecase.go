@@ -0,0 +1,113 @@
+// internal/application/usecases/stock_snapshot_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Snapshots written per repository call while capturing
+const snapshotBatchSize = 500
+
+// Input DTO
+type ProductStockAsOfRequest struct {
+	TenantID  string
+	ProductID string
+	// Stock after every movement created before AsOf; now when zero
+	AsOf time.Time
+}
+
+// Output DTO
+type CaptureSnapshotsResponse struct {
+	Day      time.Time
+	Products int
+}
+
+// Use Case interface (what handlers depend on)
+type StockSnapshotUseCase interface {
+	// Capture records every product's current stock as the snapshot of
+	// the day now falls in. Capturing a day again replaces its snapshots.
+	Capture(ctx context.Context, now time.Time) (*CaptureSnapshotsResponse, error)
+	ProductStockAsOf(ctx context.Context, req ProductStockAsOfRequest) (*domain.ProductStockAsOf, error)
+}
+
+// Implementation
+type stockSnapshotUseCase struct {
+	uow      interfaces.UnitOfWork
+	timeline stockTimeline
+}
+
+func NewStockSnapshotUseCase(uow interfaces.UnitOfWork) StockSnapshotUseCase {
+	return &stockSnapshotUseCase{uow: uow, timeline: stockTimeline{uow: uow}}
+}
+
+func (uc *stockSnapshotUseCase) Capture(ctx context.Context, now time.Time) (*CaptureSnapshotsResponse, error) {
+	day := domain.SnapshotDay(now)
+	batch := make([]domain.DailyStockSnapshot, 0, snapshotBatchSize)
+	captured := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := uc.uow.DailySnapshots().SaveAll(ctx, batch); err != nil {
+			return err
+		}
+		captured += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	err := uc.uow.Products().Each(ctx, func(p *domain.Product) error {
+		batch = append(batch, domain.DailyStockSnapshot{
+			TenantID:  p.TenantID,
+			ProductID: p.ID,
+			Day:       day,
+			Stock:     p.CurrentStock.Decimal(),
+			TakenAt:   now,
+		})
+		if len(batch) == snapshotBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return &CaptureSnapshotsResponse{Day: day, Products: captured}, nil
+}
+
+func (uc *stockSnapshotUseCase) ProductStockAsOf(ctx context.Context, req ProductStockAsOfRequest) (*domain.ProductStockAsOf, error) {
+	// 1. Validate input
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if req.ProductID == "" {
+		return nil, domain.ErrInvalidProductID
+	}
+	now := time.Now()
+	asOf := req.AsOf
+	if asOf.IsZero() || asOf.After(now) {
+		asOf = now
+	}
+
+	// 2. Get product
+	product, err := uc.uow.Products().FindByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.TenantID != req.TenantID {
+		return nil, domain.ErrProductNotFound
+	}
+
+	// 3. Rebuild its stock
+	stock, err := uc.timeline.stockAsOf(ctx, req.TenantID, product.ID, []*domain.Product{product}, asOf, now)
+	if err != nil {
+		return nil, err
+	}
+	return &stock[0], nil
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+	"time"
+)
+
+var (
+	march1 = time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC)
+	march2 = time.Date(2024, 3, 2, 0, 30, 0, 0, time.UTC)
+)
+
+// p1 has 40 in stock now: 10 on March 1st at 00:30, +20 and +15 that day,
+// -5 on March 2nd
+func snapshotFixture() *mocks.MockUnitOfWork {
+	gadget := testProduct("p2", "Gadget", 7)
+	gadget.TenantID = "t2"
+	uow, _ := stockFixture(testProduct("p1", "Widget", 40), gadget)
+	uow.StockHistRepo.Entries = []domain.StockHistoryEntry{
+		{TenantID: "t1", ProductID: "p1", Quantity: 20, CreatedAt: march1.Add(2 * time.Hour)},
+		{TenantID: "t1", ProductID: "p1", Quantity: 15, CreatedAt: march1.Add(10 * time.Hour)},
+		{TenantID: "t1", ProductID: "p1", Quantity: -5, CreatedAt: march2.Add(time.Hour)},
+	}
+	uow.DailyRepo = &mocks.MockDailySnapshotRepo{}
+	return uow
+}
+
+func TestStockSnapshotUseCase_Capture(t *testing.T) {
+	uow := snapshotFixture()
+	uc := NewStockSnapshotUseCase(uow)
+
+	got, err := uc.Capture(context.Background(), march1)
+	if err != nil {
+		t.Fatalf("Capture() err = %v", err)
+	}
+	if got.Products != 2 || !got.Day.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Capture() = %+v", got)
+	}
+
+	// Retaking the day replaces its snapshots
+	uow.ProductsRepo.Products[0].CurrentStock = mustQuantity(12)
+	if _, err := uc.Capture(context.Background(), march1.Add(time.Hour)); err != nil {
+		t.Fatalf("Capture() err = %v", err)
+	}
+	snapshots := uow.DailyRepo.Snapshots
+	if len(snapshots) != 2 || snapshots[0].ProductID != "p1" || snapshots[0].Stock.String() != "12" || snapshots[1].TenantID != "t2" {
+		t.Errorf("snapshots = %+v", snapshots)
+	}
+}
+
+func TestStockSnapshotUseCase_ProductStockAsOf_FromSnapshot(t *testing.T) {
+	uow := snapshotFixture()
+	uow.DailyRepo.Snapshots = []domain.DailyStockSnapshot{
+		{TenantID: "t1", ProductID: "p1", Day: domain.SnapshotDay(march1), Stock: domain.DecimalFromInt(10), TakenAt: march1},
+		// Taken after the moment asked about, so not used
+		{TenantID: "t1", ProductID: "p1", Day: domain.SnapshotDay(march2), Stock: domain.DecimalFromInt(45), TakenAt: march2},
+	}
+
+	got, err := NewStockSnapshotUseCase(uow).ProductStockAsOf(context.Background(), ProductStockAsOfRequest{
+		TenantID: "t1", ProductID: "p1", AsOf: march1.Add(5 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ProductStockAsOf() err = %v", err)
+	}
+	if got.Stock.String() != "30" || !got.SnapshotDay.Equal(domain.SnapshotDay(march1)) {
+		t.Errorf("ProductStockAsOf() = %+v, want 30 from the March 1st snapshot", got)
+	}
+}
+
+func TestStockSnapshotUseCase_ProductStockAsOf_WithoutSnapshot(t *testing.T) {
+	uc := NewStockSnapshotUseCase(snapshotFixture())
+
+	got, err := uc.ProductStockAsOf(context.Background(), ProductStockAsOfRequest{
+		TenantID: "t1", ProductID: "p1", AsOf: march1.Add(5 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ProductStockAsOf() err = %v", err)
+	}
+	// 40 now, less the +15 and -5 since
+	if got.Stock.String() != "30" || !got.SnapshotDay.IsZero() {
+		t.Errorf("ProductStockAsOf() = %+v, want 30 worked back from current stock", got)
+	}
+
+	current, err := uc.ProductStockAsOf(context.Background(), ProductStockAsOfRequest{TenantID: "t1", ProductID: "p1"})
+	if err != nil {
+		t.Fatalf("ProductStockAsOf() err = %v", err)
+	}
+	if current.Stock.String() != "40" {
+		t.Errorf("stock now = %s, want 40", current.Stock)
+	}
+}
+
+func TestStockSnapshotUseCase_ProductStockAsOf_Errors(t *testing.T) {
+	uc := NewStockSnapshotUseCase(snapshotFixture())
+	ctx := context.Background()
+
+	if _, err := uc.ProductStockAsOf(ctx, ProductStockAsOfRequest{ProductID: "p1"}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("no tenant err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+	if _, err := uc.ProductStockAsOf(ctx, ProductStockAsOfRequest{TenantID: "t1", ProductID: "p2"}); !errors.Is(err, domain.ErrProductNotFound) {
+		t.Errorf("other tenant's product err = %v, want %v", err, domain.ErrProductNotFound)
+	}
+}
@@ -0,0 +1,77 @@
+// internal/application/usecases/stock_timeline.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Rebuilds past stock from daily snapshots and stock_history. Shared by
+// the as-of query and the as-of export.
+type stockTimeline struct {
+	uow interfaces.UnitOfWork
+}
+
+// stockAsOf returns the stock the tenant's products had at at, in the
+// order given. productID narrows the lookups when only that product is
+// asked for. A product with a snapshot before at gets the snapshot plus
+// the history after it; one without is worked back from current stock.
+func (t stockTimeline) stockAsOf(ctx context.Context, tenantID, productID string, products []*domain.Product, at, now time.Time) ([]domain.ProductStockAsOf, error) {
+	// 1. Snapshots of the latest day before at, grouped by when they were
+	// taken so that each group replays history from its own moment
+	snapshots, err := t.uow.DailySnapshots().FindLatest(ctx, tenantID, productID, at)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[string]domain.DailyStockSnapshot, len(snapshots))
+	takenAt := make(map[time.Time]bool)
+	for _, s := range snapshots {
+		byProduct[s.ProductID] = s
+		takenAt[s.TakenAt] = true
+	}
+	forward := make(map[time.Time]map[string]domain.Decimal, len(takenAt))
+	for taken := range takenAt {
+		changes, err := t.uow.StockHistory().NetChangeByProduct(ctx, tenantID, productID, taken, at)
+		if err != nil {
+			return nil, err
+		}
+		forward[taken] = changes
+	}
+
+	// 2. History since at, for products without a snapshot
+	var backward map[string]domain.Decimal
+	for _, p := range products {
+		if _, ok := byProduct[p.ID]; !ok {
+			backward, err = t.uow.StockHistory().NetChangeByProduct(ctx, tenantID, productID, at, now)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	// 3. Combine
+	result := make([]domain.ProductStockAsOf, 0, len(products))
+	for _, p := range products {
+		stock := domain.ProductStockAsOf{
+			ProductID:   p.ID,
+			ProductName: p.Name,
+			TenantID:    p.TenantID,
+			AsOf:        at,
+		}
+		var err error
+		if s, ok := byProduct[p.ID]; ok {
+			stock.Stock, err = s.Stock.Add(forward[s.TakenAt][p.ID])
+			stock.SnapshotDay = s.Day
+		} else {
+			stock.Stock, err = p.CurrentStock.Decimal().Sub(backward[p.ID])
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stock)
+	}
+	return result, nil
+}
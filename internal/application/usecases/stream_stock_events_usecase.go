@@ -0,0 +1,51 @@
+// internal/application/usecases/stream_stock_events_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Input DTO
+type StreamStockEventsRequest struct {
+	TenantID    string
+	ProductIDs  []string // empty streams all products
+	LastEventID string   // resume after this event when still retained
+}
+
+// Use Case interface (what handlers depend on)
+type StreamStockEventsUseCase interface {
+	// Subscribe follows stock and alert events until ctx is done or the
+	// subscription is closed.
+	Subscribe(ctx context.Context, req StreamStockEventsRequest) (interfaces.EventSubscription, error)
+}
+
+// Implementation
+type streamStockEventsUseCase struct {
+	uow    interfaces.UnitOfWork
+	stream interfaces.EventStream
+}
+
+func NewStreamStockEventsUseCase(uow interfaces.UnitOfWork, stream interfaces.EventStream) StreamStockEventsUseCase {
+	return &streamStockEventsUseCase{
+		uow:    uow,
+		stream: stream,
+	}
+}
+
+func (uc *streamStockEventsUseCase) Subscribe(ctx context.Context, req StreamStockEventsRequest) (interfaces.EventSubscription, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if _, err := uc.uow.Tenants().FindByID(ctx, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	filter := domain.EventFilter{
+		TenantID:   req.TenantID,
+		EventTypes: domain.StockStreamEventTypes,
+		ProductIDs: req.ProductIDs,
+	}
+	return uc.stream.Subscribe(ctx, filter, req.LastEventID)
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+// Records the filter the use case subscribes with
+type recordingEventStream struct {
+	filter      domain.EventFilter
+	lastEventID string
+}
+
+func (s *recordingEventStream) Subscribe(ctx context.Context, filter domain.EventFilter, lastEventID string) (interfaces.EventSubscription, error) {
+	s.filter = filter
+	s.lastEventID = lastEventID
+	return nil, nil
+}
+
+func TestStreamStockEventsUseCase_Subscribe(t *testing.T) {
+	tenant := &domain.Tenant{ID: "t1", IsActive: true}
+	stream := &recordingEventStream{}
+	uc := NewStreamStockEventsUseCase(&mocks.MockUnitOfWork{TenantsRepo: &mocks.MockTenantRepo{Tenant: tenant}}, stream)
+
+	_, err := uc.Subscribe(context.Background(), StreamStockEventsRequest{
+		TenantID: "t1", ProductIDs: []string{"p1"}, LastEventID: "e1",
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() err = %v", err)
+	}
+	if stream.filter.TenantID != "t1" || len(stream.filter.ProductIDs) != 1 || stream.lastEventID != "e1" {
+		t.Errorf("filter = %+v, last = %q", stream.filter, stream.lastEventID)
+	}
+	if len(stream.filter.EventTypes) != len(domain.StockStreamEventTypes) {
+		t.Errorf("EventTypes = %v, want stock stream types", stream.filter.EventTypes)
+	}
+}
+
+func TestStreamStockEventsUseCase_Subscribe_UnknownTenant(t *testing.T) {
+	stream := &recordingEventStream{}
+	uc := NewStreamStockEventsUseCase(&mocks.MockUnitOfWork{TenantsRepo: &mocks.MockTenantRepo{FindErr: domain.ErrTenantNotFound}}, stream)
+
+	if _, err := uc.Subscribe(context.Background(), StreamStockEventsRequest{}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("Subscribe(no tenant) err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+	if _, err := uc.Subscribe(context.Background(), StreamStockEventsRequest{TenantID: "t9"}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("Subscribe(unknown) err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+}
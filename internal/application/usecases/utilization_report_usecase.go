@@ -0,0 +1,117 @@
+// internal/application/usecases/utilization_report_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Fullest products listed when no top is given, and the most allowed;
+// low-stock products listed at most
+const (
+	defaultUtilizationTopN = 10
+	maxUtilizationTopN     = 100
+	maxLowStockListed      = 100
+)
+
+// Input DTO
+type UtilizationReportRequest struct {
+	TenantID string
+	TopN     int
+	// Products below it are low on stock; domain.DefaultLowStockThreshold
+	// when not set
+	LowStockThreshold int
+}
+
+// Output DTOs
+type UtilizationTotals struct {
+	Products int
+	Units    int
+	// Units the products could hold at the tenant limit
+	Capacity    int
+	Utilization float64
+}
+
+type UtilizationReportResponse struct {
+	TenantID          string
+	MaxStock          int
+	LowStockThreshold int
+	Bands             []domain.UtilizationBandTotals
+	// Fullest first
+	Top []domain.ProductUtilization
+	// Emptiest first, at most 100; LowStockCount counts them all
+	LowStock      []domain.ProductUtilization
+	LowStockCount int
+	Totals        UtilizationTotals
+}
+
+// Use Case interface (what handlers depend on)
+type UtilizationReportUseCase interface {
+	Execute(ctx context.Context, req UtilizationReportRequest) (*UtilizationReportResponse, error)
+}
+
+// Implementation
+type utilizationReportUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewUtilizationReportUseCase(uow interfaces.UnitOfWork) UtilizationReportUseCase {
+	return &utilizationReportUseCase{uow: uow}
+}
+
+func (uc *utilizationReportUseCase) Execute(ctx context.Context, req UtilizationReportRequest) (*UtilizationReportResponse, error) {
+	// 1. Validate input
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	topN := req.TopN
+	if topN <= 0 {
+		topN = defaultUtilizationTopN
+	}
+	if topN > maxUtilizationTopN {
+		topN = maxUtilizationTopN
+	}
+	threshold := req.LowStockThreshold
+	if threshold <= 0 {
+		threshold = domain.DefaultLowStockThreshold
+	}
+
+	// 2. Utilization is measured against the tenant limit
+	tenant, err := uc.uow.Tenants().FindByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Summarize in the repository
+	summary, err := uc.uow.Products().SummarizeUtilization(ctx, domain.UtilizationQuery{
+		TenantID:          tenant.ID,
+		MaxStock:          tenant.MaxStock,
+		Bands:             domain.DefaultUtilizationBands,
+		TopN:              topN,
+		LowStockThreshold: threshold,
+		LowStockLimit:     maxLowStockListed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := UtilizationTotals{
+		Products: summary.Products,
+		Units:    summary.TotalUnits,
+		Capacity: summary.Capacity(tenant.MaxStock),
+	}
+	if totals.Capacity > 0 {
+		totals.Utilization = float64(totals.Units) / float64(totals.Capacity) * 100
+	}
+	return &UtilizationReportResponse{
+		TenantID:          tenant.ID,
+		MaxStock:          tenant.MaxStock.Value(),
+		LowStockThreshold: threshold,
+		Bands:             summary.Bands,
+		Top:               summary.Top,
+		LowStock:          summary.LowStock,
+		LowStockCount:     summary.LowStockCount,
+		Totals:            totals,
+	}, nil
+}
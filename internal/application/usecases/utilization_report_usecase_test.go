@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+)
+
+func utilizationFixture() *mocks.MockUnitOfWork {
+	otherTenant := testProduct("x1", "", 99)
+	otherTenant.TenantID = "t2"
+	uow, _ := stockFixture(
+		testProduct("p1", "Product p1", 5),
+		testProduct("p2", "Product p2", 30),
+		testProduct("p3", "Product p3", 90),
+		testProduct("p4", "Product p4", 90),
+		testProduct("p5", "Product p5", 120), // over the limit after an adjustment
+		testProduct("p6", "Product p6", 0),
+		otherTenant,
+	)
+	return uow
+}
+
+func TestUtilizationReportUseCase_Execute(t *testing.T) {
+	got, err := NewUtilizationReportUseCase(utilizationFixture()).Execute(context.Background(), UtilizationReportRequest{
+		TenantID: "t1", TopN: 3,
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+
+	wantBands := map[string][2]int{"0-25": {2, 5}, "25-50": {1, 30}, "50-80": {0, 0}, "80-100": {2, 180}, "100+": {1, 120}}
+	if len(got.Bands) != len(wantBands) {
+		t.Fatalf("bands = %+v", got.Bands)
+	}
+	for _, b := range got.Bands {
+		want := wantBands[b.Band.Label]
+		if b.Products != want[0] || b.Units != want[1] {
+			t.Errorf("band %s = %d products, %d units; want %v", b.Band.Label, b.Products, b.Units, want)
+		}
+	}
+
+	if len(got.Top) != 3 || got.Top[0].ProductID != "p5" || got.Top[1].ProductID != "p3" || got.Top[2].ProductID != "p4" {
+		t.Errorf("top = %+v, want p5, p3, p4", got.Top)
+	}
+	if got.LowStockThreshold != domain.DefaultLowStockThreshold || got.LowStockCount != 2 ||
+		len(got.LowStock) != 2 || got.LowStock[0].ProductID != "p6" || got.LowStock[1].ProductID != "p1" {
+		t.Errorf("low stock = %+v (count %d), want p6, p1", got.LowStock, got.LowStockCount)
+	}
+	if got.Totals.Products != 6 || got.Totals.Units != 335 || got.Totals.Capacity != 600 {
+		t.Errorf("totals = %+v", got.Totals)
+	}
+	if got.Totals.Utilization < 55.8 || got.Totals.Utilization > 55.9 {
+		t.Errorf("total utilization = %v, want 55.83", got.Totals.Utilization)
+	}
+}
+
+func TestUtilizationReportUseCase_Execute_LimitsAndThreshold(t *testing.T) {
+	got, err := NewUtilizationReportUseCase(utilizationFixture()).Execute(context.Background(), UtilizationReportRequest{
+		TenantID: "t1", TopN: 1000, LowStockThreshold: 50,
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if len(got.Top) != 6 {
+		t.Errorf("top = %d products, want all 6", len(got.Top))
+	}
+	if got.LowStockCount != 3 {
+		t.Errorf("low stock count = %d, want 3 below 50", got.LowStockCount)
+	}
+}
+
+func TestUtilizationReportUseCase_Execute_Errors(t *testing.T) {
+	uc := NewUtilizationReportUseCase(utilizationFixture())
+	if _, err := uc.Execute(context.Background(), UtilizationReportRequest{}); !errors.Is(err, domain.ErrTenantNotFound) {
+		t.Errorf("no tenant err = %v, want %v", err, domain.ErrTenantNotFound)
+	}
+
+	uow := utilizationFixture()
+	uow.ProductsRepo.FindErr = errors.New("database error")
+	if _, err := NewUtilizationReportUseCase(uow).Execute(context.Background(), UtilizationReportRequest{TenantID: "t1"}); err == nil {
+		t.Error("Execute() err = nil, want the repository error")
+	}
+}
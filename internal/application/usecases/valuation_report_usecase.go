@@ -0,0 +1,113 @@
+// internal/application/usecases/valuation_report_usecase.go
+package usecases
+
+import (
+	"context"
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"time"
+)
+
+// Input DTO
+type ValuationReportRequest struct {
+	TenantID string
+	// Period whose cost of goods removed is reported
+	From time.Time // inclusive
+	To   time.Time // exclusive
+}
+
+// Output DTO
+type ValuationReportResponse struct {
+	TenantID string
+	// Costing method the layers were kept under
+	Method   string
+	From     time.Time
+	To       time.Time
+	Products []domain.ProductValuation
+	// Across products
+	TotalValue              domain.Money
+	TotalCostOfGoodsRemoved domain.Money
+	UncostedQuantity        int
+}
+
+// Use Case interface (what handlers depend on)
+type ValuationReportUseCase interface {
+	Execute(ctx context.Context, req ValuationReportRequest) (*ValuationReportResponse, error)
+}
+
+// Implementation
+type valuationReportUseCase struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewValuationReportUseCase(uow interfaces.UnitOfWork) ValuationReportUseCase {
+	return &valuationReportUseCase{uow: uow}
+}
+
+func (uc *valuationReportUseCase) Execute(ctx context.Context, req ValuationReportRequest) (*ValuationReportResponse, error) {
+	if req.TenantID == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if !req.From.Before(req.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+	tenant, err := uc.uow.Tenants().FindByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Value current stock at its cost layers
+	costs, err := uc.uow.ProductCosts().FindByTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	costByProduct := make(map[string]*domain.ProductCost, len(costs))
+	for _, c := range costs {
+		costByProduct[c.ProductID] = c
+	}
+
+	report := &ValuationReportResponse{
+		TenantID: req.TenantID,
+		Method:   tenant.Costing(),
+		From:     req.From,
+		To:       req.To,
+	}
+	index := map[string]int{}
+	err = uc.uow.Products().EachByTenant(ctx, req.TenantID, func(p *domain.Product) error {
+		index[p.ID] = len(report.Products)
+		report.Products = append(report.Products, domain.NewProductValuation(p, costByProduct[p.ID]))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Add up the cost of the period's removals; reversed removals put
+	// their units back and are left out
+	err = uc.uow.StockHistory().EachInRange(ctx, req.TenantID, req.From, req.To, func(e domain.StockHistoryEntry) error {
+		if e.Operation != domain.HistoryOperationStockRemove || e.ReversedBy != "" || e.Cost == nil {
+			return nil
+		}
+		i, ok := index[e.ProductID]
+		if !ok {
+			return nil
+		}
+		v := &report.Products[i]
+		v.CostOfGoodsRemoved = v.CostOfGoodsRemoved.Add(*e.Cost)
+		v.QuantityRemoved += -e.Quantity
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range report.Products {
+		report.TotalValue = report.TotalValue.Add(v.Value)
+		report.TotalCostOfGoodsRemoved = report.TotalCostOfGoodsRemoved.Add(v.CostOfGoodsRemoved)
+		report.UncostedQuantity += v.UncostedQuantity
+	}
+	return report, nil
+}
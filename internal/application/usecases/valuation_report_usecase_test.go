@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+	"testing"
+	"time"
+)
+
+func TestValuationReportUseCase_Execute(t *testing.T) {
+	now := time.Now()
+	uow, _ := widgetFixture(12)
+	uow.ProductsRepo.Products = append(uow.ProductsRepo.Products,
+		&domain.Product{ID: "p2", Name: "Bolt", TenantID: "t1", CurrentStock: mustQuantity(0)})
+	uow.CostsRepo = &mocks.MockProductCostRepo{Costs: []*domain.ProductCost{{
+		ProductID: "p1", TenantID: "t1",
+		Layers: []domain.CostLayer{
+			{Quantity: 4, Value: domain.NewMoney(800)},
+			{Quantity: 6, Value: domain.NewMoney(1800)},
+		},
+	}}}
+	uow.StockHistRepo.Entries = []domain.StockHistoryEntry{
+		{ID: "h1", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockRemove, Quantity: -5, Cost: mustMoney("10.00"), CreatedAt: now.Add(-time.Hour)},
+		// Reversed, so its units came back
+		{ID: "h2", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockRemove, Quantity: -2, Cost: mustMoney("4.00"), ReversedBy: "h3", CreatedAt: now.Add(-time.Hour)},
+		// Before the period
+		{ID: "h4", ProductID: "p1", TenantID: "t1", Operation: domain.HistoryOperationStockRemove, Quantity: -1, Cost: mustMoney("2.00"), CreatedAt: now.AddDate(0, 0, -40)},
+	}
+
+	got, err := NewValuationReportUseCase(uow).Execute(context.Background(), ValuationReportRequest{
+		TenantID: "t1", From: now.AddDate(0, 0, -30), To: now,
+	})
+	if err != nil {
+		t.Fatalf("Execute() err = %v", err)
+	}
+	if got.Method != domain.CostingFIFO || len(got.Products) != 2 {
+		t.Fatalf("report = %+v", got)
+	}
+	p1 := got.Products[0]
+	if p1.Stock != 12 || p1.CostedQuantity != 10 || p1.UncostedQuantity != 2 || p1.Value.String() != "26.00" || p1.AverageUnitCost.String() != "2.60" {
+		t.Errorf("p1 valuation = %+v", p1)
+	}
+	if p1.CostOfGoodsRemoved.String() != "10.00" || p1.QuantityRemoved != 5 {
+		t.Errorf("p1 removed = %d worth %s, want 5 worth 10.00", p1.QuantityRemoved, p1.CostOfGoodsRemoved)
+	}
+	if p2 := got.Products[1]; !p2.Value.IsZero() || p2.Stock != 0 {
+		t.Errorf("p2 valuation = %+v", p2)
+	}
+	if got.TotalValue.String() != "26.00" || got.TotalCostOfGoodsRemoved.String() != "10.00" || got.UncostedQuantity != 2 {
+		t.Errorf("totals = %s / %s / %d", got.TotalValue, got.TotalCostOfGoodsRemoved, got.UncostedQuantity)
+	}
+}
+
+func TestValuationReportUseCase_Execute_Rejections(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  ValuationReportRequest
+		want error
+	}{
+		{"missing tenant", ValuationReportRequest{From: now.Add(-time.Hour), To: now}, domain.ErrTenantNotFound},
+		{"empty range", ValuationReportRequest{TenantID: "t1", From: now, To: now}, domain.ErrInvalidTimeRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow, _ := widgetFixture(1)
+			_, err := NewValuationReportUseCase(uow).Execute(context.Background(), tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// internal/domain/adjustment_reason.go
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Built-in reason codes every tenant starts with
+const (
+	ReasonDamage  = "damage"
+	ReasonTheft   = "theft"
+	ReasonFound   = "found"
+	ReasonRecount = AdjustmentReasonRecount
+	ReasonReturn  = "return"
+)
+
+// Which way an adjustment with a reason may move stock
+const (
+	ReasonDirectionIncrease = "increase"
+	ReasonDirectionDecrease = "decrease"
+	ReasonDirectionAny      = "any"
+)
+
+// Entry of a tenant's adjustment reason catalog. Tenants override the
+// built-in reasons by code, can add their own and deactivate any.
+type AdjustmentReason struct {
+	TenantID  string
+	Code      string
+	Label     string
+	Direction string
+	IsActive  bool
+	IsCustom  bool
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+func DefaultAdjustmentReasons() []AdjustmentReason {
+	return []AdjustmentReason{
+		{Code: ReasonDamage, Label: "Damaged", Direction: ReasonDirectionDecrease, IsActive: true},
+		{Code: ReasonTheft, Label: "Theft", Direction: ReasonDirectionDecrease, IsActive: true},
+		{Code: ReasonFound, Label: "Found", Direction: ReasonDirectionIncrease, IsActive: true},
+		{Code: ReasonRecount, Label: "Recount", Direction: ReasonDirectionAny, IsActive: true},
+		{Code: ReasonReturn, Label: "Customer return", Direction: ReasonDirectionIncrease, IsActive: true},
+	}
+}
+
+var reasonCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,31}$`)
+
+func (r AdjustmentReason) Validate() error {
+	if !reasonCodePattern.MatchString(r.Code) {
+		return ErrInvalidReasonCode
+	}
+	switch r.Direction {
+	case ReasonDirectionIncrease, ReasonDirectionDecrease, ReasonDirectionAny:
+		return nil
+	}
+	return ErrInvalidReasonDirection
+}
+
+// Allows reports whether the reason may be used for an adjustment of delta.
+func (r AdjustmentReason) Allows(delta int) bool {
+	switch r.Direction {
+	case ReasonDirectionIncrease:
+		return delta > 0
+	case ReasonDirectionDecrease:
+		return delta < 0
+	}
+	return true
+}
+
+// The reasons in effect for a tenant: defaults merged with overrides
+type ReasonCatalog struct {
+	reasons map[string]AdjustmentReason
+}
+
+func NewReasonCatalog(overrides []*AdjustmentReason) ReasonCatalog {
+	reasons := make(map[string]AdjustmentReason)
+	for _, r := range DefaultAdjustmentReasons() {
+		reasons[r.Code] = r
+	}
+	for _, r := range overrides {
+		override := *r
+		override.IsCustom = true
+		reasons[r.Code] = override
+	}
+	return ReasonCatalog{reasons: reasons}
+}
+
+// Check validates a reason code for an adjustment of delta.
+func (c ReasonCatalog) Check(code string, delta int) (AdjustmentReason, error) {
+	if code == "" {
+		return AdjustmentReason{}, ErrReasonCodeRequired
+	}
+	reason, ok := c.reasons[code]
+	if !ok || !reason.IsActive {
+		return AdjustmentReason{}, ErrInvalidReasonCode
+	}
+	if !reason.Allows(delta) {
+		return AdjustmentReason{}, ErrReasonDirectionMismatch
+	}
+	return reason, nil
+}
+
+func (c ReasonCatalog) Lookup(code string) (AdjustmentReason, bool) {
+	reason, ok := c.reasons[code]
+	return reason, ok
+}
+
+// All reasons, inactive included, sorted by code
+func (c ReasonCatalog) Reasons() []AdjustmentReason {
+	reasons := make([]AdjustmentReason, 0, len(c.reasons))
+	for _, r := range c.reasons {
+		reasons = append(reasons, r)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return reasons[i].Code < reasons[j].Code })
+	return reasons
+}
+
+// Adjustments with one reason code over a period
+type AdjustmentTotals struct {
+	ReasonCode  string
+	Adjustments int
+	Increase    int // sum of positive deltas
+	Decrease    int // sum of negative deltas, as a positive number
+}
+
+func (t AdjustmentTotals) Net() int {
+	return t.Increase - t.Decrease
+}
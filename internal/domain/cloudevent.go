@@ -0,0 +1,220 @@
+// internal/domain/cloudevent.go
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+	CloudEventsContentType = "application/cloudevents+json"
+	EventSource            = "/inventory"
+)
+
+var ErrUnsupportedEventVersion = errors.New("unsupported event version")
+
+// CloudEvents 1.0 envelope in structured JSON format. Every event leaving a
+// use case is wrapped in one, so consumers get a stable ID, type and
+// schema version instead of guessing from the payload.
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	DataSchema      string    `json:"dataschema"`
+	// Extension attributes
+	TenantID    string `json:"tenantid"`
+	DataVersion int    `json:"dataversion"`
+
+	Data json.RawMessage `json:"data"`
+}
+
+// NewCloudEvent wraps a domain event at its current schema version.
+func NewCloudEvent(event Event, tenantID string) (CloudEvent, error) {
+	def, ok := eventDefinitions[event.EventType()]
+	if !ok {
+		return CloudEvent{}, fmt.Errorf("%w: %s", ErrInvalidEventType, event.EventType())
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return CloudEvent{}, fmt.Errorf("encode %s: %w", event.EventType(), err)
+	}
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              newEventID(),
+		Source:          EventSource,
+		Type:            event.EventType(),
+		Subject:         event.AggregateID(),
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DataSchema:      EventSchemaURI(event.EventType(), def.version),
+		TenantID:        tenantID,
+		DataVersion:     def.version,
+		Data:            data,
+	}, nil
+}
+
+// EventSchemaURI identifies the JSON Schema of an event type version.
+func EventSchemaURI(eventType string, version int) string {
+	return fmt.Sprintf("urn:inventory:event-schema:%s:v%d", eventType, version)
+}
+
+// CurrentEventVersion returns the schema version new events are written with.
+func CurrentEventVersion(eventType string) (int, bool) {
+	def, ok := eventDefinitions[eventType]
+	return def.version, ok
+}
+
+// Upcast rewrites the data of an older event version to the current one.
+func (ce CloudEvent) Upcast() (CloudEvent, error) {
+	def, ok := eventDefinitions[ce.Type]
+	if !ok {
+		return ce, fmt.Errorf("%w: %s", ErrInvalidEventType, ce.Type)
+	}
+	if ce.DataVersion < 1 || ce.DataVersion > def.version {
+		return ce, fmt.Errorf("%w: %s v%d", ErrUnsupportedEventVersion, ce.Type, ce.DataVersion)
+	}
+
+	for ce.DataVersion < def.version {
+		upcast, ok := def.upcasters[ce.DataVersion]
+		if !ok {
+			return ce, fmt.Errorf("%w: no upcaster for %s v%d", ErrUnsupportedEventVersion, ce.Type, ce.DataVersion)
+		}
+		var data map[string]interface{}
+		if err := json.Unmarshal(ce.Data, &data); err != nil {
+			return ce, fmt.Errorf("decode %s v%d: %w", ce.Type, ce.DataVersion, err)
+		}
+		upgraded, err := upcast(data)
+		if err != nil {
+			return ce, fmt.Errorf("upcast %s v%d: %w", ce.Type, ce.DataVersion, err)
+		}
+		if ce.Data, err = json.Marshal(upgraded); err != nil {
+			return ce, err
+		}
+		ce.DataVersion++
+		ce.DataSchema = EventSchemaURI(ce.Type, ce.DataVersion)
+	}
+	return ce, nil
+}
+
+// Decode upcasts the envelope and rebuilds the typed domain event.
+func (ce CloudEvent) Decode() (Event, error) {
+	current, err := ce.Upcast()
+	if err != nil {
+		return nil, err
+	}
+	return eventDefinitions[ce.Type].decode(current.Data)
+}
+
+// Upcaster converts event data from one version to the next.
+type Upcaster func(data map[string]interface{}) (map[string]interface{}, error)
+
+type eventDefinition struct {
+	version   int
+	decode    func(data []byte) (Event, error)
+	upcasters map[int]Upcaster // keyed by the version they upgrade from
+}
+
+// Version 1 of the stock events was the untagged Go encoding written by
+// the first outbox release; version 2 uses snake_case field names. The
+// versions after those allow fractional quantities for decimal-mode
+// products.
+var eventDefinitions = map[string]eventDefinition{
+	EventTypeStockAdded: {
+		version: 3,
+		decode:  decodeEvent[StockAddedEvent],
+		upcasters: map[int]Upcaster{
+			1: renameFields(map[string]string{
+				"ProductID": "product_id", "TenantID": "tenant_id", "Quantity": "quantity",
+				"Previous": "previous_stock", "Current": "new_stock", "AddedBy": "added_by",
+				"Timestamp": "timestamp", "Notes": "notes",
+			}),
+			2: wholeQuantities,
+		},
+	},
+	EventTypeStockLimitAlert: {
+		version: 3,
+		decode:  decodeEvent[StockLimitAlertEvent],
+		upcasters: map[int]Upcaster{
+			1: renameFields(map[string]string{
+				"ProductID": "product_id", "ProductName": "product_name", "Current": "current_stock",
+				"MaxLimit": "max_stock", "Utilization": "utilization_percentage", "TenantID": "tenant_id",
+				"Timestamp": "timestamp", "ProductTags": "product_tags",
+			}),
+			2: wholeQuantities,
+		},
+	},
+	EventTypeLowStock: {
+		version: 3,
+		decode:  decodeEvent[LowStockEvent],
+		upcasters: map[int]Upcaster{
+			1: renameFields(map[string]string{
+				"ProductID": "product_id", "ProductName": "product_name", "TenantID": "tenant_id",
+				"Current": "current_stock", "Threshold": "threshold", "ProductTags": "product_tags",
+				"Timestamp": "timestamp",
+			}),
+			2: wholeQuantities,
+		},
+	},
+	EventTypeStockAdjusted: {
+		version:   2,
+		decode:    decodeEvent[StockAdjustedEvent],
+		upcasters: map[int]Upcaster{1: wholeQuantities},
+	},
+	EventTypeStockRemoved: {
+		version:   2,
+		decode:    decodeEvent[StockRemovedEvent],
+		upcasters: map[int]Upcaster{1: wholeQuantities},
+	},
+	EventTypeStockReversed: {
+		version:   2,
+		decode:    decodeEvent[StockReversedEvent],
+		upcasters: map[int]Upcaster{1: wholeQuantities},
+	},
+	EventTypeNotification: {
+		version: 1,
+		decode:  decodeEvent[Notification],
+	},
+}
+
+// Whole-unit quantities of the integer versions are valid fractional ones
+func wholeQuantities(data map[string]interface{}) (map[string]interface{}, error) {
+	return data, nil
+}
+
+func renameFields(names map[string]string) Upcaster {
+	return func(data map[string]interface{}) (map[string]interface{}, error) {
+		out := make(map[string]interface{}, len(data))
+		for key, value := range data {
+			if renamed, ok := names[key]; ok {
+				key = renamed
+			}
+			out[key] = value
+		}
+		return out, nil
+	}
+}
+
+func decodeEvent[E Event](data []byte) (Event, error) {
+	var event E
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Random RFC 4122 version 4 UUID
+func newEventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,190 @@
+// internal/domain/cycle_count.go
+package domain
+
+import "time"
+
+// Count session lifecycle: open -> approved | cancelled
+const (
+	CountStatusOpen      = "open"
+	CountStatusApproved  = "approved"
+	CountStatusCancelled = "cancelled"
+)
+
+// A physical stock count of a set of products. Expected stock is captured
+// when the session opens; on approval each product is adjusted by its
+// variance, so movements made while the count was running are kept.
+// Locking sessions block stock changes to their products until closed.
+type CountSession struct {
+	ID       string
+	TenantID string
+	Name     string
+	Location string
+	Lock     bool
+	Status   string
+	Lines    []CountLine
+	OpenedBy string
+	OpenedAt time.Time
+	ClosedBy string
+	ClosedAt time.Time
+}
+
+type CountLine struct {
+	ProductID     string
+	ProductName   string
+	ExpectedStock int
+	Counts        []CountEntry
+	// Set on approval
+	ReasonCode string
+	Adjustment int
+}
+
+// One counter's submission. A later submission by the same counter
+// replaces their earlier one.
+type CountEntry struct {
+	CountedBy string
+	Quantity  int
+	CountedAt time.Time
+}
+
+func NewCountSession(tenantID, name, location string, lock bool, products []*Product, openedBy string) (*CountSession, error) {
+	if len(products) == 0 {
+		return nil, ErrEmptyCountSession
+	}
+	lines := make([]CountLine, 0, len(products))
+	for _, p := range products {
+		// Counts are in whole units
+		if p.IsDecimal() {
+			return nil, ErrDecimalUnsupported
+		}
+		lines = append(lines, CountLine{
+			ProductID:     p.ID,
+			ProductName:   p.Name,
+			ExpectedStock: p.CurrentStock.Value(),
+		})
+	}
+	return &CountSession{
+		TenantID: tenantID,
+		Name:     name,
+		Location: location,
+		Lock:     lock,
+		Status:   CountStatusOpen,
+		Lines:    lines,
+		OpenedBy: openedBy,
+		OpenedAt: time.Now(),
+	}, nil
+}
+
+func (s *CountSession) IsOpen() bool {
+	return s.Status == CountStatusOpen
+}
+
+func (s *CountSession) Line(productID string) *CountLine {
+	for i := range s.Lines {
+		if s.Lines[i].ProductID == productID {
+			return &s.Lines[i]
+		}
+	}
+	return nil
+}
+
+// NewCountEntry validates a submission for the session.
+func (s *CountSession) NewCountEntry(productID, countedBy string, quantity int) (CountEntry, error) {
+	if !s.IsOpen() {
+		return CountEntry{}, ErrCountSessionClosed
+	}
+	if s.Line(productID) == nil {
+		return CountEntry{}, ErrProductNotInCount
+	}
+	if quantity < 0 {
+		return CountEntry{}, ErrInvalidQuantity
+	}
+	return CountEntry{CountedBy: countedBy, Quantity: quantity, CountedAt: time.Now()}, nil
+}
+
+// Approve checks every product was counted without disagreement and
+// records the adjustment each line will apply.
+func (s *CountSession) Approve(approvedBy string, reasonCodes map[string]string) error {
+	if !s.IsOpen() {
+		return ErrCountSessionClosed
+	}
+	for i := range s.Lines {
+		line := &s.Lines[i]
+		if _, ok := line.Counted(); !ok {
+			return ErrCountIncomplete
+		}
+		if line.IsDisputed() {
+			return ErrCountDisputed
+		}
+		line.Adjustment = line.Variance()
+		line.ReasonCode = reasonCodes[line.ProductID]
+		if line.ReasonCode == "" {
+			line.ReasonCode = AdjustmentReasonRecount
+		}
+	}
+	s.close(CountStatusApproved, approvedBy)
+	return nil
+}
+
+func (s *CountSession) Cancel(cancelledBy string) error {
+	if !s.IsOpen() {
+		return ErrCountSessionClosed
+	}
+	s.close(CountStatusCancelled, cancelledBy)
+	return nil
+}
+
+func (s *CountSession) close(status, by string) {
+	s.Status = status
+	s.ClosedBy = by
+	s.ClosedAt = time.Now()
+}
+
+// Latest count of each counter, in order of submission
+func (l CountLine) latestCounts() []CountEntry {
+	index := map[string]int{}
+	var latest []CountEntry
+	for _, c := range l.Counts {
+		if i, ok := index[c.CountedBy]; ok {
+			latest[i] = c
+			continue
+		}
+		index[c.CountedBy] = len(latest)
+		latest = append(latest, c)
+	}
+	return latest
+}
+
+// Counted returns the agreed quantity, or the most recent one when
+// counters disagree. ok is false until someone has counted.
+func (l CountLine) Counted() (quantity int, ok bool) {
+	var newest *CountEntry
+	for _, c := range l.latestCounts() {
+		c := c
+		if newest == nil || c.CountedAt.After(newest.CountedAt) {
+			newest = &c
+		}
+	}
+	if newest == nil {
+		return 0, false
+	}
+	return newest.Quantity, true
+}
+
+func (l CountLine) IsDisputed() bool {
+	latest := l.latestCounts()
+	for _, c := range latest {
+		if c.Quantity != latest[0].Quantity {
+			return true
+		}
+	}
+	return false
+}
+
+// Counted minus expected stock; zero until counted
+func (l CountLine) Variance() int {
+	counted, ok := l.Counted()
+	if !ok {
+		return 0
+	}
+	return counted - l.ExpectedStock
+}
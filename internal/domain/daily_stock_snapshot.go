@@ -0,0 +1,35 @@
+// internal/domain/daily_stock_snapshot.go
+package domain
+
+import "time"
+
+// A product's stock as recorded by the daily snapshot job. There is one
+// snapshot per product and UTC day; retaking a day replaces it.
+type DailyStockSnapshot struct {
+	TenantID  string
+	ProductID string
+	// Midnight UTC of the day the snapshot belongs to
+	Day   time.Time
+	Stock Decimal
+	// When the stock was read; history created from then on is not included
+	TakenAt time.Time
+}
+
+// SnapshotDay is the day, in UTC, a snapshot taken at t belongs to
+func SnapshotDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// A product's stock at a past moment, rebuilt from a snapshot and the
+// history after it, or from current stock and the history since
+type ProductStockAsOf struct {
+	ProductID   string
+	ProductName string
+	TenantID    string
+	AsOf        time.Time
+	Stock       Decimal
+	// Day of the snapshot the stock was rebuilt from; zero when it was
+	// worked back from current stock
+	SnapshotDay time.Time
+}
@@ -0,0 +1,337 @@
+// internal/domain/decimal.go
+package domain
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Fixed-point decimal, coef × 10^-scale. Quantities of goods weighed or
+// measured use it instead of floats so sums stay exact.
+type Decimal struct {
+	coef  int64
+	scale int
+}
+
+// Quantities are kept to at most this many decimal places
+const MaxDecimalScale = 9
+
+// Digits a coefficient may have; every 18-digit number fits in an int64
+const maxDecimalDigits = 18
+
+// Largest exponent ParseDecimal reads, either sign; larger ones cannot
+// give a value in range
+const maxDecimalExponent = 1000
+
+var decimalPattern = regexp.MustCompile(`^([-+]?)(\d+)(?:\.(\d+))?(?:[eE]([-+]?\d+))?$`)
+
+var pow10 = func() [19]int64 {
+	var p [19]int64
+	p[0] = 1
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1] * 10
+	}
+	return p
+}()
+
+func DecimalFromInt(n int) Decimal {
+	return Decimal{coef: int64(n)}
+}
+
+// ParseDecimal reads a decimal such as "2.5", "-0.125" or "1e3" exactly
+func ParseDecimal(s string) (Decimal, error) {
+	m := decimalPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	digits := strings.TrimLeft(m[2]+m[3], "0")
+	scale := len(m[3])
+	if m[4] != "" {
+		exp, err := strconv.Atoi(m[4])
+		if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return Decimal{}, ErrInvalidDecimal
+		}
+		scale -= exp
+	}
+	// Trailing zeros beyond the maximum scale carry no value
+	for scale > MaxDecimalScale && strings.HasSuffix(digits, "0") {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+	if scale < 0 {
+		// Checked before the zeros are written out, so a large exponent
+		// cannot make a huge string
+		if len(digits)-scale > maxDecimalDigits && digits != "" {
+			return Decimal{}, ErrInvalidDecimal
+		}
+		if digits != "" {
+			digits += strings.Repeat("0", -scale)
+		}
+		scale = 0
+	}
+	if scale > MaxDecimalScale || len(digits) > maxDecimalDigits {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	var coef int64
+	if digits != "" {
+		parsed, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return Decimal{}, ErrInvalidDecimal
+		}
+		coef = parsed
+	}
+	if m[1] == "-" {
+		coef = -coef
+	}
+	return Decimal{coef: coef, scale: scale}, nil
+}
+
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef > 0:
+		return 1
+	case d.coef < 0:
+		return -1
+	}
+	return 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: -d.coef, scale: d.scale}
+}
+
+// Add fails with ErrQuantityOutOfRange rather than wrap around when the
+// sum does not fit
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	a, b, err := align(d, other)
+	if err != nil {
+		return Decimal{}, err
+	}
+	sum := a.coef + b.coef
+	if (b.coef > 0 && sum < a.coef) || (b.coef < 0 && sum > a.coef) {
+		return Decimal{}, ErrQuantityOutOfRange
+	}
+	return Decimal{coef: sum, scale: a.scale}, nil
+}
+
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	return d.Add(other.Neg())
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than
+// other. It compares whole and fractional parts apart, so it cannot
+// overflow.
+func (d Decimal) Cmp(other Decimal) int {
+	if c := compareInt64(d.coef/pow10[d.scale], other.coef/pow10[other.scale]); c != 0 {
+		return c
+	}
+	// Fractions are below 10^MaxDecimalScale at the maximum scale
+	return compareInt64(
+		d.coef%pow10[d.scale]*pow10[MaxDecimalScale-d.scale],
+		other.coef%pow10[other.scale]*pow10[MaxDecimalScale-other.scale],
+	)
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// IsInteger reports whether d has no fractional part
+func (d Decimal) IsInteger() bool {
+	return d.coef%pow10[d.scale] == 0
+}
+
+// IntPart is d truncated toward zero
+func (d Decimal) IntPart() int {
+	return int(d.coef / pow10[d.scale])
+}
+
+func (d Decimal) Float64() float64 {
+	return float64(d.coef) / math.Pow10(d.scale)
+}
+
+// Rescale changes d to scale decimal places. It fails with
+// ErrQuantityTooPrecise when that would drop non-zero digits and with
+// ErrQuantityOutOfRange when the digits no longer fit.
+func (d Decimal) Rescale(scale int) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, ErrQuantityTooPrecise
+	}
+	if scale >= d.scale {
+		coef, err := mulInt64(d.coef, pow10[scale-d.scale])
+		if err != nil {
+			return Decimal{}, err
+		}
+		return Decimal{coef: coef, scale: scale}, nil
+	}
+	factor := pow10[d.scale-scale]
+	if d.coef%factor != 0 {
+		return Decimal{}, ErrQuantityTooPrecise
+	}
+	return Decimal{coef: d.coef / factor, scale: scale}, nil
+}
+
+// mulInt64 fails with ErrQuantityOutOfRange rather than wrap around
+func mulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrQuantityOutOfRange
+	}
+	return product, nil
+}
+
+// String keeps the scale, so 2.5 at three places is "2.500"
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.coef, 10)
+	if d.scale == 0 {
+		return s
+	}
+	sign := ""
+	if d.coef < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= d.scale {
+		s = strings.Repeat("0", d.scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+}
+
+// Encoded as a JSON number with every digit, never through a float
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Accepts a number or a numeric string
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// align brings a and b to the larger of their scales
+func align(a, b Decimal) (Decimal, Decimal, error) {
+	var err error
+	if a.scale < b.scale {
+		a, err = a.Rescale(b.scale)
+	} else if b.scale < a.scale {
+		b, err = b.Rescale(a.scale)
+	}
+	return a, b, err
+}
+
+// How a product counts its stock
+const (
+	// Whole units (default)
+	QuantityModeInteger = "integer"
+	// Goods weighed or measured, e.g. in kg or litres
+	QuantityModeDecimal = "decimal"
+)
+
+// Decimal places kept for decimal-mode products that set none
+const DefaultQuantityPrecision = 3
+
+func (p *Product) IsDecimal() bool {
+	return p.QuantityMode == QuantityModeDecimal
+}
+
+func (p *Product) QuantityModeName() string {
+	if p.IsDecimal() {
+		return QuantityModeDecimal
+	}
+	return QuantityModeInteger
+}
+
+// Precision is how many decimal places the product's quantities keep
+func (p *Product) Precision() int {
+	if !p.IsDecimal() {
+		return 0
+	}
+	if p.QuantityPrecision <= 0 || p.QuantityPrecision > MaxDecimalScale {
+		return DefaultQuantityPrecision
+	}
+	return p.QuantityPrecision
+}
+
+// SetQuantityMode switches how the product counts stock; an empty mode
+// keeps the current one. Stock is kept at the new precision, so it must
+// fit: stock with a fraction cannot switch to integer mode.
+func (p *Product) SetQuantityMode(mode string, precision int) error {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = p.QuantityMode
+	}
+	switch mode {
+	case "", QuantityModeInteger:
+		if precision != 0 {
+			return ErrInvalidQuantityMode
+		}
+		mode = QuantityModeInteger
+	case QuantityModeDecimal:
+		if precision == 0 {
+			precision = DefaultQuantityPrecision
+		}
+		if precision < 1 || precision > MaxDecimalScale {
+			return ErrInvalidQuantityMode
+		}
+	default:
+		return ErrInvalidQuantityMode
+	}
+	stock, err := p.CurrentStock.value.Rescale(precision)
+	if err == ErrQuantityTooPrecise && mode == QuantityModeInteger {
+		return ErrNonIntegralQuantity
+	}
+	if err != nil {
+		return err
+	}
+	p.QuantityMode = mode
+	p.QuantityPrecision = precision
+	p.CurrentStock = StockQuantity{value: stock}
+	return nil
+}
+
+// NewQuantity checks an amount of the product's base unit against its
+// quantity mode
+func (p *Product) NewQuantity(amount Decimal) (StockQuantity, error) {
+	return p.ToBaseQuantity(amount, "")
+}
+
+// Exact amounts of a decimal-mode product's stock change, whose whole
+// unit counts elsewhere are truncated
+type DecimalStockChange struct {
+	Quantity      Decimal
+	PreviousStock Decimal
+	NewStock      Decimal
+}
+
+// NewDecimalStockChange returns nil unless the quantities are decimal
+func NewDecimalStockChange(quantity Decimal, previous, current StockQuantity) *DecimalStockChange {
+	if quantity.Scale() == 0 && previous.value.scale == 0 && current.value.scale == 0 {
+		return nil
+	}
+	return &DecimalStockChange{Quantity: quantity, PreviousStock: previous.value, NewStock: current.value}
+}
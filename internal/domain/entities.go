@@ -2,32 +2,70 @@
 package domain
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 )
 
 // Value Objects
 type StockQuantity struct {
-	value int
+	value Decimal
 }
 
 func NewStockQuantity(q int) (StockQuantity, error) {
 	if q < 0 {
 		return StockQuantity{}, errors.New("quantity cannot be negative")
 	}
+	return StockQuantity{value: DecimalFromInt(q)}, nil
+}
+
+func NewDecimalStockQuantity(q Decimal) (StockQuantity, error) {
+	if q.Sign() < 0 {
+		return StockQuantity{}, errors.New("quantity cannot be negative")
+	}
 	return StockQuantity{value: q}, nil
 }
 
+// Value is the quantity in whole units, exact unless the product is in
+// decimal mode
 func (q StockQuantity) Value() int {
+	return q.value.IntPart()
+}
+
+// Decimal is the exact quantity
+func (q StockQuantity) Decimal() Decimal {
 	return q.value
 }
 
-func (q StockQuantity) Add(other StockQuantity) StockQuantity {
-	return StockQuantity{value: q.value + other.value}
+func (q StockQuantity) Add(other StockQuantity) (StockQuantity, error) {
+	sum, err := q.value.Add(other.value)
+	if err != nil {
+		return StockQuantity{}, err
+	}
+	return StockQuantity{value: sum}, nil
 }
 
 func (q StockQuantity) Exceeds(limit StockQuantity) bool {
-	return q.value > limit.value
+	return q.value.Cmp(limit.value) > 0
+}
+
+// Encoded as a plain number so events can be serialized (e.g. to the
+// outbox); decimal quantities keep every digit
+func (q StockQuantity) MarshalJSON() ([]byte, error) {
+	return q.value.MarshalJSON()
+}
+
+func (q *StockQuantity) UnmarshalJSON(data []byte) error {
+	var v Decimal
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := NewDecimalStockQuantity(v)
+	if err != nil {
+		return err
+	}
+	*q = parsed
+	return nil
 }
 
 // Domain Entity with business behavior
@@ -37,10 +75,36 @@ type Product struct {
 	CurrentStock StockQuantity
 	LastUpdated  time.Time
 	TenantID     string
+	Tags         []string
+	// Running total of stock ever added (products.total_added), exact for
+	// decimal-mode products
+	TotalAdded Decimal
+	Location   string
+	// Set while a locking count session covers the product
+	CountSessionID string
+	// Unit the stock is kept in ("each" when empty) and the other units
+	// stock can be entered in
+	BaseUnit string
+	Units    []UnitConversion
+	// QuantityModeInteger (default) or QuantityModeDecimal, whose
+	// quantities keep QuantityPrecision decimal places
+	QuantityMode      string
+	QuantityPrecision int
+	// Stock keeping unit and EAN/UPC barcode, each unique within the
+	// tenant when set
+	SKU     string
+	Barcode string
+	// Set on variants: the product they are grouped under and what sets
+	// them apart, e.g. size: L
+	ParentID       string
+	VariantOptions map[string]string
 }
 
 func (p *Product) AddStock(quantity StockQuantity, maxLimit StockQuantity) error {
-	newStock := p.CurrentStock.Add(quantity)
+	newStock, err := p.CurrentStock.Add(quantity)
+	if err != nil {
+		return err
+	}
 	
 	if newStock.Exceeds(maxLimit) {
 		return ErrStockExceedsLimit{
@@ -51,31 +115,120 @@ func (p *Product) AddStock(quantity StockQuantity, maxLimit StockQuantity) error
 		}
 	}
 	
+	totalAdded, err := p.TotalAdded.Add(quantity.value)
+	if err != nil {
+		return err
+	}
 	p.CurrentStock = newStock
+	p.TotalAdded = totalAdded
+	p.LastUpdated = time.Now()
+	return nil
+}
+
+// AdjustStock applies a signed correction. Corrections reflect physical
+// stock, so the tenant limit does not apply.
+func (p *Product) AdjustStock(delta int) error {
+	return p.AdjustStockBy(DecimalFromInt(delta))
+}
+
+// AdjustStockBy is AdjustStock for an exact, possibly fractional delta.
+func (p *Product) AdjustStockBy(delta Decimal) error {
+	newStock, err := p.CurrentStock.value.Add(delta)
+	if err != nil {
+		return err
+	}
+	if newStock.Sign() < 0 {
+		return ErrInsufficientStock
+	}
+	p.CurrentStock = StockQuantity{value: newStock}
+	p.LastUpdated = time.Now()
+	return nil
+}
+
+// RemoveStock takes stock out, e.g. when it ships or is consumed.
+func (p *Product) RemoveStock(quantity StockQuantity) error {
+	if quantity.Exceeds(p.CurrentStock) {
+		return ErrInsufficientStock
+	}
+	newStock, err := p.CurrentStock.value.Sub(quantity.value)
+	if err != nil {
+		return err
+	}
+	p.CurrentStock = StockQuantity{value: newStock}
 	p.LastUpdated = time.Now()
 	return nil
 }
 
+func (p *Product) IsLockedForCount() bool {
+	return p.CountSessionID != ""
+}
+
 func (p *Product) IsRecentlyUpdated(threshold time.Duration) bool {
 	return time.Since(p.LastUpdated) < threshold
 }
 
 func (p *Product) IsLowStock(threshold int) bool {
-	return p.CurrentStock.Value() < threshold
+	return p.CurrentStock.value.Cmp(DecimalFromInt(threshold)) < 0
 }
 
 func (p *Product) UtilizationPercentage(maxLimit StockQuantity) float64 {
-	if maxLimit.Value() == 0 {
+	if maxLimit.value.Sign() == 0 {
 		return 0
 	}
-	return float64(p.CurrentStock.Value()) / float64(maxLimit.Value()) * 100
+	return p.CurrentStock.value.Float64() / maxLimit.value.Float64() * 100
 }
 
+// How a tenant's stock is stored
+const (
+	// products.current_stock is updated in place (default)
+	StockModeState = "state"
+	// The stock ledger is the source of truth; current_stock is a projection
+	StockModeEventSourced = "event_sourced"
+)
+
 type Tenant struct {
-	ID       string
-	Name     string
-	MaxStock StockQuantity
-	IsActive bool
+	ID        string
+	Name      string
+	MaxStock  StockQuantity
+	IsActive  bool
+	StockMode string
+	// Adds and removals of more than this quantity wait for approval;
+	// zero disables approvals
+	ApprovalThreshold int
+	// How long a change request stays pending; DefaultApprovalTTL when zero
+	ApprovalTTL time.Duration
+	// Demand forecasting; unset values take the defaults
+	Forecast ForecastSettings
+	// CostingFIFO or CostingWeightedAverage; FIFO when unset
+	CostingMethod string
+}
+
+func (t *Tenant) IsEventSourced() bool {
+	return t.StockMode == StockModeEventSourced
+}
+
+func (t *Tenant) Costing() string {
+	if t.CostingMethod == CostingWeightedAverage {
+		return CostingWeightedAverage
+	}
+	return CostingFIFO
+}
+
+func (t *Tenant) RequiresApproval(quantity int) bool {
+	return t.ApprovalThreshold > 0 && quantity > t.ApprovalThreshold
+}
+
+// RequiresApprovalFor is RequiresApproval for an exact, possibly
+// fractional quantity
+func (t *Tenant) RequiresApprovalFor(quantity StockQuantity) bool {
+	return t.ApprovalThreshold > 0 && quantity.Exceeds(StockQuantity{value: DecimalFromInt(t.ApprovalThreshold)})
+}
+
+func (t *Tenant) ApprovalExpiry(requestedAt time.Time) time.Time {
+	if t.ApprovalTTL <= 0 {
+		return requestedAt.Add(DefaultApprovalTTL)
+	}
+	return requestedAt.Add(t.ApprovalTTL)
 }
 
 func (t *Tenant) CanReceiveStock() error {
@@ -86,23 +239,181 @@ func (t *Tenant) CanReceiveStock() error {
 }
 
 // Domain Events
+
+// Implemented by every domain event
+type Event interface {
+	EventType() string
+	// Events sharing an aggregate ID are delivered in order
+	AggregateID() string
+}
+
+// Event type names, used by subscribers to select the events they receive
+const (
+	EventTypeStockAdded      = "stock.added"
+	EventTypeStockLimitAlert = "stock.limit_alert"
+	EventTypeLowStock        = "stock.low"
+	EventTypeStockAdjusted   = "stock.adjusted"
+	EventTypeStockRemoved    = "stock.removed"
+	EventTypeStockReversed   = "stock.reversed"
+	EventTypeNotification    = "notification"
+)
+
+func IsKnownEventType(eventType string) bool {
+	switch eventType {
+	case EventTypeStockAdded, EventTypeStockLimitAlert, EventTypeLowStock, EventTypeStockAdjusted, EventTypeStockRemoved,
+		EventTypeStockReversed, EventTypeNotification:
+		return true
+	}
+	return false
+}
+
 type StockAddedEvent struct {
-	ProductID    string
-	TenantID     string
-	Quantity     StockQuantity
-	Previous     StockQuantity
-	Current      StockQuantity
-	AddedBy      string
-	Timestamp    time.Time
-	Notes        string
+	ProductID string        `json:"product_id"`
+	TenantID  string        `json:"tenant_id"`
+	Quantity  StockQuantity `json:"quantity"`
+	Previous  StockQuantity `json:"previous_stock"`
+	Current   StockQuantity `json:"new_stock"`
+	AddedBy   string        `json:"added_by"`
+	Timestamp time.Time     `json:"timestamp"`
+	Notes     string        `json:"notes,omitempty"`
+	// Set when the add ran as an approved change request
+	ApprovedBy string `json:"approved_by,omitempty"`
+	// The approved change request or the purchase order received against
+	Reference string `json:"reference,omitempty"`
+	// Supplier the stock was received from
+	SupplierID string `json:"supplier_id,omitempty"`
+	// Quantity as entered and the unit it was entered in; Quantity is in
+	// the product's base unit
+	Unit            string  `json:"unit,omitempty"`
+	EnteredQuantity Decimal `json:"entered_quantity"`
+	// Cost of each entered unit, and of the units together
+	UnitCost *Money `json:"unit_cost,omitempty"`
+	Cost     *Money `json:"cost,omitempty"`
+}
+
+func (e StockAddedEvent) EventType() string {
+	return EventTypeStockAdded
+}
+
+func (e StockAddedEvent) AggregateID() string {
+	return e.ProductID
 }
 
 type StockLimitAlertEvent struct {
-	ProductID   string
-	ProductName string
-	Current     StockQuantity
-	MaxLimit    StockQuantity
-	Utilization float64
-	TenantID    string
-	Timestamp   time.Time
-}
\ No newline at end of file
+	ProductID   string        `json:"product_id"`
+	ProductName string        `json:"product_name"`
+	Current     StockQuantity `json:"current_stock"`
+	MaxLimit    StockQuantity `json:"max_stock"`
+	Utilization float64       `json:"utilization_percentage"`
+	TenantID    string        `json:"tenant_id"`
+	Timestamp   time.Time     `json:"timestamp"`
+	ProductTags []string      `json:"product_tags,omitempty"`
+}
+
+func (e StockLimitAlertEvent) EventType() string {
+	return EventTypeStockLimitAlert
+}
+
+func (e StockLimitAlertEvent) AggregateID() string {
+	return e.ProductID
+}
+
+func (e StockLimitAlertEvent) Severity() Severity {
+	if e.Utilization > 90 {
+		return SeverityCritical
+	}
+	return SeverityWarning
+}
+
+type LowStockEvent struct {
+	ProductID   string        `json:"product_id"`
+	ProductName string        `json:"product_name"`
+	TenantID    string        `json:"tenant_id"`
+	Current     StockQuantity `json:"current_stock"`
+	Threshold   int           `json:"threshold"`
+	ProductTags []string      `json:"product_tags,omitempty"`
+	Timestamp   time.Time     `json:"timestamp"`
+
+	// Set when the tenant alerts on forecasts
+	ReorderPoint      int      `json:"reorder_point,omitempty"`
+	DaysUntilStockout *float64 `json:"days_until_stockout,omitempty"`
+	SuggestedQuantity int      `json:"suggested_quantity,omitempty"`
+}
+
+func (e LowStockEvent) EventType() string {
+	return EventTypeLowStock
+}
+
+func (e LowStockEvent) AggregateID() string {
+	return e.ProductID
+}
+
+// Stock corrected outside of receiving, e.g. by a count or a manual adjustment
+type StockAdjustedEvent struct {
+	ProductID  string        `json:"product_id"`
+	TenantID   string        `json:"tenant_id"`
+	Delta      int           `json:"delta"`
+	Previous   StockQuantity `json:"previous_stock"`
+	Current    StockQuantity `json:"new_stock"`
+	ReasonCode string        `json:"reason_code"`
+	AdjustedBy string        `json:"adjusted_by"`
+	// Operation recorded in stock history and the document it came from
+	Operation string    `json:"operation"`
+	Reference string    `json:"reference,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e StockAdjustedEvent) EventType() string {
+	return EventTypeStockAdjusted
+}
+
+func (e StockAdjustedEvent) AggregateID() string {
+	return e.ProductID
+}
+
+// Stock taken out of a product, e.g. shipped or consumed
+type StockRemovedEvent struct {
+	ProductID  string        `json:"product_id"`
+	TenantID   string        `json:"tenant_id"`
+	Quantity   StockQuantity `json:"quantity"`
+	Previous   StockQuantity `json:"previous_stock"`
+	Current    StockQuantity `json:"new_stock"`
+	RemovedBy  string        `json:"removed_by"`
+	ApprovedBy string        `json:"approved_by,omitempty"`
+	Reference  string        `json:"reference,omitempty"`
+	Notes      string        `json:"notes,omitempty"`
+	Timestamp  time.Time     `json:"timestamp"`
+	// What the removed units cost, under the tenant's costing method
+	CostOfGoods *Money `json:"cost_of_goods,omitempty"`
+}
+
+func (e StockRemovedEvent) EventType() string {
+	return EventTypeStockRemoved
+}
+
+func (e StockRemovedEvent) AggregateID() string {
+	return e.ProductID
+}
+
+// A mistaken add or removal was compensated
+type StockReversedEvent struct {
+	ProductID         string        `json:"product_id"`
+	TenantID          string        `json:"tenant_id"`
+	Delta             int           `json:"delta"`
+	Previous          StockQuantity `json:"previous_stock"`
+	Current           StockQuantity `json:"new_stock"`
+	ReversedBy        string        `json:"reversed_by"`
+	OriginalEntryID   string        `json:"original_entry_id"`
+	OriginalOperation string        `json:"original_operation"`
+	Notes             string        `json:"notes,omitempty"`
+	Timestamp         time.Time     `json:"timestamp"`
+}
+
+func (e StockReversedEvent) EventType() string {
+	return EventTypeStockReversed
+}
+
+func (e StockReversedEvent) AggregateID() string {
+	return e.ProductID
+}
@@ -12,6 +12,97 @@ var (
 	ErrTenantInactive   = errors.New("tenant is inactive")
 	ErrInvalidQuantity  = errors.New("invalid quantity")
 	ErrInvalidProductID = errors.New("invalid product id")
+
+	ErrWebhookNotFound      = errors.New("webhook subscription not found")
+	ErrInvalidWebhookURL    = errors.New("webhook url must be an absolute http(s) url")
+	ErrWebhookURLNotPublic  = errors.New("webhook url must not point at a loopback, private or link-local address")
+	ErrInvalidWebhookSecret = errors.New("webhook secret must be at least 16 characters")
+	ErrInvalidEventType     = errors.New("invalid event type")
+
+	ErrTemplateNotFound = errors.New("notification template not found")
+
+	ErrRoutingRuleNotFound = errors.New("routing rule not found")
+	ErrInvalidChannel      = errors.New("invalid notification channel")
+	ErrInvalidSeverity     = errors.New("invalid severity")
+
+	ErrConcurrentStockUpdate = errors.New("stock was changed concurrently")
+	ErrLedgerOutOfOrder      = errors.New("stock ledger entry out of order")
+	ErrTenantNotEventSourced = errors.New("tenant does not use event-sourced stock")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrReasonCodeRequired    = errors.New("reason code is required")
+
+	ErrInvalidReasonCode        = errors.New("unknown or inactive reason code")
+	ErrInvalidReasonDirection   = errors.New("reason direction must be increase, decrease or any")
+	ErrReasonDirectionMismatch  = errors.New("reason code does not allow this adjustment direction")
+	ErrAdjustmentReasonNotFound = errors.New("adjustment reason not found")
+	ErrInvalidTimeRange         = errors.New("time range start must be before its end")
+
+	ErrCountSessionNotFound  = errors.New("count session not found")
+	ErrCountSessionClosed    = errors.New("count session is closed")
+	ErrEmptyCountSession     = errors.New("count session has no products")
+	ErrProductNotInCount     = errors.New("product is not part of the count session")
+	ErrCountIncomplete       = errors.New("count session has uncounted products")
+	ErrCountDisputed         = errors.New("counters disagree on counted quantities")
+	ErrProductLockedForCount = errors.New("product is locked by a stock count")
+
+	ErrChangeRequestNotFound = errors.New("stock change request not found")
+	ErrChangeRequestClosed   = errors.New("stock change request was already decided")
+	ErrChangeRequestExpired  = errors.New("stock change request has expired")
+	ErrApproverRoleRequired  = errors.New("approving stock changes requires the stock_approver role")
+	ErrSelfApproval          = errors.New("stock changes must be approved by someone other than the requester")
+
+	ErrHistoryEntryNotFound    = errors.New("stock history entry not found")
+	ErrMovementAlreadyReversed = errors.New("stock movement was already reversed")
+	ErrMovementNotReversible   = errors.New("only stock adds and removals can be reversed")
+
+	ErrEmptyBatch       = errors.New("batch has no items")
+	ErrBatchTooLarge    = errors.New("batch has too many items")
+	ErrInvalidBatchMode = errors.New("batch mode must be all_or_nothing or best_effort")
+	ErrBatchAborted     = errors.New("not applied because another item in the batch failed")
+
+	ErrOutboxEntryNotFound = errors.New("outbox entry not found")
+	ErrOutboxEntryNotDead  = errors.New("only dead outbox entries can be requeued or discarded")
+
+	ErrImportJobNotFound       = errors.New("import job not found")
+	ErrUnsupportedImportFormat = errors.New("import files must be csv or xlsx")
+	ErrDuplicateImport         = errors.New("file was already imported")
+
+	ErrUnsupportedExportFormat = errors.New("export format must be csv, ndjson or parquet")
+
+	ErrInvalidForecastSettings = errors.New("forecast method must be moving_average or exponential_smoothing, with a window of 1 to 365 days, alpha in (0, 1] and no negative day counts")
+
+	ErrPurchaseOrderNotFound      = errors.New("purchase order not found")
+	ErrPurchaseOrderClosed        = errors.New("purchase order is received or cancelled")
+	ErrPurchaseOrderChanged       = errors.New("purchase order was changed concurrently")
+	ErrEmptyPurchaseOrder         = errors.New("purchase order has no lines")
+	ErrDuplicatePurchaseOrderLine = errors.New("product appears on more than one line")
+	ErrProductNotOnPurchaseOrder  = errors.New("product is not on the purchase order")
+
+	ErrSupplierNotFound       = errors.New("supplier not found")
+	ErrSupplierInactive       = errors.New("supplier is inactive")
+	ErrInvalidSupplier        = errors.New("supplier name is required")
+	ErrInvalidSupplierProduct = errors.New("supplier products need a product id, appear once and have no negative lead time, minimum order quantity or pack size")
+	ErrProductNotSupplied     = errors.New("supplier does not supply the product")
+
+	ErrInvalidMoney       = errors.New("amount must be a decimal number with at most 2 decimal places")
+	ErrInvalidUnitCost    = errors.New("unit cost cannot be negative")
+	ErrProductCostChanged = errors.New("product cost layers were changed concurrently")
+
+	ErrUnknownUnit           = errors.New("unit is not defined for the product")
+	ErrInvalidUnitConversion = errors.New("unit conversions need a unit, a positive quantity of another unit, no contradictions and a path to the base unit")
+	ErrNonIntegralQuantity   = errors.New("quantity is not a whole number of base units")
+
+	ErrInvalidDecimal      = errors.New("quantity must be a decimal number")
+	ErrQuantityTooPrecise  = errors.New("quantity has more decimal places than the product keeps")
+	ErrQuantityOutOfRange  = errors.New("quantity is out of range")
+	ErrInvalidQuantityMode = errors.New("quantity mode must be integer or decimal, with a precision of 1 to 9 decimal places")
+	ErrDecimalUnsupported  = errors.New("operation does not support decimal-mode products")
+
+	ErrInvalidSKU                 = errors.New("sku must be up to 64 letters, digits, '-', '_', '.' or '/'")
+	ErrInvalidBarcode             = errors.New("barcode must be an EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check digit")
+	ErrInvalidProductIdentifier   = errors.New("give exactly one of product id, sku or barcode")
+	ErrDuplicateProductIdentifier = errors.New("sku or barcode is already used by another product of the tenant")
+	ErrInvalidVariant             = errors.New("variants need a parent of the same tenant that is not itself a variant, and named options")
 )
 
 type ErrStockExceedsLimit struct {
@@ -0,0 +1,48 @@
+// internal/domain/event_stream.go
+package domain
+
+import "errors"
+
+// Returned to a live stream subscriber that fell too far behind
+var ErrSlowConsumer = errors.New("event stream consumer is too slow")
+
+// Sent to a resuming subscriber whose Last-Event-ID is no longer retained,
+// e.g. after a restart or when it reconnects to another instance. The
+// events in between cannot be replayed, so it should reload current stock.
+var ErrStreamGap = errors.New("events after Last-Event-ID are no longer retained; reload current stock")
+
+// Event types pushed to stock dashboards
+var StockStreamEventTypes = []string{
+	EventTypeStockAdded, EventTypeStockAdjusted, EventTypeStockRemoved, EventTypeStockReversed,
+	EventTypeStockLimitAlert, EventTypeLowStock,
+}
+
+// Selects the events a stream subscriber receives. Empty EventTypes or
+// ProductIDs match everything.
+type EventFilter struct {
+	TenantID   string
+	EventTypes []string
+	ProductIDs []string
+}
+
+func (f EventFilter) Matches(event CloudEvent) bool {
+	if event.TenantID != f.TenantID {
+		return false
+	}
+	if len(f.EventTypes) > 0 && !contains(f.EventTypes, event.Type) {
+		return false
+	}
+	if len(f.ProductIDs) > 0 && !contains(f.ProductIDs, event.Subject) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
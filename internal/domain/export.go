@@ -0,0 +1,134 @@
+// internal/domain/export.go
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Formats stock data can be exported in
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatNDJSON  = "ndjson"
+	ExportFormatParquet = "parquet"
+)
+
+func IsValidExportFormat(format string) bool {
+	switch format {
+	case ExportFormatCSV, ExportFormatNDJSON, ExportFormatParquet:
+		return true
+	}
+	return false
+}
+
+// Value types of export columns
+const (
+	ExportColumnString = "string"
+	ExportColumnInt    = "int"
+	ExportColumnFloat  = "float"
+	ExportColumnTime   = "time"
+)
+
+// A named, typed column of an export. Rows hold one value per column, in
+// column order: string, int, float64 or time.Time by column type.
+type ExportColumn struct {
+	Name string
+	Type string
+}
+
+var StockLevelExportColumns = []ExportColumn{
+	{Name: "product_id", Type: ExportColumnString},
+	{Name: "product_name", Type: ExportColumnString},
+	{Name: "tenant_id", Type: ExportColumnString},
+	{Name: "location", Type: ExportColumnString},
+	{Name: "tags", Type: ExportColumnString},
+	{Name: "current_stock", Type: ExportColumnInt},
+	{Name: "max_stock", Type: ExportColumnInt},
+	{Name: "utilization_percentage", Type: ExportColumnFloat},
+	{Name: "total_added", Type: ExportColumnInt},
+	{Name: "last_updated", Type: ExportColumnTime},
+}
+
+// StockLevelExportRow is a product's row of a stock level export. Tags
+// are joined with semicolons.
+func StockLevelExportRow(p *Product, maxStock StockQuantity) []interface{} {
+	return []interface{}{
+		p.ID,
+		p.Name,
+		p.TenantID,
+		p.Location,
+		strings.Join(p.Tags, ";"),
+		p.CurrentStock.Value(),
+		maxStock.Value(),
+		p.UtilizationPercentage(maxStock),
+		p.TotalAdded.IntPart(),
+		p.LastUpdated,
+	}
+}
+
+var StockAsOfExportColumns = []ExportColumn{
+	{Name: "product_id", Type: ExportColumnString},
+	{Name: "product_name", Type: ExportColumnString},
+	{Name: "tenant_id", Type: ExportColumnString},
+	{Name: "as_of", Type: ExportColumnTime},
+	{Name: "stock", Type: ExportColumnFloat},
+	{Name: "snapshot_day", Type: ExportColumnString},
+}
+
+// StockAsOfExportRow is a product's row of a past stock level export. The
+// snapshot day is empty when stock was worked back from current stock.
+func StockAsOfExportRow(s ProductStockAsOf) []interface{} {
+	snapshotDay := ""
+	if !s.SnapshotDay.IsZero() {
+		snapshotDay = s.SnapshotDay.Format("2006-01-02")
+	}
+	return []interface{}{
+		s.ProductID,
+		s.ProductName,
+		s.TenantID,
+		s.AsOf,
+		s.Stock.Float64(),
+		snapshotDay,
+	}
+}
+
+var StockHistoryExportColumns = []ExportColumn{
+	{Name: "id", Type: ExportColumnString},
+	{Name: "product_id", Type: ExportColumnString},
+	{Name: "tenant_id", Type: ExportColumnString},
+	{Name: "operation", Type: ExportColumnString},
+	{Name: "quantity", Type: ExportColumnInt},
+	{Name: "previous_stock", Type: ExportColumnInt},
+	{Name: "new_stock", Type: ExportColumnInt},
+	{Name: "actor", Type: ExportColumnString},
+	{Name: "approved_by", Type: ExportColumnString},
+	{Name: "reason_code", Type: ExportColumnString},
+	{Name: "reference", Type: ExportColumnString},
+	{Name: "reversal_of", Type: ExportColumnString},
+	{Name: "reversed_by", Type: ExportColumnString},
+	{Name: "notes", Type: ExportColumnString},
+	{Name: "created_at", Type: ExportColumnTime},
+}
+
+func StockHistoryExportRow(e StockHistoryEntry) []interface{} {
+	return []interface{}{
+		e.ID,
+		e.ProductID,
+		e.TenantID,
+		e.Operation,
+		e.Quantity,
+		e.PreviousStock,
+		e.NewStock,
+		e.Actor,
+		e.ApprovedBy,
+		e.ReasonCode,
+		e.Reference,
+		e.ReversalOf,
+		e.ReversedBy,
+		e.Notes,
+		e.CreatedAt,
+	}
+}
+
+// Default history export range when none is given
+const DefaultHistoryExportRange = 30 * 24 * time.Hour
@@ -0,0 +1,184 @@
+// internal/domain/forecast.go
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// How average daily consumption is estimated from history
+const (
+	// Mean of the window's days
+	ForecastMethodMovingAverage = "moving_average"
+	// Recent days weigh more, by Alpha
+	ForecastMethodExponentialSmoothing = "exponential_smoothing"
+)
+
+// Forecast settings used for values a tenant leaves unset
+const (
+	DefaultForecastWindowDays = 28
+	DefaultSmoothingAlpha     = 0.3
+	DefaultLeadTimeDays       = 7
+	DefaultSafetyStockDays    = 3
+	DefaultCoverDays          = 30
+	MaxForecastWindowDays     = 365
+)
+
+// A tenant's demand forecasting and reordering settings
+type ForecastSettings struct {
+	Method     string
+	WindowDays int
+	// Smoothing factor of exponential smoothing, in (0, 1]
+	Alpha float64
+	// Days between ordering and receiving stock
+	LeadTimeDays int
+	// Extra days of demand kept in stock against surprises
+	SafetyStockDays int
+	// Days of demand a suggested order covers beyond the reorder point
+	CoverDays int
+	// Low-stock alerts also fire when stock reaches the reorder point
+	Alerts bool
+}
+
+// WithDefaults fills unset settings with the defaults
+func (s ForecastSettings) WithDefaults() ForecastSettings {
+	if s.Method == "" {
+		s.Method = ForecastMethodMovingAverage
+	}
+	if s.WindowDays == 0 {
+		s.WindowDays = DefaultForecastWindowDays
+	}
+	if s.Alpha == 0 {
+		s.Alpha = DefaultSmoothingAlpha
+	}
+	if s.LeadTimeDays == 0 {
+		s.LeadTimeDays = DefaultLeadTimeDays
+	}
+	if s.SafetyStockDays == 0 {
+		s.SafetyStockDays = DefaultSafetyStockDays
+	}
+	if s.CoverDays == 0 {
+		s.CoverDays = DefaultCoverDays
+	}
+	return s
+}
+
+func (s ForecastSettings) Validate() error {
+	switch s.Method {
+	case ForecastMethodMovingAverage, ForecastMethodExponentialSmoothing:
+	default:
+		return ErrInvalidForecastSettings
+	}
+	if s.WindowDays < 1 || s.WindowDays > MaxForecastWindowDays {
+		return ErrInvalidForecastSettings
+	}
+	if s.Alpha <= 0 || s.Alpha > 1 {
+		return ErrInvalidForecastSettings
+	}
+	if s.LeadTimeDays < 0 || s.SafetyStockDays < 0 || s.CoverDays < 0 {
+		return ErrInvalidForecastSettings
+	}
+	return nil
+}
+
+// Window returns the whole UTC days the forecast looks at, ending before
+// the day now falls in, which is still incomplete
+func (s ForecastSettings) Window(now time.Time) (from, to time.Time) {
+	to = SnapshotDay(now)
+	return to.AddDate(0, 0, -s.WindowDays), to
+}
+
+// Stock a product's removals took out on one UTC day
+type DailyConsumption struct {
+	ProductID string
+	Day       time.Time
+	Quantity  Decimal
+}
+
+// ConsumptionSeries lays out a product's consumption day by day from
+// from, oldest first, with zeros for days without removals
+func ConsumptionSeries(days []DailyConsumption, from time.Time, windowDays int) []float64 {
+	series := make([]float64, windowDays)
+	for _, d := range days {
+		i := int(d.Day.Sub(from).Hours() / 24)
+		if i >= 0 && i < windowDays {
+			series[i] += d.Quantity.Float64()
+		}
+	}
+	return series
+}
+
+func MovingAverage(series []float64) float64 {
+	if len(series) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, v := range series {
+		total += v
+	}
+	return total / float64(len(series))
+}
+
+// ExponentialSmoothing starts from the oldest day and weighs each later
+// day by alpha
+func ExponentialSmoothing(series []float64, alpha float64) float64 {
+	if len(series) == 0 {
+		return 0
+	}
+	level := series[0]
+	for _, v := range series[1:] {
+		level = alpha*v + (1-alpha)*level
+	}
+	return level
+}
+
+// A product's expected demand and what to reorder
+type ProductForecast struct {
+	ProductID    string
+	ProductName  string
+	CurrentStock int
+	// Average units consumed per day
+	DailyDemand float64
+	// nil when nothing is consumed
+	DaysUntilStockout *float64
+	SafetyStock       int
+	// Stock at or below which an order should be placed
+	ReorderPoint int
+	NeedsReorder bool
+	// Units to order now, kept within the tenant limit; zero unless
+	// NeedsReorder
+	ReorderQuantity int
+}
+
+// NewProductForecast forecasts a product from its consumption series
+func NewProductForecast(p *Product, series []float64, s ForecastSettings, maxStock StockQuantity) ProductForecast {
+	demand := MovingAverage(series)
+	if s.Method == ForecastMethodExponentialSmoothing {
+		demand = ExponentialSmoothing(series, s.Alpha)
+	}
+
+	f := ProductForecast{
+		ProductID:    p.ID,
+		ProductName:  p.Name,
+		CurrentStock: p.CurrentStock.Value(),
+		DailyDemand:  demand,
+	}
+	if demand <= 0 {
+		return f
+	}
+	days := float64(f.CurrentStock) / demand
+	f.DaysUntilStockout = &days
+	f.SafetyStock = int(math.Ceil(demand * float64(s.SafetyStockDays)))
+	f.ReorderPoint = int(math.Ceil(demand*float64(s.LeadTimeDays))) + f.SafetyStock
+	f.NeedsReorder = f.CurrentStock <= f.ReorderPoint
+	if f.NeedsReorder {
+		target := f.ReorderPoint + int(math.Ceil(demand*float64(s.CoverDays)))
+		if maxStock.Value() > 0 && target > maxStock.Value() {
+			target = maxStock.Value()
+		}
+		if target > f.CurrentStock {
+			f.ReorderQuantity = target - f.CurrentStock
+		}
+	}
+	return f
+}
@@ -0,0 +1,296 @@
+// internal/domain/import_job.go
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spreadsheet formats accepted for stock imports
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+)
+
+// Import job lifecycle: pending -> running -> completed | failed
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// Most data rows accepted in one import file
+const MaxImportRows = 10000
+
+// How long a claimed job stays with its worker without saving progress.
+// A running job whose lease ran out was left by a worker that stopped and
+// may be claimed again.
+const ImportJobLease = 5 * time.Minute
+
+// Header names of the columns an import reads. Only Notes is optional.
+type ImportColumnMapping struct {
+	ProductID string
+	Quantity  string
+	Notes     string
+}
+
+func DefaultImportColumnMapping() ImportColumnMapping {
+	return ImportColumnMapping{
+		ProductID: "product_id",
+		Quantity:  "quantity",
+		Notes:     "notes",
+	}
+}
+
+// WithDefaults fills unmapped columns with the default header names
+func (m ImportColumnMapping) WithDefaults() ImportColumnMapping {
+	defaults := DefaultImportColumnMapping()
+	if m.ProductID == "" {
+		m.ProductID = defaults.ProductID
+	}
+	if m.Quantity == "" {
+		m.Quantity = defaults.Quantity
+	}
+	if m.Notes == "" {
+		m.Notes = defaults.Notes
+	}
+	return m
+}
+
+// ParseRows maps the data rows of a sheet, whose first row holds the
+// headers, to import rows. Header names match case-insensitively. Rows
+// whose quantity is not a whole number are returned as row errors.
+func (m ImportColumnMapping) ParseRows(sheet [][]string) ([]ImportRow, []ImportRowError, error) {
+	if len(sheet) == 0 {
+		return nil, nil, ErrInvalidImportFile{Reason: "file is empty"}
+	}
+	columns := make(map[string]int)
+	for i, name := range sheet[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	productCol, ok := columns[strings.ToLower(m.ProductID)]
+	if !ok {
+		return nil, nil, ErrInvalidImportFile{Reason: fmt.Sprintf("missing column %q", m.ProductID)}
+	}
+	quantityCol, ok := columns[strings.ToLower(m.Quantity)]
+	if !ok {
+		return nil, nil, ErrInvalidImportFile{Reason: fmt.Sprintf("missing column %q", m.Quantity)}
+	}
+	notesCol, hasNotes := columns[strings.ToLower(m.Notes)]
+
+	if len(sheet)-1 > MaxImportRows {
+		return nil, nil, ErrInvalidImportFile{Reason: fmt.Sprintf("more than %d rows", MaxImportRows)}
+	}
+
+	var rows []ImportRow
+	var rowErrors []ImportRowError
+	for i, record := range sheet[1:] {
+		// Line numbers count the header, as spreadsheet programs do
+		line := i + 2
+		if isBlankRecord(record) {
+			continue
+		}
+		row := ImportRow{Line: line, ProductID: cell(record, productCol)}
+		if hasNotes {
+			row.Notes = cell(record, notesCol)
+		}
+		quantity, err := strconv.Atoi(cell(record, quantityCol))
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{
+				Line:      line,
+				ProductID: row.ProductID,
+				Quantity:  cell(record, quantityCol),
+				Message:   "quantity must be a whole number",
+			})
+			continue
+		}
+		row.Quantity = quantity
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		return nil, nil, ErrInvalidImportFile{Reason: "file has no data rows"}
+	}
+	return rows, rowErrors, nil
+}
+
+func cell(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// A stock receipt line read from an import file
+type ImportRow struct {
+	Line      int
+	ProductID string
+	Quantity  int
+	Notes     string
+}
+
+// Why a line of an import file was not applied
+type ImportRowError struct {
+	Line      int
+	ProductID string
+	// As written in the file
+	Quantity string
+	Message  string
+}
+
+// An uploaded file of stock receipts, applied in the background. Jobs are
+// unique per tenant and file hash so that uploading a file twice does
+// not add its stock twice; a failed job keeps its file and is resumed
+// rather than imported again.
+type ImportJob struct {
+	ID        string
+	TenantID  string
+	FileName  string
+	FileHash  string
+	Format    string
+	Mapping   ImportColumnMapping
+	Status    string
+	Rows      []ImportRow
+	TotalRows int
+	// Index in Rows of the next row to apply; the rows before it were
+	// handled and are not applied again when the job resumes
+	NextRow int
+	// Rows handled so far, applied or not
+	ProcessedRows int
+	AppliedRows   int
+	// Rows held as change requests because they exceed the tenant's
+	// approval threshold. They are listed in Errors with the request.
+	PendingApprovalRows int
+	Errors              []ImportRowError
+	// Set when the job failed as a whole
+	Failure    string
+	CreatedBy  string
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Set while running; see ImportJobLease
+	LeaseUntil time.Time
+}
+
+// NewImportJob creates a pending job. Rows that could not be parsed count
+// as processed failures from the start.
+func NewImportJob(tenantID, fileName, fileHash, format string, mapping ImportColumnMapping, rows []ImportRow, rowErrors []ImportRowError, createdBy string) *ImportJob {
+	return &ImportJob{
+		TenantID:      tenantID,
+		FileName:      fileName,
+		FileHash:      fileHash,
+		Format:        format,
+		Mapping:       mapping,
+		Status:        ImportStatusPending,
+		Rows:          rows,
+		TotalRows:     len(rows) + len(rowErrors),
+		ProcessedRows: len(rowErrors),
+		Errors:        rowErrors,
+		CreatedBy:     createdBy,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Share of rows processed, 0 to 100
+func (j *ImportJob) Progress() float64 {
+	if j.TotalRows == 0 {
+		return 100
+	}
+	return float64(j.ProcessedRows) / float64(j.TotalRows) * 100
+}
+
+func (j *ImportJob) FailedRows() int {
+	return len(j.Errors) - j.PendingApprovalRows
+}
+
+// IsFinished reports whether the job has stopped for good
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportStatusCompleted || j.Status == ImportStatusFailed
+}
+
+// Start marks a claimed job as running and leases it to the worker. A
+// reclaimed job keeps the time it first started.
+func (j *ImportJob) Start(now time.Time) {
+	j.Status = ImportStatusRunning
+	if j.StartedAt.IsZero() {
+		j.StartedAt = now
+	}
+	j.LeaseUntil = now.Add(ImportJobLease)
+}
+
+// Renew extends the worker's lease on a running job
+func (j *ImportJob) Renew(now time.Time) {
+	j.LeaseUntil = now.Add(ImportJobLease)
+}
+
+// IsLeaseExpired reports whether a running job was left by its worker
+func (j *ImportJob) IsLeaseExpired(now time.Time) bool {
+	return j.Status == ImportStatusRunning && now.After(j.LeaseUntil)
+}
+
+// RecordRow counts a processed row; a nil rowErr means it was applied
+func (j *ImportJob) RecordRow(row ImportRow, rowErr error) {
+	j.NextRow++
+	j.ProcessedRows++
+	if rowErr == nil {
+		j.AppliedRows++
+		return
+	}
+	j.Errors = append(j.Errors, rowError(row, rowErr.Error()))
+}
+
+// RecordHeldRow counts a processed row that was held for approval as the
+// change request requestID instead of being applied
+func (j *ImportJob) RecordHeldRow(row ImportRow, requestID string) {
+	j.NextRow++
+	j.ProcessedRows++
+	j.PendingApprovalRows++
+	j.Errors = append(j.Errors, rowError(row, fmt.Sprintf("held for approval as change request %s", requestID)))
+}
+
+func rowError(row ImportRow, message string) ImportRowError {
+	return ImportRowError{
+		Line:      row.Line,
+		ProductID: row.ProductID,
+		Quantity:  strconv.Itoa(row.Quantity),
+		Message:   message,
+	}
+}
+
+func (j *ImportJob) Complete(now time.Time) {
+	j.Status = ImportStatusCompleted
+	j.FinishedAt = now
+}
+
+// Fail stops the job; rows already applied stay applied
+func (j *ImportJob) Fail(reason string, now time.Time) {
+	j.Status = ImportStatusFailed
+	j.Failure = reason
+	j.FinishedAt = now
+}
+
+// Requeue queues a failed job again; it resumes at NextRow
+func (j *ImportJob) Requeue() {
+	j.Status = ImportStatusPending
+	j.Failure = ""
+	j.FinishedAt = time.Time{}
+}
+
+type ErrInvalidImportFile struct {
+	Reason string
+}
+
+func (e ErrInvalidImportFile) Error() string {
+	return fmt.Sprintf("invalid import file: %s", e.Reason)
+}
@@ -0,0 +1,162 @@
+// internal/domain/notification_routing.go
+package domain
+
+import "time"
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+var severityRank = map[Severity]int{
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityCritical: 3,
+}
+
+func (s Severity) IsValid() bool {
+	_, ok := severityRank[s]
+	return ok
+}
+
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
+// Delivery channels a notification can be routed to
+const (
+	ChannelSlack   = "slack"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelLog     = "log"
+)
+
+func IsKnownChannel(channel string) bool {
+	switch channel {
+	case ChannelSlack, ChannelEmail, ChannelWebhook, ChannelLog:
+		return true
+	}
+	return false
+}
+
+// Event types notifications are raised for
+func IsRoutableEventType(eventType string) bool {
+	return eventType == EventTypeStockLimitAlert || eventType == EventTypeLowStock
+}
+
+// What routing rules are matched against
+type AlertContext struct {
+	EventType   string
+	Severity    Severity
+	ProductTags []string
+}
+
+// Tenant rule mapping alerts to a set of channels.
+// Empty criteria match everything.
+type RoutingRule struct {
+	ID          string
+	TenantID    string
+	Name        string
+	EventType   string
+	MinSeverity Severity
+	ProductTags []string
+	Channels    []string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (r *RoutingRule) Validate() error {
+	if r.EventType != "" && !IsRoutableEventType(r.EventType) {
+		return ErrInvalidEventType
+	}
+	if r.MinSeverity != "" && !r.MinSeverity.IsValid() {
+		return ErrInvalidSeverity
+	}
+	if len(r.Channels) == 0 {
+		return ErrInvalidChannel
+	}
+	for _, c := range r.Channels {
+		if !IsKnownChannel(c) {
+			return ErrInvalidChannel
+		}
+	}
+	return nil
+}
+
+func (r *RoutingRule) Matches(alert AlertContext) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.EventType != "" && r.EventType != alert.EventType {
+		return false
+	}
+	if r.MinSeverity != "" && !alert.Severity.AtLeast(r.MinSeverity) {
+		return false
+	}
+	if len(r.ProductTags) > 0 && !hasAnyTag(alert.ProductTags, r.ProductTags) {
+		return false
+	}
+	return true
+}
+
+// Rules used for tenants that have not configured any: Slack for every
+// alert, plus email for critical ones.
+func DefaultRoutingRules() []*RoutingRule {
+	return []*RoutingRule{
+		{Name: "default", Channels: []string{ChannelSlack}, IsActive: true},
+		{Name: "default-critical", MinSeverity: SeverityCritical, Channels: []string{ChannelEmail}, IsActive: true},
+	}
+}
+
+// ResolveChannels returns the union of channels of all matching rules, in
+// first-seen order.
+func ResolveChannels(rules []*RoutingRule, alert AlertContext) []string {
+	seen := make(map[string]bool)
+	var channels []string
+	for _, rule := range rules {
+		if !rule.Matches(alert) {
+			continue
+		}
+		for _, c := range rule.Channels {
+			if !seen[c] {
+				seen[c] = true
+				channels = append(channels, c)
+			}
+		}
+	}
+	return channels
+}
+
+func hasAnyTag(tags, wanted []string) bool {
+	for _, w := range wanted {
+		for _, t := range tags {
+			if t == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Rendered alert handed to a channel
+type Notification struct {
+	TenantID    string    `json:"tenant_id"`
+	AlertType   string    `json:"alert_type"`
+	Severity    Severity  `json:"severity"`
+	ProductID   string    `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Message     string    `json:"message"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (n Notification) EventType() string {
+	return EventTypeNotification
+}
+
+func (n Notification) AggregateID() string {
+	return n.ProductID
+}
@@ -0,0 +1,45 @@
+// internal/domain/notification_template.go
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Names of the notification templates a tenant can override
+const (
+	TemplateStockAlertSlack = "stock_alert_slack"
+	TemplateStockAlertEmail = "stock_alert_email"
+	TemplateLowStockAlert   = "low_stock_alert"
+)
+
+func NotificationTemplateNames() []string {
+	return []string{TemplateStockAlertSlack, TemplateStockAlertEmail, TemplateLowStockAlert}
+}
+
+func IsKnownTemplate(name string) bool {
+	for _, n := range NotificationTemplateNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Tenant override of a default notification template
+type NotificationTemplate struct {
+	TenantID  string
+	Name      string
+	Body      string
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+type ErrInvalidTemplate struct {
+	Name   string
+	Reason string
+}
+
+func (e ErrInvalidTemplate) Error() string {
+	return fmt.Sprintf("invalid template %s: %s", e.Name, e.Reason)
+}
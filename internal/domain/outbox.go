@@ -0,0 +1,158 @@
+// internal/domain/outbox.go
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxPublished OutboxStatus = "published"
+	// Gave up after MaxOutboxAttempts; needs manual attention. It holds
+	// back its aggregate's later entries until it is requeued or
+	// discarded.
+	OutboxDead OutboxStatus = "dead"
+	// A dead entry an operator chose not to publish
+	OutboxDiscarded OutboxStatus = "discarded"
+)
+
+const MaxOutboxAttempts = 10
+
+// How long published entries and relay attempts are kept before they are
+// pruned
+const OutboxRetention = 7 * 24 * time.Hour
+
+// Domain event persisted in the same unit of work as the state change
+// that produced it, waiting to be published by the relay.
+type OutboxEntry struct {
+	ID            string
+	EventType     string
+	AggregateID   string
+	TenantID      string
+	Payload       []byte
+	Status        OutboxStatus
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	PublishedAt   time.Time
+}
+
+func NewOutboxEntry(event Event, tenantID string) (*OutboxEntry, error) {
+	envelope, err := NewCloudEvent(event, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", event.EventType(), err)
+	}
+	now := time.Now()
+	return &OutboxEntry{
+		EventType:     event.EventType(),
+		AggregateID:   event.AggregateID(),
+		TenantID:      tenantID,
+		Payload:       payload,
+		Status:        OutboxPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// Envelope returns the stored CloudEvent. Entries written before envelopes
+// were introduced hold bare version 1 event data and are wrapped here.
+func (e *OutboxEntry) Envelope() (CloudEvent, error) {
+	var envelope CloudEvent
+	if err := json.Unmarshal(e.Payload, &envelope); err != nil {
+		return CloudEvent{}, fmt.Errorf("decode outbox entry %s: %w", e.ID, err)
+	}
+	if envelope.SpecVersion != "" {
+		return envelope, nil
+	}
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              e.ID,
+		Source:          EventSource,
+		Type:            e.EventType,
+		Subject:         e.AggregateID,
+		Time:            e.CreatedAt.UTC(),
+		DataContentType: "application/json",
+		DataSchema:      EventSchemaURI(e.EventType, 1),
+		TenantID:        e.TenantID,
+		DataVersion:     1,
+		Data:            e.Payload,
+	}, nil
+}
+
+func (e *OutboxEntry) Decode() (Event, error) {
+	envelope, err := e.Envelope()
+	if err != nil {
+		return nil, err
+	}
+	return envelope.Decode()
+}
+
+func (e *OutboxEntry) MarkPublished(at time.Time) {
+	e.Attempts++
+	e.Status = OutboxPublished
+	e.LastError = ""
+	e.PublishedAt = at
+}
+
+// MarkFailed schedules a retry with exponential backoff, or marks the
+// entry dead once MaxOutboxAttempts is reached.
+func (e *OutboxEntry) MarkFailed(err error, at time.Time, baseBackoff time.Duration) {
+	e.Attempts++
+	e.LastError = err.Error()
+	if e.Attempts >= MaxOutboxAttempts {
+		e.Status = OutboxDead
+		return
+	}
+	e.NextAttemptAt = at.Add(baseBackoff << (e.Attempts - 1))
+}
+
+// Requeue makes a dead entry due again with a fresh set of attempts
+func (e *OutboxEntry) Requeue(at time.Time) error {
+	if e.Status != OutboxDead {
+		return ErrOutboxEntryNotDead
+	}
+	e.Status = OutboxPending
+	e.Attempts = 0
+	e.NextAttemptAt = at
+	return nil
+}
+
+// Discard gives up on a dead entry for good, releasing the entries of its
+// aggregate behind it. LastError is kept for the record.
+func (e *OutboxEntry) Discard() error {
+	if e.Status != OutboxDead {
+		return ErrOutboxEntryNotDead
+	}
+	e.Status = OutboxDiscarded
+	return nil
+}
+
+// One relay attempt to publish an outbox entry
+type OutboxAttempt struct {
+	EntryID   string
+	Attempt   int
+	Success   bool
+	Error     string
+	Timestamp time.Time
+}
+
+// Progress of a relay, persisted so a restarted relay can report where it
+// stopped. Pending entries are always found by status, so a stale cursor
+// can cause re-delivery but never loss.
+type OutboxCursor struct {
+	Relay           string
+	LastEntryID     string
+	LastPublishedAt time.Time
+	Published       int64
+	Failed          int64
+	UpdatedAt       time.Time
+}
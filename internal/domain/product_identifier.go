@@ -0,0 +1,152 @@
+// internal/domain/product_identifier.go
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SKUs are letters, digits and - _ . / up to this length
+const MaxSKULength = 64
+
+var skuPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._/-]*$`)
+
+// Another way than its ID to find a product; exactly one field is set.
+// SKUs and barcodes are unique within a tenant.
+type ProductIdentifier struct {
+	SKU     string
+	Barcode string
+}
+
+// NormalizeSKU trims and upper-cases sku, so lookups ignore case
+func NormalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
+
+// ValidateSKU checks a normalized SKU; an empty one is allowed
+func ValidateSKU(sku string) error {
+	if sku == "" {
+		return nil
+	}
+	if len(sku) > MaxSKULength || !skuPattern.MatchString(sku) {
+		return ErrInvalidSKU
+	}
+	return nil
+}
+
+// Barcodes are kept as GTIN-13, so the UPC-A 036000291452 and the EAN-13
+// 0036000291452 are one barcode
+const gtin13Length = 13
+
+// NormalizeBarcode checks an EAN-8, UPC-A (12 digits), EAN-13 or GTIN-14
+// barcode, including its GS1 check digit, and returns it as GTIN-13. Short
+// forms are padded with leading zeros, which keeps the check digit valid;
+// a GTIN-14 keeps its 14 digits unless its indicator digit is 0. An empty
+// barcode stays empty.
+func NormalizeBarcode(barcode string) (string, error) {
+	barcode = strings.TrimSpace(barcode)
+	if barcode == "" {
+		return "", nil
+	}
+	switch len(barcode) {
+	case 8, 12, 13, 14:
+	default:
+		return "", ErrInvalidBarcode
+	}
+	sum := 0
+	for i := len(barcode) - 1; i >= 0; i-- {
+		digit := int(barcode[i] - '0')
+		if digit < 0 || digit > 9 {
+			return "", ErrInvalidBarcode
+		}
+		// Weights alternate 1, 3, 1, ... from the check digit leftwards
+		if (len(barcode)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	if sum%10 != 0 {
+		return "", ErrInvalidBarcode
+	}
+	if len(barcode) < gtin13Length {
+		return strings.Repeat("0", gtin13Length-len(barcode)) + barcode, nil
+	}
+	if len(barcode) > gtin13Length && barcode[0] == '0' {
+		return barcode[1:], nil
+	}
+	return barcode, nil
+}
+
+// normalized normalizes the SKU and barcode, failing when either is invalid
+func (id ProductIdentifier) normalized() (ProductIdentifier, error) {
+	sku := NormalizeSKU(id.SKU)
+	if err := ValidateSKU(sku); err != nil {
+		return ProductIdentifier{}, err
+	}
+	barcode, err := NormalizeBarcode(id.Barcode)
+	if err != nil {
+		return ProductIdentifier{}, err
+	}
+	return ProductIdentifier{SKU: sku, Barcode: barcode}, nil
+}
+
+// NewProductIdentifier checks that exactly one of sku and barcode is set
+// and valid
+func NewProductIdentifier(sku, barcode string) (ProductIdentifier, error) {
+	if (strings.TrimSpace(sku) == "") == (strings.TrimSpace(barcode) == "") {
+		return ProductIdentifier{}, ErrInvalidProductIdentifier
+	}
+	return ProductIdentifier{SKU: sku, Barcode: barcode}.normalized()
+}
+
+// SetIdentifiers replaces the product's SKU and barcode; empty ones are
+// cleared. Uniqueness within the tenant is for the repository to enforce.
+func (p *Product) SetIdentifiers(sku, barcode string) error {
+	id, err := ProductIdentifier{SKU: sku, Barcode: barcode}.normalized()
+	if err != nil {
+		return err
+	}
+	p.SKU = id.SKU
+	p.Barcode = id.Barcode
+	return nil
+}
+
+// IsVariant reports whether the product is grouped under a parent
+func (p *Product) IsVariant() bool {
+	return p.ParentID != ""
+}
+
+// SetParent groups the product as a variant under parent, or makes it
+// stand alone when parent is nil. Variants are one level deep: a parent is
+// not itself a variant, and a product with variants cannot become one.
+// options describe what sets the variant apart, e.g. size: L.
+func (p *Product) SetParent(parent *Product, hasVariants bool, options map[string]string) error {
+	if parent == nil {
+		if len(options) > 0 {
+			return ErrInvalidVariant
+		}
+		p.ParentID = ""
+		p.VariantOptions = nil
+		return nil
+	}
+	if parent.ID == p.ID || parent.TenantID != p.TenantID || parent.IsVariant() || hasVariants {
+		return ErrInvalidVariant
+	}
+	cleaned := make(map[string]string, len(options))
+	for name, value := range options {
+		name, value = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value)
+		if name == "" || value == "" {
+			return ErrInvalidVariant
+		}
+		if _, dup := cleaned[name]; dup {
+			return ErrInvalidVariant
+		}
+		cleaned[name] = value
+	}
+	if len(cleaned) == 0 {
+		cleaned = nil
+	}
+	p.ParentID = parent.ID
+	p.VariantOptions = cleaned
+	return nil
+}
@@ -0,0 +1,209 @@
+// internal/domain/purchase_order.go
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Purchase order lifecycle: open -> partially_received -> received, or
+// cancelled while not fully received
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// Stock ordered from a supplier. Receiving against the order adds stock
+// line by line; the order closes as received once every line has
+// arrived in full.
+type PurchaseOrder struct {
+	ID       string
+	TenantID string
+	Supplier string
+	// Set when the order is placed with a registered supplier; receipts
+	// then record it in stock history
+	SupplierID string
+	// The supplier's or buyer's own order number
+	Number     string
+	Notes      string
+	Status     string
+	Lines      []PurchaseOrderLine
+	Receipts   []PurchaseOrderReceipt
+	ExpectedAt time.Time
+	CreatedBy  string
+	CreatedAt  time.Time
+	ClosedBy   string
+	ClosedAt   time.Time
+	// Incremented on every stored change, so concurrent receipts cannot
+	// both count against the same outstanding quantity
+	Version int
+}
+
+type PurchaseOrderLine struct {
+	ProductID   string
+	ProductName string
+	Expected    int
+	Received    int
+}
+
+// Products and quantities of one delivery
+type PurchaseOrderReceipt struct {
+	Lines      []ProductQuantity
+	ReceivedBy string
+	Notes      string
+	ReceivedAt time.Time
+}
+
+// A product and a quantity of it, as ordered or received
+type ProductQuantity struct {
+	ProductID string
+	Quantity  int
+}
+
+// Quantity still to arrive; zero once the line is received or over-received
+func (l PurchaseOrderLine) Outstanding() int {
+	if l.Received >= l.Expected {
+		return 0
+	}
+	return l.Expected - l.Received
+}
+
+// Quantity received beyond what was ordered
+func (l PurchaseOrderLine) OverReceived() int {
+	if l.Received <= l.Expected {
+		return 0
+	}
+	return l.Received - l.Expected
+}
+
+// NewPurchaseOrder opens an order for the given products, keyed by ID, and
+// quantities. Every line must be for a different product.
+func NewPurchaseOrder(tenantID, supplier, number, notes string, lines []ProductQuantity, products map[string]*Product, expectedAt time.Time, createdBy string) (*PurchaseOrder, error) {
+	if len(lines) == 0 {
+		return nil, ErrEmptyPurchaseOrder
+	}
+	orderLines := make([]PurchaseOrderLine, 0, len(lines))
+	seen := map[string]bool{}
+	for _, l := range lines {
+		if seen[l.ProductID] {
+			return nil, ErrDuplicatePurchaseOrderLine
+		}
+		seen[l.ProductID] = true
+		if l.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		product := products[l.ProductID]
+		if product == nil || product.TenantID != tenantID {
+			return nil, ErrProductNotFound
+		}
+		orderLines = append(orderLines, PurchaseOrderLine{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Expected:    l.Quantity,
+		})
+	}
+	return &PurchaseOrder{
+		TenantID:   tenantID,
+		Supplier:   supplier,
+		Number:     number,
+		Notes:      notes,
+		Status:     PurchaseOrderOpen,
+		Lines:      orderLines,
+		ExpectedAt: expectedAt,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// IsReceivable reports whether stock can still be received against the order
+func (o *PurchaseOrder) IsReceivable() bool {
+	return o.Status == PurchaseOrderOpen || o.Status == PurchaseOrderPartiallyReceived
+}
+
+func (o *PurchaseOrder) Line(productID string) *PurchaseOrderLine {
+	for i := range o.Lines {
+		if o.Lines[i].ProductID == productID {
+			return &o.Lines[i]
+		}
+	}
+	return nil
+}
+
+// Receive records a delivery against the order's lines and moves the order
+// to partially_received or received. A line receiving more than is
+// outstanding fails with ErrOverReceipt unless allowOverReceipt is set.
+func (o *PurchaseOrder) Receive(receipt PurchaseOrderReceipt, allowOverReceipt bool) error {
+	if !o.IsReceivable() {
+		return ErrPurchaseOrderClosed
+	}
+	if len(receipt.Lines) == 0 {
+		return ErrEmptyPurchaseOrder
+	}
+	seen := map[string]bool{}
+	for _, r := range receipt.Lines {
+		if seen[r.ProductID] {
+			return ErrDuplicatePurchaseOrderLine
+		}
+		seen[r.ProductID] = true
+		if r.Quantity <= 0 {
+			return ErrInvalidQuantity
+		}
+		line := o.Line(r.ProductID)
+		if line == nil {
+			return ErrProductNotOnPurchaseOrder
+		}
+		if !allowOverReceipt && r.Quantity > line.Outstanding() {
+			return ErrOverReceipt{ProductID: r.ProductID, Outstanding: line.Outstanding(), Receiving: r.Quantity}
+		}
+	}
+
+	for _, r := range receipt.Lines {
+		o.Line(r.ProductID).Received += r.Quantity
+	}
+	o.Receipts = append(o.Receipts, receipt)
+	o.Status = PurchaseOrderPartiallyReceived
+	if o.IsFullyReceived() {
+		o.Status = PurchaseOrderReceived
+		o.ClosedBy = receipt.ReceivedBy
+		o.ClosedAt = receipt.ReceivedAt
+	}
+	return nil
+}
+
+func (o *PurchaseOrder) IsFullyReceived() bool {
+	for _, l := range o.Lines {
+		if l.Outstanding() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Cancel closes an order that is not fully received; stock already
+// received stays
+func (o *PurchaseOrder) Cancel(cancelledBy string) error {
+	if !o.IsReceivable() {
+		return ErrPurchaseOrderClosed
+	}
+	o.Status = PurchaseOrderCancelled
+	o.ClosedBy = cancelledBy
+	o.ClosedAt = time.Now()
+	return nil
+}
+
+// ErrOverReceipt is returned when a delivery line exceeds the quantity the
+// purchase order still expects
+type ErrOverReceipt struct {
+	ProductID   string
+	Outstanding int
+	Receiving   int
+}
+
+func (e ErrOverReceipt) Error() string {
+	return fmt.Sprintf(
+		"receiving %d of product %s exceeds the %d outstanding on the purchase order",
+		e.Receiving, e.ProductID, e.Outstanding,
+	)
+}
@@ -0,0 +1,120 @@
+// internal/domain/stock_change_request.go
+package domain
+
+import "time"
+
+// Change request lifecycle: pending -> approved | rejected | expired
+const (
+	ChangeRequestPending  = "pending"
+	ChangeRequestApproved = "approved"
+	ChangeRequestRejected = "rejected"
+	ChangeRequestExpired  = "expired"
+)
+
+// Stock operations that can wait for approval
+const (
+	ChangeOperationAdd    = "add"
+	ChangeOperationRemove = "remove"
+)
+
+// Role a user needs to decide on change requests
+const RoleStockApprover = "stock_approver"
+
+// How long a change request stays pending when the tenant sets no TTL
+const DefaultApprovalTTL = 24 * time.Hour
+
+// A stock change above the tenant's approval threshold, held until a
+// second person approves or rejects it. Approving runs the original
+// operation with both people recorded in history.
+type StockChangeRequest struct {
+	ID          string
+	TenantID    string
+	ProductID   string
+	ProductName string
+	Operation   string
+	Quantity    int
+	Notes       string
+	Status      string
+	RequestedBy string
+	RequestedAt time.Time
+	ExpiresAt   time.Time
+	DecidedBy   string
+	DecidedAt   time.Time
+	// Reason given when rejecting
+	DecisionNote string
+	// Details of an add, applied with it once approved. Quantity is in
+	// Unit when one was entered, otherwise in the product's base unit.
+	Unit       string
+	SupplierID string
+	UnitCost   *Money
+	// Exact quantity of a decimal-mode product; Quantity holds its whole
+	// units
+	DecimalQuantity *Decimal
+}
+
+func NewStockChangeRequest(tenant *Tenant, product *Product, operation string, quantity int, notes, requestedBy string) *StockChangeRequest {
+	now := time.Now()
+	return &StockChangeRequest{
+		TenantID:    tenant.ID,
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Operation:   operation,
+		Quantity:    quantity,
+		Notes:       notes,
+		Status:      ChangeRequestPending,
+		RequestedBy: requestedBy,
+		RequestedAt: now,
+		ExpiresAt:   tenant.ApprovalExpiry(now),
+	}
+}
+
+func (r *StockChangeRequest) IsPending() bool {
+	return r.Status == ChangeRequestPending
+}
+
+// IsExpired reports whether a pending request has outlived its TTL.
+func (r *StockChangeRequest) IsExpired(now time.Time) bool {
+	return r.IsPending() && !now.Before(r.ExpiresAt)
+}
+
+// Approve records the approver. An expired request is marked expired
+// and ErrChangeRequestExpired returned so the caller can store that.
+func (r *StockChangeRequest) Approve(approvedBy string, roles []string, now time.Time) error {
+	if err := r.checkDecision(approvedBy, roles, now); err != nil {
+		return err
+	}
+	if approvedBy == r.RequestedBy {
+		return ErrSelfApproval
+	}
+	r.decide(ChangeRequestApproved, approvedBy, "", now)
+	return nil
+}
+
+func (r *StockChangeRequest) Reject(rejectedBy string, roles []string, note string, now time.Time) error {
+	if err := r.checkDecision(rejectedBy, roles, now); err != nil {
+		return err
+	}
+	r.decide(ChangeRequestRejected, rejectedBy, note, now)
+	return nil
+}
+
+func (r *StockChangeRequest) checkDecision(by string, roles []string, now time.Time) error {
+	if !r.IsPending() {
+		return ErrChangeRequestClosed
+	}
+	if !contains(roles, RoleStockApprover) {
+		return ErrApproverRoleRequired
+	}
+	if r.IsExpired(now) {
+		r.decide(ChangeRequestExpired, "", "", now)
+		return ErrChangeRequestExpired
+	}
+	return nil
+}
+
+func (r *StockChangeRequest) decide(status, by, note string, now time.Time) {
+	r.Status = status
+	r.DecidedBy = by
+	r.DecisionNote = note
+	r.DecidedAt = now
+}
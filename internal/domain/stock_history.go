@@ -0,0 +1,227 @@
+// internal/domain/stock_history.go
+package domain
+
+import "time"
+
+// Values of stock_history.operation
+const (
+	HistoryOperationStockAdd    = "stock_add"
+	HistoryOperationStockRemove = "stock_remove"
+	// Written by reconciliation to bring history in line with current_stock
+	HistoryOperationReconciliation = "reconciliation_adjustment"
+	// Variance applied when a count session is approved
+	HistoryOperationCountAdjustment = "count_adjustment"
+	// Manual correction with a reason code
+	HistoryOperationStockAdjustment = "stock_adjustment"
+	// Compensates a mistaken stock_add or stock_remove
+	HistoryOperationReversal = "stock_reversal"
+)
+
+// Reason code of count adjustments when the approver gives none
+const AdjustmentReasonRecount = "recount"
+
+// One row of the stock_history audit log. Quantity is signed: negative
+// for operations that remove stock.
+type StockHistoryEntry struct {
+	ID            string
+	ProductID     string
+	TenantID      string
+	Operation     string
+	Quantity      int
+	PreviousStock int
+	NewStock      int
+	Actor         string
+	Notes         string
+	// Why stock was adjusted, for adjustment operations
+	ReasonCode string
+	// ID of the document behind the entry, e.g. a count session
+	Reference string
+	// Supplier the stock was received from, for stock adds
+	SupplierID string
+	// Quantity as entered and the unit it was entered in, for stock adds;
+	// Quantity is in the product's base unit
+	Unit            string
+	EnteredQuantity Decimal
+	// Exact quantities of decimal-mode products, whose Quantity,
+	// PreviousStock and NewStock are whole units
+	Decimal *DecimalStockChange
+	// Cost of each entered unit, for stock adds
+	UnitCost *Money
+	// Cost of the units the entry added or removed, when they were costed;
+	// for removals, the cost of goods removed
+	Cost *Money
+	// Second person who approved the change, when approval was required
+	ApprovedBy string
+	// Links between a movement and its reversal: ReversalOf is set on the
+	// compensating entry, ReversedBy on the original
+	ReversalOf string
+	ReversedBy string
+	CreatedAt  time.Time
+}
+
+// ExactQuantity is Quantity including the decimal places of decimal-mode
+// products
+func (e StockHistoryEntry) ExactQuantity() Decimal {
+	if e.Decimal != nil {
+		return e.Decimal.Quantity
+	}
+	return DecimalFromInt(e.Quantity)
+}
+
+// ExactPreviousStock is PreviousStock including the decimal places of
+// decimal-mode products
+func (e StockHistoryEntry) ExactPreviousStock() Decimal {
+	if e.Decimal != nil {
+		return e.Decimal.PreviousStock
+	}
+	return DecimalFromInt(e.PreviousStock)
+}
+
+// CanBeReversed reports why the entry cannot be reversed, if it cannot.
+// Only stock movements are reversed; corrections are corrected with
+// another adjustment.
+func (e StockHistoryEntry) CanBeReversed() error {
+	if e.ReversedBy != "" {
+		return ErrMovementAlreadyReversed
+	}
+	switch e.Operation {
+	case HistoryOperationStockAdd, HistoryOperationStockRemove:
+		return nil
+	}
+	return ErrMovementNotReversible
+}
+
+func StockAddedHistoryEntry(e StockAddedEvent) StockHistoryEntry {
+	return StockHistoryEntry{
+		ProductID:       e.ProductID,
+		TenantID:        e.TenantID,
+		Operation:       HistoryOperationStockAdd,
+		Quantity:        e.Quantity.Value(),
+		PreviousStock:   e.Previous.Value(),
+		NewStock:        e.Current.Value(),
+		Actor:           e.AddedBy,
+		Notes:           e.Notes,
+		Reference:       e.Reference,
+		SupplierID:      e.SupplierID,
+		Unit:            e.Unit,
+		EnteredQuantity: e.EnteredQuantity,
+		Decimal:         NewDecimalStockChange(e.Quantity.Decimal(), e.Previous, e.Current),
+		UnitCost:        e.UnitCost,
+		Cost:            e.Cost,
+		ApprovedBy:      e.ApprovedBy,
+		CreatedAt:       e.Timestamp,
+	}
+}
+
+func StockRemovedHistoryEntry(e StockRemovedEvent) StockHistoryEntry {
+	return StockHistoryEntry{
+		ProductID:     e.ProductID,
+		TenantID:      e.TenantID,
+		Operation:     HistoryOperationStockRemove,
+		Quantity:      -e.Quantity.Value(),
+		PreviousStock: e.Previous.Value(),
+		NewStock:      e.Current.Value(),
+		Actor:         e.RemovedBy,
+		Notes:         e.Notes,
+		Reference:     e.Reference,
+		ApprovedBy:    e.ApprovedBy,
+		Cost:          e.CostOfGoods,
+		Decimal:       NewDecimalStockChange(e.Quantity.Decimal().Neg(), e.Previous, e.Current),
+		CreatedAt:     e.Timestamp,
+	}
+}
+
+// Per-product totals of stock_history, oldest entry first. Stock totals
+// are exact, decimal places of decimal-mode products included.
+type StockHistorySummary struct {
+	ProductID string
+	// previous_stock of the oldest entry: stock the product had before
+	// history started
+	OpeningStock Decimal
+	NetChange    Decimal
+	// Sum of stock_add quantities, what products.total_added should hold
+	TotalAdded Decimal
+	Entries    int
+}
+
+func (s StockHistorySummary) ExpectedStock() (Decimal, error) {
+	return s.OpeningStock.Add(s.NetChange)
+}
+
+// Difference between a product's stored counters and its history
+type StockDrift struct {
+	ProductID          string
+	ProductName        string
+	CurrentStock       Decimal
+	ExpectedStock      Decimal
+	TotalAdded         Decimal
+	ExpectedTotalAdded Decimal
+	HistoryEntries     int
+	// Set once an adjustment entry and the corrected counter were written
+	Corrected bool
+}
+
+// NewStockDrift fails with ErrQuantityOutOfRange when history sums to
+// more than a quantity can hold
+func NewStockDrift(product *Product, summary StockHistorySummary) (StockDrift, error) {
+	expected, err := summary.ExpectedStock()
+	if err != nil {
+		return StockDrift{}, err
+	}
+	if _, err := product.CurrentStock.Decimal().Sub(expected); err != nil {
+		return StockDrift{}, err
+	}
+	if _, err := product.TotalAdded.Sub(summary.TotalAdded); err != nil {
+		return StockDrift{}, err
+	}
+	return StockDrift{
+		ProductID:          product.ID,
+		ProductName:        product.Name,
+		CurrentStock:       product.CurrentStock.Decimal(),
+		ExpectedStock:      expected,
+		TotalAdded:         product.TotalAdded,
+		ExpectedTotalAdded: summary.TotalAdded,
+		HistoryEntries:     summary.Entries,
+	}, nil
+}
+
+// Positive when current_stock is above what history accounts for.
+// NewStockDrift checked that it is in range.
+func (d StockDrift) StockDifference() Decimal {
+	difference, _ := d.CurrentStock.Sub(d.ExpectedStock)
+	return difference
+}
+
+// NewStockDrift checked that it is in range
+func (d StockDrift) TotalAddedDifference() Decimal {
+	difference, _ := d.TotalAdded.Sub(d.ExpectedTotalAdded)
+	return difference
+}
+
+func (d StockDrift) HasDrift() bool {
+	return d.StockDifference().Sign() != 0 || d.TotalAddedDifference().Sign() != 0
+}
+
+// Adjustment entry that makes history end at the product's current stock.
+// current_stock is treated as the physical truth.
+func (d StockDrift) AdjustmentEntry(tenantID, actor string, at time.Time) StockHistoryEntry {
+	entry := StockHistoryEntry{
+		ProductID:     d.ProductID,
+		TenantID:      tenantID,
+		Operation:     HistoryOperationReconciliation,
+		Quantity:      d.StockDifference().IntPart(),
+		PreviousStock: d.ExpectedStock.IntPart(),
+		NewStock:      d.CurrentStock.IntPart(),
+		Actor:         actor,
+		Notes:         "stock reconciliation",
+		CreatedAt:     at,
+	}
+	if d.StockDifference().Scale() > 0 || d.ExpectedStock.Scale() > 0 || d.CurrentStock.Scale() > 0 {
+		entry.Decimal = &DecimalStockChange{
+			Quantity:      d.StockDifference(),
+			PreviousStock: d.ExpectedStock,
+			NewStock:      d.CurrentStock,
+		}
+	}
+	return entry
+}
@@ -0,0 +1,137 @@
+// internal/domain/stock_ledger.go
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Snapshot interval used when none is configured
+const DefaultSnapshotInterval = 100
+
+// Kinds of stock ledger entries
+const (
+	// Stock a product already had when its tenant switched to the ledger
+	LedgerOpeningBalance = "opening_balance"
+	LedgerStockAdded     = "stock_added"
+	LedgerStockAdjusted  = "stock_adjusted"
+	LedgerStockRemoved   = "stock_removed"
+	LedgerStockReversed  = "stock_reversed"
+)
+
+// One immutable change in a product's stock. Sequence numbers are
+// consecutive per product starting at 1, which makes a duplicate
+// sequence a concurrent write.
+type StockLedgerEntry struct {
+	ID         string
+	ProductID  string
+	TenantID   string
+	Sequence   int64
+	Type       string
+	Delta      int
+	Actor      string
+	Notes      string
+	OccurredAt time.Time
+}
+
+// Current stock of a product derived from its ledger
+type StockProjection struct {
+	ProductID string
+	TenantID  string
+	Stock     int
+	Version   int64 // sequence of the last applied entry
+	UpdatedAt time.Time
+}
+
+// Projection state persisted every N entries so loading does not replay
+// the whole ledger
+type StockSnapshot struct {
+	ProductID string
+	TenantID  string
+	Stock     int
+	Version   int64
+	TakenAt   time.Time
+}
+
+func NewStockProjection(productID, tenantID string) *StockProjection {
+	return &StockProjection{ProductID: productID, TenantID: tenantID}
+}
+
+func ProjectionFromSnapshot(s StockSnapshot) *StockProjection {
+	return &StockProjection{
+		ProductID: s.ProductID,
+		TenantID:  s.TenantID,
+		Stock:     s.Stock,
+		Version:   s.Version,
+		UpdatedAt: s.TakenAt,
+	}
+}
+
+func (p *StockProjection) CurrentStock() StockQuantity {
+	return StockQuantity{value: DecimalFromInt(p.Stock)}
+}
+
+// Apply folds the next ledger entry into the projection.
+func (p *StockProjection) Apply(entry StockLedgerEntry) error {
+	if entry.Sequence != p.Version+1 {
+		return fmt.Errorf("%w: product %s expected sequence %d, got %d",
+			ErrLedgerOutOfOrder, p.ProductID, p.Version+1, entry.Sequence)
+	}
+	if p.Stock+entry.Delta < 0 {
+		return fmt.Errorf("%w: product %s sequence %d would leave stock at %d",
+			ErrInvalidQuantity, p.ProductID, entry.Sequence, p.Stock+entry.Delta)
+	}
+	p.Stock += entry.Delta
+	p.Version = entry.Sequence
+	p.UpdatedAt = entry.OccurredAt
+	return nil
+}
+
+// Record builds the entry that follows the projection and applies it.
+func (p *StockProjection) Record(entryType string, delta int, actor, notes string) (StockLedgerEntry, error) {
+	entry := StockLedgerEntry{
+		ProductID:  p.ProductID,
+		TenantID:   p.TenantID,
+		Sequence:   p.Version + 1,
+		Type:       entryType,
+		Delta:      delta,
+		Actor:      actor,
+		Notes:      notes,
+		OccurredAt: time.Now(),
+	}
+	if err := p.Apply(entry); err != nil {
+		return StockLedgerEntry{}, err
+	}
+	return entry, nil
+}
+
+// SnapshotDue reports whether a snapshot is due once the entry with this
+// sequence has been applied.
+func SnapshotDue(sequence int64, every int) bool {
+	return every > 0 && sequence > 0 && sequence%int64(every) == 0
+}
+
+func (p *StockProjection) Snapshot() StockSnapshot {
+	return StockSnapshot{
+		ProductID: p.ProductID,
+		TenantID:  p.TenantID,
+		Stock:     p.Stock,
+		Version:   p.Version,
+		TakenAt:   time.Now(),
+	}
+}
+
+// A product whose stored current_stock disagrees with its ledger
+type StockDiscrepancy struct {
+	ProductID      string
+	TenantID       string
+	ProjectedStock int
+	RecordedStock  int
+	LedgerVersion  int64
+	// The ledger has entries for a product that no longer exists
+	ProductMissing bool
+}
+
+func (d StockDiscrepancy) Difference() int {
+	return d.RecordedStock - d.ProjectedStock
+}
@@ -0,0 +1,108 @@
+// internal/domain/supplier.go
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// A tenant's source of stock and the products it supplies
+type Supplier struct {
+	ID          string
+	TenantID    string
+	Name        string
+	ContactName string
+	Email       string
+	Phone       string
+	Notes       string
+	// Inactive suppliers are kept for history but cannot be received from
+	IsActive  bool
+	Products  []SupplierProduct
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// How a supplier supplies one product
+type SupplierProduct struct {
+	ProductID string
+	// The supplier's own code for the product
+	SupplierSKU string
+	// Days between ordering and receiving
+	LeadTimeDays int
+	// Smallest quantity the supplier accepts; zero for no minimum
+	MinOrderQuantity int
+	// Orders are multiples of this; zero or one for single units
+	PackSize int
+}
+
+func (s *Supplier) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return ErrInvalidSupplier
+	}
+	seen := map[string]bool{}
+	for _, p := range s.Products {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if seen[p.ProductID] {
+			return ErrInvalidSupplierProduct
+		}
+		seen[p.ProductID] = true
+	}
+	return nil
+}
+
+func (p SupplierProduct) Validate() error {
+	if p.ProductID == "" || p.LeadTimeDays < 0 || p.MinOrderQuantity < 0 || p.PackSize < 0 {
+		return ErrInvalidSupplierProduct
+	}
+	return nil
+}
+
+// Product returns the supplier's link to the product, or nil
+func (s *Supplier) Product(productID string) *SupplierProduct {
+	for i := range s.Products {
+		if s.Products[i].ProductID == productID {
+			return &s.Products[i]
+		}
+	}
+	return nil
+}
+
+// SetProduct adds the link or replaces the one for the same product
+func (s *Supplier) SetProduct(link SupplierProduct) error {
+	if err := link.Validate(); err != nil {
+		return err
+	}
+	if existing := s.Product(link.ProductID); existing != nil {
+		*existing = link
+		return nil
+	}
+	s.Products = append(s.Products, link)
+	return nil
+}
+
+func (s *Supplier) RemoveProduct(productID string) error {
+	for i, p := range s.Products {
+		if p.ProductID == productID {
+			s.Products = append(s.Products[:i], s.Products[i+1:]...)
+			return nil
+		}
+	}
+	return ErrProductNotSupplied
+}
+
+// OrderQuantity rounds a needed quantity up to what the supplier accepts:
+// at least the minimum order, in whole packs. Zero stays zero.
+func (p SupplierProduct) OrderQuantity(needed int) int {
+	if needed <= 0 {
+		return 0
+	}
+	if needed < p.MinOrderQuantity {
+		needed = p.MinOrderQuantity
+	}
+	if p.PackSize > 1 && needed%p.PackSize != 0 {
+		needed += p.PackSize - needed%p.PackSize
+	}
+	return needed
+}
@@ -0,0 +1,165 @@
+// internal/domain/unit_of_measure.go
+package domain
+
+import "strings"
+
+// Unit a product's stock is kept in when it names none
+const DefaultBaseUnit = "each"
+
+// Defines Unit as Quantity of another unit, e.g. case = 12 each or
+// pallet = 40 case. Every unit must lead to the product's base unit.
+type UnitConversion struct {
+	Unit     string
+	Quantity int
+	Of       string
+}
+
+// Base units per unit, as a fraction so units smaller than the base
+// convert exactly
+type unitFactor struct {
+	num, den int64
+}
+
+func (f unitFactor) times(n int64) unitFactor {
+	return newUnitFactor(f.num*n, f.den)
+}
+
+func (f unitFactor) divide(n int64) unitFactor {
+	return newUnitFactor(f.num, f.den*n)
+}
+
+func newUnitFactor(num, den int64) unitFactor {
+	g := gcd(num, den)
+	return unitFactor{num: num / g, den: den / g}
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func normalizeUnit(unit string) string {
+	return strings.ToLower(strings.TrimSpace(unit))
+}
+
+// unitFactors resolves every unit to base units. Conversions may chain and
+// point either way, as long as they agree and reach the base unit.
+func unitFactors(base string, conversions []UnitConversion) (map[string]unitFactor, error) {
+	base = normalizeUnit(base)
+	if base == "" {
+		return nil, ErrInvalidUnitConversion
+	}
+	defined := map[string]bool{}
+	for _, c := range conversions {
+		unit, of := normalizeUnit(c.Unit), normalizeUnit(c.Of)
+		if unit == "" || of == "" || unit == of || c.Quantity <= 0 || defined[unit] {
+			return nil, ErrInvalidUnitConversion
+		}
+		defined[unit] = true
+	}
+
+	factors := map[string]unitFactor{base: {num: 1, den: 1}}
+	for changed := true; changed; {
+		changed = false
+		for _, c := range conversions {
+			unit, of := normalizeUnit(c.Unit), normalizeUnit(c.Of)
+			perUnit, hasUnit := factors[unit]
+			perOf, hasOf := factors[of]
+			switch {
+			case hasUnit && hasOf:
+				if perUnit != perOf.times(int64(c.Quantity)) {
+					return nil, ErrInvalidUnitConversion
+				}
+			case hasOf:
+				factors[unit] = perOf.times(int64(c.Quantity))
+				changed = true
+			case hasUnit:
+				factors[of] = perUnit.divide(int64(c.Quantity))
+				changed = true
+			}
+		}
+	}
+	for _, c := range conversions {
+		if _, ok := factors[normalizeUnit(c.Unit)]; !ok {
+			return nil, ErrInvalidUnitConversion
+		}
+	}
+	return factors, nil
+}
+
+// ValidateUnits checks that every conversion leads to the base unit
+// without contradicting another
+func ValidateUnits(base string, conversions []UnitConversion) error {
+	_, err := unitFactors(base, conversions)
+	return err
+}
+
+// BaseUnitName is the unit the product's stock is kept in
+func (p *Product) BaseUnitName() string {
+	if p.BaseUnit == "" {
+		return DefaultBaseUnit
+	}
+	return p.BaseUnit
+}
+
+// SetUnits replaces the product's base unit, "each" when empty, and
+// conversions. Stock is not converted: it is taken to be counted in the
+// new base unit.
+func (p *Product) SetUnits(base string, conversions []UnitConversion) error {
+	base = strings.TrimSpace(base)
+	if base == "" {
+		base = DefaultBaseUnit
+	}
+	if err := ValidateUnits(base, conversions); err != nil {
+		return err
+	}
+	p.BaseUnit = base
+	p.Units = conversions
+	return nil
+}
+
+// ToBaseQuantity converts quantity of unit into the base unit; an empty
+// unit is the base unit. Quantities finer than the product keeps, such as
+// a fraction of a base unit in integer mode, are rejected.
+func (p *Product) ToBaseQuantity(quantity Decimal, unit string) (StockQuantity, error) {
+	if quantity.Sign() < 0 {
+		return StockQuantity{}, ErrInvalidQuantity
+	}
+	tooFine := ErrNonIntegralQuantity
+	if p.IsDecimal() {
+		tooFine = ErrQuantityTooPrecise
+	}
+	if unit != "" {
+		factors, err := unitFactors(p.BaseUnitName(), p.Units)
+		if err != nil {
+			return StockQuantity{}, err
+		}
+		factor, ok := factors[normalizeUnit(unit)]
+		if !ok {
+			return StockQuantity{}, ErrUnknownUnit
+		}
+		if quantity.scale < p.Precision() {
+			if quantity, err = quantity.Rescale(p.Precision()); err != nil {
+				return StockQuantity{}, err
+			}
+		}
+		base, err := mulInt64(quantity.coef, factor.num)
+		if err != nil {
+			return StockQuantity{}, err
+		}
+		if base%factor.den != 0 {
+			return StockQuantity{}, tooFine
+		}
+		quantity = Decimal{coef: base / factor.den, scale: quantity.scale}
+	}
+	exact, err := quantity.Rescale(p.Precision())
+	if err == ErrQuantityTooPrecise {
+		return StockQuantity{}, tooFine
+	}
+	if err != nil {
+		return StockQuantity{}, err
+	}
+	return StockQuantity{value: exact}, nil
+}
@@ -0,0 +1,137 @@
+// internal/domain/utilization.go
+package domain
+
+import "sort"
+
+// Products below this stock are reported and alerted as low
+const DefaultLowStockThreshold = 10
+
+// A utilization range in percent of the tenant limit, from Min up to but
+// excluding Max. The last band of a set has no upper bound.
+type UtilizationBand struct {
+	Label string
+	Min   float64
+	Max   float64
+}
+
+// Bands used when a report asks for none; 80% is where stock limit alerts start
+var DefaultUtilizationBands = []UtilizationBand{
+	{Label: "0-25", Min: 0, Max: 25},
+	{Label: "25-50", Min: 25, Max: 50},
+	{Label: "50-80", Min: 50, Max: 80},
+	{Label: "80-100", Min: 80, Max: 100},
+	{Label: "100+", Min: 100},
+}
+
+// BandIndex returns the band a utilization falls in
+func BandIndex(bands []UtilizationBand, utilization float64) int {
+	for i, band := range bands[:len(bands)-1] {
+		if utilization < band.Max {
+			return i
+		}
+	}
+	return len(bands) - 1
+}
+
+// What a utilization summary covers
+type UtilizationQuery struct {
+	TenantID string
+	MaxStock StockQuantity
+	Bands    []UtilizationBand
+	// Length of the fullest products list
+	TopN int
+	// Products with less stock are listed as low, up to LowStockLimit
+	LowStockThreshold int
+	LowStockLimit     int
+}
+
+type ProductUtilization struct {
+	ProductID    string
+	ProductName  string
+	CurrentStock int
+	Utilization  float64
+}
+
+type UtilizationBandTotals struct {
+	Band     UtilizationBand
+	Products int
+	Units    int
+}
+
+// A tenant's stock against its limit. Bands follow the query's order and
+// include empty ones; Top is fullest first, LowStock emptiest first, both
+// tie-broken by product ID.
+type UtilizationSummary struct {
+	Bands         []UtilizationBandTotals
+	Top           []ProductUtilization
+	LowStock      []ProductUtilization
+	LowStockCount int
+	Products      int
+	TotalUnits    int
+}
+
+// Capacity is what the products could hold at the tenant limit
+func (s UtilizationSummary) Capacity(maxStock StockQuantity) int {
+	return s.Products * maxStock.Value()
+}
+
+// SummarizeUtilization computes the summary from loaded products. The
+// product repository computes the same in the database.
+func SummarizeUtilization(products []*Product, q UtilizationQuery) UtilizationSummary {
+	summary := UtilizationSummary{Bands: make([]UtilizationBandTotals, len(q.Bands))}
+	for i, band := range q.Bands {
+		summary.Bands[i].Band = band
+	}
+
+	all := make([]ProductUtilization, 0, len(products))
+	low := []ProductUtilization{}
+	for _, p := range products {
+		if p.TenantID != q.TenantID {
+			continue
+		}
+		row := ProductUtilization{
+			ProductID:    p.ID,
+			ProductName:  p.Name,
+			CurrentStock: p.CurrentStock.Value(),
+			Utilization:  p.UtilizationPercentage(q.MaxStock),
+		}
+		all = append(all, row)
+		summary.Products++
+		summary.TotalUnits += row.CurrentStock
+		if len(q.Bands) > 0 {
+			band := &summary.Bands[BandIndex(q.Bands, row.Utilization)]
+			band.Products++
+			band.Units += row.CurrentStock
+		}
+		if p.IsLowStock(q.LowStockThreshold) {
+			low = append(low, row)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Utilization != all[j].Utilization {
+			return all[i].Utilization > all[j].Utilization
+		}
+		return all[i].ProductID < all[j].ProductID
+	})
+	sort.Slice(low, func(i, j int) bool {
+		if low[i].CurrentStock != low[j].CurrentStock {
+			return low[i].CurrentStock < low[j].CurrentStock
+		}
+		return low[i].ProductID < low[j].ProductID
+	})
+	summary.Top = firstN(all, q.TopN)
+	summary.LowStock = firstN(low, q.LowStockLimit)
+	summary.LowStockCount = len(low)
+	return summary
+}
+
+func firstN(rows []ProductUtilization, n int) []ProductUtilization {
+	if n < 0 {
+		n = 0
+	}
+	if len(rows) > n {
+		rows = rows[:n]
+	}
+	return rows
+}
@@ -0,0 +1,281 @@
+// internal/domain/valuation.go
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Money is an amount in minor currency units, e.g. cents, so sums and
+// splits are exact. Amounts have two decimal places.
+type Money struct {
+	minor int64
+}
+
+var moneyPattern = regexp.MustCompile(`^-?\d+(\.\d{1,2})?$`)
+
+func NewMoney(minor int64) Money {
+	return Money{minor: minor}
+}
+
+// ParseMoney reads a decimal amount such as "12.5" or "-3.99"
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if !moneyPattern.MatchString(s) {
+		return Money{}, ErrInvalidMoney
+	}
+	negative := strings.HasPrefix(s, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{minor: minor}, nil
+}
+
+// Minor returns the amount in minor units
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{minor: m.minor + other.minor}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{minor: m.minor - other.minor}
+}
+
+// Times is the amount of quantity units at m each
+func (m Money) Times(quantity int) Money {
+	return Money{minor: m.minor * int64(quantity)}
+}
+
+// Share is part/whole of the amount, rounded half away from zero. Taking
+// shares of what remains never loses or invents a minor unit.
+func (m Money) Share(part, whole int) Money {
+	if whole <= 0 {
+		return Money{}
+	}
+	n := m.minor * int64(part)
+	d := int64(whole)
+	q, r := n/d, n%d
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= d {
+		if n < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Money{minor: q}
+}
+
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+func (m Money) String() string {
+	minor := m.minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+// Encoded as a JSON number with two decimals, e.g. 12.50, which keeps the
+// exact amount
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// Decodes a JSON number or a decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if strings.HasPrefix(raw, `"`) {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(raw)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// How a tenant costs the stock it removes
+const (
+	// Oldest units leave first, at what they cost (default)
+	CostingFIFO = "fifo"
+	// Every unit costs the running average of what was received
+	CostingWeightedAverage = "weighted_average"
+)
+
+// Units received together at one cost and still in stock
+type CostLayer struct {
+	Quantity int
+	// Cost of the layer's remaining units together
+	Value      Money
+	ReceivedAt time.Time
+}
+
+// Cost layers of a product, oldest first. Under weighted average there is
+// at most one layer holding every costed unit. Stock on hand before
+// costing began has no layer; it is uncosted and leaves first.
+type ProductCost struct {
+	ProductID string
+	TenantID  string
+	Layers    []CostLayer
+	UpdatedAt time.Time
+	// Incremented on every stored change, so concurrent movements cannot
+	// both consume the same layer
+	Version int
+}
+
+// Quantity is the number of costed units
+func (c *ProductCost) Quantity() int {
+	total := 0
+	for _, l := range c.Layers {
+		total += l.Quantity
+	}
+	return total
+}
+
+// Value is the cost of every costed unit
+func (c *ProductCost) Value() Money {
+	var total Money
+	for _, l := range c.Layers {
+		total = total.Add(l.Value)
+	}
+	return total
+}
+
+// AverageUnitCost is Value per costed unit, zero without costed units
+func (c *ProductCost) AverageUnitCost() Money {
+	return c.Value().Share(1, c.Quantity())
+}
+
+// Receive adds units bought together for value
+func (c *ProductCost) Receive(quantity int, value Money, method string, at time.Time) error {
+	if value.IsNegative() {
+		return ErrInvalidUnitCost
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	c.addLayer(CostLayer{Quantity: quantity, Value: value, ReceivedAt: at}, method, false)
+	return nil
+}
+
+// Return puts back units that left costing value together, e.g. when a
+// removal is reversed. Under FIFO they are the oldest units again.
+func (c *ProductCost) Return(quantity int, value Money, method string, at time.Time) error {
+	if value.IsNegative() {
+		return ErrInvalidUnitCost
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	c.addLayer(CostLayer{Quantity: quantity, Value: value, ReceivedAt: at}, method, true)
+	return nil
+}
+
+func (c *ProductCost) addLayer(layer CostLayer, method string, oldest bool) {
+	if method == CostingWeightedAverage {
+		merged := CostLayer{Quantity: layer.Quantity, Value: layer.Value, ReceivedAt: layer.ReceivedAt}
+		for _, l := range c.Layers {
+			merged.Quantity += l.Quantity
+			merged.Value = merged.Value.Add(l.Value)
+		}
+		c.Layers = []CostLayer{merged}
+		return
+	}
+	if oldest {
+		c.Layers = append([]CostLayer{layer}, c.Layers...)
+		return
+	}
+	c.Layers = append(c.Layers, layer)
+}
+
+// Consume takes quantity units out of a product that had stockBefore units
+// and returns their cost. Uncosted units leave first at no cost, then
+// layers oldest first; a partly taken layer gives up its share of value.
+func (c *ProductCost) Consume(quantity, stockBefore int) Money {
+	if uncosted := stockBefore - c.Quantity(); uncosted > 0 {
+		if uncosted > quantity {
+			uncosted = quantity
+		}
+		quantity -= uncosted
+	}
+
+	var cost Money
+	layers := append([]CostLayer(nil), c.Layers...)
+	for quantity > 0 && len(layers) > 0 {
+		layer := &layers[0]
+		if quantity >= layer.Quantity {
+			cost = cost.Add(layer.Value)
+			quantity -= layer.Quantity
+			layers = layers[1:]
+			continue
+		}
+		taken := layer.Value.Share(quantity, layer.Quantity)
+		layer.Value = layer.Value.Sub(taken)
+		layer.Quantity -= quantity
+		cost = cost.Add(taken)
+		quantity = 0
+	}
+	c.Layers = layers
+	return cost
+}
+
+// A product's stock value at its cost layers
+type ProductValuation struct {
+	ProductID   string
+	ProductName string
+	Stock       int
+	// Units with a cost layer, and those on hand from before costing began
+	CostedQuantity   int
+	UncostedQuantity int
+	Value            Money
+	AverageUnitCost  Money
+	// Cost of the stock removed in the report's period, and how many units
+	CostOfGoodsRemoved Money
+	QuantityRemoved    int
+}
+
+func NewProductValuation(product *Product, cost *ProductCost) ProductValuation {
+	v := ProductValuation{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Stock:       product.CurrentStock.Value(),
+	}
+	if cost != nil {
+		v.CostedQuantity = cost.Quantity()
+		v.Value = cost.Value()
+		v.AverageUnitCost = cost.AverageUnitCost()
+	}
+	if uncosted := v.Stock - v.CostedQuantity; uncosted > 0 {
+		v.UncostedQuantity = uncosted
+	}
+	return v
+}
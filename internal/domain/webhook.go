@@ -0,0 +1,102 @@
+// internal/domain/webhook.go
+package domain
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const minWebhookSecretLength = 16
+
+// Tenant-managed subscription to outbound event notifications
+type WebhookSubscription struct {
+	ID         string
+	TenantID   string
+	URL        string
+	Secret     string
+	EventTypes []string
+	IsActive   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewWebhookSubscription(tenantID, rawURL, secret string, eventTypes []string) (*WebhookSubscription, error) {
+	now := time.Now()
+	sub := &WebhookSubscription{
+		TenantID:   tenantID,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *WebhookSubscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	// Names are checked again when the publisher dials them
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookURLNotPublic
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return ErrWebhookURLNotPublic
+	}
+	if len(s.Secret) < minWebhookSecretLength {
+		return ErrInvalidWebhookSecret
+	}
+	if len(s.EventTypes) == 0 {
+		return ErrInvalidEventType
+	}
+	for _, t := range s.EventTypes {
+		if !IsKnownEventType(t) {
+			return ErrInvalidEventType
+		}
+	}
+	return nil
+}
+
+// IsPublicIP reports whether webhooks may be delivered to ip. Loopback,
+// private, link-local (cloud metadata), multicast and unspecified
+// addresses are reserved for the service's own network.
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	if !s.IsActive {
+		return false
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// One delivery attempt of an event to a subscription
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	TenantID       string
+	EventID        string
+	EventType      string
+	Attempt        int
+	StatusCode     int
+	Success        bool
+	Error          string
+	Duration       time.Duration
+	DeliveredAt    time.Time
+}
@@ -0,0 +1,183 @@
+// internal/infrastructure/events/bus.go
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+
+	"myapp/internal/domain"
+)
+
+// Subscribes a handler to every event type
+const AllEvents = "*"
+
+var ErrBusClosed = errors.New("event bus is closed")
+
+type DeliveryMode int
+
+const (
+	// Sync handlers run inside Publish and their errors are returned to the publisher
+	Sync DeliveryMode = iota
+	// Async handlers run on background workers; errors are only logged
+	Async
+)
+
+type Handler func(ctx context.Context, event domain.CloudEvent) error
+
+type subscription struct {
+	name    string
+	mode    DeliveryMode
+	handler Handler
+}
+
+type asyncJob struct {
+	ctx   context.Context
+	sub   *subscription
+	event domain.CloudEvent
+}
+
+// In-process implementation of interfaces.EventPublisher.
+// Async deliveries are sharded by the envelope subject (the aggregate ID),
+// so events of the same product reach each handler in publish order.
+type Bus struct {
+	mu      sync.RWMutex
+	subs    map[string][]*subscription
+	shards  []chan asyncJob
+	wg      sync.WaitGroup
+	closed  bool
+	schemas *SchemaRegistry
+}
+
+func NewBus(workers, queueSize int) *Bus {
+	if workers < 1 {
+		workers = 1
+	}
+	b := &Bus{
+		subs:   make(map[string][]*subscription),
+		shards: make([]chan asyncJob, workers),
+	}
+	for i := range b.shards {
+		b.shards[i] = make(chan asyncJob, queueSize)
+		b.wg.Add(1)
+		go b.work(b.shards[i])
+	}
+	return b
+}
+
+// Subscribe registers an untyped handler for an event type, or AllEvents.
+func (b *Bus) Subscribe(eventType, name string, mode DeliveryMode, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[eventType] = append(b.subs[eventType], &subscription{name: name, mode: mode, handler: handler})
+}
+
+// Subscribe registers a handler that only receives events of type E.
+// Envelopes of older versions are upcast before decoding.
+func Subscribe[E domain.Event](b *Bus, name string, mode DeliveryMode, handler func(ctx context.Context, event E) error) {
+	var zero E
+	b.Subscribe(zero.EventType(), name, mode, func(ctx context.Context, envelope domain.CloudEvent) error {
+		event, err := envelope.Decode()
+		if err != nil {
+			return err
+		}
+		typed, ok := event.(E)
+		if !ok {
+			return nil
+		}
+		return handler(ctx, typed)
+	})
+}
+
+// ValidateWith makes Publish reject events whose data does not match the
+// registered JSON Schema.
+func (b *Bus) ValidateWith(schemas *SchemaRegistry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.schemas = schemas
+}
+
+func (b *Bus) Publish(ctx context.Context, event domain.CloudEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrBusClosed
+	}
+	if b.schemas != nil {
+		if err := b.schemas.Validate(event); err != nil {
+			return err
+		}
+	}
+
+	eventType, key := event.Type, event.Subject
+	subs := append(append([]*subscription(nil), b.subs[eventType]...), b.subs[AllEvents]...)
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.mode == Sync {
+			if err := call(ctx, sub, event); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		// Async handlers outlive the request, so drop its cancellation
+		job := asyncJob{ctx: context.WithoutCancel(ctx), sub: sub, event: event}
+		select {
+		case b.shards[b.shardFor(key)] <- job:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("enqueue %s for %s: %w", eventType, sub.name, ctx.Err()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops accepting events and waits for queued async deliveries.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, shard := range b.shards {
+		close(shard)
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+func (b *Bus) work(jobs <-chan asyncJob) {
+	defer b.wg.Done()
+	for job := range jobs {
+		if err := call(job.ctx, job.sub, job.event); err != nil {
+			log.Printf("Event handler error: %v", err)
+		}
+	}
+}
+
+func (b *Bus) shardFor(key string) int {
+	if key == "" || len(b.shards) == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(b.shards)))
+}
+
+// call runs a handler, converting a panic into an error so one faulty
+// subscriber cannot affect the others.
+func call(ctx context.Context, sub *subscription, event domain.CloudEvent) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("handler %s panicked: %v", sub.name, rec)
+		}
+	}()
+	if err := sub.handler(ctx, event); err != nil {
+		return fmt.Errorf("handler %s: %w", sub.name, err)
+	}
+	return nil
+}
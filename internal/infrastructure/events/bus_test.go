@@ -0,0 +1,195 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"myapp/internal/domain"
+)
+
+func envelope(t *testing.T, event domain.Event) domain.CloudEvent {
+	t.Helper()
+	ce, err := domain.NewCloudEvent(event, "t1")
+	if err != nil {
+		t.Fatalf("NewCloudEvent() err = %v", err)
+	}
+	return ce
+}
+
+func addedEvent(t *testing.T, productID string, quantity int) domain.CloudEvent {
+	q, _ := domain.NewStockQuantity(quantity)
+	return envelope(t, domain.StockAddedEvent{ProductID: productID, TenantID: "t1", Quantity: q, Timestamp: time.Now()})
+}
+
+func TestBus_Subscribe_TypedSyncHandler(t *testing.T) {
+	bus := NewBus(1, 8)
+	defer bus.Close()
+
+	var added []domain.StockAddedEvent
+	var alerts int
+	Subscribe(bus, "added", Sync, func(ctx context.Context, e domain.StockAddedEvent) error {
+		added = append(added, e)
+		return nil
+	})
+	Subscribe(bus, "alerts", Sync, func(ctx context.Context, e domain.StockLimitAlertEvent) error {
+		alerts++
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), addedEvent(t, "p1", 5)); err != nil {
+		t.Fatalf("Publish() err = %v", err)
+	}
+	if len(added) != 1 || added[0].ProductID != "p1" {
+		t.Errorf("added = %+v, want one p1 event", added)
+	}
+	if alerts != 0 {
+		t.Errorf("alert handler calls = %d, want 0", alerts)
+	}
+}
+
+func TestBus_Publish_SyncErrorsAndPanicsAreIsolated(t *testing.T) {
+	bus := NewBus(1, 8)
+	defer bus.Close()
+
+	errHandler := errors.New("handler failed")
+	var reached bool
+	bus.Subscribe(domain.EventTypeStockAdded, "failing", Sync, func(ctx context.Context, e domain.CloudEvent) error {
+		return errHandler
+	})
+	bus.Subscribe(domain.EventTypeStockAdded, "panicking", Sync, func(ctx context.Context, e domain.CloudEvent) error {
+		panic("boom")
+	})
+	bus.Subscribe(domain.EventTypeStockAdded, "healthy", Sync, func(ctx context.Context, e domain.CloudEvent) error {
+		reached = true
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), addedEvent(t, "p1", 5))
+	if !errors.Is(err, errHandler) {
+		t.Errorf("Publish() err = %v, want %v", err, errHandler)
+	}
+	if err == nil || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Errorf("Publish() err = %v, want panic reported", err)
+	}
+	if !reached {
+		t.Error("healthy handler should run after others fail")
+	}
+}
+
+func TestBus_Publish_AsyncOrderedPerProduct(t *testing.T) {
+	bus := NewBus(4, 64)
+
+	var mu sync.Mutex
+	seen := map[string][]int{}
+	Subscribe(bus, "recorder", Async, func(ctx context.Context, e domain.StockAddedEvent) error {
+		if e.Quantity.Value() == 7 {
+			panic("async panics must not stop the worker")
+		}
+		mu.Lock()
+		seen[e.ProductID] = append(seen[e.ProductID], e.Quantity.Value())
+		mu.Unlock()
+		return nil
+	})
+
+	products := []string{"p1", "p2", "p3", "p4", "p5"}
+	for i := 1; i <= 50; i++ {
+		for _, p := range products {
+			if err := bus.Publish(context.Background(), addedEvent(t, p, i)); err != nil {
+				t.Fatalf("Publish() err = %v", err)
+			}
+		}
+	}
+	bus.Close()
+
+	for _, p := range products {
+		got := seen[p]
+		if len(got) != 49 {
+			t.Errorf("%s received %d events, want %d", p, len(got), 49)
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i] <= got[i-1] {
+				t.Errorf("%s out of order: %v", p, got)
+				break
+			}
+		}
+	}
+}
+
+func TestBus_Publish_AllEventsAndClosed(t *testing.T) {
+	bus := NewBus(1, 8)
+
+	var count int
+	bus.Subscribe(AllEvents, "all", Sync, func(ctx context.Context, e domain.CloudEvent) error {
+		count++
+		return nil
+	})
+	_ = bus.Publish(context.Background(), addedEvent(t, "p1", 1))
+	_ = bus.Publish(context.Background(), envelope(t, domain.StockLimitAlertEvent{ProductID: "p1"}))
+	if count != 2 {
+		t.Errorf("wildcard handler calls = %d, want 2", count)
+	}
+
+	bus.Close()
+	if err := bus.Publish(context.Background(), addedEvent(t, "p1", 1)); !errors.Is(err, ErrBusClosed) {
+		t.Errorf("Publish() after Close err = %v, want %v", err, ErrBusClosed)
+	}
+}
+
+func TestBus_Subscribe_UpcastsOlderVersions(t *testing.T) {
+	bus := NewBus(1, 8)
+	defer bus.Close()
+
+	var added []domain.StockAddedEvent
+	Subscribe(bus, "added", Sync, func(ctx context.Context, e domain.StockAddedEvent) error {
+		added = append(added, e)
+		return nil
+	})
+
+	legacy := domain.CloudEvent{
+		SpecVersion: domain.CloudEventsSpecVersion,
+		ID:          "legacy-1",
+		Type:        domain.EventTypeStockAdded,
+		Subject:     "p1",
+		TenantID:    "t1",
+		DataVersion: 1,
+		Data:        []byte(`{"ProductID":"p1","TenantID":"t1","Quantity":4,"Previous":1,"Current":5,"AddedBy":"u1"}`),
+	}
+	if err := bus.Publish(context.Background(), legacy); err != nil {
+		t.Fatalf("Publish() err = %v", err)
+	}
+	if len(added) != 1 || added[0].ProductID != "p1" || added[0].Quantity.Value() != 4 || added[0].Current.Value() != 5 {
+		t.Errorf("added = %+v, want upcast v1 event", added)
+	}
+}
+
+func TestBus_Publish_ValidatesAgainstSchemas(t *testing.T) {
+	schemas, err := NewSchemaRegistry()
+	if err != nil {
+		t.Fatalf("NewSchemaRegistry() err = %v", err)
+	}
+	bus := NewBus(1, 8)
+	defer bus.Close()
+	bus.ValidateWith(schemas)
+
+	var count int
+	bus.Subscribe(AllEvents, "all", Sync, func(ctx context.Context, e domain.CloudEvent) error {
+		count++
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), addedEvent(t, "p1", 3)); err != nil {
+		t.Errorf("Publish(valid) err = %v", err)
+	}
+	invalid := addedEvent(t, "p1", 3)
+	invalid.Data = []byte(`{"product_id":"p1"}`)
+	if err := bus.Publish(context.Background(), invalid); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("Publish(invalid) err = %v, want %v", err, ErrInvalidEvent)
+	}
+	if count != 1 {
+		t.Errorf("handler calls = %d, want 1", count)
+	}
+}
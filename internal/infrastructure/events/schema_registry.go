@@ -0,0 +1,141 @@
+// internal/infrastructure/events/schema_registry.go
+package events
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"myapp/internal/domain"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var (
+	ErrSchemaNotFound = errors.New("event schema not found")
+	ErrInvalidEvent   = errors.New("event does not match its schema")
+)
+
+// A JSON Schema for one version of an event type
+type EventSchema struct {
+	EventType string
+	Version   int
+	URI       string
+	Body      json.RawMessage
+}
+
+// JSON Schema definitions of every published event version, loaded from
+// schemas/<type>.v<version>.json and addressed by domain.EventSchemaURI.
+type SchemaRegistry struct {
+	schemas  map[string]EventSchema
+	compiled map[string]*jsonschema.Schema
+}
+
+func NewSchemaRegistry() (*SchemaRegistry, error) {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SchemaRegistry{
+		schemas:  make(map[string]EventSchema),
+		compiled: make(map[string]*jsonschema.Schema),
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+
+	for _, entry := range entries {
+		eventType, version, err := parseSchemaFileName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		body, err := schemaFiles.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		uri := domain.EventSchemaURI(eventType, version)
+		if err := compiler.AddResource(uri, bytes.NewReader(body)); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", entry.Name(), err)
+		}
+		r.schemas[uri] = EventSchema{EventType: eventType, Version: version, URI: uri, Body: body}
+	}
+
+	for uri := range r.schemas {
+		schema, err := compiler.Compile(uri)
+		if err != nil {
+			return nil, fmt.Errorf("compile %s: %w", uri, err)
+		}
+		r.compiled[uri] = schema
+	}
+	return r, nil
+}
+
+// Schema returns the definition of an event type version.
+func (r *SchemaRegistry) Schema(eventType string, version int) (EventSchema, error) {
+	schema, ok := r.schemas[domain.EventSchemaURI(eventType, version)]
+	if !ok {
+		return EventSchema{}, fmt.Errorf("%w: %s v%d", ErrSchemaNotFound, eventType, version)
+	}
+	return schema, nil
+}
+
+// List returns all schemas ordered by event type and version.
+func (r *SchemaRegistry) List() []EventSchema {
+	list := make([]EventSchema, 0, len(r.schemas))
+	for _, schema := range r.schemas {
+		list = append(list, schema)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].EventType != list[j].EventType {
+			return list[i].EventType < list[j].EventType
+		}
+		return list[i].Version < list[j].Version
+	})
+	return list
+}
+
+// Validate upcasts an envelope to the current version and checks its data
+// against that version's schema.
+func (r *SchemaRegistry) Validate(event domain.CloudEvent) error {
+	current, err := event.Upcast()
+	if err != nil {
+		return err
+	}
+	schema, ok := r.compiled[domain.EventSchemaURI(current.Type, current.DataVersion)]
+	if !ok {
+		return fmt.Errorf("%w: %s v%d", ErrSchemaNotFound, current.Type, current.DataVersion)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(current.Data))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("%w: %s %s: %v", ErrInvalidEvent, current.Type, current.ID, err)
+	}
+	if err := schema.Validate(data); err != nil {
+		return fmt.Errorf("%w: %s %s: %v", ErrInvalidEvent, current.Type, current.ID, err)
+	}
+	return nil
+}
+
+// "stock.added.v2.json" -> ("stock.added", 2)
+func parseSchemaFileName(name string) (string, int, error) {
+	base := strings.TrimSuffix(name, ".json")
+	i := strings.LastIndex(base, ".v")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("schema file %s: expected <type>.v<version>.json", name)
+	}
+	var version int
+	if _, err := fmt.Sscanf(base[i+2:], "%d", &version); err != nil || version < 1 {
+		return "", 0, fmt.Errorf("schema file %s: invalid version", name)
+	}
+	return base[:i], version, nil
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"myapp/internal/domain"
+)
+
+func TestSchemaRegistry_CoversCurrentEventVersions(t *testing.T) {
+	schemas, err := NewSchemaRegistry()
+	if err != nil {
+		t.Fatalf("NewSchemaRegistry() err = %v", err)
+	}
+
+	for _, eventType := range []string{
+		domain.EventTypeStockAdded, domain.EventTypeStockLimitAlert,
+		domain.EventTypeLowStock, domain.EventTypeStockAdjusted, domain.EventTypeStockRemoved,
+		domain.EventTypeStockReversed, domain.EventTypeNotification,
+	} {
+		version, ok := domain.CurrentEventVersion(eventType)
+		if !ok {
+			t.Errorf("%s: no current version", eventType)
+			continue
+		}
+		schema, err := schemas.Schema(eventType, version)
+		if err != nil {
+			t.Errorf("Schema(%s, %d) err = %v", eventType, version, err)
+			continue
+		}
+		if schema.URI != domain.EventSchemaURI(eventType, version) {
+			t.Errorf("%s URI = %q", eventType, schema.URI)
+		}
+	}
+	if _, err := schemas.Schema(domain.EventTypeStockAdded, 99); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("Schema(v99) err = %v, want %v", err, ErrSchemaNotFound)
+	}
+}
+
+func TestSchemaRegistry_Validate(t *testing.T) {
+	schemas, err := NewSchemaRegistry()
+	if err != nil {
+		t.Fatalf("NewSchemaRegistry() err = %v", err)
+	}
+	q := func(n int) domain.StockQuantity {
+		v, _ := domain.NewStockQuantity(n)
+		return v
+	}
+	now := time.Now()
+
+	valid := []domain.Event{
+		domain.StockAddedEvent{ProductID: "p1", TenantID: "t1", Quantity: q(5), Previous: q(1), Current: q(6), AddedBy: "u1", Timestamp: now},
+		domain.StockLimitAlertEvent{ProductID: "p1", ProductName: "Widget", Current: q(9), MaxLimit: q(10), Utilization: 90, TenantID: "t1", Timestamp: now, ProductTags: []string{"a"}},
+		domain.LowStockEvent{ProductID: "p1", ProductName: "Widget", TenantID: "t1", Current: q(2), Threshold: 10, Timestamp: now},
+		domain.StockAdjustedEvent{ProductID: "p1", TenantID: "t1", Delta: -2, Previous: q(5), Current: q(3), ReasonCode: "recount", AdjustedBy: "u1", Operation: "count_adjustment", Timestamp: now},
+		domain.StockRemovedEvent{ProductID: "p1", TenantID: "t1", Quantity: q(2), Previous: q(5), Current: q(3), RemovedBy: "u1", ApprovedBy: "u2", Reference: "r1", Timestamp: now},
+		domain.StockReversedEvent{ProductID: "p1", TenantID: "t1", Delta: -2, Previous: q(5), Current: q(3), ReversedBy: "u1", OriginalEntryID: "h1", OriginalOperation: "stock_add", Timestamp: now},
+		domain.Notification{TenantID: "t1", AlertType: "stock_alert", Severity: domain.SeverityCritical, ProductID: "p1", Message: "hi", Timestamp: now},
+	}
+	for _, event := range valid {
+		ce, err := domain.NewCloudEvent(event, "t1")
+		if err != nil {
+			t.Fatalf("NewCloudEvent(%s) err = %v", event.EventType(), err)
+		}
+		if err := schemas.Validate(ce); err != nil {
+			t.Errorf("Validate(%s) err = %v", event.EventType(), err)
+		}
+	}
+
+	// Legacy v1 data is validated after upcasting
+	legacy := domain.CloudEvent{
+		Type: domain.EventTypeLowStock, DataVersion: 1,
+		Data: []byte(`{"ProductID":"p1","ProductName":"Widget","TenantID":"t1","Current":2,"Threshold":10,"Timestamp":"2024-01-02T03:04:05Z"}`),
+	}
+	if err := schemas.Validate(legacy); err != nil {
+		t.Errorf("Validate(legacy) err = %v", err)
+	}
+
+	// Integer versions upcast to the ones allowing fractional quantities
+	removed := domain.CloudEvent{
+		Type: domain.EventTypeStockRemoved, DataVersion: 1,
+		Data: []byte(`{"product_id":"p1","tenant_id":"t1","quantity":2,"previous_stock":5,"new_stock":3,"removed_by":"u1","timestamp":"2024-01-02T03:04:05Z"}`),
+	}
+	if err := schemas.Validate(removed); err != nil {
+		t.Errorf("Validate(stock.removed v1) err = %v", err)
+	}
+	fractional := domain.CloudEvent{
+		Type: domain.EventTypeStockRemoved, DataVersion: 2,
+		Data: []byte(`{"product_id":"p1","tenant_id":"t1","quantity":0.25,"previous_stock":1.5,"new_stock":1.25,"removed_by":"u1","timestamp":"2024-01-02T03:04:05Z"}`),
+	}
+	if err := schemas.Validate(fractional); err != nil {
+		t.Errorf("Validate(stock.removed v2) err = %v", err)
+	}
+
+	invalid := domain.CloudEvent{
+		Type: domain.EventTypeNotification, DataVersion: 1,
+		Data: []byte(`{"tenant_id":"t1","alert_type":"x","severity":"urgent","product_id":"p1","message":"m","timestamp":"2024-01-02T03:04:05Z"}`),
+	}
+	if err := schemas.Validate(invalid); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("Validate(bad severity) err = %v, want %v", err, ErrInvalidEvent)
+	}
+
+	future := domain.CloudEvent{Type: domain.EventTypeStockAdded, DataVersion: 4, Data: []byte(`{}`)}
+	if err := schemas.Validate(future); !errors.Is(err, domain.ErrUnsupportedEventVersion) {
+		t.Errorf("Validate(v4) err = %v, want %v", err, domain.ErrUnsupportedEventVersion)
+	}
+}
@@ -0,0 +1,148 @@
+// internal/infrastructure/events/stream_hub.go
+package events
+
+import (
+	"context"
+	"sync"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Fans published events out to live stream subscribers (SSE, WebSocket).
+// It keeps the last historySize events per tenant so reconnecting clients
+// can resume from their Last-Event-ID; the history is in memory, so clients
+// resuming from an older event, or one this instance never saw, are told
+// about the gap instead. Subscribers that fill their buffer
+// are disconnected with domain.ErrSlowConsumer instead of slowing the
+// publisher down; they can reconnect and resume from history.
+type StreamHub struct {
+	mu          sync.Mutex
+	subs        map[*streamSubscription]struct{}
+	history     map[string][]domain.CloudEvent
+	historySize int
+	bufferSize  int
+}
+
+func NewStreamHub(historySize, bufferSize int) *StreamHub {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &StreamHub{
+		subs:        make(map[*streamSubscription]struct{}),
+		history:     make(map[string][]domain.CloudEvent),
+		historySize: historySize,
+		bufferSize:  bufferSize,
+	}
+}
+
+func (h *StreamHub) Publish(ctx context.Context, event domain.CloudEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.historySize > 0 {
+		history := append(h.history[event.TenantID], event)
+		if len(history) > h.historySize {
+			history = history[len(history)-h.historySize:]
+		}
+		h.history[event.TenantID] = history
+	}
+
+	for sub := range h.subs {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub, domain.ErrSlowConsumer)
+		}
+	}
+	return nil
+}
+
+// Subscribe replays retained events published after lastEventID. When
+// lastEventID is not retained nothing is replayed and the subscription
+// reports a gap: replaying whatever is retained would both repeat events
+// the client has and silently skip the ones it missed.
+func (h *StreamHub) Subscribe(ctx context.Context, filter domain.EventFilter, lastEventID string) (interfaces.EventSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var replay []domain.CloudEvent
+	gap := false
+	if lastEventID != "" {
+		history := h.history[filter.TenantID]
+		start := -1
+		for i, event := range history {
+			if event.ID == lastEventID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			gap = true
+		} else {
+			for _, event := range history[start:] {
+				if filter.Matches(event) {
+					replay = append(replay, event)
+				}
+			}
+		}
+	}
+
+	sub := &streamSubscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan domain.CloudEvent, h.bufferSize+len(replay)),
+		gap:    gap,
+	}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	h.subs[sub] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		sub.Close()
+	}()
+	return sub, nil
+}
+
+// remove must be called with h.mu held.
+func (h *StreamHub) remove(sub *streamSubscription, err error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.err = err
+	close(sub.events)
+}
+
+type streamSubscription struct {
+	hub    *StreamHub
+	filter domain.EventFilter
+	events chan domain.CloudEvent
+	err    error
+	gap    bool
+}
+
+func (s *streamSubscription) Events() <-chan domain.CloudEvent {
+	return s.events
+}
+
+func (s *streamSubscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+func (s *streamSubscription) Gap() bool {
+	return s.gap
+}
+
+func (s *streamSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s, nil)
+}
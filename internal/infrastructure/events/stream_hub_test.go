@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+func streamEvent(id, tenantID, productID, eventType string) domain.CloudEvent {
+	return domain.CloudEvent{ID: id, TenantID: tenantID, Subject: productID, Type: eventType}
+}
+
+func receive(t *testing.T, ch <-chan domain.CloudEvent) (domain.CloudEvent, bool) {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		return e, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return domain.CloudEvent{}, false
+	}
+}
+
+func TestStreamHub_Subscribe_FiltersByTenantProductAndType(t *testing.T) {
+	hub := NewStreamHub(10, 8)
+	sub, _ := hub.Subscribe(context.Background(), domain.EventFilter{
+		TenantID:   "t1",
+		EventTypes: domain.StockStreamEventTypes,
+		ProductIDs: []string{"p1"},
+	}, "")
+	defer sub.Close()
+
+	ctx := context.Background()
+	_ = hub.Publish(ctx, streamEvent("e1", "t2", "p1", domain.EventTypeStockAdded))
+	_ = hub.Publish(ctx, streamEvent("e2", "t1", "p2", domain.EventTypeStockAdded))
+	_ = hub.Publish(ctx, streamEvent("e3", "t1", "p1", domain.EventTypeNotification))
+	_ = hub.Publish(ctx, streamEvent("e4", "t1", "p1", domain.EventTypeStockLimitAlert))
+
+	if e, _ := receive(t, sub.Events()); e.ID != "e4" {
+		t.Errorf("received %s, want e4", e.ID)
+	}
+	select {
+	case e := <-sub.Events():
+		t.Errorf("unexpected event %s", e.ID)
+	default:
+	}
+}
+
+func TestStreamHub_Subscribe_ResumesAfterLastEventID(t *testing.T) {
+	hub := NewStreamHub(3, 8)
+	ctx := context.Background()
+	for _, id := range []string{"e1", "e2", "e3", "e4"} {
+		_ = hub.Publish(ctx, streamEvent(id, "t1", "p1", domain.EventTypeStockAdded))
+	}
+
+	filter := domain.EventFilter{TenantID: "t1"}
+	sub, _ := hub.Subscribe(ctx, filter, "e2")
+	defer sub.Close()
+	for _, want := range []string{"e3", "e4"} {
+		if e, _ := receive(t, sub.Events()); e.ID != want {
+			t.Errorf("replayed %s, want %s", e.ID, want)
+		}
+	}
+
+	if sub.Gap() {
+		t.Error("Gap() = true for a retained Last-Event-ID")
+	}
+
+	// e1 fell out of the retained history, and a restarted instance knows
+	// no events at all: nothing can be replayed reliably
+	old, _ := hub.Subscribe(ctx, filter, "e1")
+	defer old.Close()
+	restarted, _ := NewStreamHub(3, 8).Subscribe(ctx, filter, "e4")
+	defer restarted.Close()
+	for _, s := range []interfaces.EventSubscription{old, restarted} {
+		if !s.Gap() {
+			t.Error("Gap() = false, want the missing events reported")
+		}
+		select {
+		case e := <-s.Events():
+			t.Errorf("replayed %s after a gap, want nothing", e.ID)
+		default:
+		}
+	}
+}
+
+func TestStreamHub_Publish_DropsSlowConsumers(t *testing.T) {
+	hub := NewStreamHub(10, 1)
+	ctx := context.Background()
+	slow, _ := hub.Subscribe(ctx, domain.EventFilter{TenantID: "t1"}, "")
+
+	_ = hub.Publish(ctx, streamEvent("e1", "t1", "p1", domain.EventTypeStockAdded))
+	_ = hub.Publish(ctx, streamEvent("e2", "t1", "p1", domain.EventTypeStockAdded))
+
+	if e, ok := receive(t, slow.Events()); !ok || e.ID != "e1" {
+		t.Errorf("first event = %s (open=%v), want buffered e1", e.ID, ok)
+	}
+	if _, ok := receive(t, slow.Events()); ok {
+		t.Error("slow subscription should be closed")
+	}
+	if !errors.Is(slow.Err(), domain.ErrSlowConsumer) {
+		t.Errorf("Err() = %v, want %v", slow.Err(), domain.ErrSlowConsumer)
+	}
+
+	// The dropped client resumes from history
+	resumed, _ := hub.Subscribe(ctx, domain.EventFilter{TenantID: "t1"}, "e1")
+	defer resumed.Close()
+	if e, _ := receive(t, resumed.Events()); e.ID != "e2" {
+		t.Errorf("resumed at %s, want e2", e.ID)
+	}
+}
+
+func TestStreamHub_Subscribe_EndsWithContext(t *testing.T) {
+	hub := NewStreamHub(10, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, _ := hub.Subscribe(ctx, domain.EventFilter{TenantID: "t1"}, "")
+
+	cancel()
+	if _, ok := receive(t, sub.Events()); ok {
+		t.Error("subscription should close when its context is done")
+	}
+	if sub.Err() != nil {
+		t.Errorf("Err() = %v, want nil for a normal close", sub.Err())
+	}
+}
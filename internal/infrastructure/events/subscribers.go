@@ -0,0 +1,41 @@
+// internal/infrastructure/events/subscribers.go
+package events
+
+import (
+	"context"
+	"log"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// SubscribeNotifications sends stock and low stock alerts through svc.
+func SubscribeNotifications(b *Bus, svc interfaces.NotificationService) {
+	Subscribe(b, "notifications.stock_alert", Async, func(ctx context.Context, e domain.StockLimitAlertEvent) error {
+		return svc.SendStockAlert(ctx, e)
+	})
+	Subscribe(b, "notifications.low_stock", Async, func(ctx context.Context, e domain.LowStockEvent) error {
+		product := &domain.Product{
+			ID:           e.ProductID,
+			Name:         e.ProductName,
+			CurrentStock: e.Current,
+			TenantID:     e.TenantID,
+			Tags:         e.ProductTags,
+		}
+		return svc.SendLowStockAlert(ctx, product, e.Threshold)
+	})
+}
+
+// SubscribePublisher forwards every event to another publisher, e.g. webhooks.
+func SubscribePublisher(b *Bus, name string, publisher interfaces.EventPublisher) {
+	b.Subscribe(AllEvents, name, Async, publisher.Publish)
+}
+
+// SubscribeAuditLog writes one audit line per event.
+func SubscribeAuditLog(b *Bus, logger *log.Logger) {
+	b.Subscribe(AllEvents, "audit", Async, func(ctx context.Context, event domain.CloudEvent) error {
+		logger.Printf("audit: %s v%d id=%s tenant=%s aggregate=%s %s",
+			event.Type, event.DataVersion, event.ID, event.TenantID, event.Subject, event.Data)
+		return nil
+	})
+}
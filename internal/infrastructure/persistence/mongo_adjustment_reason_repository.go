@@ -0,0 +1,94 @@
+// internal/infrastructure/persistence/mongo_adjustment_reason_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type adjustmentReasonDocument struct {
+	TenantID  string    `bson:"tenant_id"`
+	Code      string    `bson:"code"`
+	Label     string    `bson:"label"`
+	Direction string    `bson:"direction"`
+	IsActive  bool      `bson:"is_active"`
+	UpdatedBy string    `bson:"updated_by"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func (d adjustmentReasonDocument) toDomain() *domain.AdjustmentReason {
+	return &domain.AdjustmentReason{
+		TenantID:  d.TenantID,
+		Code:      d.Code,
+		Label:     d.Label,
+		Direction: d.Direction,
+		IsActive:  d.IsActive,
+		IsCustom:  true,
+		UpdatedBy: d.UpdatedBy,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// Adjustment Reason Repository Implementation
+type mongoAdjustmentReasonRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoAdjustmentReasonRepository) FindByTenant(ctx context.Context, tenantID string) ([]*domain.AdjustmentReason, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []adjustmentReasonDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	reasons := make([]*domain.AdjustmentReason, 0, len(results))
+	for _, doc := range results {
+		reasons = append(reasons, doc.toDomain())
+	}
+	return reasons, nil
+}
+
+func (r *mongoAdjustmentReasonRepository) Save(ctx context.Context, reason *domain.AdjustmentReason) error {
+
+	update := bson.M{
+		"$set": bson.M{
+			"label":      reason.Label,
+			"direction":  reason.Direction,
+			"is_active":  reason.IsActive,
+			"updated_by": reason.UpdatedBy,
+			"updated_at": reason.UpdatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"tenant_id": reason.TenantID, "code": reason.Code},
+		update,
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *mongoAdjustmentReasonRepository) Delete(ctx context.Context, tenantID, code string) error {
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"tenant_id": tenantID, "code": code})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrAdjustmentReasonNotFound
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// internal/infrastructure/persistence/mongo_count_session_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type countEntryDocument struct {
+	CountedBy string    `bson:"counted_by"`
+	Quantity  int       `bson:"quantity"`
+	CountedAt time.Time `bson:"counted_at"`
+}
+
+type countLineDocument struct {
+	ProductID     string               `bson:"product_id"`
+	ProductName   string               `bson:"product_name"`
+	ExpectedStock int                  `bson:"expected_stock"`
+	Counts        []countEntryDocument `bson:"counts"`
+	ReasonCode    string               `bson:"reason_code,omitempty"`
+	Adjustment    int                  `bson:"adjustment"`
+}
+
+type countSessionDocument struct {
+	ID       primitive.ObjectID  `bson:"_id,omitempty"`
+	TenantID string              `bson:"tenant_id"`
+	Name     string              `bson:"name"`
+	Location string              `bson:"location,omitempty"`
+	Lock     bool                `bson:"lock"`
+	Status   string              `bson:"status"`
+	Lines    []countLineDocument `bson:"lines"`
+	OpenedBy string              `bson:"opened_by"`
+	OpenedAt time.Time           `bson:"opened_at"`
+	ClosedBy string              `bson:"closed_by,omitempty"`
+	ClosedAt time.Time           `bson:"closed_at,omitempty"`
+}
+
+func toCountLineDocuments(lines []domain.CountLine) []countLineDocument {
+	docs := make([]countLineDocument, 0, len(lines))
+	for _, l := range lines {
+		counts := make([]countEntryDocument, 0, len(l.Counts))
+		for _, c := range l.Counts {
+			counts = append(counts, countEntryDocument{CountedBy: c.CountedBy, Quantity: c.Quantity, CountedAt: c.CountedAt})
+		}
+		docs = append(docs, countLineDocument{
+			ProductID:     l.ProductID,
+			ProductName:   l.ProductName,
+			ExpectedStock: l.ExpectedStock,
+			Counts:        counts,
+			ReasonCode:    l.ReasonCode,
+			Adjustment:    l.Adjustment,
+		})
+	}
+	return docs
+}
+
+func (d countSessionDocument) toDomain() *domain.CountSession {
+	lines := make([]domain.CountLine, 0, len(d.Lines))
+	for _, l := range d.Lines {
+		counts := make([]domain.CountEntry, 0, len(l.Counts))
+		for _, c := range l.Counts {
+			counts = append(counts, domain.CountEntry{CountedBy: c.CountedBy, Quantity: c.Quantity, CountedAt: c.CountedAt})
+		}
+		lines = append(lines, domain.CountLine{
+			ProductID:     l.ProductID,
+			ProductName:   l.ProductName,
+			ExpectedStock: l.ExpectedStock,
+			Counts:        counts,
+			ReasonCode:    l.ReasonCode,
+			Adjustment:    l.Adjustment,
+		})
+	}
+	return &domain.CountSession{
+		ID:       d.ID.Hex(),
+		TenantID: d.TenantID,
+		Name:     d.Name,
+		Location: d.Location,
+		Lock:     d.Lock,
+		Status:   d.Status,
+		Lines:    lines,
+		OpenedBy: d.OpenedBy,
+		OpenedAt: d.OpenedAt,
+		ClosedBy: d.ClosedBy,
+		ClosedAt: d.ClosedAt,
+	}
+}
+
+// Count Session Repository Implementation
+type mongoCountSessionRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoCountSessionRepository) Create(ctx context.Context, session *domain.CountSession) error {
+
+	document := countSessionDocument{
+		ID:       primitive.NewObjectID(),
+		TenantID: session.TenantID,
+		Name:     session.Name,
+		Location: session.Location,
+		Lock:     session.Lock,
+		Status:   session.Status,
+		Lines:    toCountLineDocuments(session.Lines),
+		OpenedBy: session.OpenedBy,
+		OpenedAt: session.OpenedAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	session.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoCountSessionRepository) FindByID(ctx context.Context, tenantID, sessionID string) (*domain.CountSession, error) {
+
+	objID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return nil, domain.ErrCountSessionNotFound
+	}
+
+	var result countSessionDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCountSessionNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoCountSessionRepository) FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.CountSession, error) {
+
+	filter := bson.M{"tenant_id": tenantID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "opened_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []countSessionDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	sessions := make([]*domain.CountSession, 0, len(results))
+	for _, doc := range results {
+		sessions = append(sessions, doc.toDomain())
+	}
+	return sessions, nil
+}
+
+func (r *mongoCountSessionRepository) AddCount(ctx context.Context, tenantID, sessionID, productID string, entry domain.CountEntry) error {
+
+	objID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return domain.ErrCountSessionNotFound
+	}
+
+	filter := bson.M{
+		"_id":              objID,
+		"tenant_id":        tenantID,
+		"status":           domain.CountStatusOpen,
+		"lines.product_id": productID,
+	}
+	update := bson.M{
+		"$push": bson.M{"lines.$.counts": countEntryDocument{
+			CountedBy: entry.CountedBy,
+			Quantity:  entry.Quantity,
+			CountedAt: entry.CountedAt,
+		}},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		// Closed between loading the session and this write
+		return domain.ErrCountSessionClosed
+	}
+	return nil
+}
+
+func (r *mongoCountSessionRepository) Close(ctx context.Context, session *domain.CountSession) error {
+
+	objID, err := primitive.ObjectIDFromHex(session.ID)
+	if err != nil {
+		return domain.ErrCountSessionNotFound
+	}
+
+	// Only closing fields are written; counts stay as stored
+	set := bson.M{
+		"status":    session.Status,
+		"closed_by": session.ClosedBy,
+		"closed_at": session.ClosedAt,
+	}
+	for i, line := range session.Lines {
+		set[fmt.Sprintf("lines.%d.reason_code", i)] = line.ReasonCode
+		set[fmt.Sprintf("lines.%d.adjustment", i)] = line.Adjustment
+	}
+	update := bson.M{"$set": set}
+
+	filter := bson.M{"_id": objID, "tenant_id": session.TenantID, "status": domain.CountStatusOpen}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrCountSessionClosed
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// internal/infrastructure/persistence/mongo_daily_snapshot_repository.go
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type dailySnapshotDocument struct {
+	TenantID  string             `bson:"tenant_id"`
+	ProductID primitive.ObjectID `bson:"product_id"`
+	Day       time.Time          `bson:"day"`
+	Stock     bsonDecimal        `bson:"stock"`
+	TakenAt   time.Time          `bson:"taken_at"`
+}
+
+func (d dailySnapshotDocument) toDomain() domain.DailyStockSnapshot {
+	return domain.DailyStockSnapshot{
+		TenantID:  d.TenantID,
+		ProductID: d.ProductID.Hex(),
+		Day:       d.Day.UTC(),
+		Stock:     d.Stock.Decimal,
+		TakenAt:   d.TakenAt,
+	}
+}
+
+type mongoDailySnapshotRepository struct {
+	collection *mongo.Collection
+}
+
+// EnsureDailySnapshotIndexes creates the unique (tenant_id, product_id,
+// day) index that makes retaking a day replace its snapshots.
+func EnsureDailySnapshotIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("daily_stock_snapshots").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "day", Value: -1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoDailySnapshotRepository) SaveAll(ctx context.Context, snapshots []domain.DailyStockSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(snapshots))
+	for _, s := range snapshots {
+		productID, err := primitive.ObjectIDFromHex(s.ProductID)
+		if err != nil {
+			return domain.ErrInvalidProductID
+		}
+		document := dailySnapshotDocument{
+			TenantID:  s.TenantID,
+			ProductID: productID,
+			Day:       s.Day,
+			Stock:     bsonDecimal{s.Stock},
+			TakenAt:   s.TakenAt,
+		}
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"tenant_id": s.TenantID, "product_id": productID, "day": s.Day}).
+			SetReplacement(document).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoDailySnapshotRepository) FindLatest(ctx context.Context, tenantID, productID string, at time.Time) ([]domain.DailyStockSnapshot, error) {
+	filter := bson.M{"tenant_id": tenantID, "taken_at": bson.M{"$lt": at}}
+	if productID != "" {
+		objID, err := primitive.ObjectIDFromHex(productID)
+		if err != nil {
+			return nil, domain.ErrProductNotFound
+		}
+		filter["product_id"] = objID
+	}
+
+	// 1. The latest day with a snapshot before at
+	var latest dailySnapshotDocument
+	err := r.collection.FindOne(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "day", Value: -1}})).Decode(&latest)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	// 2. All snapshots of that day
+	filter["day"] = latest.Day
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []dailySnapshotDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	snapshots := make([]domain.DailyStockSnapshot, 0, len(results))
+	for _, doc := range results {
+		snapshots = append(snapshots, doc.toDomain())
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,255 @@
+// internal/infrastructure/persistence/mongo_import_job_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type importJobDocument struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID string             `bson:"tenant_id"`
+	FileName string             `bson:"file_name"`
+	FileHash string             `bson:"file_hash"`
+	// Unique per tenant. Kept when the job fails, since some of its rows
+	// may be applied already; the job is resumed instead.
+	ActiveHash          string                   `bson:"active_hash,omitempty"`
+	Format              string                   `bson:"format"`
+	Mapping             importMappingDocument    `bson:"mapping"`
+	Status              string                   `bson:"status"`
+	Rows                []importRowDocument      `bson:"rows"`
+	TotalRows           int                      `bson:"total_rows"`
+	NextRow             int                      `bson:"next_row"`
+	ProcessedRows       int                      `bson:"processed_rows"`
+	AppliedRows         int                      `bson:"applied_rows"`
+	PendingApprovalRows int                      `bson:"pending_approval_rows"`
+	Errors              []importRowErrorDocument `bson:"errors"`
+	Failure             string                   `bson:"failure,omitempty"`
+	CreatedBy           string                   `bson:"created_by"`
+	CreatedAt           time.Time                `bson:"created_at"`
+	StartedAt           time.Time                `bson:"started_at,omitempty"`
+	FinishedAt          time.Time                `bson:"finished_at,omitempty"`
+	LeaseUntil          time.Time                `bson:"lease_until,omitempty"`
+}
+
+type importMappingDocument struct {
+	ProductID string `bson:"product_id"`
+	Quantity  string `bson:"quantity"`
+	Notes     string `bson:"notes"`
+}
+
+type importRowDocument struct {
+	Line      int    `bson:"line"`
+	ProductID string `bson:"product_id"`
+	Quantity  int    `bson:"quantity"`
+	Notes     string `bson:"notes,omitempty"`
+}
+
+type importRowErrorDocument struct {
+	Line      int    `bson:"line"`
+	ProductID string `bson:"product_id"`
+	Quantity  string `bson:"quantity"`
+	Message   string `bson:"message"`
+}
+
+func (d importJobDocument) toDomain() *domain.ImportJob {
+	rows := make([]domain.ImportRow, 0, len(d.Rows))
+	for _, r := range d.Rows {
+		rows = append(rows, domain.ImportRow{Line: r.Line, ProductID: r.ProductID, Quantity: r.Quantity, Notes: r.Notes})
+	}
+	return &domain.ImportJob{
+		ID:       d.ID.Hex(),
+		TenantID: d.TenantID,
+		FileName: d.FileName,
+		FileHash: d.FileHash,
+		Format:   d.Format,
+		Mapping: domain.ImportColumnMapping{
+			ProductID: d.Mapping.ProductID,
+			Quantity:  d.Mapping.Quantity,
+			Notes:     d.Mapping.Notes,
+		},
+		Status:              d.Status,
+		Rows:                rows,
+		TotalRows:           d.TotalRows,
+		NextRow:             d.NextRow,
+		ProcessedRows:       d.ProcessedRows,
+		AppliedRows:         d.AppliedRows,
+		PendingApprovalRows: d.PendingApprovalRows,
+		Errors:              toImportRowErrors(d.Errors),
+		Failure:             d.Failure,
+		CreatedBy:           d.CreatedBy,
+		CreatedAt:           d.CreatedAt,
+		StartedAt:           d.StartedAt,
+		FinishedAt:          d.FinishedAt,
+		LeaseUntil:          d.LeaseUntil,
+	}
+}
+
+func toImportRowErrors(docs []importRowErrorDocument) []domain.ImportRowError {
+	errs := make([]domain.ImportRowError, 0, len(docs))
+	for _, e := range docs {
+		errs = append(errs, domain.ImportRowError{Line: e.Line, ProductID: e.ProductID, Quantity: e.Quantity, Message: e.Message})
+	}
+	return errs
+}
+
+func toImportRowErrorDocuments(errs []domain.ImportRowError) []importRowErrorDocument {
+	docs := make([]importRowErrorDocument, 0, len(errs))
+	for _, e := range errs {
+		docs = append(docs, importRowErrorDocument{Line: e.Line, ProductID: e.ProductID, Quantity: e.Quantity, Message: e.Message})
+	}
+	return docs
+}
+
+// Import Job Repository Implementation
+type mongoImportJobRepository struct {
+	collection *mongo.Collection
+}
+
+// EnsureImportJobIndexes creates the unique (tenant_id, active_hash) index
+// that keeps a file from being imported twice, even by concurrent uploads.
+func EnsureImportJobIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("import_jobs").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "active_hash", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+			"active_hash": bson.M{"$exists": true},
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoImportJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+
+	rows := make([]importRowDocument, 0, len(job.Rows))
+	for _, row := range job.Rows {
+		rows = append(rows, importRowDocument{Line: row.Line, ProductID: row.ProductID, Quantity: row.Quantity, Notes: row.Notes})
+	}
+	document := importJobDocument{
+		ID:         primitive.NewObjectID(),
+		TenantID:   job.TenantID,
+		FileName:   job.FileName,
+		FileHash:   job.FileHash,
+		ActiveHash: job.FileHash,
+		Format:     job.Format,
+		Mapping: importMappingDocument{
+			ProductID: job.Mapping.ProductID,
+			Quantity:  job.Mapping.Quantity,
+			Notes:     job.Mapping.Notes,
+		},
+		Status:        job.Status,
+		Rows:          rows,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		AppliedRows:   job.AppliedRows,
+		Errors:        toImportRowErrorDocuments(job.Errors),
+		CreatedBy:     job.CreatedBy,
+		CreatedAt:     job.CreatedAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrDuplicateImport
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	job.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoImportJobRepository) FindByID(ctx context.Context, tenantID, jobID string) (*domain.ImportJob, error) {
+
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, domain.ErrImportJobNotFound
+	}
+
+	var result importJobDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoImportJobRepository) FindByHash(ctx context.Context, tenantID, fileHash string) (*domain.ImportJob, error) {
+
+	var result importJobDocument
+	err := r.collection.FindOne(ctx, bson.M{"tenant_id": tenantID, "active_hash": fileHash}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoImportJobRepository) ClaimPending(ctx context.Context, now time.Time) (*domain.ImportJob, error) {
+
+	// A running job whose lease ran out was left by a worker that stopped;
+	// it is claimed again and resumes at next_row
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": domain.ImportStatusPending},
+		bson.M{"status": domain.ImportStatusRunning, "lease_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": domain.ImportStatusRunning, "lease_until": now.Add(domain.ImportJobLease)},
+		// A reclaimed job keeps the time it first started
+		"$min": bson.M{"started_at": now},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var result importJobDocument
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoImportJobRepository) SaveProgress(ctx context.Context, job *domain.ImportJob) error {
+
+	objID, err := primitive.ObjectIDFromHex(job.ID)
+	if err != nil {
+		return domain.ErrImportJobNotFound
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":                job.Status,
+		"next_row":              job.NextRow,
+		"processed_rows":        job.ProcessedRows,
+		"applied_rows":          job.AppliedRows,
+		"pending_approval_rows": job.PendingApprovalRows,
+		"errors":                toImportRowErrorDocuments(job.Errors),
+		"failure":               job.Failure,
+		"finished_at":           job.FinishedAt,
+		"lease_until":           job.LeaseUntil,
+	}}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": job.TenantID}, update); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
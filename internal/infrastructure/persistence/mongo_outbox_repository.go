@@ -0,0 +1,280 @@
+// internal/infrastructure/persistence/mongo_outbox_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxDocument struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	EventType     string             `bson:"event_type"`
+	AggregateID   string             `bson:"aggregate_id"`
+	TenantID      string             `bson:"tenant_id"`
+	Payload       []byte             `bson:"payload"`
+	Status        string             `bson:"status"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	PublishedAt   time.Time          `bson:"published_at,omitempty"`
+}
+
+// Outbox Repository Implementation
+type mongoOutboxRepository struct {
+	collection *mongo.Collection
+	attempts   *mongo.Collection
+	cursors    *mongo.Collection
+}
+
+// EnsureOutboxIndexes creates the indexes the relay polls with and TTL
+// indexes that prune published entries and attempts after
+// domain.OutboxRetention.
+func EnsureOutboxIndexes(ctx context.Context, db *mongo.Database) error {
+	retention := int32(domain.OutboxRetention / time.Second)
+	_, err := db.Collection("outbox").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// Due entries
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		// Older entries of the same aggregate
+		{Keys: bson.D{{Key: "aggregate_id", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "published_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(retention).SetPartialFilterExpression(bson.M{
+				"status": string(domain.OutboxPublished),
+			}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	_, err = db.Collection("outbox_attempts").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "timestamp", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(retention),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoOutboxRepository) Append(ctx context.Context, entries []*domain.OutboxEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		id := primitive.NewObjectID()
+		e.ID = id.Hex()
+		documents = append(documents, outboxDocument{
+			ID:            id,
+			EventType:     e.EventType,
+			AggregateID:   e.AggregateID,
+			TenantID:      e.TenantID,
+			Payload:       e.Payload,
+			Status:        string(e.Status),
+			Attempts:      e.Attempts,
+			CreatedAt:     e.CreatedAt,
+			NextAttemptAt: e.NextAttemptAt,
+		})
+	}
+
+	// Ordered insert keeps the ObjectID order equal to the event order
+	_, err := r.collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(true))
+	return err
+}
+
+func (r *mongoOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEntry, error) {
+
+	// An older entry of the same aggregate that is waiting for its retry,
+	// or was given up on, holds the aggregate's later entries back. The
+	// lookup joins on aggregate_id so it uses the (aggregate_id, _id)
+	// index.
+	blocking := bson.A{
+		bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+			bson.M{"$lt": bson.A{"$_id", "$$id"}},
+			bson.M{"$or": bson.A{
+				bson.M{"$eq": bson.A{"$status", string(domain.OutboxDead)}},
+				bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$status", string(domain.OutboxPending)}},
+					bson.M{"$gt": bson.A{"$next_attempt_at", now}},
+				}},
+			}},
+		}}}},
+		bson.M{"$limit": 1},
+		bson.M{"$project": bson.M{"_id": 1}},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status":          string(domain.OutboxPending),
+			"next_attempt_at": bson.M{"$lte": now},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.collection.Name(),
+			"localField":   "aggregate_id",
+			"foreignField": "aggregate_id",
+			"let":          bson.M{"id": "$_id"},
+			"pipeline":     blocking,
+			"as":           "blocked_by",
+		}}},
+		{{Key: "$match", Value: bson.M{"blocked_by": bson.M{"$size": 0}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []outboxDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return toOutboxEntries(results), nil
+}
+
+func (r *mongoOutboxRepository) FindDead(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{"status": string(domain.OutboxDead)}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []outboxDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return toOutboxEntries(results), nil
+}
+
+func (r *mongoOutboxRepository) FindByID(ctx context.Context, id string) (*domain.OutboxEntry, error) {
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrOutboxEntryNotFound
+	}
+
+	var result outboxDocument
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrOutboxEntryNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return result.toDomain(), nil
+}
+
+func (d outboxDocument) toDomain() *domain.OutboxEntry {
+	return &domain.OutboxEntry{
+		ID:            d.ID.Hex(),
+		EventType:     d.EventType,
+		AggregateID:   d.AggregateID,
+		TenantID:      d.TenantID,
+		Payload:       d.Payload,
+		Status:        domain.OutboxStatus(d.Status),
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+		NextAttemptAt: d.NextAttemptAt,
+		PublishedAt:   d.PublishedAt,
+	}
+}
+
+func toOutboxEntries(docs []outboxDocument) []*domain.OutboxEntry {
+	entries := make([]*domain.OutboxEntry, 0, len(docs))
+	for _, d := range docs {
+		entries = append(entries, d.toDomain())
+	}
+	return entries
+}
+
+func (r *mongoOutboxRepository) Update(ctx context.Context, entry *domain.OutboxEntry) error {
+
+	objID, err := primitive.ObjectIDFromHex(entry.ID)
+	if err != nil {
+		return fmt.Errorf("invalid outbox entry id %q", entry.ID)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":          string(entry.Status),
+			"attempts":        entry.Attempts,
+			"last_error":      entry.LastError,
+			"next_attempt_at": entry.NextAttemptAt,
+			"published_at":    entry.PublishedAt,
+		},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	return err
+}
+
+func (r *mongoOutboxRepository) RecordAttempt(ctx context.Context, attempt domain.OutboxAttempt) error {
+
+	document := bson.M{
+		"entry_id":  attempt.EntryID,
+		"attempt":   attempt.Attempt,
+		"success":   attempt.Success,
+		"error":     attempt.Error,
+		"timestamp": attempt.Timestamp,
+	}
+
+	_, err := r.attempts.InsertOne(ctx, document)
+	return err
+}
+
+func (r *mongoOutboxRepository) LoadCursor(ctx context.Context, relay string) (*domain.OutboxCursor, error) {
+
+	var result struct {
+		Relay           string    `bson:"_id"`
+		LastEntryID     string    `bson:"last_entry_id"`
+		LastPublishedAt time.Time `bson:"last_published_at"`
+		Published       int64     `bson:"published"`
+		Failed          int64     `bson:"failed"`
+		UpdatedAt       time.Time `bson:"updated_at"`
+	}
+
+	err := r.cursors.FindOne(ctx, bson.M{"_id": relay}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &domain.OutboxCursor{Relay: relay}, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &domain.OutboxCursor{
+		Relay:           result.Relay,
+		LastEntryID:     result.LastEntryID,
+		LastPublishedAt: result.LastPublishedAt,
+		Published:       result.Published,
+		Failed:          result.Failed,
+		UpdatedAt:       result.UpdatedAt,
+	}, nil
+}
+
+func (r *mongoOutboxRepository) SaveCursor(ctx context.Context, cursor domain.OutboxCursor) error {
+
+	update := bson.M{
+		"$set": bson.M{
+			"last_entry_id":     cursor.LastEntryID,
+			"last_published_at": cursor.LastPublishedAt,
+			"published":         cursor.Published,
+			"failed":            cursor.Failed,
+			"updated_at":        cursor.UpdatedAt,
+		},
+	}
+
+	_, err := r.cursors.UpdateOne(ctx, bson.M{"_id": cursor.Relay}, update, options.Update().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,133 @@
+// internal/infrastructure/persistence/mongo_product_cost_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Amounts are stored in minor units
+type costLayerDocument struct {
+	Quantity   int       `bson:"quantity"`
+	Value      int64     `bson:"value"`
+	ReceivedAt time.Time `bson:"received_at"`
+}
+
+type productCostDocument struct {
+	TenantID  string              `bson:"tenant_id"`
+	ProductID string              `bson:"product_id"`
+	Layers    []costLayerDocument `bson:"layers"`
+	UpdatedAt time.Time           `bson:"updated_at"`
+	Version   int                 `bson:"version"`
+}
+
+func (d productCostDocument) toDomain() *domain.ProductCost {
+	layers := make([]domain.CostLayer, 0, len(d.Layers))
+	for _, l := range d.Layers {
+		layers = append(layers, domain.CostLayer{
+			Quantity:   l.Quantity,
+			Value:      domain.NewMoney(l.Value),
+			ReceivedAt: l.ReceivedAt,
+		})
+	}
+	return &domain.ProductCost{
+		ProductID: d.ProductID,
+		TenantID:  d.TenantID,
+		Layers:    layers,
+		UpdatedAt: d.UpdatedAt,
+		Version:   d.Version,
+	}
+}
+
+// Product Cost Repository Implementation
+type mongoProductCostRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoProductCostRepository) FindByProduct(ctx context.Context, tenantID, productID string) (*domain.ProductCost, error) {
+
+	var result productCostDocument
+	err := r.collection.FindOne(ctx, bson.M{"tenant_id": tenantID, "product_id": productID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &domain.ProductCost{ProductID: productID, TenantID: tenantID}, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return result.toDomain(), nil
+}
+
+func (r *mongoProductCostRepository) FindByTenant(ctx context.Context, tenantID string) ([]*domain.ProductCost, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []productCostDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	costs := make([]*domain.ProductCost, 0, len(results))
+	for _, doc := range results {
+		costs = append(costs, doc.toDomain())
+	}
+	return costs, nil
+}
+
+func (r *mongoProductCostRepository) Save(ctx context.Context, cost *domain.ProductCost) error {
+
+	layers := make([]costLayerDocument, 0, len(cost.Layers))
+	for _, l := range cost.Layers {
+		layers = append(layers, costLayerDocument{
+			Quantity:   l.Quantity,
+			Value:      l.Value.Minor(),
+			ReceivedAt: l.ReceivedAt,
+		})
+	}
+
+	// The version filter makes a concurrent movement lose. A product's
+	// first save inserts; when another insert won, the unique index
+	// rejects this one.
+	filter := bson.M{"tenant_id": cost.TenantID, "product_id": cost.ProductID, "version": cost.Version}
+	update := bson.M{
+		"$set": bson.M{
+			"layers":     layers,
+			"updated_at": cost.UpdatedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(cost.Version == 0))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrProductCostChanged
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		return domain.ErrProductCostChanged
+	}
+	return nil
+}
+
+// EnsureProductCostIndexes creates the unique index holding one set of cost
+// layers per product
+func EnsureProductCostIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("product_costs").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "product_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// internal/infrastructure/persistence/mongo_purchase_order_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type purchaseOrderLineDocument struct {
+	ProductID   string `bson:"product_id"`
+	ProductName string `bson:"product_name"`
+	Expected    int    `bson:"expected"`
+	Received    int    `bson:"received"`
+}
+
+type receiptLineDocument struct {
+	ProductID string `bson:"product_id"`
+	Quantity  int    `bson:"quantity"`
+}
+
+type purchaseOrderReceiptDocument struct {
+	Lines      []receiptLineDocument `bson:"lines"`
+	ReceivedBy string                `bson:"received_by"`
+	Notes      string                `bson:"notes,omitempty"`
+	ReceivedAt time.Time             `bson:"received_at"`
+}
+
+type purchaseOrderDocument struct {
+	ID         primitive.ObjectID             `bson:"_id,omitempty"`
+	TenantID   string                         `bson:"tenant_id"`
+	Supplier   string                         `bson:"supplier"`
+	SupplierID string                         `bson:"supplier_id,omitempty"`
+	Number     string                         `bson:"number,omitempty"`
+	Notes      string                         `bson:"notes,omitempty"`
+	Status     string                         `bson:"status"`
+	Lines      []purchaseOrderLineDocument    `bson:"lines"`
+	Receipts   []purchaseOrderReceiptDocument `bson:"receipts"`
+	ExpectedAt time.Time                      `bson:"expected_at,omitempty"`
+	CreatedBy  string                         `bson:"created_by"`
+	CreatedAt  time.Time                      `bson:"created_at"`
+	ClosedBy   string                         `bson:"closed_by,omitempty"`
+	ClosedAt   time.Time                      `bson:"closed_at,omitempty"`
+	Version    int                            `bson:"version"`
+}
+
+func toPurchaseOrderLineDocuments(lines []domain.PurchaseOrderLine) []purchaseOrderLineDocument {
+	docs := make([]purchaseOrderLineDocument, 0, len(lines))
+	for _, l := range lines {
+		docs = append(docs, purchaseOrderLineDocument{
+			ProductID:   l.ProductID,
+			ProductName: l.ProductName,
+			Expected:    l.Expected,
+			Received:    l.Received,
+		})
+	}
+	return docs
+}
+
+func toPurchaseOrderReceiptDocuments(receipts []domain.PurchaseOrderReceipt) []purchaseOrderReceiptDocument {
+	docs := make([]purchaseOrderReceiptDocument, 0, len(receipts))
+	for _, r := range receipts {
+		lines := make([]receiptLineDocument, 0, len(r.Lines))
+		for _, l := range r.Lines {
+			lines = append(lines, receiptLineDocument{ProductID: l.ProductID, Quantity: l.Quantity})
+		}
+		docs = append(docs, purchaseOrderReceiptDocument{
+			Lines:      lines,
+			ReceivedBy: r.ReceivedBy,
+			Notes:      r.Notes,
+			ReceivedAt: r.ReceivedAt,
+		})
+	}
+	return docs
+}
+
+func (d purchaseOrderDocument) toDomain() *domain.PurchaseOrder {
+	lines := make([]domain.PurchaseOrderLine, 0, len(d.Lines))
+	for _, l := range d.Lines {
+		lines = append(lines, domain.PurchaseOrderLine{
+			ProductID:   l.ProductID,
+			ProductName: l.ProductName,
+			Expected:    l.Expected,
+			Received:    l.Received,
+		})
+	}
+	receipts := make([]domain.PurchaseOrderReceipt, 0, len(d.Receipts))
+	for _, r := range d.Receipts {
+		receiptLines := make([]domain.ProductQuantity, 0, len(r.Lines))
+		for _, l := range r.Lines {
+			receiptLines = append(receiptLines, domain.ProductQuantity{ProductID: l.ProductID, Quantity: l.Quantity})
+		}
+		receipts = append(receipts, domain.PurchaseOrderReceipt{
+			Lines:      receiptLines,
+			ReceivedBy: r.ReceivedBy,
+			Notes:      r.Notes,
+			ReceivedAt: r.ReceivedAt,
+		})
+	}
+	return &domain.PurchaseOrder{
+		ID:         d.ID.Hex(),
+		TenantID:   d.TenantID,
+		Supplier:   d.Supplier,
+		SupplierID: d.SupplierID,
+		Number:     d.Number,
+		Notes:      d.Notes,
+		Status:     d.Status,
+		Lines:      lines,
+		Receipts:   receipts,
+		ExpectedAt: d.ExpectedAt,
+		CreatedBy:  d.CreatedBy,
+		CreatedAt:  d.CreatedAt,
+		ClosedBy:   d.ClosedBy,
+		ClosedAt:   d.ClosedAt,
+		Version:    d.Version,
+	}
+}
+
+// Purchase Order Repository Implementation
+type mongoPurchaseOrderRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoPurchaseOrderRepository) Create(ctx context.Context, order *domain.PurchaseOrder) error {
+
+	document := purchaseOrderDocument{
+		ID:         primitive.NewObjectID(),
+		TenantID:   order.TenantID,
+		Supplier:   order.Supplier,
+		SupplierID: order.SupplierID,
+		Number:     order.Number,
+		Notes:      order.Notes,
+		Status:     order.Status,
+		Lines:      toPurchaseOrderLineDocuments(order.Lines),
+		Receipts:   toPurchaseOrderReceiptDocuments(order.Receipts),
+		ExpectedAt: order.ExpectedAt,
+		CreatedBy:  order.CreatedBy,
+		CreatedAt:  order.CreatedAt,
+		Version:    order.Version,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	order.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoPurchaseOrderRepository) FindByID(ctx context.Context, tenantID, orderID string) (*domain.PurchaseOrder, error) {
+
+	objID, err := primitive.ObjectIDFromHex(orderID)
+	if err != nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	var result purchaseOrderDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoPurchaseOrderRepository) FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.PurchaseOrder, error) {
+
+	filter := bson.M{"tenant_id": tenantID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []purchaseOrderDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	orders := make([]*domain.PurchaseOrder, 0, len(results))
+	for _, doc := range results {
+		orders = append(orders, doc.toDomain())
+	}
+	return orders, nil
+}
+
+func (r *mongoPurchaseOrderRepository) Update(ctx context.Context, order *domain.PurchaseOrder) error {
+
+	objID, err := primitive.ObjectIDFromHex(order.ID)
+	if err != nil {
+		return domain.ErrPurchaseOrderNotFound
+	}
+
+	// The version filter makes a concurrent receipt or cancellation lose
+	filter := bson.M{"_id": objID, "tenant_id": order.TenantID, "version": order.Version}
+	update := bson.M{
+		"$set": bson.M{
+			"status":    order.Status,
+			"lines":     toPurchaseOrderLineDocuments(order.Lines),
+			"receipts":  toPurchaseOrderReceiptDocuments(order.Receipts),
+			"closed_by": order.ClosedBy,
+			"closed_at": order.ClosedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrPurchaseOrderChanged
+	}
+	return nil
+}
+
+// EnsurePurchaseOrderIndexes creates the index listing a tenant's orders
+func EnsurePurchaseOrderIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("purchase_orders").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
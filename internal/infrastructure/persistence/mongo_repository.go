@@ -4,15 +4,17 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"myapp/internal/application/interfaces"
 	"myapp/internal/domain"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	// "go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type mongoUnitOfWork struct {
@@ -27,6 +29,24 @@ func NewMongoUnitOfWork(client *mongo.Client, dbName string) interfaces.UnitOfWo
 	}
 }
 
+func (uow *mongoUnitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Already inside a transaction: join it
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := uow.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
 func (uow *mongoUnitOfWork) Products() interfaces.ProductRepository {
 	return &mongoProductRepository{
 		collection: uow.db.Collection("products"),
@@ -45,12 +65,205 @@ func (uow *mongoUnitOfWork) StockHistory() interfaces.StockHistoryRepository {
 	}
 }
 
+func (uow *mongoUnitOfWork) WebhookSubscriptions() interfaces.WebhookSubscriptionRepository {
+	return &mongoWebhookSubscriptionRepository{
+		collection: uow.db.Collection("webhook_subscriptions"),
+	}
+}
+
+func (uow *mongoUnitOfWork) WebhookDeliveries() interfaces.WebhookDeliveryRepository {
+	return &mongoWebhookDeliveryRepository{
+		collection: uow.db.Collection("webhook_deliveries"),
+	}
+}
+
+func (uow *mongoUnitOfWork) NotificationTemplates() interfaces.NotificationTemplateRepository {
+	return &mongoNotificationTemplateRepository{
+		collection: uow.db.Collection("notification_templates"),
+	}
+}
+
+func (uow *mongoUnitOfWork) RoutingRules() interfaces.RoutingRuleRepository {
+	return &mongoRoutingRuleRepository{
+		collection: uow.db.Collection("notification_routing_rules"),
+	}
+}
+
+func (uow *mongoUnitOfWork) Outbox() interfaces.OutboxRepository {
+	return &mongoOutboxRepository{
+		collection: uow.db.Collection("outbox"),
+		attempts:   uow.db.Collection("outbox_attempts"),
+		cursors:    uow.db.Collection("outbox_cursors"),
+	}
+}
+
+func (uow *mongoUnitOfWork) StockLedger() interfaces.StockLedgerRepository {
+	return &mongoStockLedgerRepository{
+		collection: uow.db.Collection("stock_ledger"),
+		snapshots:  uow.db.Collection("stock_snapshots"),
+	}
+}
+
+func (uow *mongoUnitOfWork) CountSessions() interfaces.CountSessionRepository {
+	return &mongoCountSessionRepository{
+		collection: uow.db.Collection("count_sessions"),
+	}
+}
+
+func (uow *mongoUnitOfWork) AdjustmentReasons() interfaces.AdjustmentReasonRepository {
+	return &mongoAdjustmentReasonRepository{
+		collection: uow.db.Collection("adjustment_reasons"),
+	}
+}
+
+func (uow *mongoUnitOfWork) StockChangeRequests() interfaces.StockChangeRequestRepository {
+	return &mongoStockChangeRequestRepository{
+		collection: uow.db.Collection("stock_change_requests"),
+	}
+}
+
+func (uow *mongoUnitOfWork) ImportJobs() interfaces.ImportJobRepository {
+	return &mongoImportJobRepository{
+		collection: uow.db.Collection("import_jobs"),
+	}
+}
+
+func (uow *mongoUnitOfWork) DailySnapshots() interfaces.DailyStockSnapshotRepository {
+	return &mongoDailySnapshotRepository{
+		collection: uow.db.Collection("daily_stock_snapshots"),
+	}
+}
+
+func (uow *mongoUnitOfWork) PurchaseOrders() interfaces.PurchaseOrderRepository {
+	return &mongoPurchaseOrderRepository{
+		collection: uow.db.Collection("purchase_orders"),
+	}
+}
+
+func (uow *mongoUnitOfWork) Suppliers() interfaces.SupplierRepository {
+	return &mongoSupplierRepository{
+		collection: uow.db.Collection("suppliers"),
+	}
+}
+
+func (uow *mongoUnitOfWork) ProductCosts() interfaces.ProductCostRepository {
+	return &mongoProductCostRepository{
+		collection: uow.db.Collection("product_costs"),
+	}
+}
+
 // Product Repository Implementation
 type mongoProductRepository struct {
 	collection *mongo.Collection
 	// session    mongo.Session
 }
 
+type productDocument struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Name         string             `bson:"name"`
+	CurrentStock bsonDecimal        `bson:"current_stock"`
+	LastUpdated  time.Time          `bson:"last_updated"`
+	TenantID     string             `bson:"tenant_id"`
+	Tags         []string           `bson:"tags"`
+	TotalAdded   bsonDecimal        `bson:"total_added"`
+	Location     string             `bson:"location"`
+	CountSession string             `bson:"count_session_id"`
+	BaseUnit     string             `bson:"base_unit"`
+	Units        []unitDocument     `bson:"units"`
+	QuantityMode string             `bson:"quantity_mode"`
+	Precision    int                `bson:"quantity_precision"`
+	SKU          string             `bson:"sku"`
+	Barcode      string             `bson:"barcode"`
+	ParentID     string             `bson:"parent_id"`
+	Options      map[string]string  `bson:"variant_options"`
+}
+
+type unitDocument struct {
+	Unit     string `bson:"unit"`
+	Quantity int    `bson:"quantity"`
+	Of       string `bson:"of"`
+}
+
+func (d productDocument) toDomain() *domain.Product {
+	stock, _ := domain.NewDecimalStockQuantity(d.CurrentStock.Decimal)
+	return &domain.Product{
+		ID:                d.ID.Hex(),
+		Name:              d.Name,
+		CurrentStock:      stock,
+		LastUpdated:       d.LastUpdated,
+		TenantID:          d.TenantID,
+		Tags:              d.Tags,
+		TotalAdded:        d.TotalAdded.Decimal,
+		Location:          d.Location,
+		CountSessionID:    d.CountSession,
+		BaseUnit:          d.BaseUnit,
+		Units:             toUnitConversions(d.Units),
+		QuantityMode:      d.QuantityMode,
+		QuantityPrecision: d.Precision,
+		SKU:               d.SKU,
+		Barcode:           d.Barcode,
+		ParentID:          d.ParentID,
+		VariantOptions:    d.Options,
+	}
+}
+
+// A quantity stored as any BSON number. Quantities with decimal places
+// are written as Decimal128 so no digit is lost; whole ones stay plain
+// integers that existing queries and aggregations read as before.
+type bsonDecimal struct {
+	domain.Decimal
+}
+
+func (d bsonDecimal) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if d.Scale() == 0 {
+		return bson.MarshalValue(int64(d.IntPart()))
+	}
+	value, err := primitive.ParseDecimal128(d.String())
+	if err != nil {
+		return 0, nil, err
+	}
+	return bson.MarshalValue(value)
+}
+
+func (d *bsonDecimal) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	var err error
+	switch t {
+	case bsontype.Int32:
+		d.Decimal = domain.DecimalFromInt(int(raw.Int32()))
+	case bsontype.Int64:
+		d.Decimal = domain.DecimalFromInt(int(raw.Int64()))
+	case bsontype.Double:
+		d.Decimal, err = domain.ParseDecimal(strconv.FormatFloat(raw.Double(), 'f', -1, 64))
+	case bsontype.Decimal128:
+		d.Decimal, err = domain.ParseDecimal(raw.Decimal128().String())
+	case bsontype.Null, bsontype.Undefined:
+		d.Decimal = domain.Decimal{}
+	default:
+		err = fmt.Errorf("cannot decode %s as a quantity", t)
+	}
+	return err
+}
+
+// optionalDecimal is nil for nil, for optional quantity fields
+func optionalDecimal(d *domain.Decimal) *bsonDecimal {
+	if d == nil {
+		return nil
+	}
+	return &bsonDecimal{Decimal: *d}
+}
+
+func toUnitConversions(docs []unitDocument) []domain.UnitConversion {
+	if len(docs) == 0 {
+		return nil
+	}
+	units := make([]domain.UnitConversion, 0, len(docs))
+	for _, u := range docs {
+		units = append(units, domain.UnitConversion{Unit: u.Unit, Quantity: u.Quantity, Of: u.Of})
+	}
+	return units
+}
+
 func (r *mongoProductRepository) FindByID(ctx context.Context, productID string) (*domain.Product, error) {
 
 	objID, err := primitive.ObjectIDFromHex(productID)
@@ -58,30 +271,153 @@ func (r *mongoProductRepository) FindByID(ctx context.Context, productID string)
 		return nil, domain.ErrInvalidProductID
 	}
 
-	var result struct {
-		ID           primitive.ObjectID `bson:"_id"`
-		Name         string             `bson:"name"`
-		CurrentStock int                `bson:"current_stock"`
-		LastUpdated  time.Time          `bson:"last_updated"`
-		TenantID     string             `bson:"tenant_id"`
+	var result productDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+	return result.toDomain(), nil
+}
+
+func (r *mongoProductRepository) FindByIDs(ctx context.Context, productIDs []string) ([]*domain.Product, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(productIDs))
+	for _, id := range productIDs {
+		// Malformed IDs cannot match a product
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []productDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	products := make([]*domain.Product, 0, len(docs))
+	for _, d := range docs {
+		products = append(products, d.toDomain())
+	}
+	return products, nil
+}
+
+func (r *mongoProductRepository) FindByTenant(ctx context.Context, tenantID string) ([]*domain.Product, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []productDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	products := make([]*domain.Product, 0, len(docs))
+	for _, d := range docs {
+		products = append(products, d.toDomain())
+	}
+	return products, nil
+}
+
+func (r *mongoProductRepository) FindByIdentifier(ctx context.Context, tenantID string, id domain.ProductIdentifier) (*domain.Product, error) {
+	filter := bson.M{"tenant_id": tenantID}
+	switch {
+	case id.SKU != "":
+		filter["sku"] = id.SKU
+	case id.Barcode != "":
+		filter["barcode"] = id.Barcode
+	default:
+		return nil, domain.ErrInvalidProductIdentifier
+	}
+
+	var result productDocument
+	err := r.collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, domain.ErrProductNotFound
 		}
 		return nil, fmt.Errorf("database error: %w", err)
 	}
+	return result.toDomain(), nil
+}
 
-	stock, _ := domain.NewStockQuantity(result.CurrentStock)
-	return &domain.Product{
-		ID:           result.ID.Hex(),
-		Name:         result.Name,
-		CurrentStock: stock,
-		LastUpdated:  result.LastUpdated,
-		TenantID:     result.TenantID,
-	}, nil
+func (r *mongoProductRepository) FindVariants(ctx context.Context, tenantID, parentID string) ([]*domain.Product, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID, "parent_id": parentID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []productDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	products := make([]*domain.Product, 0, len(docs))
+	for _, d := range docs {
+		products = append(products, d.toDomain())
+	}
+	return products, nil
+}
+
+func (r *mongoProductRepository) EachByTenant(ctx context.Context, tenantID string, fn func(*domain.Product) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID}, opts)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc productDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if err := fn(doc.toDomain()); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) Each(ctx context.Context, fn func(*domain.Product) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc productDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if err := fn(doc.toDomain()); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
 }
 
 func (r *mongoProductRepository) Save(ctx context.Context, product *domain.Product) error {
@@ -90,11 +426,10 @@ func (r *mongoProductRepository) Save(ctx context.Context, product *domain.Produ
 
 	update := bson.M{
 		"$set": bson.M{
-			"current_stock": product.CurrentStock.Value(),
+			"current_stock": bsonDecimal{product.CurrentStock.Decimal()},
 			"last_updated":  product.LastUpdated,
-		},
-		"$inc": bson.M{
-			"total_added": product.CurrentStock.Value(), // Simplified
+			// Kept by Product.AddStock, like current_stock
+			"total_added": bsonDecimal{product.TotalAdded},
 		},
 	}
 
@@ -114,7 +449,7 @@ func (r *mongoProductRepository) UpdateStock(ctx context.Context, productID stri
 
 	update := bson.M{
 		"$set": bson.M{
-			"current_stock": newStock.Value(),
+			"current_stock": bsonDecimal{newStock.Decimal()},
 			"last_updated":  time.Now(),
 		},
 	}
@@ -124,6 +459,312 @@ func (r *mongoProductRepository) UpdateStock(ctx context.Context, productID stri
 	return err
 }
 
+func (r *mongoProductRepository) SetTotalAdded(ctx context.Context, productID string, totalAdded domain.Decimal) error {
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{"total_added": bsonDecimal{totalAdded}},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) SetCountLock(ctx context.Context, productID, sessionID string) error {
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{"count_session_id": sessionID},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) SetUnits(ctx context.Context, productID, baseUnit string, units []domain.UnitConversion) error {
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+
+	docs := make([]unitDocument, 0, len(units))
+	for _, u := range units {
+		docs = append(docs, unitDocument{Unit: u.Unit, Quantity: u.Quantity, Of: u.Of})
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{"base_unit": baseUnit, "units": docs},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) SetQuantityMode(ctx context.Context, productID, mode string, precision int, stock domain.StockQuantity) error {
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{
+			"quantity_mode":      mode,
+			"quantity_precision": precision,
+			"current_stock":      bsonDecimal{stock.Decimal()},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+// SetIdentifiers unsets empty identifiers rather than storing "", so the
+// partial unique indexes only cover products that have one
+func (r *mongoProductRepository) SetIdentifiers(ctx context.Context, productID, sku, barcode string) error {
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+
+	set, unset := bson.M{}, bson.M{}
+	for field, value := range map[string]string{"sku": sku, "barcode": barcode} {
+		if value == "" {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrDuplicateProductIdentifier
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *mongoProductRepository) SetParent(ctx context.Context, productID, parentID string, variantOptions map[string]string) error {
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+
+	update := bson.M{"$set": bson.M{"parent_id": parentID, "variant_options": variantOptions}}
+	if parentID == "" {
+		update = bson.M{"$unset": bson.M{"parent_id": "", "variant_options": ""}}
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+// EnsureProductIndexes creates the unique indexes on a tenant's SKUs and
+// barcodes, and the one listing a product's variants. Products without an
+// identifier are left out of its index.
+func EnsureProductIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("products").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "sku", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"sku": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "barcode", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"barcode": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "parent_id", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"parent_id": bson.M{"$type": "string"}}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// SummarizeUtilization computes domain.SummarizeUtilization in one
+// aggregation, with a facet per part of the summary.
+func (r *mongoProductRepository) SummarizeUtilization(ctx context.Context, q domain.UtilizationQuery) (*domain.UtilizationSummary, error) {
+	// Same arithmetic as Product.UtilizationPercentage, so products land
+	// in the same bands
+	var utilization interface{} = 0.0
+	if q.MaxStock.Value() > 0 {
+		utilization = bson.M{"$multiply": bson.A{
+			bson.M{"$divide": bson.A{bson.M{"$toDouble": "$current_stock"}, q.MaxStock.Value()}}, 100,
+		}}
+	}
+	bandIndex := bson.M{"$literal": 0}
+	if len(q.Bands) > 1 {
+		branches := bson.A{}
+		for i, band := range q.Bands[:len(q.Bands)-1] {
+			branches = append(branches, bson.M{
+				"case": bson.M{"$lt": bson.A{"$utilization", band.Max}},
+				"then": i,
+			})
+		}
+		bandIndex = bson.M{"$switch": bson.M{"branches": branches, "default": len(q.Bands) - 1}}
+	}
+	// Summaries count whole units; decimal-mode stock is truncated
+	project := bson.M{"name": 1, "current_stock": "$whole_stock", "utilization": 1}
+	lowStock := bson.M{"current_stock": bson.M{"$lt": q.LowStockThreshold}}
+
+	// $limit rejects 0, so lists of no rows match nothing instead
+	noRows := mongo.Pipeline{{{Key: "$match", Value: bson.M{"$expr": false}}}}
+	top := noRows
+	if q.TopN > 0 {
+		top = mongo.Pipeline{
+			{{Key: "$sort", Value: bson.D{{Key: "utilization", Value: -1}, {Key: "_id", Value: 1}}}},
+			{{Key: "$limit", Value: q.TopN}},
+			{{Key: "$project", Value: project}},
+		}
+	}
+	low := noRows
+	if q.LowStockLimit > 0 {
+		low = mongo.Pipeline{
+			{{Key: "$match", Value: lowStock}},
+			{{Key: "$sort", Value: bson.D{{Key: "current_stock", Value: 1}, {Key: "_id", Value: 1}}}},
+			{{Key: "$limit", Value: q.LowStockLimit}},
+			{{Key: "$project", Value: project}},
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tenant_id": q.TenantID}}},
+		{{Key: "$addFields", Value: bson.M{
+			"utilization": utilization,
+			"whole_stock": bson.M{"$toLong": bson.M{"$trunc": "$current_stock"}},
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"bands": mongo.Pipeline{
+				{{Key: "$group", Value: bson.M{
+					"_id":      bandIndex,
+					"products": bson.M{"$sum": 1},
+					"units":    bson.M{"$sum": "$whole_stock"},
+				}}},
+			},
+			"top": top,
+			"low": low,
+			"low_count": mongo.Pipeline{
+				{{Key: "$match", Value: lowStock}},
+				{{Key: "$count", Value: "count"}},
+			},
+			"totals": mongo.Pipeline{
+				{{Key: "$group", Value: bson.M{
+					"_id":      nil,
+					"products": bson.M{"$sum": 1},
+					"units":    bson.M{"$sum": "$whole_stock"},
+				}}},
+			},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	type productRow struct {
+		ID           primitive.ObjectID `bson:"_id"`
+		Name         string             `bson:"name"`
+		CurrentStock int                `bson:"current_stock"`
+		Utilization  float64            `bson:"utilization"`
+	}
+	type totalsRow struct {
+		Index    int `bson:"_id"`
+		Products int `bson:"products"`
+		Units    int `bson:"units"`
+	}
+	var facets []struct {
+		Bands    []totalsRow  `bson:"bands"`
+		Top      []productRow `bson:"top"`
+		Low      []productRow `bson:"low"`
+		LowCount []struct {
+			Count int `bson:"count"`
+		} `bson:"low_count"`
+		Totals []totalsRow `bson:"totals"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	summary := &domain.UtilizationSummary{
+		Bands:    make([]domain.UtilizationBandTotals, len(q.Bands)),
+		Top:      []domain.ProductUtilization{},
+		LowStock: []domain.ProductUtilization{},
+	}
+	for i, band := range q.Bands {
+		summary.Bands[i].Band = band
+	}
+	if len(facets) == 0 {
+		return summary, nil
+	}
+	result := facets[0]
+
+	toUtilization := func(rows []productRow) []domain.ProductUtilization {
+		products := make([]domain.ProductUtilization, 0, len(rows))
+		for _, row := range rows {
+			products = append(products, domain.ProductUtilization{
+				ProductID:    row.ID.Hex(),
+				ProductName:  row.Name,
+				CurrentStock: row.CurrentStock,
+				Utilization:  row.Utilization,
+			})
+		}
+		return products
+	}
+	for _, row := range result.Bands {
+		if row.Index >= 0 && row.Index < len(summary.Bands) {
+			summary.Bands[row.Index].Products = row.Products
+			summary.Bands[row.Index].Units = row.Units
+		}
+	}
+	summary.Top = toUtilization(result.Top)
+	summary.LowStock = toUtilization(result.Low)
+	if len(result.LowCount) > 0 {
+		summary.LowStockCount = result.LowCount[0].Count
+	}
+	if len(result.Totals) > 0 {
+		summary.Products = result.Totals[0].Products
+		summary.TotalUnits = result.Totals[0].Units
+	}
+	return summary, nil
+}
+
 // Tenant Repository Implementation
 type mongoTenantRepository struct {
 	collection *mongo.Collection
@@ -132,10 +773,23 @@ type mongoTenantRepository struct {
 func (r *mongoTenantRepository) FindByID(ctx context.Context, tenantID string) (*domain.Tenant, error) {
 
 	var result struct {
-		ID       string `bson:"_id"`
-		Name     string `bson:"name"`
-		MaxStock int    `bson:"max_stock"`
-		IsActive bool   `bson:"is_active"`
+		ID                 string `bson:"_id"`
+		Name               string `bson:"name"`
+		MaxStock           int    `bson:"max_stock"`
+		IsActive           bool   `bson:"is_active"`
+		StockMode          string `bson:"stock_mode"`
+		ApprovalThreshold  int    `bson:"approval_threshold"`
+		ApprovalTTLSeconds int64  `bson:"approval_ttl_seconds"`
+		Forecast           struct {
+			Method          string  `bson:"method"`
+			WindowDays      int     `bson:"window_days"`
+			Alpha           float64 `bson:"alpha"`
+			LeadTimeDays    int     `bson:"lead_time_days"`
+			SafetyStockDays int     `bson:"safety_stock_days"`
+			CoverDays       int     `bson:"cover_days"`
+			Alerts          bool    `bson:"alerts"`
+		} `bson:"forecast"`
+		CostingMethod string `bson:"costing_method"`
 	}
 
 	err := r.collection.FindOne(ctx, bson.M{"_id": tenantID}).Decode(&result)
@@ -148,10 +802,24 @@ func (r *mongoTenantRepository) FindByID(ctx context.Context, tenantID string) (
 
 	maxStock, _ := domain.NewStockQuantity(result.MaxStock)
 	return &domain.Tenant{
-		ID:       result.ID,
-		Name:     result.Name,
-		MaxStock: maxStock,
-		IsActive: result.IsActive,
+		ID:        result.ID,
+		Name:      result.Name,
+		MaxStock:  maxStock,
+		IsActive:  result.IsActive,
+		StockMode: result.StockMode,
+
+		ApprovalThreshold: result.ApprovalThreshold,
+		ApprovalTTL:       time.Duration(result.ApprovalTTLSeconds) * time.Second,
+		Forecast: domain.ForecastSettings{
+			Method:          result.Forecast.Method,
+			WindowDays:      result.Forecast.WindowDays,
+			Alpha:           result.Forecast.Alpha,
+			LeadTimeDays:    result.Forecast.LeadTimeDays,
+			SafetyStockDays: result.Forecast.SafetyStockDays,
+			CoverDays:       result.Forecast.CoverDays,
+			Alerts:          result.Forecast.Alerts,
+		},
+		CostingMethod: result.CostingMethod,
 	}, nil
 }
 
@@ -161,21 +829,455 @@ type mongoStockHistoryRepository struct {
 }
 
 func (r *mongoStockHistoryRepository) Create(ctx context.Context, event domain.StockAddedEvent) error {
+	entry := domain.StockAddedHistoryEntry(event)
+	return r.Append(ctx, &entry)
+}
 
-	productID, _ := primitive.ObjectIDFromHex(event.ProductID)
+func (r *mongoStockHistoryRepository) Append(ctx context.Context, entry *domain.StockHistoryEntry) error {
+
+	productID, _ := primitive.ObjectIDFromHex(entry.ProductID)
 
 	document := bson.M{
 		"product_id":     productID,
-		"tenant_id":      event.TenantID,
-		"quantity":       event.Quantity.Value(),
-		"previous_stock": event.Previous.Value(),
-		"new_stock":      event.Current.Value(),
-		"added_by":       event.AddedBy,
-		"notes":          event.Notes,
-		"created_at":     event.Timestamp,
-		"operation":      "stock_add",
+		"tenant_id":      entry.TenantID,
+		"quantity":       entry.Quantity,
+		"previous_stock": entry.PreviousStock,
+		"new_stock":      entry.NewStock,
+		"added_by":       entry.Actor,
+		"notes":          entry.Notes,
+		"created_at":     entry.CreatedAt,
+		"operation":      entry.Operation,
+	}
+	if entry.ReasonCode != "" {
+		document["reason_code"] = entry.ReasonCode
+	}
+	if entry.Reference != "" {
+		document["reference"] = entry.Reference
+	}
+	if entry.SupplierID != "" {
+		document["supplier_id"] = entry.SupplierID
+	}
+	if entry.Unit != "" {
+		document["unit"] = entry.Unit
+		document["entered_quantity"] = bsonDecimal{entry.EnteredQuantity}
+	}
+	if entry.Decimal != nil {
+		document["decimal"] = decimalChangeDocument{
+			Quantity:      bsonDecimal{entry.Decimal.Quantity},
+			PreviousStock: bsonDecimal{entry.Decimal.PreviousStock},
+			NewStock:      bsonDecimal{entry.Decimal.NewStock},
+		}
+	}
+	// Amounts in minor units
+	if entry.UnitCost != nil {
+		document["unit_cost"] = entry.UnitCost.Minor()
+	}
+	if entry.Cost != nil {
+		document["cost"] = entry.Cost.Minor()
+	}
+	if entry.ApprovedBy != "" {
+		document["approved_by"] = entry.ApprovedBy
+	}
+	if entry.ReversalOf != "" {
+		document["reversal_of"] = entry.ReversalOf
 	}
 
-	_, err := r.collection.InsertOne(ctx, document)
-	return err
+	result, err := r.collection.InsertOne(ctx, document)
+	if err != nil {
+		return err
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = id.Hex()
+	}
+	return nil
+}
+
+type stockHistoryDocument struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	ProductID     primitive.ObjectID `bson:"product_id"`
+	TenantID      string             `bson:"tenant_id"`
+	Operation     string             `bson:"operation"`
+	Quantity      int                `bson:"quantity"`
+	PreviousStock int                `bson:"previous_stock"`
+	NewStock      int                `bson:"new_stock"`
+	AddedBy       string             `bson:"added_by"`
+	Notes         string             `bson:"notes"`
+	ReasonCode    string             `bson:"reason_code"`
+	Reference     string             `bson:"reference"`
+	SupplierID    string             `bson:"supplier_id"`
+	Unit          string             `bson:"unit"`
+	EnteredQty    bsonDecimal        `bson:"entered_quantity"`
+	UnitCost      *int64             `bson:"unit_cost"`
+	Cost          *int64             `bson:"cost"`
+	ApprovedBy    string             `bson:"approved_by"`
+	ReversalOf    string             `bson:"reversal_of"`
+	ReversedBy    string             `bson:"reversed_by"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	// Exact quantities of decimal-mode products; the ints above are their
+	// whole units, so aggregations over them keep working
+	Decimal *decimalChangeDocument `bson:"decimal"`
+}
+
+type decimalChangeDocument struct {
+	Quantity      bsonDecimal `bson:"quantity"`
+	PreviousStock bsonDecimal `bson:"previous_stock"`
+	NewStock      bsonDecimal `bson:"new_stock"`
+}
+
+func (d *decimalChangeDocument) toDomain() *domain.DecimalStockChange {
+	if d == nil {
+		return nil
+	}
+	return &domain.DecimalStockChange{
+		Quantity:      d.Quantity.Decimal,
+		PreviousStock: d.PreviousStock.Decimal,
+		NewStock:      d.NewStock.Decimal,
+	}
+}
+
+func (d stockHistoryDocument) toDomain() domain.StockHistoryEntry {
+	operation := d.Operation
+	if operation == "" {
+		// Entries written before operations were recorded are all adds
+		operation = domain.HistoryOperationStockAdd
+	}
+	return domain.StockHistoryEntry{
+		ID:              d.ID.Hex(),
+		ProductID:       d.ProductID.Hex(),
+		TenantID:        d.TenantID,
+		Operation:       operation,
+		Quantity:        d.Quantity,
+		PreviousStock:   d.PreviousStock,
+		NewStock:        d.NewStock,
+		Actor:           d.AddedBy,
+		Notes:           d.Notes,
+		ReasonCode:      d.ReasonCode,
+		Reference:       d.Reference,
+		SupplierID:      d.SupplierID,
+		Unit:            d.Unit,
+		EnteredQuantity: d.EnteredQty.Decimal,
+		Decimal:         d.Decimal.toDomain(),
+		UnitCost:        toMoney(d.UnitCost),
+		Cost:            toMoney(d.Cost),
+		ApprovedBy:      d.ApprovedBy,
+		ReversalOf:      d.ReversalOf,
+		ReversedBy:      d.ReversedBy,
+		CreatedAt:       d.CreatedAt,
+	}
+}
+
+// toMoney reads an optional amount stored in minor units
+func toMoney(minor *int64) *domain.Money {
+	if minor == nil {
+		return nil
+	}
+	m := domain.NewMoney(*minor)
+	return &m
+}
+
+func (r *mongoStockHistoryRepository) FindByID(ctx context.Context, tenantID, entryID string) (*domain.StockHistoryEntry, error) {
+
+	objID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, domain.ErrHistoryEntryNotFound
+	}
+
+	var result stockHistoryDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrHistoryEntryNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	entry := result.toDomain()
+	return &entry, nil
+}
+
+func (r *mongoStockHistoryRepository) FindByProduct(ctx context.Context, tenantID, productID string, limit int) ([]domain.StockHistoryEntry, error) {
+
+	objID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID, "product_id": objID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []stockHistoryDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	entries := make([]domain.StockHistoryEntry, 0, len(results))
+	for _, doc := range results {
+		entries = append(entries, doc.toDomain())
+	}
+	return entries, nil
+}
+
+func (r *mongoStockHistoryRepository) FindBySupplier(ctx context.Context, tenantID, supplierID string, limit int) ([]domain.StockHistoryEntry, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID, "supplier_id": supplierID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []stockHistoryDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	entries := make([]domain.StockHistoryEntry, 0, len(results))
+	for _, doc := range results {
+		entries = append(entries, doc.toDomain())
+	}
+	return entries, nil
+}
+
+func (r *mongoStockHistoryRepository) MarkReversed(ctx context.Context, tenantID, entryID, reversalID string) error {
+
+	objID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return domain.ErrHistoryEntryNotFound
+	}
+
+	// Only the first reversal matches, so concurrent reversals cannot both commit
+	filter := bson.M{"_id": objID, "tenant_id": tenantID, "reversed_by": bson.M{"$exists": false}}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"reversed_by": reversalID}})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrMovementAlreadyReversed
+	}
+	return nil
+}
+
+func (r *mongoStockHistoryRepository) EachInRange(ctx context.Context, tenantID string, from, to time.Time, fn func(domain.StockHistoryEntry) error) error {
+	filter := bson.M{"tenant_id": tenantID, "created_at": bson.M{"$gte": from, "$lt": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc stockHistoryDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if err := fn(doc.toDomain()); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// exactHistoryField reads a quantity field of a history entry with the
+// decimal places of decimal-mode products, which only decimal.<field>
+// keeps. Sums of it are Decimal128 once any entry is fractional.
+func exactHistoryField(field string) bson.M {
+	return bson.M{"$ifNull": bson.A{"$decimal." + field, "$" + field}}
+}
+
+func (r *mongoStockHistoryRepository) SummarizeByProduct(ctx context.Context, tenantID string) ([]domain.StockHistorySummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tenant_id": tenantID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$product_id",
+			"opening_stock": bson.M{"$first": exactHistoryField("previous_stock")},
+			"net_change":    bson.M{"$sum": exactHistoryField("quantity")},
+			"total_added": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$operation", domain.HistoryOperationStockAdd}}, exactHistoryField("quantity"), 0,
+			}}},
+			"entries": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ProductID    primitive.ObjectID `bson:"_id"`
+		OpeningStock bsonDecimal        `bson:"opening_stock"`
+		NetChange    bsonDecimal        `bson:"net_change"`
+		TotalAdded   bsonDecimal        `bson:"total_added"`
+		Entries      int                `bson:"entries"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	summaries := make([]domain.StockHistorySummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, domain.StockHistorySummary{
+			ProductID:    row.ProductID.Hex(),
+			OpeningStock: row.OpeningStock.Decimal,
+			NetChange:    row.NetChange.Decimal,
+			TotalAdded:   row.TotalAdded.Decimal,
+			Entries:      row.Entries,
+		})
+	}
+	return summaries, nil
+}
+
+func (r *mongoStockHistoryRepository) NetChangeByProduct(ctx context.Context, tenantID, productID string, from, to time.Time) (map[string]domain.Decimal, error) {
+	match := bson.M{"tenant_id": tenantID, "created_at": bson.M{"$gte": from, "$lt": to}}
+	if productID != "" {
+		objID, err := primitive.ObjectIDFromHex(productID)
+		if err != nil {
+			return nil, domain.ErrProductNotFound
+		}
+		match["product_id"] = objID
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$product_id",
+			"net_change": bson.M{"$sum": exactHistoryField("quantity")},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ProductID primitive.ObjectID `bson:"_id"`
+		NetChange bsonDecimal        `bson:"net_change"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	changes := make(map[string]domain.Decimal, len(rows))
+	for _, row := range rows {
+		changes[row.ProductID.Hex()] = row.NetChange.Decimal
+	}
+	return changes, nil
+}
+
+func (r *mongoStockHistoryRepository) DailyConsumption(ctx context.Context, tenantID, productID string, from, to time.Time) ([]domain.DailyConsumption, error) {
+	match := bson.M{
+		"tenant_id":   tenantID,
+		"operation":   domain.HistoryOperationStockRemove,
+		"reversed_by": bson.M{"$in": bson.A{nil, ""}},
+		"created_at":  bson.M{"$gte": from, "$lt": to},
+	}
+	if productID != "" {
+		objID, err := primitive.ObjectIDFromHex(productID)
+		if err != nil {
+			return nil, domain.ErrProductNotFound
+		}
+		match["product_id"] = objID
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"product_id": "$product_id",
+				"day":        bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at", "timezone": "UTC"}},
+			},
+			// Removals are stored as negative quantities
+			"quantity": bson.M{"$sum": bson.M{"$multiply": bson.A{exactHistoryField("quantity"), -1}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			ProductID primitive.ObjectID `bson:"product_id"`
+			Day       string             `bson:"day"`
+		} `bson:"_id"`
+		Quantity bsonDecimal `bson:"quantity"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	days := make([]domain.DailyConsumption, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.Parse("2006-01-02", row.ID.Day)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+		days = append(days, domain.DailyConsumption{
+			ProductID: row.ID.ProductID.Hex(),
+			Day:       day,
+			Quantity:  row.Quantity.Decimal,
+		})
+	}
+	return days, nil
+}
+
+func (r *mongoStockHistoryRepository) SummarizeAdjustments(ctx context.Context, tenantID string, from, to time.Time) ([]domain.AdjustmentTotals, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"tenant_id":   tenantID,
+			"reason_code": bson.M{"$exists": true, "$ne": ""},
+			"created_at":  bson.M{"$gte": from, "$lt": to},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$reason_code",
+			"adjustments": bson.M{"$sum": 1},
+			"increase":    bson.M{"$sum": bson.M{"$max": bson.A{"$quantity", 0}}},
+			"decrease":    bson.M{"$sum": bson.M{"$max": bson.A{bson.M{"$multiply": bson.A{"$quantity", -1}}, 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ReasonCode  string `bson:"_id"`
+		Adjustments int    `bson:"adjustments"`
+		Increase    int    `bson:"increase"`
+		Decrease    int    `bson:"decrease"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	totals := make([]domain.AdjustmentTotals, 0, len(rows))
+	for _, row := range rows {
+		totals = append(totals, domain.AdjustmentTotals{
+			ReasonCode:  row.ReasonCode,
+			Adjustments: row.Adjustments,
+			Increase:    row.Increase,
+			Decrease:    row.Decrease,
+		})
+	}
+	return totals, nil
 }
@@ -0,0 +1,153 @@
+// internal/infrastructure/persistence/mongo_routing_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type routingRuleDocument struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID    string             `bson:"tenant_id"`
+	Name        string             `bson:"name"`
+	EventType   string             `bson:"event_type"`
+	MinSeverity string             `bson:"min_severity"`
+	ProductTags []string           `bson:"product_tags"`
+	Channels    []string           `bson:"channels"`
+	IsActive    bool               `bson:"is_active"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+func (d routingRuleDocument) toDomain() *domain.RoutingRule {
+	return &domain.RoutingRule{
+		ID:          d.ID.Hex(),
+		TenantID:    d.TenantID,
+		Name:        d.Name,
+		EventType:   d.EventType,
+		MinSeverity: domain.Severity(d.MinSeverity),
+		ProductTags: d.ProductTags,
+		Channels:    d.Channels,
+		IsActive:    d.IsActive,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+// Routing Rule Repository Implementation
+type mongoRoutingRuleRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoRoutingRuleRepository) FindByID(ctx context.Context, tenantID, ruleID string) (*domain.RoutingRule, error) {
+
+	objID, err := primitive.ObjectIDFromHex(ruleID)
+	if err != nil {
+		return nil, domain.ErrRoutingRuleNotFound
+	}
+
+	var result routingRuleDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrRoutingRuleNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoRoutingRuleRepository) FindByTenant(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []routingRuleDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	rules := make([]*domain.RoutingRule, 0, len(results))
+	for _, doc := range results {
+		rules = append(rules, doc.toDomain())
+	}
+	return rules, nil
+}
+
+func (r *mongoRoutingRuleRepository) Create(ctx context.Context, rule *domain.RoutingRule) error {
+
+	document := routingRuleDocument{
+		ID:          primitive.NewObjectID(),
+		TenantID:    rule.TenantID,
+		Name:        rule.Name,
+		EventType:   rule.EventType,
+		MinSeverity: string(rule.MinSeverity),
+		ProductTags: rule.ProductTags,
+		Channels:    rule.Channels,
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return err
+	}
+	rule.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoRoutingRuleRepository) Update(ctx context.Context, rule *domain.RoutingRule) error {
+
+	objID, err := primitive.ObjectIDFromHex(rule.ID)
+	if err != nil {
+		return domain.ErrRoutingRuleNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         rule.Name,
+			"event_type":   rule.EventType,
+			"min_severity": string(rule.MinSeverity),
+			"product_tags": rule.ProductTags,
+			"channels":     rule.Channels,
+			"is_active":    rule.IsActive,
+			"updated_at":   rule.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": rule.TenantID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrRoutingRuleNotFound
+	}
+	return nil
+}
+
+func (r *mongoRoutingRuleRepository) Delete(ctx context.Context, tenantID, ruleID string) error {
+
+	objID, err := primitive.ObjectIDFromHex(ruleID)
+	if err != nil {
+		return domain.ErrRoutingRuleNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrRoutingRuleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// internal/infrastructure/persistence/mongo_stock_change_request_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type stockChangeRequestDocument struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID     string             `bson:"tenant_id"`
+	ProductID    string             `bson:"product_id"`
+	ProductName  string             `bson:"product_name"`
+	Operation    string             `bson:"operation"`
+	Quantity     int                `bson:"quantity"`
+	Notes        string             `bson:"notes,omitempty"`
+	Status       string             `bson:"status"`
+	RequestedBy  string             `bson:"requested_by"`
+	RequestedAt  time.Time          `bson:"requested_at"`
+	ExpiresAt    time.Time          `bson:"expires_at"`
+	DecidedBy    string             `bson:"decided_by,omitempty"`
+	DecidedAt    time.Time          `bson:"decided_at,omitempty"`
+	DecisionNote string             `bson:"decision_note,omitempty"`
+	Unit         string             `bson:"unit,omitempty"`
+	SupplierID   string             `bson:"supplier_id,omitempty"`
+	// Minor units
+	UnitCost *int64 `bson:"unit_cost,omitempty"`
+	// Exact quantity of a decimal-mode product
+	DecimalQuantity *bsonDecimal `bson:"decimal_quantity,omitempty"`
+}
+
+func (d stockChangeRequestDocument) toDomain() *domain.StockChangeRequest {
+	request := &domain.StockChangeRequest{
+		ID:           d.ID.Hex(),
+		TenantID:     d.TenantID,
+		ProductID:    d.ProductID,
+		ProductName:  d.ProductName,
+		Operation:    d.Operation,
+		Quantity:     d.Quantity,
+		Notes:        d.Notes,
+		Status:       d.Status,
+		RequestedBy:  d.RequestedBy,
+		RequestedAt:  d.RequestedAt,
+		ExpiresAt:    d.ExpiresAt,
+		DecidedBy:    d.DecidedBy,
+		DecidedAt:    d.DecidedAt,
+		DecisionNote: d.DecisionNote,
+		Unit:         d.Unit,
+		SupplierID:   d.SupplierID,
+		UnitCost:     toMoney(d.UnitCost),
+	}
+	if d.DecimalQuantity != nil {
+		request.DecimalQuantity = &d.DecimalQuantity.Decimal
+	}
+	return request
+}
+
+// Stock Change Request Repository Implementation
+type mongoStockChangeRequestRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoStockChangeRequestRepository) Create(ctx context.Context, request *domain.StockChangeRequest) error {
+
+	document := stockChangeRequestDocument{
+		ID:          primitive.NewObjectID(),
+		TenantID:    request.TenantID,
+		ProductID:   request.ProductID,
+		ProductName: request.ProductName,
+		Operation:   request.Operation,
+		Quantity:    request.Quantity,
+		Notes:       request.Notes,
+		Status:      request.Status,
+		RequestedBy: request.RequestedBy,
+		RequestedAt: request.RequestedAt,
+		ExpiresAt:   request.ExpiresAt,
+		Unit:        request.Unit,
+		SupplierID:  request.SupplierID,
+
+		DecimalQuantity: optionalDecimal(request.DecimalQuantity),
+	}
+	if request.UnitCost != nil {
+		minor := request.UnitCost.Minor()
+		document.UnitCost = &minor
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	request.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoStockChangeRequestRepository) FindByID(ctx context.Context, tenantID, requestID string) (*domain.StockChangeRequest, error) {
+
+	objID, err := primitive.ObjectIDFromHex(requestID)
+	if err != nil {
+		return nil, domain.ErrChangeRequestNotFound
+	}
+
+	var result stockChangeRequestDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrChangeRequestNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoStockChangeRequestRepository) FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.StockChangeRequest, error) {
+
+	filter := bson.M{"tenant_id": tenantID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "requested_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []stockChangeRequestDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	requests := make([]*domain.StockChangeRequest, 0, len(results))
+	for _, doc := range results {
+		requests = append(requests, doc.toDomain())
+	}
+	return requests, nil
+}
+
+func (r *mongoStockChangeRequestRepository) Decide(ctx context.Context, request *domain.StockChangeRequest) error {
+
+	objID, err := primitive.ObjectIDFromHex(request.ID)
+	if err != nil {
+		return domain.ErrChangeRequestNotFound
+	}
+
+	filter := bson.M{"_id": objID, "tenant_id": request.TenantID, "status": domain.ChangeRequestPending}
+	update := bson.M{"$set": bson.M{
+		"status":        request.Status,
+		"decided_by":    request.DecidedBy,
+		"decided_at":    request.DecidedAt,
+		"decision_note": request.DecisionNote,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrChangeRequestClosed
+	}
+	return nil
+}
+
+func (r *mongoStockChangeRequestRepository) ExpirePending(ctx context.Context, now time.Time) (int64, error) {
+
+	filter := bson.M{"status": domain.ChangeRequestPending, "expires_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"status": domain.ChangeRequestExpired, "decided_at": now}}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,186 @@
+// internal/infrastructure/persistence/mongo_stock_ledger_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type stockLedgerDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	ProductID  string             `bson:"product_id"`
+	TenantID   string             `bson:"tenant_id"`
+	Sequence   int64              `bson:"sequence"`
+	Type       string             `bson:"type"`
+	Delta      int                `bson:"delta"`
+	Actor      string             `bson:"actor"`
+	Notes      string             `bson:"notes"`
+	OccurredAt time.Time          `bson:"occurred_at"`
+}
+
+func (d stockLedgerDocument) toDomain() domain.StockLedgerEntry {
+	return domain.StockLedgerEntry{
+		ID:         d.ID.Hex(),
+		ProductID:  d.ProductID,
+		TenantID:   d.TenantID,
+		Sequence:   d.Sequence,
+		Type:       d.Type,
+		Delta:      d.Delta,
+		Actor:      d.Actor,
+		Notes:      d.Notes,
+		OccurredAt: d.OccurredAt,
+	}
+}
+
+type stockSnapshotDocument struct {
+	ProductID string    `bson:"product_id"`
+	TenantID  string    `bson:"tenant_id"`
+	Stock     int       `bson:"stock"`
+	Version   int64     `bson:"version"`
+	TakenAt   time.Time `bson:"taken_at"`
+}
+
+// Stock Ledger Repository Implementation
+type mongoStockLedgerRepository struct {
+	collection *mongo.Collection
+	snapshots  *mongo.Collection
+}
+
+// EnsureStockLedgerIndexes creates the unique (product_id, sequence) index
+// that turns concurrent appends into duplicate key errors.
+func EnsureStockLedgerIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("stock_ledger").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "sequence", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	_, err = db.Collection("stock_snapshots").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "version", Value: -1}},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoStockLedgerRepository) Append(ctx context.Context, entry *domain.StockLedgerEntry) error {
+
+	id := primitive.NewObjectID()
+	document := stockLedgerDocument{
+		ID:         id,
+		ProductID:  entry.ProductID,
+		TenantID:   entry.TenantID,
+		Sequence:   entry.Sequence,
+		Type:       entry.Type,
+		Delta:      entry.Delta,
+		Actor:      entry.Actor,
+		Notes:      entry.Notes,
+		OccurredAt: entry.OccurredAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrConcurrentStockUpdate
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	entry.ID = id.Hex()
+	return nil
+}
+
+func (r *mongoStockLedgerRepository) FindByProduct(ctx context.Context, productID string, afterSequence int64) ([]domain.StockLedgerEntry, error) {
+
+	filter := bson.M{"product_id": productID, "sequence": bson.M{"$gt": afterSequence}}
+	opts := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []stockLedgerDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	entries := make([]domain.StockLedgerEntry, 0, len(docs))
+	for _, d := range docs {
+		entries = append(entries, d.toDomain())
+	}
+	return entries, nil
+}
+
+func (r *mongoStockLedgerRepository) FindProductIDs(ctx context.Context, tenantID string) ([]string, error) {
+
+	values, err := r.collection.Distinct(ctx, "product_id", bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *mongoStockLedgerRepository) LatestSnapshot(ctx context.Context, productID string) (*domain.StockSnapshot, error) {
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var result stockSnapshotDocument
+	err := r.snapshots.FindOne(ctx, bson.M{"product_id": productID}, opts).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &domain.StockSnapshot{
+		ProductID: result.ProductID,
+		TenantID:  result.TenantID,
+		Stock:     result.Stock,
+		Version:   result.Version,
+		TakenAt:   result.TakenAt,
+	}, nil
+}
+
+func (r *mongoStockLedgerRepository) SaveSnapshot(ctx context.Context, snapshot domain.StockSnapshot) error {
+
+	document := stockSnapshotDocument{
+		ProductID: snapshot.ProductID,
+		TenantID:  snapshot.TenantID,
+		Stock:     snapshot.Stock,
+		Version:   snapshot.Version,
+		TakenAt:   snapshot.TakenAt,
+	}
+
+	filter := bson.M{"product_id": snapshot.ProductID, "version": snapshot.Version}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.snapshots.ReplaceOne(ctx, filter, document, opts); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoStockLedgerRepository) DeleteSnapshots(ctx context.Context, productID string) error {
+
+	if _, err := r.snapshots.DeleteMany(ctx, bson.M{"product_id": productID}); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
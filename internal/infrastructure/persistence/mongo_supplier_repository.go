@@ -0,0 +1,217 @@
+// internal/infrastructure/persistence/mongo_supplier_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type supplierProductDocument struct {
+	ProductID        string `bson:"product_id"`
+	SupplierSKU      string `bson:"supplier_sku,omitempty"`
+	LeadTimeDays     int    `bson:"lead_time_days"`
+	MinOrderQuantity int    `bson:"min_order_quantity"`
+	PackSize         int    `bson:"pack_size"`
+}
+
+type supplierDocument struct {
+	ID          primitive.ObjectID        `bson:"_id,omitempty"`
+	TenantID    string                    `bson:"tenant_id"`
+	Name        string                    `bson:"name"`
+	ContactName string                    `bson:"contact_name,omitempty"`
+	Email       string                    `bson:"email,omitempty"`
+	Phone       string                    `bson:"phone,omitempty"`
+	Notes       string                    `bson:"notes,omitempty"`
+	IsActive    bool                      `bson:"is_active"`
+	Products    []supplierProductDocument `bson:"products"`
+	CreatedAt   time.Time                 `bson:"created_at"`
+	UpdatedAt   time.Time                 `bson:"updated_at"`
+}
+
+func toSupplierProductDocuments(products []domain.SupplierProduct) []supplierProductDocument {
+	docs := make([]supplierProductDocument, 0, len(products))
+	for _, p := range products {
+		docs = append(docs, supplierProductDocument{
+			ProductID:        p.ProductID,
+			SupplierSKU:      p.SupplierSKU,
+			LeadTimeDays:     p.LeadTimeDays,
+			MinOrderQuantity: p.MinOrderQuantity,
+			PackSize:         p.PackSize,
+		})
+	}
+	return docs
+}
+
+func (d supplierDocument) toDomain() *domain.Supplier {
+	products := make([]domain.SupplierProduct, 0, len(d.Products))
+	for _, p := range d.Products {
+		products = append(products, domain.SupplierProduct{
+			ProductID:        p.ProductID,
+			SupplierSKU:      p.SupplierSKU,
+			LeadTimeDays:     p.LeadTimeDays,
+			MinOrderQuantity: p.MinOrderQuantity,
+			PackSize:         p.PackSize,
+		})
+	}
+	return &domain.Supplier{
+		ID:          d.ID.Hex(),
+		TenantID:    d.TenantID,
+		Name:        d.Name,
+		ContactName: d.ContactName,
+		Email:       d.Email,
+		Phone:       d.Phone,
+		Notes:       d.Notes,
+		IsActive:    d.IsActive,
+		Products:    products,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+// Supplier Repository Implementation
+type mongoSupplierRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoSupplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
+
+	document := supplierDocument{
+		ID:          primitive.NewObjectID(),
+		TenantID:    supplier.TenantID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Email:       supplier.Email,
+		Phone:       supplier.Phone,
+		Notes:       supplier.Notes,
+		IsActive:    supplier.IsActive,
+		Products:    toSupplierProductDocuments(supplier.Products),
+		CreatedAt:   supplier.CreatedAt,
+		UpdatedAt:   supplier.UpdatedAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	supplier.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoSupplierRepository) FindByID(ctx context.Context, tenantID, supplierID string) (*domain.Supplier, error) {
+
+	objID, err := primitive.ObjectIDFromHex(supplierID)
+	if err != nil {
+		return nil, domain.ErrSupplierNotFound
+	}
+
+	var result supplierDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoSupplierRepository) FindByTenant(ctx context.Context, tenantID, productID string) ([]*domain.Supplier, error) {
+
+	filter := bson.M{"tenant_id": tenantID}
+	if productID != "" {
+		filter["products.product_id"] = productID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []supplierDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	suppliers := make([]*domain.Supplier, 0, len(results))
+	for _, doc := range results {
+		suppliers = append(suppliers, doc.toDomain())
+	}
+	return suppliers, nil
+}
+
+func (r *mongoSupplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
+
+	objID, err := primitive.ObjectIDFromHex(supplier.ID)
+	if err != nil {
+		return domain.ErrSupplierNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         supplier.Name,
+			"contact_name": supplier.ContactName,
+			"email":        supplier.Email,
+			"phone":        supplier.Phone,
+			"notes":        supplier.Notes,
+			"is_active":    supplier.IsActive,
+			"products":     toSupplierProductDocuments(supplier.Products),
+			"updated_at":   supplier.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": supplier.TenantID}, update)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrSupplierNotFound
+	}
+	return nil
+}
+
+func (r *mongoSupplierRepository) Delete(ctx context.Context, tenantID, supplierID string) error {
+
+	objID, err := primitive.ObjectIDFromHex(supplierID)
+	if err != nil {
+		return domain.ErrSupplierNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrSupplierNotFound
+	}
+	return nil
+}
+
+// EnsureSupplierIndexes creates the indexes listing a tenant's suppliers,
+// by name and by supplied product, and the one reporting history per
+// supplier
+func EnsureSupplierIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("suppliers").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: 1}}},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "products.product_id", Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	_, err = db.Collection("stock_history").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "supplier_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"supplier_id": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
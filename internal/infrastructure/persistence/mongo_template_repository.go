@@ -0,0 +1,101 @@
+// internal/infrastructure/persistence/mongo_template_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationTemplateDocument struct {
+	TenantID  string    `bson:"tenant_id"`
+	Name      string    `bson:"name"`
+	Body      string    `bson:"body"`
+	UpdatedBy string    `bson:"updated_by"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func (d notificationTemplateDocument) toDomain() *domain.NotificationTemplate {
+	return &domain.NotificationTemplate{
+		TenantID:  d.TenantID,
+		Name:      d.Name,
+		Body:      d.Body,
+		UpdatedBy: d.UpdatedBy,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// Notification Template Repository Implementation
+type mongoNotificationTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoNotificationTemplateRepository) FindByName(ctx context.Context, tenantID, name string) (*domain.NotificationTemplate, error) {
+
+	var result notificationTemplateDocument
+	err := r.collection.FindOne(ctx, bson.M{"tenant_id": tenantID, "name": name}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoNotificationTemplateRepository) FindByTenant(ctx context.Context, tenantID string) ([]*domain.NotificationTemplate, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []notificationTemplateDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	templates := make([]*domain.NotificationTemplate, 0, len(results))
+	for _, doc := range results {
+		templates = append(templates, doc.toDomain())
+	}
+	return templates, nil
+}
+
+func (r *mongoNotificationTemplateRepository) Save(ctx context.Context, template *domain.NotificationTemplate) error {
+
+	update := bson.M{
+		"$set": bson.M{
+			"body":       template.Body,
+			"updated_by": template.UpdatedBy,
+			"updated_at": template.UpdatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"tenant_id": template.TenantID, "name": template.Name},
+		update,
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *mongoNotificationTemplateRepository) Delete(ctx context.Context, tenantID, name string) error {
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"tenant_id": tenantID, "name": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrTemplateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// internal/infrastructure/persistence/mongo_webhook_repository.go
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookSubscriptionDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID   string             `bson:"tenant_id"`
+	URL        string             `bson:"url"`
+	Secret     string             `bson:"secret"`
+	EventTypes []string           `bson:"event_types"`
+	IsActive   bool               `bson:"is_active"`
+	CreatedAt  time.Time          `bson:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+}
+
+func (d webhookSubscriptionDocument) toDomain() *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:         d.ID.Hex(),
+		TenantID:   d.TenantID,
+		URL:        d.URL,
+		Secret:     d.Secret,
+		EventTypes: d.EventTypes,
+		IsActive:   d.IsActive,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+// Webhook Subscription Repository Implementation
+type mongoWebhookSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoWebhookSubscriptionRepository) FindByID(ctx context.Context, tenantID, subscriptionID string) (*domain.WebhookSubscription, error) {
+
+	objID, err := primitive.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	var result webhookSubscriptionDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *mongoWebhookSubscriptionRepository) FindByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenant_id": tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []webhookSubscriptionDocument
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	subs := make([]*domain.WebhookSubscription, 0, len(results))
+	for _, doc := range results {
+		subs = append(subs, doc.toDomain())
+	}
+	return subs, nil
+}
+
+func (r *mongoWebhookSubscriptionRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+
+	document := webhookSubscriptionDocument{
+		ID:         primitive.NewObjectID(),
+		TenantID:   sub.TenantID,
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		EventTypes: sub.EventTypes,
+		IsActive:   sub.IsActive,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, document); err != nil {
+		return err
+	}
+	sub.ID = document.ID.Hex()
+	return nil
+}
+
+func (r *mongoWebhookSubscriptionRepository) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+
+	objID, err := primitive.ObjectIDFromHex(sub.ID)
+	if err != nil {
+		return domain.ErrWebhookNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"url":         sub.URL,
+			"secret":      sub.Secret,
+			"event_types": sub.EventTypes,
+			"is_active":   sub.IsActive,
+			"updated_at":  sub.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": sub.TenantID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *mongoWebhookSubscriptionRepository) Delete(ctx context.Context, tenantID, subscriptionID string) error {
+
+	objID, err := primitive.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return domain.ErrWebhookNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenantID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Webhook Delivery Repository Implementation
+type mongoWebhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func (r *mongoWebhookDeliveryRepository) Create(ctx context.Context, delivery domain.WebhookDelivery) error {
+
+	document := bson.M{
+		"subscription_id": delivery.SubscriptionID,
+		"tenant_id":       delivery.TenantID,
+		"event_id":        delivery.EventID,
+		"event_type":      delivery.EventType,
+		"attempt":         delivery.Attempt,
+		"status_code":     delivery.StatusCode,
+		"success":         delivery.Success,
+		"error":           delivery.Error,
+		"duration_ms":     delivery.Duration.Milliseconds(),
+		"delivered_at":    delivery.DeliveredAt,
+	}
+
+	_, err := r.collection.InsertOne(ctx, document)
+	return err
+}
+
+func (r *mongoWebhookDeliveryRepository) FindBySubscription(ctx context.Context, subscriptionID string, limit int) ([]domain.WebhookDelivery, error) {
+
+	opts := options.Find().SetSort(bson.M{"delivered_at": -1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"subscription_id": subscriptionID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var results []struct {
+		ID             primitive.ObjectID `bson:"_id"`
+		SubscriptionID string             `bson:"subscription_id"`
+		TenantID       string             `bson:"tenant_id"`
+		EventID        string             `bson:"event_id"`
+		EventType      string             `bson:"event_type"`
+		Attempt        int                `bson:"attempt"`
+		StatusCode     int                `bson:"status_code"`
+		Success        bool               `bson:"success"`
+		Error          string             `bson:"error"`
+		DurationMS     int64              `bson:"duration_ms"`
+		DeliveredAt    time.Time          `bson:"delivered_at"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(results))
+	for _, d := range results {
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			ID:             d.ID.Hex(),
+			SubscriptionID: d.SubscriptionID,
+			TenantID:       d.TenantID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Attempt:        d.Attempt,
+			StatusCode:     d.StatusCode,
+			Success:        d.Success,
+			Error:          d.Error,
+			Duration:       time.Duration(d.DurationMS) * time.Millisecond,
+			DeliveredAt:    d.DeliveredAt,
+		})
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,67 @@
+// internal/infrastructure/services/notification_channels.go
+package services
+
+import (
+	"context"
+	"log"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+type slackChannel struct {
+	webhookURL string
+}
+
+func NewSlackChannel(webhookURL string) interfaces.NotificationChannel {
+	return &slackChannel{webhookURL: webhookURL}
+}
+
+func (c *slackChannel) Send(ctx context.Context, notification domain.Notification) error {
+	log.Printf("Sending to Slack: %s", notification.Message)
+	// Actual HTTP call to Slack would go here
+	return nil
+}
+
+type emailChannel struct {
+	serviceURL string
+}
+
+func NewEmailChannel(serviceURL string) interfaces.NotificationChannel {
+	return &emailChannel{serviceURL: serviceURL}
+}
+
+func (c *emailChannel) Send(ctx context.Context, notification domain.Notification) error {
+	log.Printf("Sending email: %s", notification.Message)
+	// Actual email sending logic
+	return nil
+}
+
+// Forwards notifications to tenant webhooks subscribed to "notification" events
+type webhookChannel struct {
+	publisher interfaces.EventPublisher
+}
+
+func NewWebhookChannel(publisher interfaces.EventPublisher) interfaces.NotificationChannel {
+	return &webhookChannel{publisher: publisher}
+}
+
+func (c *webhookChannel) Send(ctx context.Context, notification domain.Notification) error {
+	event, err := domain.NewCloudEvent(notification, notification.TenantID)
+	if err != nil {
+		return err
+	}
+	return c.publisher.Publish(ctx, event)
+}
+
+type logChannel struct{}
+
+func NewLogChannel() interfaces.NotificationChannel {
+	return &logChannel{}
+}
+
+func (c *logChannel) Send(ctx context.Context, notification domain.Notification) error {
+	log.Printf("[%s] %s for tenant %s: %s",
+		notification.Severity, notification.AlertType, notification.TenantID, notification.Message)
+	return nil
+}
@@ -0,0 +1,126 @@
+// internal/infrastructure/services/routing_notification_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Fans notifications out to channels according to the tenant's routing rules
+type routingNotificationService struct {
+	uow      interfaces.UnitOfWork
+	renderer interfaces.TemplateRenderer
+	channels map[string]interfaces.NotificationChannel
+}
+
+func NewRoutingNotificationService(
+	uow interfaces.UnitOfWork,
+	renderer interfaces.TemplateRenderer,
+	channels map[string]interfaces.NotificationChannel,
+) interfaces.NotificationService {
+	return &routingNotificationService{
+		uow:      uow,
+		renderer: renderer,
+		channels: channels,
+	}
+}
+
+func (s *routingNotificationService) SendStockAlert(ctx context.Context, event domain.StockLimitAlertEvent) error {
+	alert := domain.AlertContext{
+		EventType:   event.EventType(),
+		Severity:    event.Severity(),
+		ProductTags: event.ProductTags,
+	}
+	base := domain.Notification{
+		TenantID:    event.TenantID,
+		AlertType:   alert.EventType,
+		Severity:    alert.Severity,
+		ProductID:   event.ProductID,
+		ProductName: event.ProductName,
+		Timestamp:   event.Timestamp,
+	}
+	data := StockAlertTemplateData{
+		ProductID:   event.ProductID,
+		ProductName: event.ProductName,
+		Current:     event.Current.Value(),
+		MaxLimit:    event.MaxLimit.Value(),
+		Utilization: event.Utilization,
+		TenantID:    event.TenantID,
+		Timestamp:   event.Timestamp,
+	}
+	return s.route(ctx, alert, base, data)
+}
+
+func (s *routingNotificationService) SendLowStockAlert(ctx context.Context, product *domain.Product, threshold int) error {
+	alert := domain.AlertContext{
+		EventType:   domain.EventTypeLowStock,
+		Severity:    domain.SeverityWarning,
+		ProductTags: product.Tags,
+	}
+	base := domain.Notification{
+		TenantID:    product.TenantID,
+		AlertType:   alert.EventType,
+		Severity:    alert.Severity,
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Timestamp:   time.Now(),
+	}
+	data := LowStockTemplateData{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Current:     product.CurrentStock.Value(),
+		Threshold:   threshold,
+		TenantID:    product.TenantID,
+	}
+	return s.route(ctx, alert, base, data)
+}
+
+func (s *routingNotificationService) route(ctx context.Context, alert domain.AlertContext, base domain.Notification, data interface{}) error {
+	rules, err := s.uow.RoutingRules().FindByTenant(ctx, base.TenantID)
+	if err != nil {
+		// Alerts still go out through the defaults if rules can't be loaded
+		log.Printf("Loading routing rules for tenant %s failed, using defaults: %v", base.TenantID, err)
+		rules = nil
+	}
+	if len(rules) == 0 {
+		rules = domain.DefaultRoutingRules()
+	}
+
+	var errs []error
+	for _, name := range domain.ResolveChannels(rules, alert) {
+		channel, ok := s.channels[name]
+		if !ok {
+			log.Printf("Notification channel %s is not configured", name)
+			continue
+		}
+
+		message, err := s.renderer.Render(ctx, base.TenantID, templateFor(alert.EventType, name), data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+
+		notification := base
+		notification.Message = message
+		if err := channel.Send(ctx, notification); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func templateFor(eventType, channel string) string {
+	if eventType == domain.EventTypeLowStock {
+		return domain.TemplateLowStockAlert
+	}
+	if channel == domain.ChannelEmail {
+		return domain.TemplateStockAlertEmail
+	}
+	return domain.TemplateStockAlertSlack
+}
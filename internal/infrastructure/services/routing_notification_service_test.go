@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+)
+
+// recordingChannel captures notifications sent through it.
+type recordingChannel struct {
+	sent []domain.Notification
+	err  error
+}
+
+func (c *recordingChannel) Send(ctx context.Context, n domain.Notification) error {
+	c.sent = append(c.sent, n)
+	return c.err
+}
+
+func newRoutingService(rules ...*domain.RoutingRule) (interfaces.NotificationService, map[string]*recordingChannel) {
+	uow := &mocks.MockUnitOfWork{
+		TemplatesRepo: &mocks.MockNotificationTemplateRepo{},
+		RoutingRepo:   &mocks.MockRoutingRuleRepo{Rules: rules},
+	}
+	recorders := map[string]*recordingChannel{}
+	channels := map[string]interfaces.NotificationChannel{}
+	for _, name := range []string{domain.ChannelSlack, domain.ChannelEmail, domain.ChannelWebhook, domain.ChannelLog} {
+		recorders[name] = &recordingChannel{}
+		channels[name] = recorders[name]
+	}
+	return NewRoutingNotificationService(uow, NewTemplateRenderer(uow), channels), recorders
+}
+
+func alertEvent(utilization float64, tags ...string) domain.StockLimitAlertEvent {
+	current, _ := domain.NewStockQuantity(int(utilization))
+	max, _ := domain.NewStockQuantity(100)
+	return domain.StockLimitAlertEvent{
+		ProductID: "p1", ProductName: "Widget", Current: current, MaxLimit: max,
+		Utilization: utilization, TenantID: "t1", Timestamp: time.Now(), ProductTags: tags,
+	}
+}
+
+func sentCounts(recorders map[string]*recordingChannel) map[string]int {
+	counts := map[string]int{}
+	for name, r := range recorders {
+		counts[name] = len(r.sent)
+	}
+	return counts
+}
+
+func TestRoutingNotificationService_DefaultRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		utilization float64
+		want        map[string]int
+	}{
+		{"warning goes to slack", 85, map[string]int{domain.ChannelSlack: 1, domain.ChannelEmail: 0, domain.ChannelWebhook: 0, domain.ChannelLog: 0}},
+		{"critical adds email", 95, map[string]int{domain.ChannelSlack: 1, domain.ChannelEmail: 1, domain.ChannelWebhook: 0, domain.ChannelLog: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, recorders := newRoutingService()
+			if err := svc.SendStockAlert(context.Background(), alertEvent(tt.utilization)); err != nil {
+				t.Fatalf("SendStockAlert() err = %v", err)
+			}
+			if got := sentCounts(recorders); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	svc, recorders := newRoutingService()
+	_ = svc.SendStockAlert(context.Background(), alertEvent(95))
+	if msg := recorders[domain.ChannelEmail].sent[0].Message; msg != "CRITICAL: Product Widget is at 95% capacity (95/100)" {
+		t.Errorf("email message = %q", msg)
+	}
+}
+
+func TestRoutingNotificationService_TenantRules(t *testing.T) {
+	rules := []*domain.RoutingRule{
+		{ID: "r1", TenantID: "t1", ProductTags: []string{"fragile"}, Channels: []string{domain.ChannelWebhook}, IsActive: true},
+		{ID: "r2", TenantID: "t1", EventType: domain.EventTypeLowStock, Channels: []string{domain.ChannelLog}, IsActive: true},
+		{ID: "r3", TenantID: "t1", MinSeverity: domain.SeverityCritical, Channels: []string{domain.ChannelEmail}, IsActive: false},
+	}
+	svc, recorders := newRoutingService(rules...)
+	ctx := context.Background()
+
+	_ = svc.SendStockAlert(ctx, alertEvent(95, "fragile"))
+	_ = svc.SendStockAlert(ctx, alertEvent(95, "sturdy"))
+	stock, _ := domain.NewStockQuantity(3)
+	_ = svc.SendLowStockAlert(ctx, &domain.Product{ID: "p2", Name: "Bolt", CurrentStock: stock, TenantID: "t1"}, 10)
+
+	want := map[string]int{domain.ChannelSlack: 0, domain.ChannelEmail: 0, domain.ChannelWebhook: 1, domain.ChannelLog: 1}
+	if got := sentCounts(recorders); !reflect.DeepEqual(got, want) {
+		t.Errorf("sent = %v, want %v", got, want)
+	}
+	n := recorders[domain.ChannelLog].sent[0]
+	if n.AlertType != domain.EventTypeLowStock || n.Severity != domain.SeverityWarning || n.ProductID != "p2" {
+		t.Errorf("low stock notification = %+v", n)
+	}
+}
+
+func TestRoutingNotificationService_ChannelErrorDoesNotStopFanOut(t *testing.T) {
+	svc, recorders := newRoutingService()
+	errSlack := errors.New("slack down")
+	recorders[domain.ChannelSlack].err = errSlack
+
+	err := svc.SendStockAlert(context.Background(), alertEvent(95))
+	if !errors.Is(err, errSlack) {
+		t.Errorf("SendStockAlert() err = %v, want %v", err, errSlack)
+	}
+	if len(recorders[domain.ChannelEmail].sent) != 1 {
+		t.Errorf("email should still be sent after slack failure")
+	}
+}
@@ -0,0 +1,63 @@
+// internal/infrastructure/services/spreadsheet_reader.go
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Byte order mark some spreadsheet programs write at the start of CSV files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type spreadsheetReader struct{}
+
+// NewSpreadsheetReader reads CSV files and the first sheet of XLSX files
+func NewSpreadsheetReader() interfaces.SpreadsheetReader {
+	return spreadsheetReader{}
+}
+
+func (spreadsheetReader) Read(format string, data []byte) ([][]string, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return readCSV(data)
+	case domain.ImportFormatXLSX:
+		return readXLSX(data)
+	}
+	return nil, domain.ErrUnsupportedImportFormat
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	// Packing lists do not always pad short rows
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, domain.ErrInvalidImportFile{Reason: err.Error()}
+	}
+	return records, nil
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidImportFile{Reason: err.Error()}
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, domain.ErrInvalidImportFile{Reason: "workbook has no sheets"}
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, domain.ErrInvalidImportFile{Reason: fmt.Sprintf("sheet %s: %v", sheets[0], err)}
+	}
+	return rows, nil
+}
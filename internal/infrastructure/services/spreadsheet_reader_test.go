@@ -0,0 +1,60 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"myapp/internal/domain"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSpreadsheetReader_Read_CSV(t *testing.T) {
+	data := append([]byte{0xEF, 0xBB, 0xBF}, []byte("product_id,quantity,notes\np1, 5,first\np2,7\n")...)
+
+	got, err := NewSpreadsheetReader().Read(domain.ImportFormatCSV, data)
+	if err != nil {
+		t.Fatalf("Read() err = %v", err)
+	}
+	want := [][]string{{"product_id", "quantity", "notes"}, {"p1", "5", "first"}, {"p2", "7"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %q, want %q", got, want)
+	}
+}
+
+func TestSpreadsheetReader_Read_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	_ = f.SetSheetRow(sheet, "A1", &[]interface{}{"SKU", "Qty"})
+	_ = f.SetSheetRow(sheet, "A2", &[]interface{}{"p1", 12})
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatalf("write workbook: %v", err)
+	}
+
+	got, err := NewSpreadsheetReader().Read(domain.ImportFormatXLSX, buf.Bytes())
+	if err != nil {
+		t.Fatalf("Read() err = %v", err)
+	}
+	want := [][]string{{"SKU", "Qty"}, {"p1", "12"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %q, want %q", got, want)
+	}
+}
+
+func TestSpreadsheetReader_Read_Errors(t *testing.T) {
+	reader := NewSpreadsheetReader()
+
+	if _, err := reader.Read("ods", []byte("x")); err != domain.ErrUnsupportedImportFormat {
+		t.Errorf("ods err = %v, want %v", err, domain.ErrUnsupportedImportFormat)
+	}
+	var invalid domain.ErrInvalidImportFile
+	if _, err := reader.Read(domain.ImportFormatXLSX, []byte("not a workbook")); !errors.As(err, &invalid) {
+		t.Errorf("xlsx err = %v, want ErrInvalidImportFile", err)
+	}
+	if _, err := reader.Read(domain.ImportFormatCSV, []byte("a,\"b\n")); !errors.As(err, &invalid) {
+		t.Errorf("csv err = %v, want ErrInvalidImportFile", err)
+	}
+}
@@ -0,0 +1,206 @@
+// internal/infrastructure/services/table_encoder.go
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Rows buffered per Parquet row group; bounds the memory an export holds
+const parquetRowGroupSize = 10000
+
+type tableEncoder struct{}
+
+// NewTableEncoder writes CSV, NDJSON (one JSON object per line) and Parquet
+func NewTableEncoder() interfaces.TableEncoder {
+	return tableEncoder{}
+}
+
+func (tableEncoder) NewWriter(format string, w io.Writer, columns []domain.ExportColumn) (interfaces.TableWriter, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return newCSVTableWriter(w, columns)
+	case domain.ExportFormatNDJSON:
+		return &ndjsonTableWriter{enc: json.NewEncoder(w), columns: columns}, nil
+	case domain.ExportFormatParquet:
+		return newParquetTableWriter(w, columns), nil
+	}
+	return nil, domain.ErrUnsupportedExportFormat
+}
+
+// CSV: a header row, then times as RFC 3339
+type csvTableWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVTableWriter(w io.Writer, columns []domain.ExportColumn) (*csvTableWriter, error) {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvTableWriter{w: cw, record: make([]string, len(columns))}, nil
+}
+
+func (t *csvTableWriter) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		switch v := v.(type) {
+		case string:
+			t.record[i] = v
+		case int:
+			t.record[i] = strconv.Itoa(v)
+		case float64:
+			t.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case time.Time:
+			t.record[i] = formatExportTime(v)
+		default:
+			t.record[i] = fmt.Sprint(v)
+		}
+	}
+	return t.w.Write(t.record)
+}
+
+func (t *csvTableWriter) Close() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+type ndjsonTableWriter struct {
+	enc     *json.Encoder
+	columns []domain.ExportColumn
+}
+
+func (t *ndjsonTableWriter) WriteRow(values []interface{}) error {
+	// An ordered object keeps the columns in export order
+	object := make(orderedObject, len(values))
+	for i, v := range values {
+		if tv, ok := v.(time.Time); ok {
+			v = formatExportTime(tv)
+		}
+		object[i] = keyValue{key: t.columns[i].Name, value: v}
+	}
+	return t.enc.Encode(object)
+}
+
+func (t *ndjsonTableWriter) Close() error {
+	return nil
+}
+
+type keyValue struct {
+	key   string
+	value interface{}
+}
+
+type orderedObject []keyValue
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, kv := range o {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		key, err := json.Marshal(kv.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(kv.value)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, key...)
+		buf = append(buf, ':')
+		buf = append(buf, value...)
+	}
+	return append(buf, '}'), nil
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Parquet: every column is required; times are UTC milliseconds
+type parquetTableWriter struct {
+	w *parquet.Writer
+	// Position of each export column among the schema's leaf columns,
+	// which Parquet orders by name
+	leaf    []int
+	columns []domain.ExportColumn
+	row     parquet.Row
+}
+
+func newParquetTableWriter(w io.Writer, columns []domain.ExportColumn) *parquetTableWriter {
+	group := parquet.Group{}
+	for _, c := range columns {
+		group[c.Name] = parquet.Required(parquetNode(c.Type))
+	}
+	schema := parquet.NewSchema("export", group)
+
+	leafIndex := make(map[string]int)
+	for i, path := range schema.Columns() {
+		leafIndex[path[0]] = i
+	}
+	leaf := make([]int, len(columns))
+	for i, c := range columns {
+		leaf[i] = leafIndex[c.Name]
+	}
+
+	return &parquetTableWriter{
+		w:       parquet.NewWriter(w, schema, parquet.MaxRowsPerRowGroup(parquetRowGroupSize)),
+		leaf:    leaf,
+		columns: columns,
+		row:     make(parquet.Row, len(columns)),
+	}
+}
+
+func parquetNode(columnType string) parquet.Node {
+	switch columnType {
+	case domain.ExportColumnInt:
+		return parquet.Int(64)
+	case domain.ExportColumnFloat:
+		return parquet.Leaf(parquet.DoubleType)
+	case domain.ExportColumnTime:
+		return parquet.Timestamp(parquet.Millisecond)
+	}
+	return parquet.String()
+}
+
+func (t *parquetTableWriter) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		var value parquet.Value
+		switch v := v.(type) {
+		case string:
+			value = parquet.ByteArrayValue([]byte(v))
+		case int:
+			value = parquet.Int64Value(int64(v))
+		case float64:
+			value = parquet.DoubleValue(v)
+		case time.Time:
+			value = parquet.Int64Value(v.UnixMilli())
+		default:
+			return fmt.Errorf("column %s: unsupported value %T", t.columns[i].Name, v)
+		}
+		t.row[t.leaf[i]] = value.Level(0, 0, t.leaf[i])
+	}
+	_, err := t.w.WriteRows([]parquet.Row{t.row})
+	return err
+}
+
+func (t *parquetTableWriter) Close() error {
+	return t.w.Close()
+}
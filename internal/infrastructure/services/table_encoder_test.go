@@ -0,0 +1,94 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"myapp/internal/domain"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+var testExportColumns = []domain.ExportColumn{
+	{Name: "product_id", Type: domain.ExportColumnString},
+	{Name: "current_stock", Type: domain.ExportColumnInt},
+	{Name: "utilization", Type: domain.ExportColumnFloat},
+	{Name: "last_updated", Type: domain.ExportColumnTime},
+}
+
+var testExportTime = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+func encodeTestTable(t *testing.T, format string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := NewTableEncoder().NewWriter(format, &buf, testExportColumns)
+	if err != nil {
+		t.Fatalf("NewWriter(%s) err = %v", format, err)
+	}
+	rows := [][]interface{}{
+		{"p1", 40, 40.5, testExportTime},
+		{"p,2", 0, 0.0, time.Time{}},
+	}
+	for _, row := range rows {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow() err = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() err = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestTableEncoder_CSV(t *testing.T) {
+	got := string(encodeTestTable(t, domain.ExportFormatCSV))
+
+	want := "product_id,current_stock,utilization,last_updated\n" +
+		"p1,40,40.5,2024-05-01T12:30:00Z\n" +
+		"\"p,2\",0,0,\n"
+	if got != want {
+		t.Errorf("csv = %q, want %q", got, want)
+	}
+}
+
+func TestTableEncoder_NDJSON(t *testing.T) {
+	got := string(encodeTestTable(t, domain.ExportFormatNDJSON))
+
+	want := `{"product_id":"p1","current_stock":40,"utilization":40.5,"last_updated":"2024-05-01T12:30:00Z"}` + "\n" +
+		`{"product_id":"p,2","current_stock":0,"utilization":0,"last_updated":""}` + "\n"
+	if got != want {
+		t.Errorf("ndjson = %q, want %q", got, want)
+	}
+}
+
+func TestTableEncoder_Parquet(t *testing.T) {
+	data := encodeTestTable(t, domain.ExportFormatParquet)
+
+	type row struct {
+		ProductID    string    `parquet:"product_id"`
+		CurrentStock int64     `parquet:"current_stock"`
+		Utilization  float64   `parquet:"utilization"`
+		LastUpdated  time.Time `parquet:"last_updated,timestamp(millisecond)"`
+	}
+	rows, err := parquet.Read[row](bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("read parquet: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+	got := rows[0]
+	if got.ProductID != "p1" || got.CurrentStock != 40 || got.Utilization != 40.5 || !got.LastUpdated.Equal(testExportTime) {
+		t.Errorf("row 0 = %+v", got)
+	}
+	if rows[1].ProductID != "p,2" {
+		t.Errorf("row 1 product = %q, want p,2", rows[1].ProductID)
+	}
+}
+
+func TestTableEncoder_UnsupportedFormat(t *testing.T) {
+	if _, err := NewTableEncoder().NewWriter("xml", &bytes.Buffer{}, testExportColumns); err != domain.ErrUnsupportedExportFormat {
+		t.Errorf("err = %v, want %v", err, domain.ErrUnsupportedExportFormat)
+	}
+}
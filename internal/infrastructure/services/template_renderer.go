@@ -0,0 +1,304 @@
+// internal/infrastructure/services/template_renderer.go
+package services
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+const maxTemplateSize = 4096
+
+// Most a template may render. Loops such as {{range 2000000000}} fit in
+// a small source but would otherwise render until memory runs out.
+const maxRenderedSize = 64 << 10
+
+var errRenderedTooLarge = fmt.Errorf("template output exceeds %d bytes", maxRenderedSize)
+
+// Longest a template may execute. Executions that take longer are
+// abandoned and stop at their next write.
+const maxRenderTime = 250 * time.Millisecond
+
+var errRenderTimeout = fmt.Errorf("template took longer than %s to render", maxRenderTime)
+
+// Template data has no lists, so range could only loop over numbers, and
+// {{range 2000000000}}{{end}} spins without writing anything. Calling
+// templates is refused too: templates that each call the next twice run
+// exponentially long. Without either, running time follows the source.
+var errActionNotAllowed = errors.New("range and template calls are not supported in notification templates")
+
+// Widest printf padding or precision allowed; %0999999999d would build a
+// gigabyte string before anything is written
+const maxFormatWidth = 100
+
+var formatVerb = regexp.MustCompile(`%[-+# 0]*(?:\[\d+\])?(\*|\d*)(?:\.(\*|\d*))?`)
+
+// Templates rendered as HTML (escaped) rather than plain text
+var htmlTemplates = map[string]bool{
+	domain.TemplateStockAlertEmail: true,
+}
+
+// Data available to the stock alert templates
+type StockAlertTemplateData struct {
+	ProductID   string
+	ProductName string
+	Current     int
+	MaxLimit    int
+	Utilization float64
+	TenantID    string
+	Timestamp   time.Time
+}
+
+// Data available to the low stock template
+type LowStockTemplateData struct {
+	ProductID   string
+	ProductName string
+	Current     int
+	Threshold   int
+	TenantID    string
+}
+
+type templateRenderer struct {
+	uow interfaces.UnitOfWork
+}
+
+func NewTemplateRenderer(uow interfaces.UnitOfWork) interfaces.TemplateRenderer {
+	return &templateRenderer{
+		uow: uow,
+	}
+}
+
+func (r *templateRenderer) Default(name string) (string, error) {
+	if !domain.IsKnownTemplate(name) {
+		return "", domain.ErrTemplateNotFound
+	}
+	body, err := defaultTemplates.ReadFile("templates/" + name + ".tmpl")
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// Render uses the tenant override when it exists and renders cleanly,
+// otherwise it falls back to the embedded default.
+func (r *templateRenderer) Render(ctx context.Context, tenantID, name string, data interface{}) (string, error) {
+	override, err := r.uow.NotificationTemplates().FindByName(ctx, tenantID, name)
+	switch {
+	case err == nil:
+		out, execErr := execute(name, override.Body, data)
+		if execErr == nil {
+			return out, nil
+		}
+		log.Printf("Template %s for tenant %s failed, using default: %v", name, tenantID, execErr)
+	case !errors.Is(err, domain.ErrTemplateNotFound):
+		log.Printf("Loading template %s for tenant %s failed, using default: %v", name, tenantID, err)
+	}
+
+	body, err := r.Default(name)
+	if err != nil {
+		return "", err
+	}
+	return execute(name, body, data)
+}
+
+func (r *templateRenderer) Validate(name, body string) error {
+	if !domain.IsKnownTemplate(name) {
+		return domain.ErrTemplateNotFound
+	}
+	if strings.TrimSpace(body) == "" {
+		return domain.ErrInvalidTemplate{Name: name, Reason: "template body is empty"}
+	}
+	if len(body) > maxTemplateSize {
+		return domain.ErrInvalidTemplate{Name: name, Reason: fmt.Sprintf("template exceeds %d bytes", maxTemplateSize)}
+	}
+
+	// Executing against sample data catches references to unknown fields
+	if _, err := execute(name, body, sampleTemplateData(name)); err != nil {
+		return domain.ErrInvalidTemplate{Name: name, Reason: err.Error()}
+	}
+	return nil
+}
+
+func (r *templateRenderer) Preview(name, body string) (string, error) {
+	if err := r.Validate(name, body); err != nil {
+		return "", err
+	}
+	return execute(name, body, sampleTemplateData(name))
+}
+
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+func execute(name, body string, data interface{}) (string, error) {
+	type result struct {
+		out string
+		err error
+	}
+	deadline := time.Now().Add(maxRenderTime)
+	done := make(chan result, 1)
+	go func() {
+		out, err := executeUntil(name, body, data, deadline)
+		done <- result{out: out, err: err}
+	}()
+
+	timer := time.NewTimer(maxRenderTime)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.out, r.err
+	case <-timer.C:
+		return "", errRenderTimeout
+	}
+}
+
+func executeUntil(name, body string, data interface{}, deadline time.Time) (out string, err error) {
+	// A broken template must never take down the alerting goroutine
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("template panicked: %v", rec)
+		}
+	}()
+
+	funcs := map[string]interface{}{"printf": boundedPrintf}
+	var tmpl executor
+	var trees []*parse.Tree
+	if htmlTemplates[name] {
+		var t *htmltemplate.Template
+		t, err = htmltemplate.New(name).Option("missingkey=error").Funcs(funcs).Parse(body)
+		if err == nil {
+			tmpl = t
+			for _, defined := range t.Templates() {
+				trees = append(trees, defined.Tree)
+			}
+		}
+	} else {
+		var t *template.Template
+		t, err = template.New(name).Option("missingkey=error").Funcs(funcs).Parse(body)
+		if err == nil {
+			tmpl = t
+			for _, defined := range t.Templates() {
+				trees = append(trees, defined.Tree)
+			}
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, tree := range trees {
+		if tree != nil && hasUnboundedAction(tree.Root) {
+			return "", errActionNotAllowed
+		}
+	}
+
+	var sb strings.Builder
+	w := &limitedWriter{w: &sb, remaining: maxRenderedSize, deadline: deadline}
+	if err := tmpl.Execute(w, data); err != nil {
+		if errors.Is(err, errRenderedTooLarge) {
+			return "", errRenderedTooLarge
+		}
+		if errors.Is(err, errRenderTimeout) {
+			return "", errRenderTimeout
+		}
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// hasUnboundedAction reports whether a range or template call appears
+// anywhere below node
+func hasUnboundedAction(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if hasUnboundedAction(child) {
+				return true
+			}
+		}
+	case *parse.RangeNode, *parse.TemplateNode:
+		return true
+	case *parse.IfNode:
+		return hasUnboundedAction(n.List) || hasUnboundedAction(n.ElseList)
+	case *parse.WithNode:
+		return hasUnboundedAction(n.List) || hasUnboundedAction(n.ElseList)
+	}
+	return false
+}
+
+// boundedPrintf is fmt.Sprintf without padding or precision wide enough
+// to build huge strings
+func boundedPrintf(format string, args ...interface{}) (string, error) {
+	for _, m := range formatVerb.FindAllStringSubmatch(format, -1) {
+		for _, size := range m[1:] {
+			if size == "" {
+				continue
+			}
+			// * takes the width from the arguments
+			if n, err := strconv.Atoi(size); err != nil || n > maxFormatWidth {
+				return "", fmt.Errorf("printf width and precision are limited to %d", maxFormatWidth)
+			}
+		}
+	}
+	return fmt.Sprintf(format, args...), nil
+}
+
+// limitedWriter fails once more than remaining bytes are written or the
+// deadline has passed, which stops the template executing
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+	deadline  time.Time
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.remaining {
+		return 0, errRenderedTooLarge
+	}
+	if time.Now().After(l.deadline) {
+		return 0, errRenderTimeout
+	}
+	l.remaining -= len(p)
+	return l.w.Write(p)
+}
+
+func sampleTemplateData(name string) interface{} {
+	switch name {
+	case domain.TemplateLowStockAlert:
+		return LowStockTemplateData{
+			ProductID:   "sample-product",
+			ProductName: "Sample Widget",
+			Current:     4,
+			Threshold:   10,
+			TenantID:    "sample-tenant",
+		}
+	default:
+		return StockAlertTemplateData{
+			ProductID:   "sample-product",
+			ProductName: "Sample Widget",
+			Current:     92,
+			MaxLimit:    100,
+			Utilization: 92,
+			TenantID:    "sample-tenant",
+			Timestamp:   time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+		}
+	}
+}
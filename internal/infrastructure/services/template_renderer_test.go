@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+)
+
+func TestTemplateRenderer_Render_Defaults(t *testing.T) {
+	renderer := NewTemplateRenderer(&mocks.MockUnitOfWork{TemplatesRepo: &mocks.MockNotificationTemplateRepo{}})
+	ctx := context.Background()
+	alert := StockAlertTemplateData{ProductName: "Widget", Current: 92, MaxLimit: 100, Utilization: 92}
+
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{domain.TemplateStockAlertSlack, alert, "🚨 Stock alert for Widget: 92/100 (92% full)"},
+		{domain.TemplateStockAlertEmail, alert, "CRITICAL: Product Widget is at 92% capacity (92/100)"},
+		{domain.TemplateLowStockAlert, LowStockTemplateData{ProductName: "Widget", Current: 3, Threshold: 10},
+			"⚠️ Low stock alert: Widget has only 3 units left (threshold: 10)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderer.Render(ctx, "t1", tt.name, tt.data)
+			if err != nil {
+				t.Fatalf("Render() err = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateRenderer_Render_TenantOverride(t *testing.T) {
+	repo := &mocks.MockNotificationTemplateRepo{Templates: []*domain.NotificationTemplate{
+		{TenantID: "t1", Name: domain.TemplateLowStockAlert, Body: "{{.ProductName}} low: {{.Current}}"},
+		// Parses, but fails at execution time
+		{TenantID: "t2", Name: domain.TemplateLowStockAlert, Body: "{{.Missing}}"},
+	}}
+	renderer := NewTemplateRenderer(&mocks.MockUnitOfWork{TemplatesRepo: repo})
+	data := LowStockTemplateData{ProductName: "Widget", Current: 3, Threshold: 10}
+
+	got, err := renderer.Render(context.Background(), "t1", domain.TemplateLowStockAlert, data)
+	if err != nil || got != "Widget low: 3" {
+		t.Errorf("Render(t1) = %q, %v", got, err)
+	}
+
+	got, err = renderer.Render(context.Background(), "t2", domain.TemplateLowStockAlert, data)
+	if err != nil || got != "⚠️ Low stock alert: Widget has only 3 units left (threshold: 10)" {
+		t.Errorf("Render(t2) should fall back to default, got %q, %v", got, err)
+	}
+}
+
+func TestTemplateRenderer_Validate(t *testing.T) {
+	renderer := NewTemplateRenderer(&mocks.MockUnitOfWork{TemplatesRepo: &mocks.MockNotificationTemplateRepo{}})
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		body    string
+		wantErr bool
+	}{
+		{"valid", domain.TemplateStockAlertSlack, "{{.ProductName}} at {{.Utilization}}", false},
+		{"parse error", domain.TemplateStockAlertSlack, "{{.ProductName", true},
+		{"unknown field", domain.TemplateStockAlertSlack, "{{.Threshold}}", true},
+		{"empty", domain.TemplateLowStockAlert, "  ", true},
+		// Small sources that would run for ever or build huge strings
+		{"runaway loop", domain.TemplateStockAlertSlack, "{{range 2000000000}}AAAAAAAA{{end}}", true},
+		{"runaway loop in html", domain.TemplateStockAlertEmail, "{{range 2000000000}}<p>{{$.ProductName}}</p>{{end}}", true},
+		{"silent loop", domain.TemplateStockAlertSlack, "{{range 30000000}}{{end}}ok", true},
+		{"nested loops", domain.TemplateStockAlertSlack, "{{range 2000000000}}{{range 2000000000}}{{end}}{{end}}", true},
+		{"loop over a variable", domain.TemplateStockAlertSlack, "{{$n := 2000000000}}{{if true}}{{range $n}}{{end}}{{end}}", true},
+		{"loop in a defined template", domain.TemplateStockAlertSlack, `{{define "x"}}{{range 2000000000}}{{end}}{{end}}{{template "x"}}`, true},
+		{"templates calling each other", domain.TemplateStockAlertSlack, `{{define "a"}}{{template "b"}}{{template "b"}}{{end}}{{define "b"}}x{{end}}{{template "a"}}`, true},
+		{"huge printf width", domain.TemplateStockAlertSlack, `{{printf "%0999999999d" 1}}`, true},
+		{"printf width from arguments", domain.TemplateStockAlertSlack, `{{printf "%*d" 999999999 1}}`, true},
+		{"modest printf", domain.TemplateStockAlertSlack, `{{printf "%5.1f%%" .Utilization}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := renderer.Validate(tt.tmpl, tt.body)
+			var tmplErr domain.ErrInvalidTemplate
+			if tt.wantErr != errors.As(err, &tmplErr) {
+				t.Errorf("Validate() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := renderer.Validate("nope", "x"); !errors.Is(err, domain.ErrTemplateNotFound) {
+		t.Errorf("Validate(unknown) err = %v", err)
+	}
+}
+
+func TestTemplateRenderer_Render_RunawayOverrideFallsBack(t *testing.T) {
+	// Stored before the output cap existed
+	repo := &mocks.MockNotificationTemplateRepo{Templates: []*domain.NotificationTemplate{
+		{TenantID: "t1", Name: domain.TemplateLowStockAlert, Body: "{{range 2000000000}}AAAAAAAA{{end}}"},
+	}}
+	renderer := NewTemplateRenderer(&mocks.MockUnitOfWork{TemplatesRepo: repo})
+
+	got, err := renderer.Render(context.Background(), "t1", domain.TemplateLowStockAlert, LowStockTemplateData{ProductName: "Widget", Current: 3, Threshold: 10})
+	if err != nil {
+		t.Fatalf("Render() err = %v", err)
+	}
+	if len(got) > maxRenderedSize || !strings.Contains(got, "Widget") {
+		t.Errorf("Render() = %.80q, want the default template", got)
+	}
+}
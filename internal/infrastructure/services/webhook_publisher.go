@@ -0,0 +1,243 @@
+// internal/infrastructure/services/webhook_publisher.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"myapp/internal/application/interfaces"
+	"myapp/internal/domain"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// How long Close waits for deliveries in flight before abandoning them
+const webhookCloseTimeout = 15 * time.Second
+
+var ErrWebhookPublisherClosed = errors.New("webhook publisher is closed")
+
+// Delivers domain events to tenant webhook subscriptions
+type webhookPublisher struct {
+	uow          interfaces.UnitOfWork
+	client       *http.Client
+	maxAttempts  int
+	backoff      time.Duration
+	closeTimeout time.Duration
+
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+	// Background deliveries run under ctx; Close cancels it when they
+	// outlast closeTimeout
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// A nil client only dials public addresses, see NewPublicOnlyHTTPClient
+func NewWebhookPublisher(uow interfaces.UnitOfWork, client *http.Client, maxAttempts int, backoff time.Duration) interfaces.WebhookPublisher {
+	if client == nil {
+		client = NewPublicOnlyHTTPClient(10 * time.Second)
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &webhookPublisher{
+		uow:          uow,
+		client:       client,
+		maxAttempts:  maxAttempts,
+		backoff:      backoff,
+		closeTimeout: webhookCloseTimeout,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Events are delivered as CloudEvents in structured JSON mode, upcast to
+// the current schema version.
+func (p *webhookPublisher) Publish(ctx context.Context, event domain.CloudEvent) error {
+	if !domain.IsKnownEventType(event.Type) {
+		// Events without a webhook subscription type are not delivered
+		return nil
+	}
+
+	event, err := event.Upcast()
+	if err != nil {
+		return err
+	}
+
+	subs, err := p.uow.WebhookSubscriptions().FindByTenant(ctx, event.TenantID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrWebhookPublisherClosed
+	}
+	for _, sub := range subs {
+		if !sub.Subscribes(event.Type) {
+			continue
+		}
+
+		// Deliver in background so slow receivers don't block the caller
+		p.wg.Add(1)
+		go func(sub *domain.WebhookSubscription) {
+			defer p.wg.Done()
+			p.deliver(p.ctx, sub, event, body)
+		}(sub)
+	}
+	return nil
+}
+
+// Close stops accepting events and waits for deliveries in flight,
+// retries included. Deliveries still running after closeTimeout are
+// cancelled and Close returns context.DeadlineExceeded.
+func (p *webhookPublisher) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(p.closeTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-timer.C:
+		p.cancel()
+		<-done
+		return fmt.Errorf("webhook deliveries still running after %s: %w", p.closeTimeout, context.DeadlineExceeded)
+	}
+}
+
+func (p *webhookPublisher) deliver(ctx context.Context, sub *domain.WebhookSubscription, event domain.CloudEvent, body []byte) {
+	backoff := p.backoff
+	for attempt := 1; attempt <= p.maxAttempts; attempt++ {
+		delivery := p.send(ctx, sub, event, body, attempt)
+
+		if err := p.uow.WebhookDeliveries().Create(ctx, delivery); err != nil {
+			log.Printf("Failed to record webhook delivery for %s: %v", sub.ID, err)
+		}
+		if delivery.Success {
+			return
+		}
+
+		if attempt < p.maxAttempts {
+			select {
+			case <-ctx.Done():
+				log.Printf("Webhook %s abandoned event %s after %d attempts: %v", sub.ID, event.ID, attempt, ctx.Err())
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+	log.Printf("Webhook %s gave up on event %s after %d attempts", sub.ID, event.ID, p.maxAttempts)
+}
+
+func (p *webhookPublisher) send(ctx context.Context, sub *domain.WebhookSubscription, event domain.CloudEvent, body []byte, attempt int) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{
+		SubscriptionID: sub.ID,
+		TenantID:       sub.TenantID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Attempt:        attempt,
+		DeliveredAt:    time.Now(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", domain.CloudEventsContentType)
+	req.Header.Set(WebhookIDHeader, event.ID)
+	req.Header.Set(WebhookEventHeader, event.Type)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(sub.Secret, timestamp, body))
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	delivery.Duration = time.Since(start)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	delivery.StatusCode = resp.StatusCode
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+	return delivery
+}
+
+// NewPublicOnlyHTTPClient returns a client that refuses to connect to
+// addresses domain.IsPublicIP rejects. The check runs on the resolved
+// address of every connection, redirects included, so host names that
+// resolve to internal addresses are caught too.
+func NewPublicOnlyHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialPublicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would dial the webhook host itself, out of reach of the check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !domain.IsPublicIP(ip) {
+		return fmt.Errorf("dial %s: %w", address, domain.ErrWebhookURLNotPublic)
+	}
+	return nil
+}
+
+// SignWebhookPayload computes the signature header value for a body.
+// Receivers recompute it over "<timestamp>.<body>" with their shared secret.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"myapp/internal/domain"
+	"myapp/internal/testutil/mocks"
+)
+
+const testSecret = "0123456789abcdef"
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a local webhook receiver that fails the first failures requests.
+func newReceiver(t *testing.T, failures int) (*httptest.Server, func() []receivedWebhook) {
+	var mu sync.Mutex
+	var received []receivedWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedWebhook{header: r.Header.Clone(), body: body})
+		n := len(received)
+		mu.Unlock()
+		if n <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []receivedWebhook {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedWebhook(nil), received...)
+	}
+}
+
+func newPublisherUoW(url string, eventTypes ...string) *mocks.MockUnitOfWork {
+	return &mocks.MockUnitOfWork{
+		WebhooksRepo: &mocks.MockWebhookSubscriptionRepo{
+			Subscriptions: []*domain.WebhookSubscription{
+				{ID: "wh1", TenantID: "t1", URL: url, Secret: testSecret, EventTypes: eventTypes, IsActive: true},
+			},
+		},
+		DeliveryRepo: &mocks.MockWebhookDeliveryRepo{},
+	}
+}
+
+func stockAddedEvent() domain.CloudEvent {
+	qty, _ := domain.NewStockQuantity(5)
+	prev, _ := domain.NewStockQuantity(10)
+	cur, _ := domain.NewStockQuantity(15)
+	event, _ := domain.NewCloudEvent(domain.StockAddedEvent{
+		ProductID: "p1", TenantID: "t1", Quantity: qty, Previous: prev, Current: cur,
+		AddedBy: "u1", Timestamp: time.Now(),
+	}, "t1")
+	return event
+}
+
+func TestWebhookPublisher_Publish_SignsPayload(t *testing.T) {
+	server, received := newReceiver(t, 0)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockAdded)
+	pub := NewWebhookPublisher(uow, server.Client(), 3, time.Millisecond)
+
+	event := stockAddedEvent()
+	if err := pub.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() err = %v", err)
+	}
+	pub.(*webhookPublisher).wg.Wait()
+
+	got := received()
+	if len(got) != 1 {
+		t.Fatalf("received = %d, want 1", len(got))
+	}
+	h := got[0].header
+	if h.Get(WebhookEventHeader) != domain.EventTypeStockAdded || h.Get(WebhookIDHeader) != event.ID {
+		t.Errorf("event headers = %q / %q", h.Get(WebhookEventHeader), h.Get(WebhookIDHeader))
+	}
+	if h.Get("Content-Type") != domain.CloudEventsContentType {
+		t.Errorf("content type = %q", h.Get("Content-Type"))
+	}
+	ts, err := strconv.ParseInt(h.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp header: %v", err)
+	}
+	if want := SignWebhookPayload(testSecret, ts, got[0].body); h.Get(WebhookSignatureHeader) != want {
+		t.Errorf("signature = %q, want %q", h.Get(WebhookSignatureHeader), want)
+	}
+
+	var payload struct {
+		SpecVersion string `json:"specversion"`
+		ID          string `json:"id"`
+		Type        string `json:"type"`
+		TenantID    string `json:"tenantid"`
+		DataVersion int    `json:"dataversion"`
+		Data        struct {
+			ProductID string `json:"product_id"`
+			NewStock  int    `json:"new_stock"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(got[0].body, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.SpecVersion != "1.0" || payload.ID != event.ID || payload.TenantID != "t1" || payload.DataVersion != 3 {
+		t.Errorf("envelope = %+v", payload)
+	}
+	if payload.Type != domain.EventTypeStockAdded || payload.Data.ProductID != "p1" || payload.Data.NewStock != 15 {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	deliveries := uow.DeliveryRepo.Recorded()
+	if len(deliveries) != 1 || !deliveries[0].Success || deliveries[0].StatusCode != http.StatusNoContent {
+		t.Errorf("deliveries = %+v", deliveries)
+	}
+}
+
+func TestWebhookPublisher_Publish_RetriesAndLogsAttempts(t *testing.T) {
+	server, received := newReceiver(t, 2)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockAdded)
+	pub := NewWebhookPublisher(uow, server.Client(), 3, time.Millisecond)
+
+	_ = pub.Publish(context.Background(), stockAddedEvent())
+	pub.(*webhookPublisher).wg.Wait()
+
+	if n := len(received()); n != 3 {
+		t.Fatalf("received = %d, want 3", n)
+	}
+	deliveries := uow.DeliveryRepo.Recorded()
+	if len(deliveries) != 3 {
+		t.Fatalf("deliveries = %d, want 3", len(deliveries))
+	}
+	for i, d := range deliveries {
+		if d.Attempt != i+1 {
+			t.Errorf("delivery %d attempt = %d", i, d.Attempt)
+		}
+	}
+	if deliveries[0].Success || deliveries[1].Success || !deliveries[2].Success {
+		t.Errorf("success flags = %v %v %v, want false false true",
+			deliveries[0].Success, deliveries[1].Success, deliveries[2].Success)
+	}
+}
+
+func TestWebhookPublisher_Publish_SkipsUnsubscribedEvents(t *testing.T) {
+	server, received := newReceiver(t, 0)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockLimitAlert)
+	pub := NewWebhookPublisher(uow, server.Client(), 3, time.Millisecond)
+
+	_ = pub.Publish(context.Background(), stockAddedEvent())
+	pub.(*webhookPublisher).wg.Wait()
+
+	if n := len(received()); n != 0 {
+		t.Errorf("received = %d, want 0", n)
+	}
+}
+
+func TestWebhookPublisher_Publish_UpcastsOlderVersions(t *testing.T) {
+	server, received := newReceiver(t, 0)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockAdded)
+	pub := NewWebhookPublisher(uow, server.Client(), 1, time.Millisecond)
+
+	legacy := domain.CloudEvent{
+		SpecVersion: domain.CloudEventsSpecVersion,
+		ID:          "legacy-1",
+		Type:        domain.EventTypeStockAdded,
+		TenantID:    "t1",
+		DataVersion: 1,
+		Data:        []byte(`{"ProductID":"p1","Current":15}`),
+	}
+	if err := pub.Publish(context.Background(), legacy); err != nil {
+		t.Fatalf("Publish() err = %v", err)
+	}
+	pub.(*webhookPublisher).wg.Wait()
+
+	got := received()
+	if len(got) != 1 {
+		t.Fatalf("received = %d, want 1", len(got))
+	}
+	var payload struct {
+		DataVersion int    `json:"dataversion"`
+		DataSchema  string `json:"dataschema"`
+		Data        struct {
+			ProductID string `json:"product_id"`
+			NewStock  int    `json:"new_stock"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(got[0].body, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.DataVersion != 3 || payload.DataSchema != domain.EventSchemaURI(domain.EventTypeStockAdded, 3) {
+		t.Errorf("version = %d schema = %q, want current version", payload.DataVersion, payload.DataSchema)
+	}
+	if payload.Data.ProductID != "p1" || payload.Data.NewStock != 15 {
+		t.Errorf("data = %+v, want upcast fields", payload.Data)
+	}
+}
+
+func TestWebhookPublisher_Publish_RefusesInternalAddresses(t *testing.T) {
+	// Stored before URLs were checked, or a host name resolving to loopback
+	server, received := newReceiver(t, 0)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockAdded)
+	pub := NewWebhookPublisher(uow, nil, 1, time.Millisecond)
+
+	_ = pub.Publish(context.Background(), stockAddedEvent())
+	pub.(*webhookPublisher).wg.Wait()
+
+	if n := len(received()); n != 0 {
+		t.Errorf("received = %d, want 0", n)
+	}
+	deliveries := uow.DeliveryRepo.Recorded()
+	if len(deliveries) != 1 || deliveries[0].Success || !strings.Contains(deliveries[0].Error, domain.ErrWebhookURLNotPublic.Error()) {
+		t.Errorf("deliveries = %+v", deliveries)
+	}
+}
+
+func TestWebhookPublisher_Close_WaitsForDeliveries(t *testing.T) {
+	server, received := newReceiver(t, 1)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockAdded)
+	pub := NewWebhookPublisher(uow, server.Client(), 2, 20*time.Millisecond)
+
+	_ = pub.Publish(context.Background(), stockAddedEvent())
+	if err := pub.Close(); err != nil {
+		t.Fatalf("Close() err = %v", err)
+	}
+	if n := len(received()); n != 2 {
+		t.Errorf("received = %d, want the retry to finish before Close returns", n)
+	}
+	if err := pub.Publish(context.Background(), stockAddedEvent()); !errors.Is(err, ErrWebhookPublisherClosed) {
+		t.Errorf("Publish() after Close err = %v, want %v", err, ErrWebhookPublisherClosed)
+	}
+}
+
+func TestWebhookPublisher_Close_AbandonsDeliveriesAfterTimeout(t *testing.T) {
+	server, received := newReceiver(t, 10)
+	uow := newPublisherUoW(server.URL, domain.EventTypeStockAdded)
+	pub := NewWebhookPublisher(uow, server.Client(), 10, time.Hour)
+	pub.(*webhookPublisher).closeTimeout = 20 * time.Millisecond
+
+	_ = pub.Publish(context.Background(), stockAddedEvent())
+	start := time.Now()
+	if err := pub.Close(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close() err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Close() took %s", elapsed)
+	}
+	if n := len(received()); n != 1 {
+		t.Errorf("received = %d, want retries abandoned after the first attempt", n)
+	}
+}
@@ -13,3 +13,11 @@ func UserIDMiddleware(userID string) fiber.Handler {
 		return c.Next()
 	}
 }
+
+// UserRolesMiddleware sets "user_roles" in Locals, as the auth middleware does.
+func UserRolesMiddleware(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("user_roles", roles)
+		return c.Next()
+	}
+}
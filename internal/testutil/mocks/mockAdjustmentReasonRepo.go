@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"myapp/internal/domain"
+)
+
+// MockAdjustmentReasonRepo implements interfaces.AdjustmentReasonRepository for tests.
+// Reasons is the backing store of tenant overrides.
+type MockAdjustmentReasonRepo struct {
+	Reasons []*domain.AdjustmentReason
+	FindErr error
+}
+
+func (m *MockAdjustmentReasonRepo) FindByTenant(ctx context.Context, tenantID string) ([]*domain.AdjustmentReason, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var reasons []*domain.AdjustmentReason
+	for _, r := range m.Reasons {
+		if r.TenantID == tenantID {
+			reasons = append(reasons, r)
+		}
+	}
+	return reasons, nil
+}
+
+func (m *MockAdjustmentReasonRepo) Save(ctx context.Context, reason *domain.AdjustmentReason) error {
+	for i, r := range m.Reasons {
+		if r.TenantID == reason.TenantID && r.Code == reason.Code {
+			m.Reasons[i] = reason
+			return nil
+		}
+	}
+	m.Reasons = append(m.Reasons, reason)
+	return nil
+}
+
+func (m *MockAdjustmentReasonRepo) Delete(ctx context.Context, tenantID, code string) error {
+	for i, r := range m.Reasons {
+		if r.TenantID == tenantID && r.Code == code {
+			m.Reasons = append(m.Reasons[:i], m.Reasons[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrAdjustmentReasonNotFound
+}
@@ -0,0 +1,87 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"myapp/internal/domain"
+)
+
+// MockCountSessionRepo implements interfaces.CountSessionRepository for tests.
+// Sessions is the backing store; FindByID returns copies so use cases only
+// change stored sessions through the repository.
+type MockCountSessionRepo struct {
+	Sessions []*domain.CountSession
+	CloseErr error
+}
+
+func (m *MockCountSessionRepo) Create(ctx context.Context, session *domain.CountSession) error {
+	session.ID = fmt.Sprintf("count-%d", len(m.Sessions)+1)
+	stored := *session
+	m.Sessions = append(m.Sessions, &stored)
+	return nil
+}
+
+func (m *MockCountSessionRepo) find(tenantID, sessionID string) *domain.CountSession {
+	for _, s := range m.Sessions {
+		if s.ID == sessionID && s.TenantID == tenantID {
+			return s
+		}
+	}
+	return nil
+}
+
+func (m *MockCountSessionRepo) FindByID(ctx context.Context, tenantID, sessionID string) (*domain.CountSession, error) {
+	s := m.find(tenantID, sessionID)
+	if s == nil {
+		return nil, domain.ErrCountSessionNotFound
+	}
+	session := *s
+	session.Lines = make([]domain.CountLine, len(s.Lines))
+	for i, l := range s.Lines {
+		l.Counts = append([]domain.CountEntry(nil), l.Counts...)
+		session.Lines[i] = l
+	}
+	return &session, nil
+}
+
+func (m *MockCountSessionRepo) FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.CountSession, error) {
+	var sessions []*domain.CountSession
+	for _, s := range m.Sessions {
+		if s.TenantID == tenantID && (status == "" || s.Status == status) {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *MockCountSessionRepo) AddCount(ctx context.Context, tenantID, sessionID, productID string, entry domain.CountEntry) error {
+	s := m.find(tenantID, sessionID)
+	if s == nil {
+		return domain.ErrCountSessionNotFound
+	}
+	if !s.IsOpen() {
+		return domain.ErrCountSessionClosed
+	}
+	line := s.Line(productID)
+	if line == nil {
+		return domain.ErrProductNotInCount
+	}
+	line.Counts = append(line.Counts, entry)
+	return nil
+}
+
+func (m *MockCountSessionRepo) Close(ctx context.Context, session *domain.CountSession) error {
+	if m.CloseErr != nil {
+		return m.CloseErr
+	}
+	s := m.find(session.TenantID, session.ID)
+	if s == nil {
+		return domain.ErrCountSessionNotFound
+	}
+	if !s.IsOpen() {
+		return domain.ErrCountSessionClosed
+	}
+	*s = *session
+	return nil
+}
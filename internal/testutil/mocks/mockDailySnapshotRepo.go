@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/domain"
+)
+
+// MockDailySnapshotRepo implements interfaces.DailyStockSnapshotRepository
+// for tests. Snapshots is the backing store; SaveCalls counts SaveAll calls.
+type MockDailySnapshotRepo struct {
+	Snapshots []domain.DailyStockSnapshot
+	SaveCalls int
+	SaveErr   error
+}
+
+func (m *MockDailySnapshotRepo) SaveAll(ctx context.Context, snapshots []domain.DailyStockSnapshot) error {
+	m.SaveCalls++
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	for _, s := range snapshots {
+		replaced := false
+		for i, existing := range m.Snapshots {
+			if existing.TenantID == s.TenantID && existing.ProductID == s.ProductID && existing.Day.Equal(s.Day) {
+				m.Snapshots[i] = s
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			m.Snapshots = append(m.Snapshots, s)
+		}
+	}
+	return nil
+}
+
+func (m *MockDailySnapshotRepo) FindLatest(ctx context.Context, tenantID, productID string, at time.Time) ([]domain.DailyStockSnapshot, error) {
+	matches := func(s domain.DailyStockSnapshot) bool {
+		return s.TenantID == tenantID && (productID == "" || s.ProductID == productID) && s.TakenAt.Before(at)
+	}
+	var latest time.Time
+	for _, s := range m.Snapshots {
+		if matches(s) && s.Day.After(latest) {
+			latest = s.Day
+		}
+	}
+	var snapshots []domain.DailyStockSnapshot
+	for _, s := range m.Snapshots {
+		if matches(s) && s.Day.Equal(latest) {
+			snapshots = append(snapshots, s)
+		}
+	}
+	return snapshots, nil
+}
@@ -2,16 +2,18 @@ package mocks
 
 import (
 	"context"
+
+	"myapp/internal/domain"
 )
 
 // MockEventPublisher implements interfaces.EventPublisher for tests.
 // Published records all Publish calls for assertions.
 type MockEventPublisher struct {
-	Published  []interface{}
+	Published  []domain.CloudEvent
 	PublishErr error
 }
 
-func (m *MockEventPublisher) Publish(ctx context.Context, event interface{}) error {
+func (m *MockEventPublisher) Publish(ctx context.Context, event domain.CloudEvent) error {
 	m.Published = append(m.Published, event)
 	return m.PublishErr
 }
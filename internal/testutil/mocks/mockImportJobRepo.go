@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+)
+
+// MockImportJobRepo implements interfaces.ImportJobRepository for tests.
+// Jobs is the backing store; SaveCalls counts SaveProgress calls.
+type MockImportJobRepo struct {
+	Jobs      []*domain.ImportJob
+	SaveCalls int
+}
+
+func (m *MockImportJobRepo) Create(ctx context.Context, job *domain.ImportJob) error {
+	for _, j := range m.Jobs {
+		if j.TenantID == job.TenantID && j.FileHash == job.FileHash {
+			return domain.ErrDuplicateImport
+		}
+	}
+	job.ID = fmt.Sprintf("import-%d", len(m.Jobs)+1)
+	stored := *job
+	m.Jobs = append(m.Jobs, &stored)
+	return nil
+}
+
+func (m *MockImportJobRepo) FindByID(ctx context.Context, tenantID, jobID string) (*domain.ImportJob, error) {
+	for _, j := range m.Jobs {
+		if j.ID == jobID && j.TenantID == tenantID {
+			found := *j
+			return &found, nil
+		}
+	}
+	return nil, domain.ErrImportJobNotFound
+}
+
+func (m *MockImportJobRepo) FindByHash(ctx context.Context, tenantID, fileHash string) (*domain.ImportJob, error) {
+	for _, j := range m.Jobs {
+		if j.TenantID == tenantID && j.FileHash == fileHash {
+			found := *j
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockImportJobRepo) ClaimPending(ctx context.Context, now time.Time) (*domain.ImportJob, error) {
+	for _, j := range m.Jobs {
+		if j.Status == domain.ImportStatusPending || j.IsLeaseExpired(now) {
+			j.Start(now)
+			found := *j
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockImportJobRepo) SaveProgress(ctx context.Context, job *domain.ImportJob) error {
+	m.SaveCalls++
+	for i, j := range m.Jobs {
+		if j.ID == job.ID && j.TenantID == job.TenantID {
+			saved := *job
+			saved.Errors = append([]domain.ImportRowError(nil), job.Errors...)
+			m.Jobs[i] = &saved
+			return nil
+		}
+	}
+	return domain.ErrImportJobNotFound
+}
@@ -0,0 +1,96 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+)
+
+// MockOutboxRepo implements interfaces.OutboxRepository for tests.
+// Entries is the backing store; Updated and Attempts record relay calls.
+type MockOutboxRepo struct {
+	Entries   []*domain.OutboxEntry
+	AppendErr error
+	FindErr   error
+	Updated   []domain.OutboxEntry
+	Attempts  []domain.OutboxAttempt
+	Cursor    *domain.OutboxCursor
+}
+
+func (m *MockOutboxRepo) Append(ctx context.Context, entries []*domain.OutboxEntry) error {
+	if m.AppendErr != nil {
+		return m.AppendErr
+	}
+	for _, e := range entries {
+		if e.ID == "" {
+			e.ID = fmt.Sprintf("outbox-%d", len(m.Entries)+1)
+		}
+		m.Entries = append(m.Entries, e)
+	}
+	return nil
+}
+
+func (m *MockOutboxRepo) FindPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEntry, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var pending []*domain.OutboxEntry
+	blocked := make(map[string]bool)
+	for _, e := range m.Entries {
+		due := e.Status == domain.OutboxPending && !e.NextAttemptAt.After(now)
+		if due && !blocked[e.AggregateID] {
+			pending = append(pending, e)
+		}
+		if !due && e.Status != domain.OutboxPublished && e.Status != domain.OutboxDiscarded {
+			blocked[e.AggregateID] = true
+		}
+		if limit > 0 && len(pending) == limit {
+			break
+		}
+	}
+	return pending, nil
+}
+
+func (m *MockOutboxRepo) FindDead(ctx context.Context, limit int) ([]*domain.OutboxEntry, error) {
+	var dead []*domain.OutboxEntry
+	for _, e := range m.Entries {
+		if e.Status == domain.OutboxDead && (limit <= 0 || len(dead) < limit) {
+			dead = append(dead, e)
+		}
+	}
+	return dead, nil
+}
+
+func (m *MockOutboxRepo) FindByID(ctx context.Context, id string) (*domain.OutboxEntry, error) {
+	for _, e := range m.Entries {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, domain.ErrOutboxEntryNotFound
+}
+
+func (m *MockOutboxRepo) Update(ctx context.Context, entry *domain.OutboxEntry) error {
+	m.Updated = append(m.Updated, *entry)
+	return nil
+}
+
+func (m *MockOutboxRepo) RecordAttempt(ctx context.Context, attempt domain.OutboxAttempt) error {
+	m.Attempts = append(m.Attempts, attempt)
+	return nil
+}
+
+func (m *MockOutboxRepo) LoadCursor(ctx context.Context, relay string) (*domain.OutboxCursor, error) {
+	if m.Cursor == nil {
+		return &domain.OutboxCursor{Relay: relay}, nil
+	}
+	cursor := *m.Cursor
+	return &cursor, nil
+}
+
+func (m *MockOutboxRepo) SaveCursor(ctx context.Context, cursor domain.OutboxCursor) error {
+	m.Cursor = &cursor
+	return nil
+}
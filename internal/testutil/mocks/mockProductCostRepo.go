@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+
+	"myapp/internal/domain"
+)
+
+// MockProductCostRepo implements interfaces.ProductCostRepository for
+// tests. Costs is the backing store; reads return copies.
+type MockProductCostRepo struct {
+	Costs []*domain.ProductCost
+	// SaveErr makes Save fail, e.g. with domain.ErrProductCostChanged
+	SaveErr error
+}
+
+func (m *MockProductCostRepo) FindByProduct(ctx context.Context, tenantID, productID string) (*domain.ProductCost, error) {
+	for _, c := range m.Costs {
+		if c.TenantID == tenantID && c.ProductID == productID {
+			return copyProductCost(c), nil
+		}
+	}
+	return &domain.ProductCost{ProductID: productID, TenantID: tenantID}, nil
+}
+
+func (m *MockProductCostRepo) FindByTenant(ctx context.Context, tenantID string) ([]*domain.ProductCost, error) {
+	var costs []*domain.ProductCost
+	for _, c := range m.Costs {
+		if c.TenantID == tenantID {
+			costs = append(costs, copyProductCost(c))
+		}
+	}
+	return costs, nil
+}
+
+func (m *MockProductCostRepo) Save(ctx context.Context, cost *domain.ProductCost) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	stored := copyProductCost(cost)
+	stored.Version++
+	for i, c := range m.Costs {
+		if c.TenantID == cost.TenantID && c.ProductID == cost.ProductID {
+			if c.Version != cost.Version {
+				return domain.ErrProductCostChanged
+			}
+			m.Costs[i] = stored
+			return nil
+		}
+	}
+	if cost.Version != 0 {
+		return domain.ErrProductCostChanged
+	}
+	m.Costs = append(m.Costs, stored)
+	return nil
+}
+
+// Cost returns the stored cost layers of a product, or nil
+func (m *MockProductCostRepo) Cost(productID string) *domain.ProductCost {
+	for _, c := range m.Costs {
+		if c.ProductID == productID {
+			return c
+		}
+	}
+	return nil
+}
+
+func copyProductCost(c *domain.ProductCost) *domain.ProductCost {
+	cost := *c
+	cost.Layers = append([]domain.CostLayer(nil), c.Layers...)
+	return &cost
+}
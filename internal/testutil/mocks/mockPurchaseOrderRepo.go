@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"myapp/internal/domain"
+)
+
+// MockPurchaseOrderRepo implements interfaces.PurchaseOrderRepository for
+// tests. Orders is the backing store; FindByID returns copies so use cases
+// only change stored orders through the repository.
+type MockPurchaseOrderRepo struct {
+	Orders    []*domain.PurchaseOrder
+	UpdateErr error
+}
+
+func (m *MockPurchaseOrderRepo) Create(ctx context.Context, order *domain.PurchaseOrder) error {
+	order.ID = fmt.Sprintf("po-%d", len(m.Orders)+1)
+	m.Orders = append(m.Orders, copyPurchaseOrder(order))
+	return nil
+}
+
+func (m *MockPurchaseOrderRepo) find(tenantID, orderID string) *domain.PurchaseOrder {
+	for _, o := range m.Orders {
+		if o.ID == orderID && o.TenantID == tenantID {
+			return o
+		}
+	}
+	return nil
+}
+
+func (m *MockPurchaseOrderRepo) FindByID(ctx context.Context, tenantID, orderID string) (*domain.PurchaseOrder, error) {
+	o := m.find(tenantID, orderID)
+	if o == nil {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+	return copyPurchaseOrder(o), nil
+}
+
+func (m *MockPurchaseOrderRepo) FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.PurchaseOrder, error) {
+	var orders []*domain.PurchaseOrder
+	for _, o := range m.Orders {
+		if o.TenantID == tenantID && (status == "" || o.Status == status) {
+			orders = append(orders, copyPurchaseOrder(o))
+		}
+	}
+	return orders, nil
+}
+
+func (m *MockPurchaseOrderRepo) Update(ctx context.Context, order *domain.PurchaseOrder) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	o := m.find(order.TenantID, order.ID)
+	if o == nil {
+		return domain.ErrPurchaseOrderNotFound
+	}
+	if o.Version != order.Version {
+		return domain.ErrPurchaseOrderChanged
+	}
+	*o = *copyPurchaseOrder(order)
+	o.Version++
+	return nil
+}
+
+func copyPurchaseOrder(o *domain.PurchaseOrder) *domain.PurchaseOrder {
+	order := *o
+	order.Lines = append([]domain.PurchaseOrderLine(nil), o.Lines...)
+	order.Receipts = append([]domain.PurchaseOrderReceipt(nil), o.Receipts...)
+	return &order
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+
+	"myapp/internal/domain"
+)
+
+// MockRoutingRuleRepo implements interfaces.RoutingRuleRepository for tests.
+// Rules is the backing store; Created records Create calls.
+type MockRoutingRuleRepo struct {
+	Rules     []*domain.RoutingRule
+	FindErr   error
+	CreateErr error
+	Created   []*domain.RoutingRule
+}
+
+func (m *MockRoutingRuleRepo) FindByID(ctx context.Context, tenantID, ruleID string) (*domain.RoutingRule, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	for _, r := range m.Rules {
+		if r.ID == ruleID && r.TenantID == tenantID {
+			return r, nil
+		}
+	}
+	return nil, domain.ErrRoutingRuleNotFound
+}
+
+func (m *MockRoutingRuleRepo) FindByTenant(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var rules []*domain.RoutingRule
+	for _, r := range m.Rules {
+		if r.TenantID == tenantID {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+func (m *MockRoutingRuleRepo) Create(ctx context.Context, rule *domain.RoutingRule) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	if rule.ID == "" {
+		rule.ID = "rule-new"
+	}
+	m.Created = append(m.Created, rule)
+	m.Rules = append(m.Rules, rule)
+	return nil
+}
+
+func (m *MockRoutingRuleRepo) Update(ctx context.Context, rule *domain.RoutingRule) error {
+	return nil
+}
+
+func (m *MockRoutingRuleRepo) Delete(ctx context.Context, tenantID, ruleID string) error {
+	for i, r := range m.Rules {
+		if r.ID == ruleID && r.TenantID == tenantID {
+			m.Rules = append(m.Rules[:i], m.Rules[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrRoutingRuleNotFound
+}
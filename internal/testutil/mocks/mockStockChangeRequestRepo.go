@@ -0,0 +1,73 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/internal/domain"
+)
+
+// MockStockChangeRequestRepo implements interfaces.StockChangeRequestRepository for tests.
+// Requests is the backing store; DecideErr fails Decide.
+type MockStockChangeRequestRepo struct {
+	Requests  []*domain.StockChangeRequest
+	DecideErr error
+}
+
+func (m *MockStockChangeRequestRepo) Create(ctx context.Context, request *domain.StockChangeRequest) error {
+	request.ID = fmt.Sprintf("request-%d", len(m.Requests)+1)
+	stored := *request
+	m.Requests = append(m.Requests, &stored)
+	return nil
+}
+
+func (m *MockStockChangeRequestRepo) FindByID(ctx context.Context, tenantID, requestID string) (*domain.StockChangeRequest, error) {
+	for _, r := range m.Requests {
+		if r.ID == requestID && r.TenantID == tenantID {
+			found := *r
+			return &found, nil
+		}
+	}
+	return nil, domain.ErrChangeRequestNotFound
+}
+
+func (m *MockStockChangeRequestRepo) FindByTenant(ctx context.Context, tenantID, status string) ([]*domain.StockChangeRequest, error) {
+	var requests []*domain.StockChangeRequest
+	for _, r := range m.Requests {
+		if r.TenantID == tenantID && (status == "" || r.Status == status) {
+			found := *r
+			requests = append(requests, &found)
+		}
+	}
+	return requests, nil
+}
+
+func (m *MockStockChangeRequestRepo) Decide(ctx context.Context, request *domain.StockChangeRequest) error {
+	if m.DecideErr != nil {
+		return m.DecideErr
+	}
+	for i, r := range m.Requests {
+		if r.ID == request.ID && r.TenantID == request.TenantID {
+			if r.Status != domain.ChangeRequestPending {
+				return domain.ErrChangeRequestClosed
+			}
+			decided := *request
+			m.Requests[i] = &decided
+			return nil
+		}
+	}
+	return domain.ErrChangeRequestNotFound
+}
+
+func (m *MockStockChangeRequestRepo) ExpirePending(ctx context.Context, now time.Time) (int64, error) {
+	var expired int64
+	for _, r := range m.Requests {
+		if r.IsExpired(now) {
+			r.Status = domain.ChangeRequestExpired
+			r.DecidedAt = now
+			expired++
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,79 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"myapp/internal/domain"
+)
+
+// MockStockLedgerRepo implements interfaces.StockLedgerRepository for tests.
+// Entries and Snapshots are the backing stores; Append rejects a taken
+// sequence like the unique index does.
+type MockStockLedgerRepo struct {
+	Entries   []domain.StockLedgerEntry
+	Snapshots []domain.StockSnapshot
+	AppendErr error
+}
+
+func (m *MockStockLedgerRepo) Append(ctx context.Context, entry *domain.StockLedgerEntry) error {
+	if m.AppendErr != nil {
+		return m.AppendErr
+	}
+	for _, e := range m.Entries {
+		if e.ProductID == entry.ProductID && e.Sequence == entry.Sequence {
+			return domain.ErrConcurrentStockUpdate
+		}
+	}
+	entry.ID = fmt.Sprintf("ledger-%d", len(m.Entries)+1)
+	m.Entries = append(m.Entries, *entry)
+	return nil
+}
+
+func (m *MockStockLedgerRepo) FindByProduct(ctx context.Context, productID string, afterSequence int64) ([]domain.StockLedgerEntry, error) {
+	var entries []domain.StockLedgerEntry
+	for _, e := range m.Entries {
+		if e.ProductID == productID && e.Sequence > afterSequence {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (m *MockStockLedgerRepo) FindProductIDs(ctx context.Context, tenantID string) ([]string, error) {
+	seen := map[string]bool{}
+	var ids []string
+	for _, e := range m.Entries {
+		if e.TenantID == tenantID && !seen[e.ProductID] {
+			seen[e.ProductID] = true
+			ids = append(ids, e.ProductID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockStockLedgerRepo) LatestSnapshot(ctx context.Context, productID string) (*domain.StockSnapshot, error) {
+	var latest *domain.StockSnapshot
+	for i, s := range m.Snapshots {
+		if s.ProductID == productID && (latest == nil || s.Version > latest.Version) {
+			latest = &m.Snapshots[i]
+		}
+	}
+	return latest, nil
+}
+
+func (m *MockStockLedgerRepo) SaveSnapshot(ctx context.Context, snapshot domain.StockSnapshot) error {
+	m.Snapshots = append(m.Snapshots, snapshot)
+	return nil
+}
+
+func (m *MockStockLedgerRepo) DeleteSnapshots(ctx context.Context, productID string) error {
+	kept := m.Snapshots[:0]
+	for _, s := range m.Snapshots {
+		if s.ProductID != productID {
+			kept = append(kept, s)
+		}
+	}
+	m.Snapshots = kept
+	return nil
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"myapp/internal/domain"
+)
+
+// MockSupplierRepo implements interfaces.SupplierRepository for tests.
+// Suppliers is the backing store; FindByID returns copies so use cases
+// only change stored suppliers through the repository.
+type MockSupplierRepo struct {
+	Suppliers []*domain.Supplier
+}
+
+func (m *MockSupplierRepo) Create(ctx context.Context, supplier *domain.Supplier) error {
+	supplier.ID = fmt.Sprintf("supplier-%d", len(m.Suppliers)+1)
+	m.Suppliers = append(m.Suppliers, copySupplier(supplier))
+	return nil
+}
+
+func (m *MockSupplierRepo) FindByID(ctx context.Context, tenantID, supplierID string) (*domain.Supplier, error) {
+	for _, s := range m.Suppliers {
+		if s.ID == supplierID && s.TenantID == tenantID {
+			return copySupplier(s), nil
+		}
+	}
+	return nil, domain.ErrSupplierNotFound
+}
+
+func (m *MockSupplierRepo) FindByTenant(ctx context.Context, tenantID, productID string) ([]*domain.Supplier, error) {
+	var suppliers []*domain.Supplier
+	for _, s := range m.Suppliers {
+		if s.TenantID == tenantID && (productID == "" || s.Product(productID) != nil) {
+			suppliers = append(suppliers, copySupplier(s))
+		}
+	}
+	return suppliers, nil
+}
+
+func (m *MockSupplierRepo) Update(ctx context.Context, supplier *domain.Supplier) error {
+	for i, s := range m.Suppliers {
+		if s.ID == supplier.ID && s.TenantID == supplier.TenantID {
+			m.Suppliers[i] = copySupplier(supplier)
+			return nil
+		}
+	}
+	return domain.ErrSupplierNotFound
+}
+
+func (m *MockSupplierRepo) Delete(ctx context.Context, tenantID, supplierID string) error {
+	for i, s := range m.Suppliers {
+		if s.ID == supplierID && s.TenantID == tenantID {
+			m.Suppliers = append(m.Suppliers[:i], m.Suppliers[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrSupplierNotFound
+}
+
+func copySupplier(s *domain.Supplier) *domain.Supplier {
+	supplier := *s
+	supplier.Products = append([]domain.SupplierProduct(nil), s.Products...)
+	return &supplier
+}
@@ -0,0 +1,93 @@
+package mocks
+
+import (
+	"context"
+
+	"myapp/internal/domain"
+)
+
+// MockNotificationTemplateRepo implements interfaces.NotificationTemplateRepository for tests.
+// Templates is the backing store; Saved records Save calls.
+type MockNotificationTemplateRepo struct {
+	Templates []*domain.NotificationTemplate
+	FindErr   error
+	SaveErr   error
+	Saved     []*domain.NotificationTemplate
+}
+
+func (m *MockNotificationTemplateRepo) FindByName(ctx context.Context, tenantID, name string) (*domain.NotificationTemplate, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	for _, t := range m.Templates {
+		if t.TenantID == tenantID && t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, domain.ErrTemplateNotFound
+}
+
+func (m *MockNotificationTemplateRepo) FindByTenant(ctx context.Context, tenantID string) ([]*domain.NotificationTemplate, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var templates []*domain.NotificationTemplate
+	for _, t := range m.Templates {
+		if t.TenantID == tenantID {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+func (m *MockNotificationTemplateRepo) Save(ctx context.Context, template *domain.NotificationTemplate) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	m.Saved = append(m.Saved, template)
+	return nil
+}
+
+func (m *MockNotificationTemplateRepo) Delete(ctx context.Context, tenantID, name string) error {
+	for i, t := range m.Templates {
+		if t.TenantID == tenantID && t.Name == name {
+			m.Templates = append(m.Templates[:i], m.Templates[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrTemplateNotFound
+}
+
+// MockTemplateRenderer implements interfaces.TemplateRenderer for tests.
+// Defaults maps template names to default bodies; ValidateErr rejects every body.
+type MockTemplateRenderer struct {
+	Defaults    map[string]string
+	ValidateErr error
+	Previewed   []string
+}
+
+func (m *MockTemplateRenderer) Render(ctx context.Context, tenantID, name string, data interface{}) (string, error) {
+	return m.Defaults[name], nil
+}
+
+func (m *MockTemplateRenderer) Default(name string) (string, error) {
+	if !domain.IsKnownTemplate(name) {
+		return "", domain.ErrTemplateNotFound
+	}
+	return m.Defaults[name], nil
+}
+
+func (m *MockTemplateRenderer) Validate(name, body string) error {
+	if !domain.IsKnownTemplate(name) {
+		return domain.ErrTemplateNotFound
+	}
+	return m.ValidateErr
+}
+
+func (m *MockTemplateRenderer) Preview(name, body string) (string, error) {
+	if err := m.Validate(name, body); err != nil {
+		return "", err
+	}
+	m.Previewed = append(m.Previewed, body)
+	return "rendered: " + body, nil
+}
@@ -5,21 +5,141 @@ package mocks
 
 import (
 	"context"
+	"fmt"
 	"myapp/internal/domain"
+	"sort"
+	"time"
 )
 
 // MockProductRepo implements interfaces.ProductRepository for tests.
+// FindByID returns Product when set, otherwise it looks up Products.
+// StockUpdates records UpdateStock calls by product ID.
 type MockProductRepo struct {
-	Product *domain.Product
-	FindErr error
-	SaveErr error
+	Product      *domain.Product
+	Products     []*domain.Product
+	FindErr      error
+	SaveErr      error
+	StockUpdates map[string]int
+	TotalAdded   map[string]domain.Decimal
+	CountLocks   map[string]string
+
+	FindByIDsCalls int
 }
 
 func (m *MockProductRepo) FindByID(ctx context.Context, productID string) (*domain.Product, error) {
 	if m.FindErr != nil {
 		return nil, m.FindErr
 	}
-	return m.Product, nil
+	if m.Product != nil || len(m.Products) == 0 {
+		return m.Product, nil
+	}
+	for _, p := range m.Products {
+		if p.ID == productID {
+			return p, nil
+		}
+	}
+	return nil, domain.ErrProductNotFound
+}
+
+// FindByIDs matches the IDs against Product when set, otherwise Products.
+// FindByIDsCalls counts calls so tests can check lookups are batched.
+func (m *MockProductRepo) FindByIDs(ctx context.Context, productIDs []string) ([]*domain.Product, error) {
+	m.FindByIDsCalls++
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var products []*domain.Product
+	for _, id := range productIDs {
+		if m.Product != nil {
+			if m.Product.ID == id {
+				products = append(products, m.Product)
+			}
+			continue
+		}
+		for _, p := range m.Products {
+			if p.ID == id {
+				products = append(products, p)
+			}
+		}
+	}
+	return products, nil
+}
+
+func (m *MockProductRepo) FindByTenant(ctx context.Context, tenantID string) ([]*domain.Product, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var products []*domain.Product
+	for _, p := range m.Products {
+		if p.TenantID == tenantID {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+// FindByIdentifier matches SKU or barcode against Product and Products
+func (m *MockProductRepo) FindByIdentifier(ctx context.Context, tenantID string, id domain.ProductIdentifier) (*domain.Product, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	for _, p := range append(m.Products, m.Product) {
+		if p == nil || p.TenantID != tenantID {
+			continue
+		}
+		if (id.SKU != "" && p.SKU == id.SKU) || (id.Barcode != "" && p.Barcode == id.Barcode) {
+			return p, nil
+		}
+	}
+	return nil, domain.ErrProductNotFound
+}
+
+func (m *MockProductRepo) FindVariants(ctx context.Context, tenantID, parentID string) ([]*domain.Product, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var variants []*domain.Product
+	for _, p := range m.Products {
+		if p.TenantID == tenantID && p.ParentID == parentID {
+			variants = append(variants, p)
+		}
+	}
+	return variants, nil
+}
+
+func (m *MockProductRepo) EachByTenant(ctx context.Context, tenantID string, fn func(*domain.Product) error) error {
+	if m.FindErr != nil {
+		return m.FindErr
+	}
+	for _, p := range m.Products {
+		if p.TenantID != tenantID {
+			continue
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockProductRepo) Each(ctx context.Context, fn func(*domain.Product) error) error {
+	if m.FindErr != nil {
+		return m.FindErr
+	}
+	for _, p := range m.Products {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockProductRepo) SummarizeUtilization(ctx context.Context, q domain.UtilizationQuery) (*domain.UtilizationSummary, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	summary := domain.SummarizeUtilization(m.Products, q)
+	return &summary, nil
 }
 
 func (m *MockProductRepo) Save(ctx context.Context, product *domain.Product) error {
@@ -33,9 +153,115 @@ func (m *MockProductRepo) Save(ctx context.Context, product *domain.Product) err
 }
 
 func (m *MockProductRepo) UpdateStock(ctx context.Context, productID string, newStock domain.StockQuantity) error {
+	if m.StockUpdates == nil {
+		m.StockUpdates = make(map[string]int)
+	}
+	m.StockUpdates[productID] = newStock.Value()
+	for _, p := range m.Products {
+		if p.ID == productID {
+			p.CurrentStock = newStock
+		}
+	}
+	return nil
+}
+
+func (m *MockProductRepo) SetTotalAdded(ctx context.Context, productID string, totalAdded domain.Decimal) error {
+	if m.TotalAdded == nil {
+		m.TotalAdded = make(map[string]domain.Decimal)
+	}
+	m.TotalAdded[productID] = totalAdded
+	for _, p := range m.Products {
+		if p.ID == productID {
+			p.TotalAdded = totalAdded
+		}
+	}
+	return nil
+}
+
+func (m *MockProductRepo) SetCountLock(ctx context.Context, productID, sessionID string) error {
+	if m.CountLocks == nil {
+		m.CountLocks = make(map[string]string)
+	}
+	m.CountLocks[productID] = sessionID
+	for _, p := range m.Products {
+		if p.ID == productID {
+			p.CountSessionID = sessionID
+		}
+	}
+	return nil
+}
+
+func (m *MockProductRepo) SetUnits(ctx context.Context, productID, baseUnit string, units []domain.UnitConversion) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	for _, p := range append(m.Products, m.Product) {
+		if p != nil && p.ID == productID {
+			p.BaseUnit = baseUnit
+			p.Units = units
+			return nil
+		}
+	}
+	return domain.ErrProductNotFound
+}
+
+func (m *MockProductRepo) SetQuantityMode(ctx context.Context, productID, mode string, precision int, stock domain.StockQuantity) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	for _, p := range append(m.Products, m.Product) {
+		if p != nil && p.ID == productID {
+			p.QuantityMode = mode
+			p.QuantityPrecision = precision
+			p.CurrentStock = stock
+			return nil
+		}
+	}
+	return domain.ErrProductNotFound
+}
+
+// SetIdentifiers enforces uniqueness per tenant as the unique indexes do
+func (m *MockProductRepo) SetIdentifiers(ctx context.Context, productID, sku, barcode string) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	var product *domain.Product
+	products := append(m.Products, m.Product)
+	for _, p := range products {
+		if p != nil && p.ID == productID {
+			product = p
+		}
+	}
+	if product == nil {
+		return domain.ErrProductNotFound
+	}
+	for _, p := range products {
+		if p == nil || p.ID == productID || p.TenantID != product.TenantID {
+			continue
+		}
+		if (sku != "" && p.SKU == sku) || (barcode != "" && p.Barcode == barcode) {
+			return domain.ErrDuplicateProductIdentifier
+		}
+	}
+	product.SKU = sku
+	product.Barcode = barcode
 	return nil
 }
 
+func (m *MockProductRepo) SetParent(ctx context.Context, productID, parentID string, options map[string]string) error {
+	if m.SaveErr != nil {
+		return m.SaveErr
+	}
+	for _, p := range append(m.Products, m.Product) {
+		if p != nil && p.ID == productID {
+			p.ParentID = parentID
+			p.VariantOptions = options
+			return nil
+		}
+	}
+	return domain.ErrProductNotFound
+}
+
 // MockTenantRepo implements interfaces.TenantRepository for tests.
 type MockTenantRepo struct {
 	Tenant  *domain.Tenant
@@ -50,10 +276,12 @@ func (m *MockTenantRepo) FindByID(ctx context.Context, tenantID string) (*domain
 }
 
 // MockStockHistoryRepo implements interfaces.StockHistoryRepository for tests.
-// Events records all Create calls for assertions.
+// Events records all Create calls for assertions; Entries holds every
+// history row, including those written by Create, and backs the queries.
 type MockStockHistoryRepo struct {
 	CreateErr error
 	Events    []domain.StockAddedEvent
+	Entries   []domain.StockHistoryEntry
 }
 
 func (m *MockStockHistoryRepo) Create(ctx context.Context, event domain.StockAddedEvent) error {
@@ -61,5 +289,174 @@ func (m *MockStockHistoryRepo) Create(ctx context.Context, event domain.StockAdd
 		return m.CreateErr
 	}
 	m.Events = append(m.Events, event)
+	entry := domain.StockAddedHistoryEntry(event)
+	entry.ID = fmt.Sprintf("history-%d", len(m.Entries)+1)
+	m.Entries = append(m.Entries, entry)
 	return nil
 }
+
+func (m *MockStockHistoryRepo) Append(ctx context.Context, entry *domain.StockHistoryEntry) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	entry.ID = fmt.Sprintf("history-%d", len(m.Entries)+1)
+	m.Entries = append(m.Entries, *entry)
+	return nil
+}
+
+func (m *MockStockHistoryRepo) FindByID(ctx context.Context, tenantID, entryID string) (*domain.StockHistoryEntry, error) {
+	for _, e := range m.Entries {
+		if e.ID == entryID && e.TenantID == tenantID {
+			found := e
+			return &found, nil
+		}
+	}
+	return nil, domain.ErrHistoryEntryNotFound
+}
+
+// Entries are kept chronological, so the newest are at the end.
+func (m *MockStockHistoryRepo) FindByProduct(ctx context.Context, tenantID, productID string, limit int) ([]domain.StockHistoryEntry, error) {
+	var entries []domain.StockHistoryEntry
+	for i := len(m.Entries) - 1; i >= 0; i-- {
+		e := m.Entries[i]
+		if e.TenantID != tenantID || e.ProductID != productID {
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+func (m *MockStockHistoryRepo) FindBySupplier(ctx context.Context, tenantID, supplierID string, limit int) ([]domain.StockHistoryEntry, error) {
+	var entries []domain.StockHistoryEntry
+	for i := len(m.Entries) - 1; i >= 0; i-- {
+		e := m.Entries[i]
+		if e.TenantID != tenantID || e.SupplierID != supplierID {
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+func (m *MockStockHistoryRepo) MarkReversed(ctx context.Context, tenantID, entryID, reversalID string) error {
+	for i := range m.Entries {
+		e := &m.Entries[i]
+		if e.ID != entryID || e.TenantID != tenantID {
+			continue
+		}
+		if e.ReversedBy != "" {
+			return domain.ErrMovementAlreadyReversed
+		}
+		e.ReversedBy = reversalID
+		return nil
+	}
+	return domain.ErrHistoryEntryNotFound
+}
+
+// Entries are kept chronological, so slice order is oldest first.
+func (m *MockStockHistoryRepo) EachInRange(ctx context.Context, tenantID string, from, to time.Time, fn func(domain.StockHistoryEntry) error) error {
+	for _, e := range m.Entries {
+		if e.TenantID != tenantID || e.CreatedAt.Before(from) || !e.CreatedAt.Before(to) {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockStockHistoryRepo) NetChangeByProduct(ctx context.Context, tenantID, productID string, from, to time.Time) (map[string]domain.Decimal, error) {
+	changes := map[string]domain.Decimal{}
+	for _, e := range m.Entries {
+		if e.TenantID != tenantID || (productID != "" && e.ProductID != productID) ||
+			e.CreatedAt.Before(from) || !e.CreatedAt.Before(to) {
+			continue
+		}
+		sum, err := changes[e.ProductID].Add(e.ExactQuantity())
+		if err != nil {
+			return nil, err
+		}
+		changes[e.ProductID] = sum
+	}
+	return changes, nil
+}
+
+func (m *MockStockHistoryRepo) DailyConsumption(ctx context.Context, tenantID, productID string, from, to time.Time) ([]domain.DailyConsumption, error) {
+	var days []domain.DailyConsumption
+	for _, e := range m.Entries {
+		if e.TenantID != tenantID || (productID != "" && e.ProductID != productID) ||
+			e.Operation != domain.HistoryOperationStockRemove || e.ReversedBy != "" ||
+			e.CreatedAt.Before(from) || !e.CreatedAt.Before(to) {
+			continue
+		}
+		days = append(days, domain.DailyConsumption{
+			ProductID: e.ProductID,
+			Day:       domain.SnapshotDay(e.CreatedAt),
+			Quantity:  e.ExactQuantity().Neg(),
+		})
+	}
+	return days, nil
+}
+
+// Entries are summarized in slice order, which tests keep chronological.
+func (m *MockStockHistoryRepo) SummarizeByProduct(ctx context.Context, tenantID string) ([]domain.StockHistorySummary, error) {
+	index := map[string]int{}
+	var summaries []domain.StockHistorySummary
+	for _, e := range m.Entries {
+		if e.TenantID != tenantID {
+			continue
+		}
+		i, ok := index[e.ProductID]
+		if !ok {
+			i = len(summaries)
+			index[e.ProductID] = i
+			summaries = append(summaries, domain.StockHistorySummary{ProductID: e.ProductID, OpeningStock: e.ExactPreviousStock()})
+		}
+		sum, err := summaries[i].NetChange.Add(e.ExactQuantity())
+		if err != nil {
+			return nil, err
+		}
+		summaries[i].NetChange = sum
+		summaries[i].Entries++
+		if e.Operation == domain.HistoryOperationStockAdd {
+			total, err := summaries[i].TotalAdded.Add(e.ExactQuantity())
+			if err != nil {
+				return nil, err
+			}
+			summaries[i].TotalAdded = total
+		}
+	}
+	return summaries, nil
+}
+
+func (m *MockStockHistoryRepo) SummarizeAdjustments(ctx context.Context, tenantID string, from, to time.Time) ([]domain.AdjustmentTotals, error) {
+	index := map[string]int{}
+	var totals []domain.AdjustmentTotals
+	for _, e := range m.Entries {
+		if e.TenantID != tenantID || e.ReasonCode == "" || e.CreatedAt.Before(from) || !e.CreatedAt.Before(to) {
+			continue
+		}
+		i, ok := index[e.ReasonCode]
+		if !ok {
+			i = len(totals)
+			index[e.ReasonCode] = i
+			totals = append(totals, domain.AdjustmentTotals{ReasonCode: e.ReasonCode})
+		}
+		totals[i].Adjustments++
+		if e.Quantity > 0 {
+			totals[i].Increase += e.Quantity
+		} else {
+			totals[i].Decrease -= e.Quantity
+		}
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].ReasonCode < totals[j].ReasonCode })
+	return totals, nil
+}
@@ -3,13 +3,44 @@
 // UnitOfWork, repositories, and external services.
 package mocks
 
-import "myapp/internal/application/interfaces"
+import (
+	"context"
+
+	"myapp/internal/application/interfaces"
+)
 
 // MockUnitOfWork implements interfaces.UnitOfWork for tests.
 type MockUnitOfWork struct {
 	ProductsRepo  *MockProductRepo
 	TenantsRepo   *MockTenantRepo
 	StockHistRepo *MockStockHistoryRepo
+	WebhooksRepo  *MockWebhookSubscriptionRepo
+	DeliveryRepo  *MockWebhookDeliveryRepo
+	TemplatesRepo *MockNotificationTemplateRepo
+	RoutingRepo   *MockRoutingRuleRepo
+	OutboxRepo    *MockOutboxRepo
+	LedgerRepo    *MockStockLedgerRepo
+	CountsRepo    *MockCountSessionRepo
+	ReasonsRepo   *MockAdjustmentReasonRepo
+	ChangesRepo   *MockStockChangeRequestRepo
+	ImportsRepo   *MockImportJobRepo
+	DailyRepo     *MockDailySnapshotRepo
+	OrdersRepo    *MockPurchaseOrderRepo
+	SuppliersRepo *MockSupplierRepo
+	// Created on first use, since every stock movement is costed
+	CostsRepo *MockProductCostRepo
+
+	// TxErr makes WithTransaction fail without running fn
+	TxErr   error
+	TxCalls int
+}
+
+func (m *MockUnitOfWork) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.TxCalls++
+	if m.TxErr != nil {
+		return m.TxErr
+	}
+	return fn(ctx)
 }
 
 func (m *MockUnitOfWork) Products() interfaces.ProductRepository {
@@ -21,3 +52,48 @@ func (m *MockUnitOfWork) Tenants() interfaces.TenantRepository {
 func (m *MockUnitOfWork) StockHistory() interfaces.StockHistoryRepository {
 	return m.StockHistRepo
 }
+func (m *MockUnitOfWork) WebhookSubscriptions() interfaces.WebhookSubscriptionRepository {
+	return m.WebhooksRepo
+}
+func (m *MockUnitOfWork) WebhookDeliveries() interfaces.WebhookDeliveryRepository {
+	return m.DeliveryRepo
+}
+func (m *MockUnitOfWork) NotificationTemplates() interfaces.NotificationTemplateRepository {
+	return m.TemplatesRepo
+}
+func (m *MockUnitOfWork) RoutingRules() interfaces.RoutingRuleRepository {
+	return m.RoutingRepo
+}
+func (m *MockUnitOfWork) Outbox() interfaces.OutboxRepository {
+	return m.OutboxRepo
+}
+func (m *MockUnitOfWork) StockLedger() interfaces.StockLedgerRepository {
+	return m.LedgerRepo
+}
+func (m *MockUnitOfWork) CountSessions() interfaces.CountSessionRepository {
+	return m.CountsRepo
+}
+func (m *MockUnitOfWork) AdjustmentReasons() interfaces.AdjustmentReasonRepository {
+	return m.ReasonsRepo
+}
+func (m *MockUnitOfWork) StockChangeRequests() interfaces.StockChangeRequestRepository {
+	return m.ChangesRepo
+}
+func (m *MockUnitOfWork) ImportJobs() interfaces.ImportJobRepository {
+	return m.ImportsRepo
+}
+func (m *MockUnitOfWork) DailySnapshots() interfaces.DailyStockSnapshotRepository {
+	return m.DailyRepo
+}
+func (m *MockUnitOfWork) PurchaseOrders() interfaces.PurchaseOrderRepository {
+	return m.OrdersRepo
+}
+func (m *MockUnitOfWork) Suppliers() interfaces.SupplierRepository {
+	return m.SuppliersRepo
+}
+func (m *MockUnitOfWork) ProductCosts() interfaces.ProductCostRepository {
+	if m.CostsRepo == nil {
+		m.CostsRepo = &MockProductCostRepo{}
+	}
+	return m.CostsRepo
+}
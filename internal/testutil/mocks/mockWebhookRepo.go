@@ -0,0 +1,118 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"myapp/internal/domain"
+)
+
+// MockWebhookSubscriptionRepo implements interfaces.WebhookSubscriptionRepository for tests.
+// Subscriptions is the backing store; Created and Updated record write calls.
+type MockWebhookSubscriptionRepo struct {
+	Subscriptions []*domain.WebhookSubscription
+	FindErr       error
+	CreateErr     error
+	UpdateErr     error
+	DeleteErr     error
+	Created       []*domain.WebhookSubscription
+	Updated       []*domain.WebhookSubscription
+	Deleted       []string
+}
+
+func (m *MockWebhookSubscriptionRepo) FindByID(ctx context.Context, tenantID, subscriptionID string) (*domain.WebhookSubscription, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	for _, s := range m.Subscriptions {
+		if s.ID == subscriptionID && s.TenantID == tenantID {
+			return s, nil
+		}
+	}
+	return nil, domain.ErrWebhookNotFound
+}
+
+func (m *MockWebhookSubscriptionRepo) FindByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var subs []*domain.WebhookSubscription
+	for _, s := range m.Subscriptions {
+		if s.TenantID == tenantID {
+			subs = append(subs, s)
+		}
+	}
+	return subs, nil
+}
+
+func (m *MockWebhookSubscriptionRepo) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	if sub.ID == "" {
+		sub.ID = "wh-new"
+	}
+	m.Created = append(m.Created, sub)
+	m.Subscriptions = append(m.Subscriptions, sub)
+	return nil
+}
+
+func (m *MockWebhookSubscriptionRepo) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.Updated = append(m.Updated, sub)
+	return nil
+}
+
+func (m *MockWebhookSubscriptionRepo) Delete(ctx context.Context, tenantID, subscriptionID string) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.Deleted = append(m.Deleted, subscriptionID)
+	return nil
+}
+
+// MockWebhookDeliveryRepo implements interfaces.WebhookDeliveryRepository for tests.
+// Safe for concurrent use since deliveries are recorded from background goroutines.
+type MockWebhookDeliveryRepo struct {
+	mu         sync.Mutex
+	CreateErr  error
+	FindErr    error
+	Deliveries []domain.WebhookDelivery
+}
+
+func (m *MockWebhookDeliveryRepo) Create(ctx context.Context, delivery domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.Deliveries = append(m.Deliveries, delivery)
+	return nil
+}
+
+func (m *MockWebhookDeliveryRepo) FindBySubscription(ctx context.Context, subscriptionID string, limit int) ([]domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	var deliveries []domain.WebhookDelivery
+	for _, d := range m.Deliveries {
+		if d.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, d)
+		}
+	}
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// Recorded returns a copy of the recorded deliveries.
+func (m *MockWebhookDeliveryRepo) Recorded() []domain.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]domain.WebhookDelivery(nil), m.Deliveries...)
+}